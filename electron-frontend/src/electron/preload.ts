@@ -111,4 +111,5 @@ contextBridge.exposeInMainWorld("env", {
   FIREBASE_MEASUREMENT_ID: process.env.FIREBASE_MEASUREMENT_ID,
 
   SERVER_URL: process.env.SERVER_URL,
+  DRY_RUN: process.env.DRY_RUN,
 });
@@ -18,7 +18,10 @@ export const generateDocument = async (
   settings: Settings,
   token: string
 ): Promise<QueueJobResponse> => {
-  const apiKey = settings.apiKeys[llmProvider];
+  // A dry run never calls the LLM, but the secure endpoints still expect an
+  // encrypted key header.
+  const dryRun = window.env.DRY_RUN === "true";
+  const apiKey = dryRun ? "dry-run" : settings.apiKeys[llmProvider];
   if (!apiKey) {
     throw new Error(`API key for ${llmProvider} is not set.`);
   }
@@ -63,6 +66,7 @@ export const generateDocument = async (
       jobId: jobId,
       docType: docType,
       llm: llmProvider.toLowerCase(),
+      ...(dryRun && { dryRun: true }),
     },
   };
 
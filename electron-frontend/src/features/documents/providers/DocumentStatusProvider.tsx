@@ -15,6 +15,9 @@ export type JobDocumentsStatus = Map<DocumentType, DocumentStatus>;
 type DocumentStatusState = Map<string, JobDocumentsStatus>;
 
 interface DocumentStatusUpdateMessage {
+  // Set on generation, batch and tracking progress events, which aren't
+  // completion notices.
+  event?: string;
   user_id: string;
   job_id: string;
   success: boolean;
@@ -50,6 +53,7 @@ export const DocumentStatusProvider = ({ children }: { children: React.ReactNode
       setupWebSocket();
 
       webSocketService.onMessage((data: DocumentStatusUpdateMessage) => {
+        if (data.event) return;
         console.log('Received document status update:', data);
         setDocumentStatuses((prev) => {
           const newStatuses = new Map(prev);
@@ -14,6 +14,9 @@ declare global {
       FIREBASE_MEASUREMENT_ID: string;
 
       SERVER_URL: string;
+      // "true" asks the server for dry-run documents, written from canned
+      // content without calling an LLM.
+      DRY_RUN?: string;
     };
   }
 }
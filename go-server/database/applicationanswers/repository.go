@@ -0,0 +1,158 @@
+package applicationanswers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/features/application_answers/models/domain"
+	"github.com/ordo_meritum/shared/contexts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+var ErrAnswerNotFound = errors.New("application answer not found")
+
+type Repository interface {
+	List(ctx context.Context, roleID int) ([]domain.Answer, error)
+	Save(ctx context.Context, roleID int, answers []domain.Answer) ([]domain.Answer, error)
+	Update(ctx context.Context, id int, answer string) (*domain.Answer, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+// List returns the role's answers in the order they were first written.
+func (r *postgresRepository) List(ctx context.Context, roleID int) ([]domain.Answer, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var rows []models.ApplicationAnswer
+	query := "SELECT * FROM application_answers WHERE firebase_uid = $1 AND role_id = $2 ORDER BY created_at, id"
+	if err := r.db.SelectContext(ctx, &rows, query, userCtx.UID, roleID); err != nil {
+		return nil, fmt.Errorf("failed to list application answers: %w", err)
+	}
+	answers := make([]domain.Answer, 0, len(rows))
+	for i := range rows {
+		answers = append(answers, toDomain(&rows[i]))
+	}
+	return answers, nil
+}
+
+// Save writes answers for the role, replacing any stored answer to the same
+// question, and returns them as stored.
+func (r *postgresRepository) Save(ctx context.Context, roleID int, answers []domain.Answer) ([]domain.Answer, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO application_answers
+			(firebase_uid, role_id, question, question_key, answer, char_limit, provider, model)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (firebase_uid, role_id, question_key) DO UPDATE SET
+			question   = EXCLUDED.question,
+			answer     = EXCLUDED.answer,
+			char_limit = EXCLUDED.char_limit,
+			provider   = EXCLUDED.provider,
+			model      = EXCLUDED.model,
+			edited     = FALSE,
+			updated_at = NOW()
+		RETURNING *`
+	saved := make([]domain.Answer, 0, len(answers))
+	for _, a := range answers {
+		var row models.ApplicationAnswer
+		err := tx.GetContext(ctx, &row, query,
+			userCtx.UID,
+			roleID,
+			a.Question,
+			domain.QuestionKey(a.Question),
+			a.Answer,
+			a.CharLimit,
+			models.Optional(a.Provider),
+			models.Optional(a.Model),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save application answer: %w", err)
+		}
+		saved = append(saved, toDomain(&row))
+	}
+	return saved, tx.Commit()
+}
+
+// Update replaces an answer with the user's own wording and marks it edited.
+func (r *postgresRepository) Update(ctx context.Context, id int, answer string) (*domain.Answer, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var row models.ApplicationAnswer
+	query := `
+		UPDATE application_answers SET answer = $3, edited = TRUE, updated_at = NOW()
+		WHERE id = $1 AND firebase_uid = $2
+		RETURNING *`
+	err := r.db.GetContext(ctx, &row, query, id, userCtx.UID, answer)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAnswerNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update application answer: %w", err)
+	}
+	a := toDomain(&row)
+	return &a, nil
+}
+
+func (r *postgresRepository) Delete(ctx context.Context, id int) error {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return error_response.ErrNoUserContext
+	}
+
+	res, err := r.db.ExecContext(ctx, "DELETE FROM application_answers WHERE id = $1 AND firebase_uid = $2", id, userCtx.UID)
+	if err != nil {
+		return fmt.Errorf("failed to delete application answer: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrAnswerNotFound
+	}
+	return nil
+}
+
+func toDomain(row *models.ApplicationAnswer) domain.Answer {
+	return domain.Answer{
+		ID:        row.ID,
+		RoleID:    row.RoleID,
+		Question:  row.Question,
+		Answer:    row.Answer,
+		CharLimit: row.CharLimit,
+		Provider:  models.Deref(row.Provider),
+		Model:     models.Deref(row.Model),
+		Edited:    row.Edited,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
+
+var _ Repository = (*postgresRepository)(nil)
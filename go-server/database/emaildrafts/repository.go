@@ -0,0 +1,229 @@
+package emaildrafts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/features/emails/models/domain"
+	"github.com/ordo_meritum/shared/contexts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+var ErrDraftNotFound = errors.New("email draft not found")
+
+type Repository interface {
+	List(ctx context.Context, roleID int) ([]domain.Draft, error)
+	Get(ctx context.Context, id int) (*domain.Draft, error)
+	Create(ctx context.Context, draft *domain.Draft) (*domain.Draft, error)
+	Update(ctx context.Context, draft *domain.Draft) (*domain.Draft, error)
+	Delete(ctx context.Context, id int) error
+	// LatestInterviewNotes returns the most recent interview notes saved with
+	// a draft for the role, or "" if there are none.
+	LatestInterviewNotes(ctx context.Context, roleID int) (string, error)
+	// LastDrafted returns, per role and kind, when the user's newest draft
+	// was written.
+	LastDrafted(ctx context.Context) (map[int]map[domain.Kind]time.Time, error)
+}
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+// List returns the role's drafts, newest first.
+func (r *postgresRepository) List(ctx context.Context, roleID int) ([]domain.Draft, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var rows []models.EmailDraft
+	query := "SELECT * FROM email_drafts WHERE firebase_uid = $1 AND role_id = $2 ORDER BY created_at DESC, id DESC"
+	if err := r.db.SelectContext(ctx, &rows, query, userCtx.UID, roleID); err != nil {
+		return nil, fmt.Errorf("failed to list email drafts: %w", err)
+	}
+	drafts := make([]domain.Draft, 0, len(rows))
+	for i := range rows {
+		drafts = append(drafts, toDomain(&rows[i]))
+	}
+	return drafts, nil
+}
+
+func (r *postgresRepository) Get(ctx context.Context, id int) (*domain.Draft, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var row models.EmailDraft
+	err := r.db.GetContext(ctx, &row, "SELECT * FROM email_drafts WHERE id = $1 AND firebase_uid = $2", id, userCtx.UID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDraftNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email draft: %w", err)
+	}
+	draft := toDomain(&row)
+	return &draft, nil
+}
+
+func (r *postgresRepository) Create(ctx context.Context, draft *domain.Draft) (*domain.Draft, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var row models.EmailDraft
+	query := `
+		INSERT INTO email_drafts
+			(firebase_uid, role_id, kind, subject, body, recipient_name, recipient_email, interview_notes, provider, model)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING *`
+	err := r.db.GetContext(ctx, &row, query,
+		userCtx.UID,
+		draft.RoleID,
+		string(draft.Kind),
+		draft.Subject,
+		draft.Body,
+		models.Optional(draft.RecipientName),
+		models.Optional(draft.RecipientEmail),
+		models.Optional(draft.InterviewNotes),
+		models.Optional(draft.Provider),
+		models.Optional(draft.Model),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create email draft: %w", err)
+	}
+	created := toDomain(&row)
+	return &created, nil
+}
+
+// Update saves the user's edits to the draft's subject, body and recipient.
+func (r *postgresRepository) Update(ctx context.Context, draft *domain.Draft) (*domain.Draft, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var row models.EmailDraft
+	query := `
+		UPDATE email_drafts SET
+			subject = $3, body = $4, recipient_name = $5, recipient_email = $6, updated_at = NOW()
+		WHERE id = $1 AND firebase_uid = $2
+		RETURNING *`
+	err := r.db.GetContext(ctx, &row, query,
+		draft.ID,
+		userCtx.UID,
+		draft.Subject,
+		draft.Body,
+		models.Optional(draft.RecipientName),
+		models.Optional(draft.RecipientEmail),
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDraftNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update email draft: %w", err)
+	}
+	updated := toDomain(&row)
+	return &updated, nil
+}
+
+func (r *postgresRepository) Delete(ctx context.Context, id int) error {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return error_response.ErrNoUserContext
+	}
+
+	res, err := r.db.ExecContext(ctx, "DELETE FROM email_drafts WHERE id = $1 AND firebase_uid = $2", id, userCtx.UID)
+	if err != nil {
+		return fmt.Errorf("failed to delete email draft: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrDraftNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) LatestInterviewNotes(ctx context.Context, roleID int) (string, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return "", error_response.ErrNoUserContext
+	}
+
+	var notes string
+	query := `
+		SELECT interview_notes FROM email_drafts
+		WHERE firebase_uid = $1 AND role_id = $2 AND interview_notes IS NOT NULL
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1`
+	err := r.db.GetContext(ctx, &notes, query, userCtx.UID, roleID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get interview notes: %w", err)
+	}
+	return notes, nil
+}
+
+func (r *postgresRepository) LastDrafted(ctx context.Context) (map[int]map[domain.Kind]time.Time, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var rows []struct {
+		RoleID    int       `db:"role_id"`
+		Kind      string    `db:"kind"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+	query := `
+		SELECT role_id, kind, MAX(created_at) AS created_at
+		FROM email_drafts
+		WHERE firebase_uid = $1
+		GROUP BY role_id, kind`
+	if err := r.db.SelectContext(ctx, &rows, query, userCtx.UID); err != nil {
+		return nil, fmt.Errorf("failed to get last drafted emails: %w", err)
+	}
+
+	last := make(map[int]map[domain.Kind]time.Time)
+	for _, row := range rows {
+		if last[row.RoleID] == nil {
+			last[row.RoleID] = make(map[domain.Kind]time.Time)
+		}
+		last[row.RoleID][domain.Kind(row.Kind)] = row.CreatedAt
+	}
+	return last, nil
+}
+
+func toDomain(row *models.EmailDraft) domain.Draft {
+	return domain.Draft{
+		ID:             row.ID,
+		RoleID:         row.RoleID,
+		Kind:           domain.Kind(row.Kind),
+		Subject:        row.Subject,
+		Body:           row.Body,
+		RecipientName:  models.Deref(row.RecipientName),
+		RecipientEmail: models.Deref(row.RecipientEmail),
+		InterviewNotes: models.Deref(row.InterviewNotes),
+		Provider:       models.Deref(row.Provider),
+		Model:          models.Deref(row.Model),
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}
+}
+
+var _ Repository = (*postgresRepository)(nil)
@@ -0,0 +1,325 @@
+package generations
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/shared/contexts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	error_messages "github.com/ordo_meritum/shared/utils/errors"
+)
+
+var (
+	ErrGenerationNotFound = errors.New("document generation not found")
+	// ErrGenerationFinished is returned when updating a generation that has
+	// already succeeded, failed or been cancelled.
+	ErrGenerationFinished = errors.New("document generation already finished")
+	ErrBatchNotFound      = errors.New("generation batch not found")
+)
+
+const defaultListLimit = 50
+
+type Repository interface {
+	Create(ctx context.Context, g *domain.Generation) (int, error)
+	Get(ctx context.Context, id int) (*domain.Generation, error)
+	List(ctx context.Context, filter domain.GenerationFilter) ([]domain.Generation, error)
+	Update(ctx context.Context, id int, update domain.GenerationUpdate) (*domain.Generation, error)
+	FailStale(ctx context.Context, statuses []domain.GenerationStatus, before time.Time, code, message string) (int64, error)
+
+	CreateBatch(ctx context.Context) (*domain.Batch, error)
+	GetBatch(ctx context.Context, id int) (*domain.Batch, error)
+	DeleteBatch(ctx context.Context, id int) error
+}
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+func (r *postgresRepository) Create(ctx context.Context, g *domain.Generation) (int, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return 0, error_response.ErrNoUserContext
+	}
+
+	status := g.Status
+	if status == "" {
+		status = domain.GenerationQueued
+	}
+
+	var id int
+	query := `
+		INSERT INTO document_generations (firebase_uid, role_id, doc_type, status, provider, model, batch_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+	err := r.db.GetContext(ctx, &id, query,
+		userCtx.UID,
+		g.RoleID,
+		g.DocType,
+		string(status),
+		models.Optional(g.Provider),
+		models.Optional(g.Model),
+		g.BatchID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create generation: %w", err)
+	}
+	return id, nil
+}
+
+func (r *postgresRepository) Get(ctx context.Context, id int) (*domain.Generation, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var row models.DocumentGeneration
+	query := "SELECT * FROM document_generations WHERE id = $1 AND firebase_uid = $2"
+	if err := r.db.GetContext(ctx, &row, query, id, userCtx.UID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGenerationNotFound
+		}
+		return nil, fmt.Errorf("failed to get generation: %w", err)
+	}
+	g := toDomain(&row)
+	return &g, nil
+}
+
+// List returns the user's generations, newest first.
+func (r *postgresRepository) List(ctx context.Context, filter domain.GenerationFilter) ([]domain.Generation, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	conditions := []string{"firebase_uid = $1"}
+	args := []any{userCtx.UID}
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.RoleID != nil {
+		add("role_id = $%d", *filter.RoleID)
+	}
+	if filter.BatchID != nil {
+		add("batch_id = $%d", *filter.BatchID)
+	}
+	if filter.DocType != "" {
+		add("doc_type = $%d", filter.DocType)
+	}
+	if filter.Status != "" {
+		add("status = $%d", string(filter.Status))
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	args = append(args, limit)
+	query := fmt.Sprintf(
+		"SELECT * FROM document_generations WHERE %s ORDER BY created_at DESC, id DESC LIMIT $%d",
+		strings.Join(conditions, " AND "),
+		len(args),
+	)
+
+	var rows []models.DocumentGeneration
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list generations: %w", err)
+	}
+	generations := make([]domain.Generation, 0, len(rows))
+	for i := range rows {
+		generations = append(generations, toDomain(&rows[i]))
+	}
+	return generations, nil
+}
+
+// Update moves a generation to a new status. A generation that has already
+// finished is left alone and ErrGenerationFinished is returned, so a late
+// completion can't overwrite a cancellation. The one exception is a success
+// for a generation failed because its compile timed out: the document did
+// arrive, so it is kept.
+func (r *postgresRepository) Update(ctx context.Context, id int, update domain.GenerationUpdate) (*domain.Generation, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var styleMatch []byte
+	if update.StyleMatch != nil {
+		var err error
+		if styleMatch, err = json.Marshal(update.StyleMatch); err != nil {
+			return nil, fmt.Errorf("failed to encode style match: %w", err)
+		}
+	}
+
+	var row models.DocumentGeneration
+	query := `
+		UPDATE document_generations SET
+			status        = $3,
+			revision_id   = COALESCE($4, revision_id),
+			error_code    = CASE WHEN $3 = 'succeeded' THEN NULL ELSE COALESCE($5, error_code) END,
+			error_message = CASE WHEN $3 = 'succeeded' THEN NULL ELSE COALESCE($6, error_message) END,
+			download_url  = COALESCE($7, download_url),
+			changes_url   = COALESCE($8, changes_url),
+			style_match   = COALESCE($9, style_match),
+			updated_at    = NOW(),
+			started_at    = CASE WHEN $3 = 'generating' THEN COALESCE(started_at, NOW()) ELSE started_at END,
+			finished_at   = CASE WHEN $3 IN ('succeeded', 'failed', 'cancelled') THEN NOW() ELSE finished_at END
+		WHERE id = $1 AND firebase_uid = $2
+			AND (status NOT IN ('succeeded', 'failed', 'cancelled')
+				OR ($3 = 'succeeded' AND status = 'failed' AND error_code = $10))
+		RETURNING *`
+	err := r.db.GetContext(ctx, &row, query,
+		id,
+		userCtx.UID,
+		string(update.Status),
+		update.RevisionID,
+		models.Optional(update.ErrorCode),
+		models.Optional(update.Error),
+		models.Optional(update.DownloadURL),
+		models.Optional(update.ChangesURL),
+		styleMatch,
+		error_messages.ERR_COMPILATION_TIMEOUT,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, getErr := r.Get(ctx, id); getErr != nil {
+			return nil, getErr
+		}
+		return nil, ErrGenerationFinished
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update generation: %w", err)
+	}
+	g := toDomain(&row)
+	return &g, nil
+}
+
+// FailStale fails every user's rows in one of statuses not touched since
+// before. Those were left behind by an instance that stopped without
+// finishing them, or are waiting on a compiler result that never came back,
+// and nothing else will pick them up.
+func (r *postgresRepository) FailStale(
+	ctx context.Context,
+	statuses []domain.GenerationStatus,
+	before time.Time,
+	code, message string,
+) (int64, error) {
+	names := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		names = append(names, string(s))
+	}
+	query := `
+		UPDATE document_generations SET
+			status        = 'failed',
+			error_code    = $3,
+			error_message = $4,
+			updated_at    = NOW(),
+			finished_at   = NOW()
+		WHERE status = ANY($1) AND updated_at < $2`
+	res, err := r.db.ExecContext(ctx, query, pq.Array(names), before, code, message)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail stale generations: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+func (r *postgresRepository) CreateBatch(ctx context.Context) (*domain.Batch, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var row models.GenerationBatch
+	query := "INSERT INTO generation_batches (firebase_uid) VALUES ($1) RETURNING *"
+	if err := r.db.GetContext(ctx, &row, query, userCtx.UID); err != nil {
+		return nil, fmt.Errorf("failed to create generation batch: %w", err)
+	}
+	return &domain.Batch{ID: row.ID, CreatedAt: row.CreatedAt}, nil
+}
+
+// GetBatch returns the batch without its generations; List them by
+// BatchID.
+func (r *postgresRepository) GetBatch(ctx context.Context, id int) (*domain.Batch, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var row models.GenerationBatch
+	query := "SELECT * FROM generation_batches WHERE id = $1 AND firebase_uid = $2"
+	if err := r.db.GetContext(ctx, &row, query, id, userCtx.UID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrBatchNotFound
+		}
+		return nil, fmt.Errorf("failed to get generation batch: %w", err)
+	}
+	return &domain.Batch{ID: row.ID, CreatedAt: row.CreatedAt}, nil
+}
+
+// DeleteBatch removes the batch. Its generations are kept and just lose
+// their BatchID.
+func (r *postgresRepository) DeleteBatch(ctx context.Context, id int) error {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return error_response.ErrNoUserContext
+	}
+
+	query := "DELETE FROM generation_batches WHERE id = $1 AND firebase_uid = $2"
+	res, err := r.db.ExecContext(ctx, query, id, userCtx.UID)
+	if err != nil {
+		return fmt.Errorf("failed to delete generation batch: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrBatchNotFound
+	}
+	return nil
+}
+
+func toDomain(row *models.DocumentGeneration) domain.Generation {
+	return domain.Generation{
+		ID:          row.ID,
+		RoleID:      row.RoleID,
+		DocType:     row.DocType,
+		Status:      domain.GenerationStatus(row.Status),
+		Provider:    models.Deref(row.Provider),
+		Model:       models.Deref(row.Model),
+		RevisionID:  row.RevisionID,
+		ErrorCode:   models.Deref(row.ErrorCode),
+		Error:       models.Deref(row.ErrorMessage),
+		DownloadURL: models.Deref(row.DownloadURL),
+		ChangesURL:  models.Deref(row.ChangesURL),
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+		StartedAt:   row.StartedAt,
+		FinishedAt:  row.FinishedAt,
+		BatchID:     row.BatchID,
+		StyleMatch:  decodeStyleMatch(row.StyleMatch),
+	}
+}
+
+// decodeStyleMatch reads the style_match column. The match is informational,
+// so one that can't be read is left out rather than failing the generation.
+func decodeStyleMatch(raw []byte) *domain.StyleMatch {
+	if len(raw) == 0 {
+		return nil
+	}
+	var m domain.StyleMatch
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil
+	}
+	return &m
+}
+
+var _ Repository = (*postgresRepository)(nil)
@@ -12,30 +12,33 @@ import (
 	"github.com/ordo_meritum/database/models"
 	"github.com/ordo_meritum/features/application_tracking/models/domain"
 	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/libs/taxonomy"
 	error_response "github.com/ordo_meritum/shared/types/errors"
 )
 
 type FullJobPosting struct {
-	JobTitle               string         `db:"job_title"`
-	Description            *string        `db:"description"`
-	CompanyName            string         `db:"company_name"`
-	CompanyProperName      string         `db:"proper_name"`
-	CompanyCulture         *string        `db:"company_culture"`
-	CompanyValues          *string        `db:"company_values"`
-	Requirements           pq.StringArray `db:"requirements"`
-	NiceToHaves            pq.StringArray `db:"nice_to_haves"`
-	EducationLevel         *string        `db:"education_level"`
-	YearsOfExp             *string        `db:"years_of_exp"`
-	Tools                  pq.StringArray `db:"tools"`
-	ProgrammingLanguages   pq.StringArray `db:"programming_languages"`
-	FrameworksAndLibraries pq.StringArray `db:"frameworks_and_libraries"`
-	Databases              pq.StringArray `db:"databases"`
-	CloudTechnologies      pq.StringArray `db:"cloud_technologies"`
-	IndustryKeywords       pq.StringArray `db:"industry_keywords"`
-	SoftSkills             pq.StringArray `db:"soft_skills"`
-	Certifications         pq.StringArray `db:"certifications"`
-	ApplicantCount         *int           `db:"applicant_count"`
-	SalaryRange            *string        `db:"salary_range"`
+	JobTitle               string            `db:"job_title"`
+	Description            *string           `db:"description"`
+	CompanyName            string            `db:"company_name"`
+	CompanyProperName      string            `db:"proper_name"`
+	CompanyCulture         *string           `db:"company_culture"`
+	CompanyValues          *string           `db:"company_values"`
+	Requirements           pq.StringArray    `db:"requirements"`
+	NiceToHaves            pq.StringArray    `db:"nice_to_haves"`
+	EducationLevel         *string           `db:"education_level"`
+	YearsOfExp             *string           `db:"years_of_exp"`
+	Tools                  pq.StringArray    `db:"tools"`
+	ProgrammingLanguages   pq.StringArray    `db:"programming_languages"`
+	FrameworksAndLibraries pq.StringArray    `db:"frameworks_and_libraries"`
+	Databases              pq.StringArray    `db:"databases"`
+	CloudTechnologies      pq.StringArray    `db:"cloud_technologies"`
+	IndustryKeywords       pq.StringArray    `db:"industry_keywords"`
+	SoftSkills             pq.StringArray    `db:"soft_skills"`
+	Certifications         pq.StringArray    `db:"certifications"`
+	ApplicantCount         *int              `db:"applicant_count"`
+	SalaryRange            *string           `db:"salary_range"`
+	ApplicationStatus      *models.AppStatus `db:"application_status"`
+	AppliedOn              *time.Time        `db:"applied_on"`
 }
 
 type UserJobPosting struct {
@@ -50,10 +53,29 @@ type UserJobPosting struct {
 	InitialApplicationDate *time.Time        `db:"initial_application_date"`
 }
 
+// JobSkills holds the listed skills and keywords of one of the user's tracked
+// jobs.
+type JobSkills struct {
+	RoleID                 int            `db:"role_id"`
+	Tools                  pq.StringArray `db:"tools"`
+	ProgrammingLanguages   pq.StringArray `db:"programming_languages"`
+	FrameworksAndLibraries pq.StringArray `db:"frameworks_and_libraries"`
+	Databases              pq.StringArray `db:"databases"`
+	CloudTechnologies      pq.StringArray `db:"cloud_technologies"`
+	Certifications         pq.StringArray `db:"certifications"`
+	IndustryKeywords       pq.StringArray `db:"industry_keywords"`
+	SoftSkills             pq.StringArray `db:"soft_skills"`
+}
+
+// ErrJobNotFound is returned when the role doesn't exist or isn't tracked by
+// the user.
+var ErrJobNotFound = errors.New("job not found")
+
 type Repository interface {
 	GetFullJobPosting(ctx context.Context, roleID int) (*FullJobPosting, error)
 	InsertFullJobPosting(ctx context.Context, jobRawText string, jobPost *domain.JobDescription, companyName string, properName string) (*models.JobRequirements, error)
 	GetAllUserJobPostings(ctx context.Context) ([]*UserJobPosting, error)
+	GetAllUserJobSkills(ctx context.Context) ([]*JobSkills, error)
 	UpdateApplicationDetails(ctx context.Context, roleID int, status *models.AppStatus, applicationDate *time.Time) error
 	DeleteJobPostByID(ctx context.Context, roleID int) error
 }
@@ -80,7 +102,7 @@ func (r *postgresRepository) GetFullJobPosting(ctx context.Context, roleID int)
             j.tools, j.programming_languages, j.frameworks_and_libraries, j.databases,
             j.cloud_technologies, j.industry_keywords, j.soft_skills, j.certifications,
             j.applicant_count,
-            r.salary_range
+            r.salary_range, r.application_status, res.applied_on
         FROM roles r
         INNER JOIN companies c ON r.company_id = c.id
         INNER JOIN job_requirements j ON r.id = j.role_id
@@ -90,7 +112,7 @@ func (r *postgresRepository) GetFullJobPosting(ctx context.Context, roleID int)
 	err := r.db.GetContext(ctx, &job, query, roleID, userCtx.UID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("job with role ID %d not found", roleID)
+			return nil, fmt.Errorf("%w: role ID %d", ErrJobNotFound, roleID)
 		}
 		return nil, err
 	}
@@ -138,21 +160,22 @@ func (r *postgresRepository) InsertFullJobPosting(
 		return nil, fmt.Errorf("failed to create resume entry: %w", err)
 	}
 
+	tax := taxonomy.Default()
 	reqs := models.JobRequirements{
 		RoleID:                 roleID,
 		EducationLevel:         &jobPost.EducationLevel,
 		ApplicantCount:         &jobPost.ApplicantCount,
 		YearsOfExp:             &jobPost.YearsOfExp,
-		Tools:                  jobPost.ToolsAndTechnologies,
-		ProgrammingLanguages:   jobPost.ProgrammingLanguages,
-		FrameworksAndLibraries: jobPost.FrameworksAndLibraries,
-		Databases:              jobPost.Databases,
-		CloudTechnologies:      jobPost.CloudTechnologies,
+		Tools:                  tax.NormalizeList(jobPost.ToolsAndTechnologies),
+		ProgrammingLanguages:   tax.NormalizeList(jobPost.ProgrammingLanguages),
+		FrameworksAndLibraries: tax.NormalizeList(jobPost.FrameworksAndLibraries),
+		Databases:              tax.NormalizeList(jobPost.Databases),
+		CloudTechnologies:      tax.NormalizeList(jobPost.CloudTechnologies),
 		IndustryKeywords:       jobPost.IndustryKeywords,
 		Requirements:           jobPost.SkillsRequired,
 		NiceToHaves:            jobPost.SkillsNiceToHaves,
 		SoftSkills:             jobPost.SoftSkills,
-		Certifications:         jobPost.Certifications,
+		Certifications:         tax.NormalizeList(jobPost.Certifications),
 	}
 	reqQuery := `
         INSERT INTO job_requirements (role_id, education_level, applicant_count, years_of_exp, tools, programming_languages, frameworks_and_libraries, databases, cloud_technologies, industry_keywords, requirements, nice_to_haves, soft_skills, certifications)
@@ -200,6 +223,24 @@ func (r *postgresRepository) GetAllUserJobPostings(ctx context.Context) ([]*User
 	return jobs, err
 }
 
+func (r *postgresRepository) GetAllUserJobSkills(ctx context.Context) ([]*JobSkills, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+	query := `
+        SELECT
+            j.role_id, j.tools, j.programming_languages, j.frameworks_and_libraries,
+            j.databases, j.cloud_technologies, j.certifications,
+            j.industry_keywords, j.soft_skills
+        FROM job_requirements j
+        INNER JOIN resumes res ON j.role_id = res.role_id
+        WHERE res.firebase_uid = $1`
+	var skills []*JobSkills
+	err := r.db.SelectContext(ctx, &skills, query, userCtx.UID)
+	return skills, err
+}
+
 func (r *postgresRepository) UpdateApplicationDetails(ctx context.Context, roleID int, status *models.AppStatus, applicationDate *time.Time) error {
 	userCtx, ok := contexts.FromContext(ctx)
 	if !ok {
@@ -0,0 +1,199 @@
+package library
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/shared/contexts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+var ErrDocumentNotFound = errors.New("library document not found")
+
+const defaultListLimit = 50
+
+type Repository interface {
+	Create(ctx context.Context, d *domain.LibraryDocument) (*domain.LibraryDocument, error)
+	Get(ctx context.Context, id int) (*domain.LibraryDocument, error)
+	List(ctx context.Context, filter domain.LibraryFilter) ([]domain.LibraryDocument, error)
+	Delete(ctx context.Context, id int) (*domain.LibraryDocument, error)
+}
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+// Create records d. Its artifacts' keys are stored; names and URLs are not.
+func (r *postgresRepository) Create(ctx context.Context, d *domain.LibraryDocument) (*domain.LibraryDocument, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	keys := make(map[domain.ArtifactKind]string, len(d.Artifacts))
+	for _, a := range d.Artifacts {
+		keys[a.Kind] = a.Key
+	}
+	if keys[domain.ArtifactPDF] == "" {
+		return nil, errors.New("library document has no PDF")
+	}
+
+	var row models.LibraryDocument
+	query := `
+		INSERT INTO library_documents
+			(firebase_uid, role_id, generation_id, revision_id, doc_type, template, pdf_key, changes_key, source_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING *`
+	err := r.db.GetContext(ctx, &row, query,
+		userCtx.UID,
+		d.RoleID,
+		d.GenerationID,
+		d.RevisionID,
+		d.DocType,
+		models.Optional(d.Template),
+		keys[domain.ArtifactPDF],
+		models.Optional(keys[domain.ArtifactChanges]),
+		models.Optional(keys[domain.ArtifactSource]),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create library document: %w", err)
+	}
+	created := toDomain(&row)
+	return &created, nil
+}
+
+func (r *postgresRepository) Get(ctx context.Context, id int) (*domain.LibraryDocument, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var row models.LibraryDocument
+	query := "SELECT * FROM library_documents WHERE id = $1 AND firebase_uid = $2"
+	if err := r.db.GetContext(ctx, &row, query, id, userCtx.UID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDocumentNotFound
+		}
+		return nil, fmt.Errorf("failed to get library document: %w", err)
+	}
+	d := toDomain(&row)
+	return &d, nil
+}
+
+// List returns the user's library, newest first.
+func (r *postgresRepository) List(ctx context.Context, filter domain.LibraryFilter) ([]domain.LibraryDocument, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	conditions := []string{"firebase_uid = $1"}
+	args := []any{userCtx.UID}
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.RoleID != nil {
+		add("role_id = $%d", *filter.RoleID)
+	}
+	if filter.DocType != "" {
+		add("doc_type = $%d", filter.DocType)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	args = append(args, limit)
+	query := fmt.Sprintf(
+		"SELECT * FROM library_documents WHERE %s ORDER BY created_at DESC, id DESC LIMIT $%d",
+		strings.Join(conditions, " AND "),
+		len(args),
+	)
+
+	var rows []models.LibraryDocument
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list library documents: %w", err)
+	}
+	documents := make([]domain.LibraryDocument, 0, len(rows))
+	for i := range rows {
+		documents = append(documents, toDomain(&rows[i]))
+	}
+	return documents, nil
+}
+
+// Delete removes the document and returns it, so the caller can delete its
+// stored artifacts. The generation that produced it stops pointing at them.
+func (r *postgresRepository) Delete(ctx context.Context, id int) (*domain.LibraryDocument, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var row models.LibraryDocument
+	query := "DELETE FROM library_documents WHERE id = $1 AND firebase_uid = $2 RETURNING *"
+	if err := tx.GetContext(ctx, &row, query, id, userCtx.UID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDocumentNotFound
+		}
+		return nil, fmt.Errorf("failed to delete library document: %w", err)
+	}
+
+	if row.GenerationID != nil {
+		query = `
+			UPDATE document_generations SET download_url = NULL, changes_url = NULL, updated_at = NOW()
+			WHERE id = $1 AND firebase_uid = $2`
+		if _, err := tx.ExecContext(ctx, query, *row.GenerationID, userCtx.UID); err != nil {
+			return nil, fmt.Errorf("failed to unlink generation: %w", err)
+		}
+	}
+
+	d := toDomain(&row)
+	return &d, tx.Commit()
+}
+
+func toDomain(row *models.LibraryDocument) domain.LibraryDocument {
+	d := domain.LibraryDocument{
+		ID:           row.ID,
+		RoleID:       row.RoleID,
+		GenerationID: row.GenerationID,
+		RevisionID:   row.RevisionID,
+		DocType:      row.DocType,
+		CreatedAt:    row.CreatedAt,
+	}
+	if row.Template != nil {
+		d.Template = *row.Template
+	}
+
+	d.Artifacts = append(d.Artifacts, artifact(domain.ArtifactPDF, row.PDFKey))
+	if row.ChangesKey != nil {
+		d.Artifacts = append(d.Artifacts, artifact(domain.ArtifactChanges, *row.ChangesKey))
+	}
+	if row.SourceKey != nil {
+		d.Artifacts = append(d.Artifacts, artifact(domain.ArtifactSource, *row.SourceKey))
+	}
+	return d
+}
+
+func artifact(kind domain.ArtifactKind, key string) domain.Artifact {
+	return domain.Artifact{Kind: kind, Name: path.Base(key), Key: key}
+}
+
+var _ Repository = (*postgresRepository)(nil)
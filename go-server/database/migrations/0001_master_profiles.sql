@@ -0,0 +1,84 @@
+-- Master profile: the base resume data each user tailors from. One profile
+-- row per user; every collection below hangs off it and is ordered by
+-- sort_order.
+
+CREATE TABLE IF NOT EXISTS profiles (
+    firebase_uid     TEXT PRIMARY KEY REFERENCES users (firebase_uid) ON DELETE CASCADE,
+    first_name       TEXT NOT NULL DEFAULT '',
+    last_name        TEXT NOT NULL DEFAULT '',
+    current_location TEXT NOT NULL DEFAULT '',
+    email            TEXT NOT NULL DEFAULT '',
+    github           TEXT,
+    linkedin         TEXT,
+    mobile           TEXT,
+    summary          TEXT,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS profile_education (
+    id           SERIAL PRIMARY KEY,
+    firebase_uid TEXT NOT NULL REFERENCES profiles (firebase_uid) ON DELETE CASCADE,
+    school       TEXT NOT NULL,
+    degree       TEXT NOT NULL DEFAULT '',
+    location     TEXT NOT NULL DEFAULT '',
+    dates        TEXT NOT NULL DEFAULT '',
+    courses      TEXT,
+    gpa          DOUBLE PRECISION,
+    honors       TEXT,
+    sort_order   INTEGER NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS profile_experiences (
+    id            SERIAL PRIMARY KEY,
+    firebase_uid  TEXT NOT NULL REFERENCES profiles (firebase_uid) ON DELETE CASCADE,
+    company       TEXT NOT NULL,
+    position      TEXT NOT NULL,
+    years         TEXT NOT NULL DEFAULT '',
+    bullet_points TEXT[] NOT NULL DEFAULT '{}',
+    sort_order    INTEGER NOT NULL DEFAULT 0,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS profile_projects (
+    id            SERIAL PRIMARY KEY,
+    firebase_uid  TEXT NOT NULL REFERENCES profiles (firebase_uid) ON DELETE CASCADE,
+    name          TEXT NOT NULL,
+    description   TEXT NOT NULL DEFAULT '',
+    years         TEXT NOT NULL DEFAULT '',
+    bullet_points TEXT[] NOT NULL DEFAULT '{}',
+    sort_order    INTEGER NOT NULL DEFAULT 0,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS profile_skills (
+    id           SERIAL PRIMARY KEY,
+    firebase_uid TEXT NOT NULL REFERENCES profiles (firebase_uid) ON DELETE CASCADE,
+    name         TEXT NOT NULL,
+    category     TEXT NOT NULL DEFAULT '',
+    sort_order   INTEGER NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Free-form sections (volunteering, interests, etc.) that feed the prompt's
+-- additional info block.
+CREATE TABLE IF NOT EXISTS profile_sections (
+    id           SERIAL PRIMARY KEY,
+    firebase_uid TEXT NOT NULL REFERENCES profiles (firebase_uid) ON DELETE CASCADE,
+    title        TEXT NOT NULL,
+    content      TEXT NOT NULL DEFAULT '',
+    sort_order   INTEGER NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_profile_education_uid ON profile_education (firebase_uid, sort_order);
+CREATE INDEX IF NOT EXISTS idx_profile_experiences_uid ON profile_experiences (firebase_uid, sort_order);
+CREATE INDEX IF NOT EXISTS idx_profile_projects_uid ON profile_projects (firebase_uid, sort_order);
+CREATE INDEX IF NOT EXISTS idx_profile_skills_uid ON profile_skills (firebase_uid, sort_order);
+CREATE INDEX IF NOT EXISTS idx_profile_sections_uid ON profile_sections (firebase_uid, sort_order);
@@ -0,0 +1,18 @@
+-- Named base resumes built from the master profile. A NULL id list means
+-- "everything in the profile, in profile order"; otherwise the list picks
+-- and orders the entries to include.
+
+CREATE TABLE IF NOT EXISTS profile_variants (
+    id             SERIAL PRIMARY KEY,
+    firebase_uid   TEXT NOT NULL REFERENCES profiles (firebase_uid) ON DELETE CASCADE,
+    name           TEXT NOT NULL,
+    summary        TEXT,
+    experience_ids INTEGER[],
+    project_ids    INTEGER[],
+    skill_ids      INTEGER[],
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (firebase_uid, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_profile_variants_uid ON profile_variants (firebase_uid);
@@ -0,0 +1,19 @@
+-- Immutable snapshots of a tailored resume. The experiences, projects and
+-- skills tables always hold the current version; every write to them also
+-- appends a revision here so earlier versions can be compared and restored.
+
+CREATE TABLE IF NOT EXISTS resume_revisions (
+    id              SERIAL PRIMARY KEY,
+    resume_id       INTEGER NOT NULL REFERENCES resumes (id) ON DELETE CASCADE,
+    revision_number INTEGER NOT NULL,
+    author          TEXT NOT NULL CHECK (author IN ('llm', 'user')),
+    provider        TEXT,
+    model           TEXT,
+    prompt_version  TEXT,
+    restored_from   INTEGER REFERENCES resume_revisions (id) ON DELETE SET NULL,
+    content         JSONB NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (resume_id, revision_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_resume_revisions_resume ON resume_revisions (resume_id, revision_number DESC);
@@ -0,0 +1,28 @@
+-- Review state for LLM-tailored resumes. A generated resume waits here with
+-- each suggestion marked pending until the user accepts, rejects or edits it
+-- and finalizes; only then is it compiled.
+
+CREATE TABLE IF NOT EXISTS resume_reviews (
+    id           SERIAL PRIMARY KEY,
+    resume_id    INTEGER NOT NULL UNIQUE REFERENCES resumes (id) ON DELETE CASCADE,
+    revision_id  INTEGER REFERENCES resume_revisions (id) ON DELETE SET NULL,
+    status       TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'finalized')),
+    content      JSONB NOT NULL,
+    event        JSONB NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finalized_at TIMESTAMPTZ
+);
+
+-- Suggestions the user turned down. The most recent ones are passed back to
+-- the LLM as corrections the next time the resume is generated.
+CREATE TABLE IF NOT EXISTS resume_rejected_suggestions (
+    id            SERIAL PRIMARY KEY,
+    resume_id     INTEGER NOT NULL REFERENCES resumes (id) ON DELETE CASCADE,
+    section       TEXT NOT NULL,
+    text          TEXT NOT NULL,
+    justification TEXT,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_resume_rejected_suggestions_resume ON resume_rejected_suggestions (resume_id, created_at DESC);
@@ -0,0 +1,15 @@
+-- Lock flags for wording that must reach the resume unchanged, such as
+-- compliance-approved bullets. locked freezes a whole entry; locked_bullets
+-- holds the indexes of individual frozen bullets.
+
+ALTER TABLE profile_experiences
+    ADD COLUMN IF NOT EXISTS locked BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS locked_bullets INTEGER[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE profile_projects
+    ADD COLUMN IF NOT EXISTS locked BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS locked_bullets INTEGER[] NOT NULL DEFAULT '{}';
+
+-- What the post-generation checks found and repaired in an LLM revision.
+ALTER TABLE resume_revisions
+    ADD COLUMN IF NOT EXISTS report JSONB;
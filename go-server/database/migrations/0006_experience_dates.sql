@@ -0,0 +1,8 @@
+-- Experience dates keep the precision they were written with, so "2021" or
+-- "Summer 2020" don't read back as "Jan. 2021" and "Jun. 2020". A current
+-- role has no end date.
+
+ALTER TABLE experiences
+    ALTER COLUMN end_date DROP NOT NULL,
+    ADD COLUMN IF NOT EXISTS start_precision TEXT NOT NULL DEFAULT 'month',
+    ADD COLUMN IF NOT EXISTS end_precision TEXT NOT NULL DEFAULT 'month';
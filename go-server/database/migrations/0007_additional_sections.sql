@@ -0,0 +1,132 @@
+-- Certifications, publications, volunteering, awards and languages, both on
+-- the master profile and on each tailored resume. Resume rows keep the order
+-- the LLM chose in sort_order. Volunteering bullets mirror
+-- experience_descriptions so they can carry suggestions through review.
+
+CREATE TABLE IF NOT EXISTS profile_certifications (
+    id           SERIAL PRIMARY KEY,
+    firebase_uid TEXT NOT NULL REFERENCES profiles (firebase_uid) ON DELETE CASCADE,
+    name         TEXT NOT NULL,
+    issuer       TEXT NOT NULL DEFAULT '',
+    date         TEXT NOT NULL DEFAULT '',
+    url          TEXT,
+    sort_order   INTEGER NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS profile_publications (
+    id           SERIAL PRIMARY KEY,
+    firebase_uid TEXT NOT NULL REFERENCES profiles (firebase_uid) ON DELETE CASCADE,
+    title        TEXT NOT NULL,
+    publisher    TEXT NOT NULL DEFAULT '',
+    date         TEXT NOT NULL DEFAULT '',
+    url          TEXT,
+    summary      TEXT,
+    sort_order   INTEGER NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS profile_volunteering (
+    id            SERIAL PRIMARY KEY,
+    firebase_uid  TEXT NOT NULL REFERENCES profiles (firebase_uid) ON DELETE CASCADE,
+    organization  TEXT NOT NULL,
+    role          TEXT NOT NULL DEFAULT '',
+    years         TEXT NOT NULL DEFAULT '',
+    bullet_points TEXT[] NOT NULL DEFAULT '{}',
+    sort_order    INTEGER NOT NULL DEFAULT 0,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS profile_awards (
+    id           SERIAL PRIMARY KEY,
+    firebase_uid TEXT NOT NULL REFERENCES profiles (firebase_uid) ON DELETE CASCADE,
+    title        TEXT NOT NULL,
+    awarder      TEXT NOT NULL DEFAULT '',
+    date         TEXT NOT NULL DEFAULT '',
+    summary      TEXT,
+    sort_order   INTEGER NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS profile_languages (
+    id           SERIAL PRIMARY KEY,
+    firebase_uid TEXT NOT NULL REFERENCES profiles (firebase_uid) ON DELETE CASCADE,
+    language     TEXT NOT NULL,
+    fluency      TEXT NOT NULL DEFAULT '',
+    sort_order   INTEGER NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_profile_certifications_uid ON profile_certifications (firebase_uid, sort_order);
+CREATE INDEX IF NOT EXISTS idx_profile_publications_uid ON profile_publications (firebase_uid, sort_order);
+CREATE INDEX IF NOT EXISTS idx_profile_volunteering_uid ON profile_volunteering (firebase_uid, sort_order);
+CREATE INDEX IF NOT EXISTS idx_profile_awards_uid ON profile_awards (firebase_uid, sort_order);
+CREATE INDEX IF NOT EXISTS idx_profile_languages_uid ON profile_languages (firebase_uid, sort_order);
+
+CREATE TABLE IF NOT EXISTS certifications (
+    id         SERIAL PRIMARY KEY,
+    resume_id  INTEGER NOT NULL REFERENCES resumes (id) ON DELETE CASCADE,
+    name       TEXT NOT NULL,
+    issuer     TEXT NOT NULL DEFAULT '',
+    date       TEXT NOT NULL DEFAULT '',
+    url        TEXT,
+    sort_order INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS publications (
+    id         SERIAL PRIMARY KEY,
+    resume_id  INTEGER NOT NULL REFERENCES resumes (id) ON DELETE CASCADE,
+    title      TEXT NOT NULL,
+    publisher  TEXT NOT NULL DEFAULT '',
+    date       TEXT NOT NULL DEFAULT '',
+    url        TEXT,
+    summary    TEXT,
+    sort_order INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS volunteering (
+    id           SERIAL PRIMARY KEY,
+    resume_id    INTEGER NOT NULL REFERENCES resumes (id) ON DELETE CASCADE,
+    organization TEXT NOT NULL,
+    role         TEXT NOT NULL DEFAULT '',
+    start_date   TEXT NOT NULL DEFAULT '',
+    end_date     TEXT NOT NULL DEFAULT '',
+    sort_order   INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS volunteering_descriptions (
+    id                       SERIAL PRIMARY KEY,
+    volunteering_id          INTEGER NOT NULL REFERENCES volunteering (id) ON DELETE CASCADE,
+    text                     TEXT NOT NULL,
+    new_suggestion           BOOLEAN NOT NULL DEFAULT FALSE,
+    justification_for_change TEXT
+);
+
+CREATE TABLE IF NOT EXISTS awards (
+    id         SERIAL PRIMARY KEY,
+    resume_id  INTEGER NOT NULL REFERENCES resumes (id) ON DELETE CASCADE,
+    title      TEXT NOT NULL,
+    awarder    TEXT NOT NULL DEFAULT '',
+    date       TEXT NOT NULL DEFAULT '',
+    summary    TEXT,
+    sort_order INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS languages (
+    id         SERIAL PRIMARY KEY,
+    resume_id  INTEGER NOT NULL REFERENCES resumes (id) ON DELETE CASCADE,
+    language   TEXT NOT NULL,
+    fluency    TEXT NOT NULL DEFAULT '',
+    sort_order INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_certifications_resume ON certifications (resume_id, sort_order);
+CREATE INDEX IF NOT EXISTS idx_publications_resume ON publications (resume_id, sort_order);
+CREATE INDEX IF NOT EXISTS idx_volunteering_resume ON volunteering (resume_id, sort_order);
+CREATE INDEX IF NOT EXISTS idx_awards_resume ON awards (resume_id, sort_order);
+CREATE INDEX IF NOT EXISTS idx_languages_resume ON languages (resume_id, sort_order);
@@ -0,0 +1,30 @@
+-- One row per document generation request, so its outcome survives a dropped
+-- websocket. The row moves queued -> generating -> compiling (or
+-- pending_review while a resume waits on the user) and ends as succeeded,
+-- failed or cancelled; the Kafka completion consumer records the compiler's
+-- result.
+
+CREATE TABLE IF NOT EXISTS document_generations (
+    id            SERIAL PRIMARY KEY,
+    firebase_uid  TEXT NOT NULL,
+    role_id       INTEGER,
+    doc_type      TEXT NOT NULL,
+    status        TEXT NOT NULL DEFAULT 'queued' CHECK (status IN (
+                      'queued', 'generating', 'pending_review', 'compiling',
+                      'succeeded', 'failed', 'cancelled'
+                  )),
+    provider      TEXT,
+    model         TEXT,
+    revision_id   INTEGER REFERENCES resume_revisions (id) ON DELETE SET NULL,
+    error_code    TEXT,
+    error_message TEXT,
+    download_url  TEXT,
+    changes_url   TEXT,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at    TIMESTAMPTZ,
+    finished_at   TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_generations_user ON document_generations (firebase_uid, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_document_generations_role ON document_generations (firebase_uid, role_id, created_at DESC);
@@ -0,0 +1,19 @@
+-- One row per job posting submitted for tracking. Parsing runs in the
+-- background, so the row is what the client polls (or hears about over the
+-- websocket) until role_id points at the tracked job.
+
+CREATE TABLE IF NOT EXISTS tracking_requests (
+    id            SERIAL PRIMARY KEY,
+    firebase_uid  TEXT NOT NULL,
+    status        TEXT NOT NULL DEFAULT 'queued' CHECK (status IN (
+                      'queued', 'parsing', 'succeeded', 'failed'
+                  )),
+    role_id       INTEGER,
+    error_code    TEXT,
+    error_message TEXT,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at   TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_tracking_requests_user ON tracking_requests (firebase_uid, created_at DESC);
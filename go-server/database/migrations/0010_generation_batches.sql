@@ -0,0 +1,13 @@
+-- A batch groups the generations started by one batch request, so their
+-- progress can be reported together and their PDFs downloaded as one archive.
+
+CREATE TABLE IF NOT EXISTS generation_batches (
+    id           SERIAL PRIMARY KEY,
+    firebase_uid TEXT NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE document_generations
+    ADD COLUMN IF NOT EXISTS batch_id INTEGER REFERENCES generation_batches (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_document_generations_batch ON document_generations (batch_id);
@@ -0,0 +1,21 @@
+-- The document library keeps one row per compiled document, so every
+-- artifact it produced stays reachable after the websocket notice that
+-- announced it. The keys point into the document store; a document without a
+-- changes log or LaTeX source leaves that key null.
+
+CREATE TABLE IF NOT EXISTS library_documents (
+    id            SERIAL PRIMARY KEY,
+    firebase_uid  TEXT NOT NULL,
+    role_id       INTEGER,
+    generation_id INTEGER UNIQUE REFERENCES document_generations (id) ON DELETE SET NULL,
+    revision_id   INTEGER REFERENCES resume_revisions (id) ON DELETE SET NULL,
+    doc_type      TEXT NOT NULL,
+    template      TEXT,
+    pdf_key       TEXT NOT NULL,
+    changes_key   TEXT,
+    source_key    TEXT,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_library_documents_user ON library_documents (firebase_uid, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_library_documents_role ON library_documents (firebase_uid, role_id, created_at DESC);
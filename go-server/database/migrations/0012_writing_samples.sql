@@ -0,0 +1,12 @@
+-- Writing samples are kept per user so cover letters can borrow the
+-- candidate's voice without the client resending them every time.
+
+CREATE TABLE IF NOT EXISTS candidate_writing_samples (
+    id           SERIAL PRIMARY KEY,
+    firebase_uid TEXT NOT NULL,
+    content      TEXT NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_candidate_writing_samples_user ON candidate_writing_samples (firebase_uid, updated_at DESC);
@@ -0,0 +1,14 @@
+-- The stylometric fingerprint of each user's writing samples, rebuilt when
+-- the samples change. sample_count and samples_updated_at identify the
+-- samples it was built from. Cover letter generations record how far the
+-- letter's style landed from it.
+
+CREATE TABLE IF NOT EXISTS writing_style_profiles (
+    firebase_uid       TEXT PRIMARY KEY,
+    profile            JSONB NOT NULL,
+    sample_count       INTEGER NOT NULL,
+    samples_updated_at TIMESTAMPTZ NOT NULL,
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE document_generations ADD COLUMN IF NOT EXISTS style_match JSONB;
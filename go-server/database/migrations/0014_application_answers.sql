@@ -0,0 +1,22 @@
+-- Answers to free-text application questions, kept per role so the same
+-- question asked again on a form is answered from here instead of the LLM.
+-- question_key is the question normalized for matching: lowercased, with
+-- punctuation and repeated whitespace removed.
+
+CREATE TABLE IF NOT EXISTS application_answers (
+    id           SERIAL PRIMARY KEY,
+    firebase_uid TEXT NOT NULL,
+    role_id      INTEGER NOT NULL,
+    question     TEXT NOT NULL,
+    question_key TEXT NOT NULL,
+    answer       TEXT NOT NULL,
+    char_limit   INTEGER NOT NULL,
+    provider     TEXT,
+    model        TEXT,
+    edited       BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (firebase_uid, role_id, question_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_application_answers_role ON application_answers (firebase_uid, role_id, created_at);
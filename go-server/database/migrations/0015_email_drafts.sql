@@ -0,0 +1,22 @@
+-- Drafted emails for a tracked role: post-application follow-ups,
+-- post-interview thank-yous, offer negotiations and withdrawals. A role can
+-- have any number of drafts; interview_notes keeps what the user told us
+-- about the interview so later drafts for the role can reuse it.
+
+CREATE TABLE IF NOT EXISTS email_drafts (
+    id              SERIAL PRIMARY KEY,
+    firebase_uid    TEXT NOT NULL,
+    role_id         INTEGER NOT NULL,
+    kind            TEXT NOT NULL CHECK (kind IN ('follow_up', 'thank_you', 'negotiation', 'withdrawal')),
+    subject         TEXT NOT NULL,
+    body            TEXT NOT NULL,
+    recipient_name  TEXT,
+    recipient_email TEXT,
+    interview_notes TEXT,
+    provider        TEXT,
+    model           TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_drafts_role ON email_drafts (firebase_uid, role_id, created_at DESC);
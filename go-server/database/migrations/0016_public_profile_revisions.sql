@@ -0,0 +1,17 @@
+-- Generated LinkedIn and portfolio profile copy: headline and About variants
+-- and featured skills, tuned to the roles the user tracks. Each generation
+-- is kept as an immutable revision; content also records the aggregated
+-- role demand it was written for.
+
+CREATE TABLE IF NOT EXISTS public_profile_revisions (
+    id              SERIAL PRIMARY KEY,
+    firebase_uid    TEXT NOT NULL,
+    revision_number INTEGER NOT NULL,
+    provider        TEXT,
+    model           TEXT,
+    content         JSONB NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (firebase_uid, revision_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_public_profile_revisions_user ON public_profile_revisions (firebase_uid, revision_number DESC);
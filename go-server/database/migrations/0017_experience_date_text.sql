@@ -0,0 +1,8 @@
+-- Experience dates that don't parse are kept as written instead of being
+-- dropped. A NULL end_date used to be the only way to say "no end", so a
+-- typo or a missing end read back as a current role; now only an ongoing
+-- role has both end_date and end_text NULL.
+
+ALTER TABLE experiences
+    ADD COLUMN IF NOT EXISTS start_text TEXT,
+    ADD COLUMN IF NOT EXISTS end_text TEXT;
@@ -143,14 +143,20 @@ type Metrics struct {
 }
 
 type Experience struct {
-	ID        int        `db:"id"`
-	ResumeID  int        `db:"resume_id"`
-	Position  string     `db:"position"`
-	Company   string     `db:"company"`
-	StartDate time.Time  `db:"start_date"`
-	EndDate   *time.Time `db:"end_date"`
-	CreatedAt time.Time  `db:"created_at"`
-	UpdatedAt time.Time  `db:"updated_at"`
+	ID             int        `db:"id"`
+	ResumeID       int        `db:"resume_id"`
+	Position       string     `db:"position"`
+	Company        string     `db:"company"`
+	StartDate      time.Time  `db:"start_date"`
+	EndDate        *time.Time `db:"end_date"`
+	StartPrecision string     `db:"start_precision"`
+	EndPrecision   string     `db:"end_precision"`
+	// StartText and EndText hold dates as written when they didn't parse.
+	// An end with neither EndDate nor EndText is ongoing.
+	StartText *string   `db:"start_text"`
+	EndText   *string   `db:"end_text"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
 }
 
 type ExperienceDescription struct {
@@ -209,6 +215,63 @@ type Education struct {
 	UpdatedAt time.Time `db:"updated_at"`
 }
 
+type Certification struct {
+	ID        int     `db:"id"`
+	ResumeID  int     `db:"resume_id"`
+	Name      string  `db:"name"`
+	Issuer    string  `db:"issuer"`
+	Date      string  `db:"date"`
+	URL       *string `db:"url"`
+	SortOrder int     `db:"sort_order"`
+}
+
+type Publication struct {
+	ID        int     `db:"id"`
+	ResumeID  int     `db:"resume_id"`
+	Title     string  `db:"title"`
+	Publisher string  `db:"publisher"`
+	Date      string  `db:"date"`
+	URL       *string `db:"url"`
+	Summary   *string `db:"summary"`
+	SortOrder int     `db:"sort_order"`
+}
+
+type Volunteering struct {
+	ID           int    `db:"id"`
+	ResumeID     int    `db:"resume_id"`
+	Organization string `db:"organization"`
+	Role         string `db:"role"`
+	StartDate    string `db:"start_date"`
+	EndDate      string `db:"end_date"`
+	SortOrder    int    `db:"sort_order"`
+}
+
+type VolunteeringDescription struct {
+	ID                     int     `db:"id"`
+	VolunteeringID         int     `db:"volunteering_id"`
+	Text                   string  `db:"text"`
+	JustificationForChange *string `db:"justification_for_change"`
+	NewSuggestion          bool    `db:"new_suggestion"`
+}
+
+type Award struct {
+	ID        int     `db:"id"`
+	ResumeID  int     `db:"resume_id"`
+	Title     string  `db:"title"`
+	Awarder   string  `db:"awarder"`
+	Date      string  `db:"date"`
+	Summary   *string `db:"summary"`
+	SortOrder int     `db:"sort_order"`
+}
+
+type Language struct {
+	ID        int    `db:"id"`
+	ResumeID  int    `db:"resume_id"`
+	Language  string `db:"language"`
+	Fluency   string `db:"fluency"`
+	SortOrder int    `db:"sort_order"`
+}
+
 type CandidateQuestionnaire struct {
 	ID           int         `db:"id"`
 	FirebaseUID  string      `db:"firebase_uid"`
@@ -235,6 +298,55 @@ type CandidateWritingSample struct {
 	UpdatedAt   time.Time `db:"updated_at"`
 }
 
+type ApplicationAnswer struct {
+	ID          int       `db:"id"`
+	FirebaseUID string    `db:"firebase_uid"`
+	RoleID      int       `db:"role_id"`
+	Question    string    `db:"question"`
+	QuestionKey string    `db:"question_key"`
+	Answer      string    `db:"answer"`
+	CharLimit   int       `db:"char_limit"`
+	Provider    *string   `db:"provider"`
+	Model       *string   `db:"model"`
+	Edited      bool      `db:"edited"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+type EmailDraft struct {
+	ID             int       `db:"id"`
+	FirebaseUID    string    `db:"firebase_uid"`
+	RoleID         int       `db:"role_id"`
+	Kind           string    `db:"kind"`
+	Subject        string    `db:"subject"`
+	Body           string    `db:"body"`
+	RecipientName  *string   `db:"recipient_name"`
+	RecipientEmail *string   `db:"recipient_email"`
+	InterviewNotes *string   `db:"interview_notes"`
+	Provider       *string   `db:"provider"`
+	Model          *string   `db:"model"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+type PublicProfileRevision struct {
+	ID             int       `db:"id"`
+	FirebaseUID    string    `db:"firebase_uid"`
+	RevisionNumber int       `db:"revision_number"`
+	Provider       *string   `db:"provider"`
+	Model          *string   `db:"model"`
+	Content        []byte    `db:"content"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+type WritingStyleProfile struct {
+	FirebaseUID      string    `db:"firebase_uid"`
+	Profile          []byte    `db:"profile"`
+	SampleCount      int       `db:"sample_count"`
+	SamplesUpdatedAt time.Time `db:"samples_updated_at"`
+	UpdatedAt        time.Time `db:"updated_at"`
+}
+
 type QuestionnaireResponse struct {
 	ID              int       `db:"id"`
 	QuestionnaireID int       `db:"questionnaire_id"`
@@ -276,3 +388,239 @@ type DiscProfile struct {
 	Conscientiousness     string `db:"conscientiousness"`
 	Summary               string `db:"summary"`
 }
+
+type Profile struct {
+	FirebaseUID     string    `db:"firebase_uid"`
+	FirstName       string    `db:"first_name"`
+	LastName        string    `db:"last_name"`
+	CurrentLocation string    `db:"current_location"`
+	Email           string    `db:"email"`
+	Github          *string   `db:"github"`
+	Linkedin        *string   `db:"linkedin"`
+	Mobile          *string   `db:"mobile"`
+	Summary         *string   `db:"summary"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+type ProfileEducation struct {
+	ID          int       `db:"id"`
+	FirebaseUID string    `db:"firebase_uid"`
+	School      string    `db:"school"`
+	Degree      string    `db:"degree"`
+	Location    string    `db:"location"`
+	Dates       string    `db:"dates"`
+	Courses     *string   `db:"courses"`
+	GPA         *float64  `db:"gpa"`
+	Honors      *string   `db:"honors"`
+	SortOrder   int       `db:"sort_order"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+type ProfileExperience struct {
+	ID            int            `db:"id"`
+	FirebaseUID   string         `db:"firebase_uid"`
+	Company       string         `db:"company"`
+	Position      string         `db:"position"`
+	Years         string         `db:"years"`
+	BulletPoints  pq.StringArray `db:"bullet_points"`
+	Locked        bool           `db:"locked"`
+	LockedBullets pq.Int64Array  `db:"locked_bullets"`
+	SortOrder     int            `db:"sort_order"`
+	CreatedAt     time.Time      `db:"created_at"`
+	UpdatedAt     time.Time      `db:"updated_at"`
+}
+
+type ProfileProject struct {
+	ID            int            `db:"id"`
+	FirebaseUID   string         `db:"firebase_uid"`
+	Name          string         `db:"name"`
+	Description   string         `db:"description"`
+	Years         string         `db:"years"`
+	BulletPoints  pq.StringArray `db:"bullet_points"`
+	Locked        bool           `db:"locked"`
+	LockedBullets pq.Int64Array  `db:"locked_bullets"`
+	SortOrder     int            `db:"sort_order"`
+	CreatedAt     time.Time      `db:"created_at"`
+	UpdatedAt     time.Time      `db:"updated_at"`
+}
+
+type ProfileSkill struct {
+	ID          int       `db:"id"`
+	FirebaseUID string    `db:"firebase_uid"`
+	Name        string    `db:"name"`
+	Category    string    `db:"category"`
+	SortOrder   int       `db:"sort_order"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+type ProfileSection struct {
+	ID          int       `db:"id"`
+	FirebaseUID string    `db:"firebase_uid"`
+	Title       string    `db:"title"`
+	Content     string    `db:"content"`
+	SortOrder   int       `db:"sort_order"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+type ProfileCertification struct {
+	ID          int       `db:"id"`
+	FirebaseUID string    `db:"firebase_uid"`
+	Name        string    `db:"name"`
+	Issuer      string    `db:"issuer"`
+	Date        string    `db:"date"`
+	URL         *string   `db:"url"`
+	SortOrder   int       `db:"sort_order"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+type ProfilePublication struct {
+	ID          int       `db:"id"`
+	FirebaseUID string    `db:"firebase_uid"`
+	Title       string    `db:"title"`
+	Publisher   string    `db:"publisher"`
+	Date        string    `db:"date"`
+	URL         *string   `db:"url"`
+	Summary     *string   `db:"summary"`
+	SortOrder   int       `db:"sort_order"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+type ProfileVolunteering struct {
+	ID           int            `db:"id"`
+	FirebaseUID  string         `db:"firebase_uid"`
+	Organization string         `db:"organization"`
+	Role         string         `db:"role"`
+	Years        string         `db:"years"`
+	BulletPoints pq.StringArray `db:"bullet_points"`
+	SortOrder    int            `db:"sort_order"`
+	CreatedAt    time.Time      `db:"created_at"`
+	UpdatedAt    time.Time      `db:"updated_at"`
+}
+
+type ProfileAward struct {
+	ID          int       `db:"id"`
+	FirebaseUID string    `db:"firebase_uid"`
+	Title       string    `db:"title"`
+	Awarder     string    `db:"awarder"`
+	Date        string    `db:"date"`
+	Summary     *string   `db:"summary"`
+	SortOrder   int       `db:"sort_order"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+type ProfileLanguage struct {
+	ID          int       `db:"id"`
+	FirebaseUID string    `db:"firebase_uid"`
+	Language    string    `db:"language"`
+	Fluency     string    `db:"fluency"`
+	SortOrder   int       `db:"sort_order"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+type ProfileVariant struct {
+	ID            int           `db:"id"`
+	FirebaseUID   string        `db:"firebase_uid"`
+	Name          string        `db:"name"`
+	Summary       *string       `db:"summary"`
+	ExperienceIDs pq.Int64Array `db:"experience_ids"`
+	ProjectIDs    pq.Int64Array `db:"project_ids"`
+	SkillIDs      pq.Int64Array `db:"skill_ids"`
+	CreatedAt     time.Time     `db:"created_at"`
+	UpdatedAt     time.Time     `db:"updated_at"`
+}
+
+type ResumeRevision struct {
+	ID             int       `db:"id"`
+	ResumeID       int       `db:"resume_id"`
+	RevisionNumber int       `db:"revision_number"`
+	Author         string    `db:"author"`
+	Provider       *string   `db:"provider"`
+	Model          *string   `db:"model"`
+	PromptVersion  *string   `db:"prompt_version"`
+	RestoredFrom   *int      `db:"restored_from"`
+	Content        []byte    `db:"content"`
+	Report         []byte    `db:"report"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+type ResumeReview struct {
+	ID          int        `db:"id"`
+	ResumeID    int        `db:"resume_id"`
+	RevisionID  *int       `db:"revision_id"`
+	Status      string     `db:"status"`
+	Content     []byte     `db:"content"`
+	Event       []byte     `db:"event"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+	FinalizedAt *time.Time `db:"finalized_at"`
+}
+
+type ResumeRejectedSuggestion struct {
+	ID            int       `db:"id"`
+	ResumeID      int       `db:"resume_id"`
+	Section       string    `db:"section"`
+	Text          string    `db:"text"`
+	Justification *string   `db:"justification"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+type DocumentGeneration struct {
+	ID           int        `db:"id"`
+	FirebaseUID  string     `db:"firebase_uid"`
+	RoleID       *int       `db:"role_id"`
+	DocType      string     `db:"doc_type"`
+	Status       string     `db:"status"`
+	Provider     *string    `db:"provider"`
+	Model        *string    `db:"model"`
+	RevisionID   *int       `db:"revision_id"`
+	ErrorCode    *string    `db:"error_code"`
+	ErrorMessage *string    `db:"error_message"`
+	DownloadURL  *string    `db:"download_url"`
+	ChangesURL   *string    `db:"changes_url"`
+	CreatedAt    time.Time  `db:"created_at"`
+	UpdatedAt    time.Time  `db:"updated_at"`
+	StartedAt    *time.Time `db:"started_at"`
+	FinishedAt   *time.Time `db:"finished_at"`
+	BatchID      *int       `db:"batch_id"`
+	StyleMatch   []byte     `db:"style_match"`
+}
+
+type GenerationBatch struct {
+	ID          int       `db:"id"`
+	FirebaseUID string    `db:"firebase_uid"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+type LibraryDocument struct {
+	ID           int       `db:"id"`
+	FirebaseUID  string    `db:"firebase_uid"`
+	RoleID       *int      `db:"role_id"`
+	GenerationID *int      `db:"generation_id"`
+	RevisionID   *int      `db:"revision_id"`
+	DocType      string    `db:"doc_type"`
+	Template     *string   `db:"template"`
+	PDFKey       string    `db:"pdf_key"`
+	ChangesKey   *string   `db:"changes_key"`
+	SourceKey    *string   `db:"source_key"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+type TrackingRequest struct {
+	ID           int        `db:"id"`
+	FirebaseUID  string     `db:"firebase_uid"`
+	Status       string     `db:"status"`
+	RoleID       *int       `db:"role_id"`
+	ErrorCode    *string    `db:"error_code"`
+	ErrorMessage *string    `db:"error_message"`
+	CreatedAt    time.Time  `db:"created_at"`
+	UpdatedAt    time.Time  `db:"updated_at"`
+	FinishedAt   *time.Time `db:"finished_at"`
+}
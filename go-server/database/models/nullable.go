@@ -0,0 +1,17 @@
+package models
+
+// Optional stores an empty string as NULL.
+func Optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// Deref reads a nullable column, NULL as "".
+func Deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
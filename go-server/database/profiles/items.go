@@ -0,0 +1,207 @@
+package profiles
+
+import (
+	"context"
+
+	"github.com/ordo_meritum/features/profiles/models/domain"
+)
+
+func (r *postgresRepository) CreateEducation(ctx context.Context, education *domain.Education) (int, error) {
+	uid, err := userID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return r.create(ctx, educationTable, educationToDB(uid, education))
+}
+
+func (r *postgresRepository) UpdateEducation(ctx context.Context, education *domain.Education) error {
+	uid, err := userID(ctx)
+	if err != nil {
+		return err
+	}
+	return r.update(ctx, educationTable, educationToDB(uid, education))
+}
+
+func (r *postgresRepository) DeleteEducation(ctx context.Context, id int) error {
+	return r.delete(ctx, educationTable, id)
+}
+
+func (r *postgresRepository) CreateExperience(ctx context.Context, experience *domain.Experience) (int, error) {
+	uid, err := userID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return r.create(ctx, experiencesTable, experienceToDB(uid, experience))
+}
+
+func (r *postgresRepository) UpdateExperience(ctx context.Context, experience *domain.Experience) error {
+	uid, err := userID(ctx)
+	if err != nil {
+		return err
+	}
+	return r.update(ctx, experiencesTable, experienceToDB(uid, experience))
+}
+
+func (r *postgresRepository) DeleteExperience(ctx context.Context, id int) error {
+	return r.delete(ctx, experiencesTable, id)
+}
+
+func (r *postgresRepository) CreateProject(ctx context.Context, project *domain.Project) (int, error) {
+	uid, err := userID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return r.create(ctx, projectsTable, projectToDB(uid, project))
+}
+
+func (r *postgresRepository) UpdateProject(ctx context.Context, project *domain.Project) error {
+	uid, err := userID(ctx)
+	if err != nil {
+		return err
+	}
+	return r.update(ctx, projectsTable, projectToDB(uid, project))
+}
+
+func (r *postgresRepository) DeleteProject(ctx context.Context, id int) error {
+	return r.delete(ctx, projectsTable, id)
+}
+
+func (r *postgresRepository) CreateSkill(ctx context.Context, skill *domain.Skill) (int, error) {
+	uid, err := userID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return r.create(ctx, skillsTable, skillToDB(uid, skill))
+}
+
+func (r *postgresRepository) UpdateSkill(ctx context.Context, skill *domain.Skill) error {
+	uid, err := userID(ctx)
+	if err != nil {
+		return err
+	}
+	return r.update(ctx, skillsTable, skillToDB(uid, skill))
+}
+
+func (r *postgresRepository) DeleteSkill(ctx context.Context, id int) error {
+	return r.delete(ctx, skillsTable, id)
+}
+
+func (r *postgresRepository) CreateSection(ctx context.Context, section *domain.Section) (int, error) {
+	uid, err := userID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return r.create(ctx, sectionsTable, sectionToDB(uid, section))
+}
+
+func (r *postgresRepository) UpdateSection(ctx context.Context, section *domain.Section) error {
+	uid, err := userID(ctx)
+	if err != nil {
+		return err
+	}
+	return r.update(ctx, sectionsTable, sectionToDB(uid, section))
+}
+
+func (r *postgresRepository) DeleteSection(ctx context.Context, id int) error {
+	return r.delete(ctx, sectionsTable, id)
+}
+
+func (r *postgresRepository) CreateCertification(ctx context.Context, certification *domain.Certification) (int, error) {
+	uid, err := userID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return r.create(ctx, certificationsTable, certificationToDB(uid, certification))
+}
+
+func (r *postgresRepository) UpdateCertification(ctx context.Context, certification *domain.Certification) error {
+	uid, err := userID(ctx)
+	if err != nil {
+		return err
+	}
+	return r.update(ctx, certificationsTable, certificationToDB(uid, certification))
+}
+
+func (r *postgresRepository) DeleteCertification(ctx context.Context, id int) error {
+	return r.delete(ctx, certificationsTable, id)
+}
+
+func (r *postgresRepository) CreatePublication(ctx context.Context, publication *domain.Publication) (int, error) {
+	uid, err := userID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return r.create(ctx, publicationsTable, publicationToDB(uid, publication))
+}
+
+func (r *postgresRepository) UpdatePublication(ctx context.Context, publication *domain.Publication) error {
+	uid, err := userID(ctx)
+	if err != nil {
+		return err
+	}
+	return r.update(ctx, publicationsTable, publicationToDB(uid, publication))
+}
+
+func (r *postgresRepository) DeletePublication(ctx context.Context, id int) error {
+	return r.delete(ctx, publicationsTable, id)
+}
+
+func (r *postgresRepository) CreateVolunteering(ctx context.Context, volunteering *domain.Volunteering) (int, error) {
+	uid, err := userID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return r.create(ctx, volunteeringTable, volunteeringToDB(uid, volunteering))
+}
+
+func (r *postgresRepository) UpdateVolunteering(ctx context.Context, volunteering *domain.Volunteering) error {
+	uid, err := userID(ctx)
+	if err != nil {
+		return err
+	}
+	return r.update(ctx, volunteeringTable, volunteeringToDB(uid, volunteering))
+}
+
+func (r *postgresRepository) DeleteVolunteering(ctx context.Context, id int) error {
+	return r.delete(ctx, volunteeringTable, id)
+}
+
+func (r *postgresRepository) CreateAward(ctx context.Context, award *domain.Award) (int, error) {
+	uid, err := userID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return r.create(ctx, awardsTable, awardToDB(uid, award))
+}
+
+func (r *postgresRepository) UpdateAward(ctx context.Context, award *domain.Award) error {
+	uid, err := userID(ctx)
+	if err != nil {
+		return err
+	}
+	return r.update(ctx, awardsTable, awardToDB(uid, award))
+}
+
+func (r *postgresRepository) DeleteAward(ctx context.Context, id int) error {
+	return r.delete(ctx, awardsTable, id)
+}
+
+func (r *postgresRepository) CreateLanguage(ctx context.Context, language *domain.Language) (int, error) {
+	uid, err := userID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return r.create(ctx, languagesTable, languageToDB(uid, language))
+}
+
+func (r *postgresRepository) UpdateLanguage(ctx context.Context, language *domain.Language) error {
+	uid, err := userID(ctx)
+	if err != nil {
+		return err
+	}
+	return r.update(ctx, languagesTable, languageToDB(uid, language))
+}
+
+func (r *postgresRepository) DeleteLanguage(ctx context.Context, id int) error {
+	return r.delete(ctx, languagesTable, id)
+}
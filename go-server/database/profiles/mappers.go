@@ -0,0 +1,328 @@
+package profiles
+
+import (
+	"github.com/lib/pq"
+	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/features/profiles/models/domain"
+	"github.com/ordo_meritum/shared/libs/taxonomy"
+)
+
+func contactToDB(uid string, c *domain.Contact) models.Profile {
+	return models.Profile{
+		FirebaseUID:     uid,
+		FirstName:       c.FirstName,
+		LastName:        c.LastName,
+		CurrentLocation: c.CurrentLocation,
+		Email:           c.Email,
+		Github:          models.Optional(c.Github),
+		Linkedin:        models.Optional(c.Linkedin),
+		Mobile:          models.Optional(c.Mobile),
+		Summary:         models.Optional(c.Summary),
+	}
+}
+
+func contactToDomain(p *models.Profile) domain.Contact {
+	return domain.Contact{
+		FirstName:       p.FirstName,
+		LastName:        p.LastName,
+		CurrentLocation: p.CurrentLocation,
+		Email:           p.Email,
+		Github:          models.Deref(p.Github),
+		Linkedin:        models.Deref(p.Linkedin),
+		Mobile:          models.Deref(p.Mobile),
+		Summary:         models.Deref(p.Summary),
+	}
+}
+
+func educationToDB(uid string, e *domain.Education) models.ProfileEducation {
+	return models.ProfileEducation{
+		ID:          e.ID,
+		FirebaseUID: uid,
+		School:      e.School,
+		Degree:      e.Degree,
+		Location:    e.Location,
+		Dates:       e.StartEnd,
+		Courses:     e.CourseWork,
+		GPA:         e.GPA,
+		Honors:      e.Honors,
+		SortOrder:   e.SortOrder,
+	}
+}
+
+func educationToDomain(rows []models.ProfileEducation) []domain.Education {
+	education := make([]domain.Education, 0, len(rows))
+	for _, e := range rows {
+		education = append(education, domain.Education{
+			ID:         e.ID,
+			School:     e.School,
+			Degree:     e.Degree,
+			Location:   e.Location,
+			StartEnd:   e.Dates,
+			CourseWork: e.Courses,
+			GPA:        e.GPA,
+			Honors:     e.Honors,
+			SortOrder:  e.SortOrder,
+		})
+	}
+	return education
+}
+
+func experienceToDB(uid string, e *domain.Experience) models.ProfileExperience {
+	return models.ProfileExperience{
+		ID:            e.ID,
+		FirebaseUID:   uid,
+		Company:       e.Company,
+		Position:      e.Position,
+		Years:         e.Years,
+		BulletPoints:  nonNil(e.BulletPoints),
+		Locked:        e.Locked,
+		LockedBullets: lockedBulletsToDB(e.LockedBullets),
+		SortOrder:     e.SortOrder,
+	}
+}
+
+func experiencesToDomain(rows []models.ProfileExperience) []domain.Experience {
+	experiences := make([]domain.Experience, 0, len(rows))
+	for _, e := range rows {
+		experiences = append(experiences, domain.Experience{
+			ID:            e.ID,
+			Company:       e.Company,
+			Position:      e.Position,
+			Years:         e.Years,
+			BulletPoints:  e.BulletPoints,
+			Locked:        e.Locked,
+			LockedBullets: fromInt64Array(e.LockedBullets),
+			SortOrder:     e.SortOrder,
+		})
+	}
+	return experiences
+}
+
+func projectToDB(uid string, p *domain.Project) models.ProfileProject {
+	return models.ProfileProject{
+		ID:            p.ID,
+		FirebaseUID:   uid,
+		Name:          p.Name,
+		Description:   p.Description,
+		Years:         p.Years,
+		BulletPoints:  nonNil(p.BulletPoints),
+		Locked:        p.Locked,
+		LockedBullets: lockedBulletsToDB(p.LockedBullets),
+		SortOrder:     p.SortOrder,
+	}
+}
+
+func projectsToDomain(rows []models.ProfileProject) []domain.Project {
+	projects := make([]domain.Project, 0, len(rows))
+	for _, p := range rows {
+		projects = append(projects, domain.Project{
+			ID:            p.ID,
+			Name:          p.Name,
+			Description:   p.Description,
+			Years:         p.Years,
+			BulletPoints:  p.BulletPoints,
+			Locked:        p.Locked,
+			LockedBullets: fromInt64Array(p.LockedBullets),
+			SortOrder:     p.SortOrder,
+		})
+	}
+	return projects
+}
+
+// skillToDB stores the skill under its canonical name, so "k8s" and
+// "Kubernetes" end up as the same skill.
+func skillToDB(uid string, s *domain.Skill) models.ProfileSkill {
+	return models.ProfileSkill{
+		ID:          s.ID,
+		FirebaseUID: uid,
+		Name:        taxonomy.Default().Canonical(s.Name),
+		Category:    s.Category,
+		SortOrder:   s.SortOrder,
+	}
+}
+
+func skillsToDomain(rows []models.ProfileSkill) []domain.Skill {
+	skills := make([]domain.Skill, 0, len(rows))
+	for _, s := range rows {
+		skills = append(skills, domain.Skill{
+			ID:        s.ID,
+			Name:      s.Name,
+			Category:  s.Category,
+			SortOrder: s.SortOrder,
+		})
+	}
+	return skills
+}
+
+func sectionToDB(uid string, s *domain.Section) models.ProfileSection {
+	return models.ProfileSection{
+		ID:          s.ID,
+		FirebaseUID: uid,
+		Title:       s.Title,
+		Content:     s.Content,
+		SortOrder:   s.SortOrder,
+	}
+}
+
+func sectionsToDomain(rows []models.ProfileSection) []domain.Section {
+	sections := make([]domain.Section, 0, len(rows))
+	for _, s := range rows {
+		sections = append(sections, domain.Section{
+			ID:        s.ID,
+			Title:     s.Title,
+			Content:   s.Content,
+			SortOrder: s.SortOrder,
+		})
+	}
+	return sections
+}
+
+func certificationToDB(uid string, c *domain.Certification) models.ProfileCertification {
+	return models.ProfileCertification{
+		ID:          c.ID,
+		FirebaseUID: uid,
+		Name:        c.Name,
+		Issuer:      c.Issuer,
+		Date:        c.Date,
+		URL:         models.Optional(c.URL),
+		SortOrder:   c.SortOrder,
+	}
+}
+
+func certificationsToDomain(rows []models.ProfileCertification) []domain.Certification {
+	certifications := make([]domain.Certification, 0, len(rows))
+	for _, c := range rows {
+		certifications = append(certifications, domain.Certification{
+			ID:        c.ID,
+			Name:      c.Name,
+			Issuer:    c.Issuer,
+			Date:      c.Date,
+			URL:       models.Deref(c.URL),
+			SortOrder: c.SortOrder,
+		})
+	}
+	return certifications
+}
+
+func publicationToDB(uid string, p *domain.Publication) models.ProfilePublication {
+	return models.ProfilePublication{
+		ID:          p.ID,
+		FirebaseUID: uid,
+		Title:       p.Title,
+		Publisher:   p.Publisher,
+		Date:        p.Date,
+		URL:         models.Optional(p.URL),
+		Summary:     models.Optional(p.Summary),
+		SortOrder:   p.SortOrder,
+	}
+}
+
+func publicationsToDomain(rows []models.ProfilePublication) []domain.Publication {
+	publications := make([]domain.Publication, 0, len(rows))
+	for _, p := range rows {
+		publications = append(publications, domain.Publication{
+			ID:        p.ID,
+			Title:     p.Title,
+			Publisher: p.Publisher,
+			Date:      p.Date,
+			URL:       models.Deref(p.URL),
+			Summary:   models.Deref(p.Summary),
+			SortOrder: p.SortOrder,
+		})
+	}
+	return publications
+}
+
+func volunteeringToDB(uid string, v *domain.Volunteering) models.ProfileVolunteering {
+	return models.ProfileVolunteering{
+		ID:           v.ID,
+		FirebaseUID:  uid,
+		Organization: v.Organization,
+		Role:         v.Role,
+		Years:        v.Years,
+		BulletPoints: nonNil(v.BulletPoints),
+		SortOrder:    v.SortOrder,
+	}
+}
+
+func volunteeringToDomain(rows []models.ProfileVolunteering) []domain.Volunteering {
+	volunteering := make([]domain.Volunteering, 0, len(rows))
+	for _, v := range rows {
+		volunteering = append(volunteering, domain.Volunteering{
+			ID:           v.ID,
+			Organization: v.Organization,
+			Role:         v.Role,
+			Years:        v.Years,
+			BulletPoints: v.BulletPoints,
+			SortOrder:    v.SortOrder,
+		})
+	}
+	return volunteering
+}
+
+func awardToDB(uid string, a *domain.Award) models.ProfileAward {
+	return models.ProfileAward{
+		ID:          a.ID,
+		FirebaseUID: uid,
+		Title:       a.Title,
+		Awarder:     a.Awarder,
+		Date:        a.Date,
+		Summary:     models.Optional(a.Summary),
+		SortOrder:   a.SortOrder,
+	}
+}
+
+func awardsToDomain(rows []models.ProfileAward) []domain.Award {
+	awards := make([]domain.Award, 0, len(rows))
+	for _, a := range rows {
+		awards = append(awards, domain.Award{
+			ID:        a.ID,
+			Title:     a.Title,
+			Awarder:   a.Awarder,
+			Date:      a.Date,
+			Summary:   models.Deref(a.Summary),
+			SortOrder: a.SortOrder,
+		})
+	}
+	return awards
+}
+
+func languageToDB(uid string, l *domain.Language) models.ProfileLanguage {
+	return models.ProfileLanguage{
+		ID:          l.ID,
+		FirebaseUID: uid,
+		Language:    l.Language,
+		Fluency:     l.Fluency,
+		SortOrder:   l.SortOrder,
+	}
+}
+
+func languagesToDomain(rows []models.ProfileLanguage) []domain.Language {
+	languages := make([]domain.Language, 0, len(rows))
+	for _, l := range rows {
+		languages = append(languages, domain.Language{
+			ID:        l.ID,
+			Language:  l.Language,
+			Fluency:   l.Fluency,
+			SortOrder: l.SortOrder,
+		})
+	}
+	return languages
+}
+
+// nonNil keeps bullet_points from being written as NULL.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+// lockedBulletsToDB stores a missing list as empty, since the column is
+// NOT NULL.
+func lockedBulletsToDB(indexes []int) pq.Int64Array {
+	if indexes == nil {
+		return pq.Int64Array{}
+	}
+	return toInt64Array(indexes)
+}
@@ -0,0 +1,395 @@
+package profiles
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/features/profiles/models/domain"
+	"github.com/ordo_meritum/shared/contexts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+var (
+	ErrProfileNotFound = errors.New("profile not found")
+	ErrItemNotFound    = errors.New("profile item not found")
+	ErrVariantNotFound = errors.New("profile variant not found")
+	ErrDuplicateName   = errors.New("a variant with that name already exists")
+)
+
+type Repository interface {
+	GetProfile(ctx context.Context) (*domain.Profile, error)
+	UpsertContact(ctx context.Context, contact *domain.Contact) error
+	ReplaceProfile(ctx context.Context, profile *domain.Profile) error
+	DeleteProfile(ctx context.Context) error
+
+	CreateEducation(ctx context.Context, education *domain.Education) (int, error)
+	UpdateEducation(ctx context.Context, education *domain.Education) error
+	DeleteEducation(ctx context.Context, id int) error
+
+	CreateExperience(ctx context.Context, experience *domain.Experience) (int, error)
+	UpdateExperience(ctx context.Context, experience *domain.Experience) error
+	DeleteExperience(ctx context.Context, id int) error
+
+	CreateProject(ctx context.Context, project *domain.Project) (int, error)
+	UpdateProject(ctx context.Context, project *domain.Project) error
+	DeleteProject(ctx context.Context, id int) error
+
+	CreateSkill(ctx context.Context, skill *domain.Skill) (int, error)
+	UpdateSkill(ctx context.Context, skill *domain.Skill) error
+	DeleteSkill(ctx context.Context, id int) error
+
+	CreateSection(ctx context.Context, section *domain.Section) (int, error)
+	UpdateSection(ctx context.Context, section *domain.Section) error
+	DeleteSection(ctx context.Context, id int) error
+
+	CreateCertification(ctx context.Context, certification *domain.Certification) (int, error)
+	UpdateCertification(ctx context.Context, certification *domain.Certification) error
+	DeleteCertification(ctx context.Context, id int) error
+
+	CreatePublication(ctx context.Context, publication *domain.Publication) (int, error)
+	UpdatePublication(ctx context.Context, publication *domain.Publication) error
+	DeletePublication(ctx context.Context, id int) error
+
+	CreateVolunteering(ctx context.Context, volunteering *domain.Volunteering) (int, error)
+	UpdateVolunteering(ctx context.Context, volunteering *domain.Volunteering) error
+	DeleteVolunteering(ctx context.Context, id int) error
+
+	CreateAward(ctx context.Context, award *domain.Award) (int, error)
+	UpdateAward(ctx context.Context, award *domain.Award) error
+	DeleteAward(ctx context.Context, id int) error
+
+	CreateLanguage(ctx context.Context, language *domain.Language) (int, error)
+	UpdateLanguage(ctx context.Context, language *domain.Language) error
+	DeleteLanguage(ctx context.Context, id int) error
+
+	ListVariants(ctx context.Context) ([]domain.Variant, error)
+	GetVariant(ctx context.Context, id int) (*domain.Variant, error)
+	CreateVariant(ctx context.Context, variant *domain.Variant) (int, error)
+	UpdateVariant(ctx context.Context, variant *domain.Variant) error
+	DeleteVariant(ctx context.Context, id int) error
+}
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+// itemTable describes one of the per-user collections hanging off profiles.
+// Every table shares id, firebase_uid, sort_order and the timestamps; columns
+// lists the rest.
+type itemTable struct {
+	name    string
+	columns []string
+}
+
+var (
+	educationTable   = itemTable{"profile_education", []string{"school", "degree", "location", "dates", "courses", "gpa", "honors"}}
+	experiencesTable = itemTable{"profile_experiences", []string{"company", "position", "years", "bullet_points", "locked", "locked_bullets"}}
+	projectsTable    = itemTable{"profile_projects", []string{"name", "description", "years", "bullet_points", "locked", "locked_bullets"}}
+	skillsTable      = itemTable{"profile_skills", []string{"name", "category"}}
+	sectionsTable    = itemTable{"profile_sections", []string{"title", "content"}}
+
+	certificationsTable = itemTable{"profile_certifications", []string{"name", "issuer", "date", "url"}}
+	publicationsTable   = itemTable{"profile_publications", []string{"title", "publisher", "date", "url", "summary"}}
+	volunteeringTable   = itemTable{"profile_volunteering", []string{"organization", "role", "years", "bullet_points"}}
+	awardsTable         = itemTable{"profile_awards", []string{"title", "awarder", "date", "summary"}}
+	languagesTable      = itemTable{"profile_languages", []string{"language", "fluency"}}
+)
+
+// itemRow pairs a mapped row with the table it belongs in.
+type itemRow struct {
+	table itemTable
+	arg   any
+}
+
+// insertQuery appends the new row after the user's existing entries.
+func (t itemTable) insertQuery() string {
+	params := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		params[i] = ":" + c
+	}
+	return fmt.Sprintf(
+		`INSERT INTO %[1]s (firebase_uid, %[2]s, sort_order)
+		SELECT :firebase_uid, %[3]s, COALESCE(MAX(sort_order) + 1, 0)
+		FROM %[1]s WHERE firebase_uid = :firebase_uid
+		RETURNING id`,
+		t.name, strings.Join(t.columns, ", "), strings.Join(params, ", "),
+	)
+}
+
+func (t itemTable) updateQuery() string {
+	sets := make([]string, 0, len(t.columns)+2)
+	for _, c := range t.columns {
+		sets = append(sets, c+" = :"+c)
+	}
+	sets = append(sets, "sort_order = :sort_order", "updated_at = NOW()")
+	return fmt.Sprintf(
+		"UPDATE %s SET %s WHERE id = :id AND firebase_uid = :firebase_uid",
+		t.name, strings.Join(sets, ", "),
+	)
+}
+
+func (t itemTable) selectQuery() string {
+	return fmt.Sprintf("SELECT * FROM %s WHERE firebase_uid = $1 ORDER BY sort_order, id", t.name)
+}
+
+func userID(ctx context.Context) (string, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return "", error_response.ErrNoUserContext
+	}
+	return userCtx.UID, nil
+}
+
+func (r *postgresRepository) GetProfile(ctx context.Context) (*domain.Profile, error) {
+	uid, err := userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var contact models.Profile
+	if err := r.db.GetContext(ctx, &contact, "SELECT * FROM profiles WHERE firebase_uid = $1", uid); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrProfileNotFound
+		}
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	var (
+		education   []models.ProfileEducation
+		experiences []models.ProfileExperience
+		projects    []models.ProfileProject
+		skills      []models.ProfileSkill
+		sections    []models.ProfileSection
+
+		certifications []models.ProfileCertification
+		publications   []models.ProfilePublication
+		volunteering   []models.ProfileVolunteering
+		awards         []models.ProfileAward
+		languages      []models.ProfileLanguage
+	)
+	for _, q := range []struct {
+		table itemTable
+		dest  any
+	}{
+		{educationTable, &education},
+		{experiencesTable, &experiences},
+		{projectsTable, &projects},
+		{skillsTable, &skills},
+		{sectionsTable, &sections},
+		{certificationsTable, &certifications},
+		{publicationsTable, &publications},
+		{volunteeringTable, &volunteering},
+		{awardsTable, &awards},
+		{languagesTable, &languages},
+	} {
+		if err := r.db.SelectContext(ctx, q.dest, q.table.selectQuery(), uid); err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", q.table.name, err)
+		}
+	}
+
+	return &domain.Profile{
+		Contact:     contactToDomain(&contact),
+		Education:   educationToDomain(education),
+		Experiences: experiencesToDomain(experiences),
+		Projects:    projectsToDomain(projects),
+		Skills:      skillsToDomain(skills),
+		Sections:    sectionsToDomain(sections),
+
+		Certifications: certificationsToDomain(certifications),
+		Publications:   publicationsToDomain(publications),
+		Volunteering:   volunteeringToDomain(volunteering),
+		Awards:         awardsToDomain(awards),
+		Languages:      languagesToDomain(languages),
+	}, nil
+}
+
+func (r *postgresRepository) UpsertContact(ctx context.Context, contact *domain.Contact) error {
+	uid, err := userID(ctx)
+	if err != nil {
+		return err
+	}
+	return upsertContact(ctx, r.db, contactToDB(uid, contact))
+}
+
+func upsertContact(ctx context.Context, db sqlx.ExtContext, contact models.Profile) error {
+	query := `
+		INSERT INTO profiles (firebase_uid, first_name, last_name, current_location, email, github, linkedin, mobile, summary)
+		VALUES (:firebase_uid, :first_name, :last_name, :current_location, :email, :github, :linkedin, :mobile, :summary)
+		ON CONFLICT (firebase_uid) DO UPDATE SET
+			first_name = EXCLUDED.first_name,
+			last_name = EXCLUDED.last_name,
+			current_location = EXCLUDED.current_location,
+			email = EXCLUDED.email,
+			github = EXCLUDED.github,
+			linkedin = EXCLUDED.linkedin,
+			mobile = EXCLUDED.mobile,
+			summary = EXCLUDED.summary,
+			updated_at = NOW()`
+	if _, err := sqlx.NamedExecContext(ctx, db, query, contact); err != nil {
+		return fmt.Errorf("failed to upsert profile contact: %w", err)
+	}
+	return nil
+}
+
+// ReplaceProfile overwrites the user's whole profile in one transaction.
+// Entries are stored in the order given; their IDs and sort orders are
+// reassigned.
+func (r *postgresRepository) ReplaceProfile(ctx context.Context, profile *domain.Profile) error {
+	uid, err := userID(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := upsertContact(ctx, tx, contactToDB(uid, &profile.Contact)); err != nil {
+		return err
+	}
+
+	for _, t := range []itemTable{
+		educationTable, experiencesTable, projectsTable, skillsTable, sectionsTable,
+		certificationsTable, publicationsTable, volunteeringTable, awardsTable, languagesTable,
+	} {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE firebase_uid = $1", t.name), uid); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", t.name, err)
+		}
+	}
+
+	var rows []itemRow
+	for i := range profile.Education {
+		rows = append(rows, itemRow{educationTable, educationToDB(uid, &profile.Education[i])})
+	}
+	for i := range profile.Experiences {
+		rows = append(rows, itemRow{experiencesTable, experienceToDB(uid, &profile.Experiences[i])})
+	}
+	for i := range profile.Projects {
+		rows = append(rows, itemRow{projectsTable, projectToDB(uid, &profile.Projects[i])})
+	}
+	for i := range profile.Skills {
+		rows = append(rows, itemRow{skillsTable, skillToDB(uid, &profile.Skills[i])})
+	}
+	for i := range profile.Sections {
+		rows = append(rows, itemRow{sectionsTable, sectionToDB(uid, &profile.Sections[i])})
+	}
+	for i := range profile.Certifications {
+		rows = append(rows, itemRow{certificationsTable, certificationToDB(uid, &profile.Certifications[i])})
+	}
+	for i := range profile.Publications {
+		rows = append(rows, itemRow{publicationsTable, publicationToDB(uid, &profile.Publications[i])})
+	}
+	for i := range profile.Volunteering {
+		rows = append(rows, itemRow{volunteeringTable, volunteeringToDB(uid, &profile.Volunteering[i])})
+	}
+	for i := range profile.Awards {
+		rows = append(rows, itemRow{awardsTable, awardToDB(uid, &profile.Awards[i])})
+	}
+	for i := range profile.Languages {
+		rows = append(rows, itemRow{languagesTable, languageToDB(uid, &profile.Languages[i])})
+	}
+
+	for _, row := range rows {
+		if _, err := insertItem(ctx, tx, row.table, row.arg); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *postgresRepository) DeleteProfile(ctx context.Context) error {
+	uid, err := userID(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, "DELETE FROM profiles WHERE firebase_uid = $1", uid)
+	if err != nil {
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrProfileNotFound
+	}
+	return nil
+}
+
+// create makes sure the profile row exists before inserting, so entries can
+// be added before any contact details are saved.
+func (r *postgresRepository) create(ctx context.Context, t itemTable, arg any) (int, error) {
+	uid, err := userID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "INSERT INTO profiles (firebase_uid) VALUES ($1) ON CONFLICT DO NOTHING", uid); err != nil {
+		return 0, fmt.Errorf("failed to create profile: %w", err)
+	}
+
+	id, err := insertItem(ctx, tx, t, arg)
+	if err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+func insertItem(ctx context.Context, tx *sqlx.Tx, t itemTable, arg any) (int, error) {
+	rows, err := sqlx.NamedQueryContext(ctx, tx, t.insertQuery(), arg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert into %s: %w", t.name, err)
+	}
+	defer rows.Close()
+
+	var id int
+	if rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return 0, fmt.Errorf("failed to scan %s id: %w", t.name, err)
+		}
+	}
+	return id, rows.Err()
+}
+
+func (r *postgresRepository) update(ctx context.Context, t itemTable, arg any) error {
+	result, err := r.db.NamedExecContext(ctx, t.updateQuery(), arg)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", t.name, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrItemNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) delete(ctx context.Context, t itemTable, id int) error {
+	uid, err := userID(ctx)
+	if err != nil {
+		return err
+	}
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND firebase_uid = $2", t.name)
+	result, err := r.db.ExecContext(ctx, query, id, uid)
+	if err != nil {
+		return fmt.Errorf("failed to delete from %s: %w", t.name, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrItemNotFound
+	}
+	return nil
+}
+
+var _ Repository = (*postgresRepository)(nil)
@@ -0,0 +1,185 @@
+package profiles
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/features/profiles/models/domain"
+)
+
+const uniqueViolation = "23505"
+
+func (r *postgresRepository) ListVariants(ctx context.Context) ([]domain.Variant, error) {
+	uid, err := userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []models.ProfileVariant
+	query := "SELECT * FROM profile_variants WHERE firebase_uid = $1 ORDER BY id"
+	if err := r.db.SelectContext(ctx, &rows, query, uid); err != nil {
+		return nil, fmt.Errorf("failed to list variants: %w", err)
+	}
+
+	variants := make([]domain.Variant, 0, len(rows))
+	for i := range rows {
+		variants = append(variants, variantToDomain(&rows[i]))
+	}
+	return variants, nil
+}
+
+func (r *postgresRepository) GetVariant(ctx context.Context, id int) (*domain.Variant, error) {
+	uid, err := userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var row models.ProfileVariant
+	query := "SELECT * FROM profile_variants WHERE id = $1 AND firebase_uid = $2"
+	if err := r.db.GetContext(ctx, &row, query, id, uid); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrVariantNotFound
+		}
+		return nil, fmt.Errorf("failed to get variant: %w", err)
+	}
+
+	variant := variantToDomain(&row)
+	return &variant, nil
+}
+
+func (r *postgresRepository) CreateVariant(ctx context.Context, variant *domain.Variant) (int, error) {
+	uid, err := userID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "INSERT INTO profiles (firebase_uid) VALUES ($1) ON CONFLICT DO NOTHING", uid); err != nil {
+		return 0, fmt.Errorf("failed to create profile: %w", err)
+	}
+
+	query := `
+		INSERT INTO profile_variants (firebase_uid, name, summary, experience_ids, project_ids, skill_ids)
+		VALUES (:firebase_uid, :name, :summary, :experience_ids, :project_ids, :skill_ids)
+		RETURNING id`
+	rows, err := sqlx.NamedQueryContext(ctx, tx, query, variantToDB(uid, variant))
+	if err != nil {
+		return 0, variantWriteError(err)
+	}
+	var id int
+	if rows.Next() {
+		err = rows.Scan(&id)
+	}
+	rows.Close()
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan variant id: %w", err)
+	}
+
+	return id, tx.Commit()
+}
+
+func (r *postgresRepository) UpdateVariant(ctx context.Context, variant *domain.Variant) error {
+	uid, err := userID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE profile_variants SET
+			name = :name,
+			summary = :summary,
+			experience_ids = :experience_ids,
+			project_ids = :project_ids,
+			skill_ids = :skill_ids,
+			updated_at = NOW()
+		WHERE id = :id AND firebase_uid = :firebase_uid`
+	result, err := r.db.NamedExecContext(ctx, query, variantToDB(uid, variant))
+	if err != nil {
+		return variantWriteError(err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrVariantNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) DeleteVariant(ctx context.Context, id int) error {
+	uid, err := userID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, "DELETE FROM profile_variants WHERE id = $1 AND firebase_uid = $2", id, uid)
+	if err != nil {
+		return fmt.Errorf("failed to delete variant: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrVariantNotFound
+	}
+	return nil
+}
+
+func variantWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return ErrDuplicateName
+	}
+	return fmt.Errorf("failed to write variant: %w", err)
+}
+
+func variantToDB(uid string, v *domain.Variant) models.ProfileVariant {
+	return models.ProfileVariant{
+		ID:            v.ID,
+		FirebaseUID:   uid,
+		Name:          v.Name,
+		Summary:       models.Optional(v.Summary),
+		ExperienceIDs: toInt64Array(v.ExperienceIDs),
+		ProjectIDs:    toInt64Array(v.ProjectIDs),
+		SkillIDs:      toInt64Array(v.SkillIDs),
+	}
+}
+
+func variantToDomain(v *models.ProfileVariant) domain.Variant {
+	return domain.Variant{
+		ID:            v.ID,
+		Name:          v.Name,
+		Summary:       models.Deref(v.Summary),
+		ExperienceIDs: fromInt64Array(v.ExperienceIDs),
+		ProjectIDs:    fromInt64Array(v.ProjectIDs),
+		SkillIDs:      fromInt64Array(v.SkillIDs),
+	}
+}
+
+// toInt64Array keeps nil distinct from empty: nil is stored as NULL.
+func toInt64Array(ids []int) pq.Int64Array {
+	if ids == nil {
+		return nil
+	}
+	out := make(pq.Int64Array, len(ids))
+	for i, id := range ids {
+		out[i] = int64(id)
+	}
+	return out
+}
+
+func fromInt64Array(ids pq.Int64Array) []int {
+	if ids == nil {
+		return nil
+	}
+	out := make([]int, len(ids))
+	for i, id := range ids {
+		out[i] = int(id)
+	}
+	return out
+}
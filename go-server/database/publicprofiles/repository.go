@@ -0,0 +1,123 @@
+package publicprofiles
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/features/public_profiles/models/domain"
+	"github.com/ordo_meritum/shared/contexts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+var ErrRevisionNotFound = errors.New("public profile revision not found")
+
+type Repository interface {
+	CreateRevision(ctx context.Context, provider, model string, content *domain.Content) (*domain.Revision, error)
+	// ListRevisions returns the user's revisions, newest first, without
+	// their content.
+	ListRevisions(ctx context.Context) ([]domain.Revision, error)
+	GetRevision(ctx context.Context, id int) (*domain.Revision, error)
+}
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+func (r *postgresRepository) CreateRevision(
+	ctx context.Context,
+	provider, model string,
+	content *domain.Content,
+) (*domain.Revision, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	data, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public profile content: %w", err)
+	}
+
+	var row models.PublicProfileRevision
+	query := `
+		INSERT INTO public_profile_revisions (firebase_uid, revision_number, provider, model, content)
+		SELECT $1, COALESCE(MAX(revision_number), 0) + 1, $2, $3, $4
+		FROM public_profile_revisions WHERE firebase_uid = $1
+		RETURNING *`
+	if err := r.db.GetContext(ctx, &row, query, userCtx.UID, models.Optional(provider), models.Optional(model), data); err != nil {
+		return nil, fmt.Errorf("failed to create public profile revision: %w", err)
+	}
+	return toDomain(&row)
+}
+
+func (r *postgresRepository) ListRevisions(ctx context.Context) ([]domain.Revision, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var rows []models.PublicProfileRevision
+	query := `
+		SELECT id, firebase_uid, revision_number, provider, model, NULL AS content, created_at
+		FROM public_profile_revisions WHERE firebase_uid = $1
+		ORDER BY revision_number DESC`
+	if err := r.db.SelectContext(ctx, &rows, query, userCtx.UID); err != nil {
+		return nil, fmt.Errorf("failed to list public profile revisions: %w", err)
+	}
+
+	revisions := make([]domain.Revision, 0, len(rows))
+	for i := range rows {
+		revision, err := toDomain(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, *revision)
+	}
+	return revisions, nil
+}
+
+func (r *postgresRepository) GetRevision(ctx context.Context, id int) (*domain.Revision, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var row models.PublicProfileRevision
+	query := "SELECT * FROM public_profile_revisions WHERE id = $1 AND firebase_uid = $2"
+	err := r.db.GetContext(ctx, &row, query, id, userCtx.UID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public profile revision: %w", err)
+	}
+	return toDomain(&row)
+}
+
+func toDomain(row *models.PublicProfileRevision) (*domain.Revision, error) {
+	revision := &domain.Revision{
+		ID:        row.ID,
+		Number:    row.RevisionNumber,
+		Provider:  models.Deref(row.Provider),
+		Model:     models.Deref(row.Model),
+		CreatedAt: row.CreatedAt,
+	}
+	if len(row.Content) > 0 {
+		revision.Content = &domain.Content{}
+		if err := json.Unmarshal(row.Content, revision.Content); err != nil {
+			return nil, fmt.Errorf("failed to decode public profile revision %d: %w", row.ID, err)
+		}
+	}
+	return revision, nil
+}
+
+var _ Repository = (*postgresRepository)(nil)
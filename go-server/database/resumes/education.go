@@ -56,3 +56,12 @@ func transformEducationToDBModel(info *domain.EducationInfo, resumeID int) (*mod
 
 	return dbModel, nil
 }
+
+func (r *postgresRepository) GetResumeEducation(ctx context.Context, resumeID int) ([]domain.EducationInfo, error) {
+	var education []models.Education
+	if err := r.db.SelectContext(ctx, &education, "SELECT * FROM education WHERE resume_id = $1 ORDER BY id", resumeID); err != nil {
+		return nil, err
+	}
+
+	return MapEducationToDomain(education), nil
+}
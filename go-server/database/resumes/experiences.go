@@ -2,10 +2,11 @@ package resumes
 
 import (
 	"context"
-	"time"
+	"strings"
 
 	"github.com/ordo_meritum/database/models"
 	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/shared/libs/dates"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -17,11 +18,16 @@ func (r *postgresRepository) UpsertExperiences(
 	experiences []domain.Experience,
 ) error {
 	for _, e := range experiences {
-		start, _ := time.Parse(dateFormat, e.Start)
-		end, _ := time.Parse(dateFormat, e.End)
+		row := experienceToDB(resumeID, e)
 		var expID int
-		expQuery := "INSERT INTO experiences (resume_id, position, company, start_date, end_date) VALUES ($1, $2, $3, $4, $5) RETURNING id"
-		err := tx.GetContext(ctx, &expID, expQuery, resumeID, e.Position, e.Company, start, end)
+		expQuery := `
+			INSERT INTO experiences (resume_id, position, company, start_date, end_date, start_precision, end_precision, start_text, end_text)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING id`
+		err := tx.GetContext(ctx, &expID, expQuery,
+			row.ResumeID, row.Position, row.Company,
+			row.StartDate, row.EndDate, row.StartPrecision, row.EndPrecision, row.StartText, row.EndText,
+		)
 		if err != nil {
 			return err
 		}
@@ -61,3 +67,47 @@ func (r *postgresRepository) GetResumeExperiences(ctx context.Context, resumeID
 
 	return MapExperiencesToDomain(experiences, expDescs), nil
 }
+
+// experienceToDB converts an experience to its row. The LLM sometimes puts
+// the whole range in Start, so that is accepted too. Only an ongoing role is
+// stored without an end; an end that is missing or doesn't parse is kept as
+// written, so it never reads back as current.
+func experienceToDB(resumeID int, e domain.Experience) models.Experience {
+	row := models.Experience{ResumeID: resumeID, Position: e.Position, Company: e.Company}
+
+	var start, end dates.Date
+	ongoing := false
+	if r, err := dates.Parse(e.Start); err == nil && (!r.End.IsZero() || r.Ongoing) {
+		start, end, ongoing = r.Start, r.End, r.Ongoing
+	} else {
+		start, err = dates.ParseDate(e.Start)
+		if err != nil {
+			text := strings.TrimSpace(e.Start)
+			row.StartText = &text
+		}
+		switch {
+		case dates.IsOngoing(e.End):
+			ongoing = true
+		default:
+			if end, err = dates.ParseDate(e.End); err != nil {
+				text := strings.TrimSpace(e.End)
+				row.EndText = &text
+			}
+		}
+	}
+
+	row.StartDate, row.StartPrecision = start.Time(), string(precisionOf(start))
+	row.EndPrecision = string(precisionOf(end))
+	if !ongoing && !end.IsZero() {
+		t := end.Time()
+		row.EndDate = &t
+	}
+	return row
+}
+
+func precisionOf(d dates.Date) dates.Precision {
+	if d.Precision == "" {
+		return dates.PrecisionMonth
+	}
+	return d.Precision
+}
@@ -0,0 +1,35 @@
+package resumes
+
+import (
+	"testing"
+
+	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/features/documents/models/domain"
+)
+
+func TestExperienceDatesReadBack(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end string
+		wantStart  string
+		wantEnd    string
+	}{
+		{"ongoing", "Jan 2021", "Present", "Jan. 2021", "Present"},
+		{"ongoing in start", "Jan 2021 - now", "", "Jan. 2021", "Present"},
+		{"closed", "03/2019", "Dec 2020", "Mar. 2019", "Dec. 2020"},
+		{"closed in start", "2017 - 2019", "", "2017", "2019"},
+		{"end typo", "Jan 2021", "Presnt", "Jan. 2021", "Presnt"},
+		{"end missing", "Jan 2021", "", "Jan. 2021", ""},
+		{"end unparseable", "Jan 2021", "until the merger", "Jan. 2021", "until the merger"},
+		{"start unparseable", "early on", "2020", "early on", "2020"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := experienceToDB(1, domain.Experience{Company: "Acme", Start: tt.start, End: tt.end})
+			got := MapExperiencesToDomain([]models.Experience{row}, nil)[0]
+			if got.Start != tt.wantStart || got.End != tt.wantEnd {
+				t.Errorf("read back %q - %q, want %q - %q", got.Start, got.End, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
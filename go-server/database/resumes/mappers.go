@@ -1,8 +1,12 @@
 package resumes
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/ordo_meritum/database/models"
 	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/shared/libs/dates"
 )
 
 func MapExperiencesToDomain(experiences []models.Experience, descs []models.ExperienceDescription) []domain.Experience {
@@ -13,10 +17,16 @@ func MapExperiencesToDomain(experiences []models.Experience, descs []models.Expe
 
 	domainExperiences := make([]domain.Experience, 0, len(experiences))
 	for _, e := range experiences {
-		startDateStr := e.StartDate.Format(dateFormat)
-		endDateStr := "Present"
-		if e.EndDate != nil {
-			endDateStr = e.EndDate.Format(dateFormat)
+		startDateStr := dates.FromTime(e.StartDate, dates.Precision(e.StartPrecision)).Format(dates.DefaultStyle)
+		if e.StartText != nil {
+			startDateStr = *e.StartText
+		}
+		endDateStr := dates.DefaultStyle.Ongoing
+		switch {
+		case e.EndDate != nil:
+			endDateStr = dates.FromTime(*e.EndDate, dates.Precision(e.EndPrecision)).Format(dates.DefaultStyle)
+		case e.EndText != nil:
+			endDateStr = *e.EndText
 		}
 
 		bulletPoints := make([]domain.BulletPoint, 0, len(expMap[e.ID]))
@@ -99,3 +109,104 @@ func MapSummaryToDomain(overviewSummary string) []domain.SummaryBody {
 		},
 	}
 }
+
+func MapEducationToDomain(education []models.Education) []domain.EducationInfo {
+	domainEducation := make([]domain.EducationInfo, 0, len(education))
+	for _, e := range education {
+		domainEducation = append(domainEducation, domain.EducationInfo{
+			CourseWork: e.Courses,
+			Degree:     e.Degree,
+			Location:   e.Location,
+			School:     e.School,
+			StartEnd:   e.Dates,
+			GPA:        e.GPA,
+			Honors:     e.Honors,
+		})
+	}
+	return domainEducation
+}
+
+func MapCertificationsToDomain(rows []models.Certification) []domain.Certification {
+	out := make([]domain.Certification, 0, len(rows))
+	for _, c := range rows {
+		out = append(out, domain.Certification{Name: c.Name, Issuer: c.Issuer, Date: c.Date, URL: models.Deref(c.URL)})
+	}
+	return out
+}
+
+func MapPublicationsToDomain(rows []models.Publication) []domain.Publication {
+	out := make([]domain.Publication, 0, len(rows))
+	for _, p := range rows {
+		out = append(out, domain.Publication{Title: p.Title, Publisher: p.Publisher, Date: p.Date, URL: models.Deref(p.URL), Summary: models.Deref(p.Summary)})
+	}
+	return out
+}
+
+func MapAwardsToDomain(rows []models.Award) []domain.Award {
+	out := make([]domain.Award, 0, len(rows))
+	for _, a := range rows {
+		out = append(out, domain.Award{Title: a.Title, Awarder: a.Awarder, Date: a.Date, Summary: models.Deref(a.Summary)})
+	}
+	return out
+}
+
+func MapLanguagesToDomain(rows []models.Language) []domain.Language {
+	out := make([]domain.Language, 0, len(rows))
+	for _, l := range rows {
+		out = append(out, domain.Language{Language: l.Language, Fluency: l.Fluency})
+	}
+	return out
+}
+
+func MapVolunteeringToDomain(volunteering []models.Volunteering, descs []models.VolunteeringDescription) []domain.Volunteering {
+	volMap := make(map[int][]models.VolunteeringDescription)
+	for _, d := range descs {
+		volMap[d.VolunteeringID] = append(volMap[d.VolunteeringID], d)
+	}
+
+	out := make([]domain.Volunteering, 0, len(volunteering))
+	for _, v := range volunteering {
+		bulletPoints := make([]domain.BulletPoint, 0, len(volMap[v.ID]))
+		for _, desc := range volMap[v.ID] {
+			bulletPoints = append(bulletPoints, domain.BulletPoint{
+				Text:                   desc.Text,
+				IsNewSuggestion:        desc.NewSuggestion,
+				JustificationForChange: models.Deref(desc.JustificationForChange),
+			})
+		}
+
+		out = append(out, domain.Volunteering{
+			Organization: v.Organization,
+			Role:         v.Role,
+			Start:        v.StartDate,
+			End:          v.EndDate,
+			BulletPoints: bulletPoints,
+		})
+	}
+	return out
+}
+
+func MapRevisionToDomain(row *models.ResumeRevision, resume *domain.Resume) (domain.Revision, error) {
+	var report *domain.GenerationReport
+	if len(row.Report) > 0 && string(row.Report) != "null" {
+		report = &domain.GenerationReport{}
+		if err := json.Unmarshal(row.Report, report); err != nil {
+			return domain.Revision{}, fmt.Errorf("failed to decode report for revision %d: %w", row.ID, err)
+		}
+	}
+
+	return domain.Revision{
+		ID:           row.ID,
+		Number:       row.RevisionNumber,
+		RestoredFrom: row.RestoredFrom,
+		CreatedAt:    row.CreatedAt,
+		RevisionMeta: domain.RevisionMeta{
+			Author:        domain.RevisionAuthor(row.Author),
+			Provider:      models.Deref(row.Provider),
+			Model:         models.Deref(row.Model),
+			PromptVersion: models.Deref(row.PromptVersion),
+			Report:        report,
+		},
+		Resume: resume,
+	}, nil
+}
@@ -3,6 +3,8 @@ package resumes
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/jmoiron/sqlx"
@@ -12,11 +14,21 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-const dateFormat = "Jan. 2006"
+// ErrResumeNotFound is returned when the user has no resume for the role.
+var ErrResumeNotFound = errors.New("resume not found")
 
 type Repository interface {
-	UpsertResume(ctx context.Context, roleID int, resume *domain.Resume, education *domain.EducationInfo) error
+	UpsertResume(ctx context.Context, roleID int, resume *domain.Resume, education []domain.EducationInfo, meta domain.RevisionMeta) (int, error)
 	GetFullResume(ctx context.Context, roleID int) (*domain.Resume, error)
+	GetEducation(ctx context.Context, roleID int) ([]domain.EducationInfo, error)
+	ListRevisions(ctx context.Context, roleID int) ([]domain.Revision, error)
+	GetRevision(ctx context.Context, roleID int, revisionID int) (*domain.Revision, error)
+	RestoreRevision(ctx context.Context, roleID int, revisionID int) (*domain.Revision, error)
+	SaveReview(ctx context.Context, roleID int, resume *domain.Resume, event json.RawMessage, meta domain.RevisionMeta) (*domain.Review, error)
+	GetReview(ctx context.Context, roleID int) (*domain.Review, error)
+	UpdateReview(ctx context.Context, roleID int, fn func(resume *domain.Resume) error) (*domain.Review, error)
+	FinalizeReview(ctx context.Context, roleID int, acceptPending bool, education []domain.EducationInfo, meta domain.RevisionMeta) (*domain.Review, int, error)
+	ListRejectedSuggestions(ctx context.Context, roleID int, limit int) ([]domain.RejectedSuggestion, error)
 }
 
 type postgresRepository struct {
@@ -48,7 +60,7 @@ func (r *postgresRepository) getResumeID(ctx context.Context, runner sqlx.ExtCon
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return 0, fmt.Errorf("no resume found for user %s and role %d", userCtx.UID, roleID)
+			return 0, fmt.Errorf("%w for user %s and role %d", ErrResumeNotFound, userCtx.UID, roleID)
 		}
 		return 0, err
 	}
@@ -80,21 +92,51 @@ func (r *postgresRepository) dropResume(ctx context.Context, tx *sqlx.Tx, resume
 		return err
 	}
 
-	return nil
+	return r.dropSections(ctx, tx, resumeID)
 }
 
-func (r *postgresRepository) UpsertResume(ctx context.Context, roleID int, resume *domain.Resume, education *domain.EducationInfo) error {
+// UpsertResume replaces the current resume for the role and records it as a
+// new revision. It returns the revision's ID. Skill names are stored in
+// their canonical form. Each school in education is upserted; a nil
+// education leaves the stored education untouched.
+func (r *postgresRepository) UpsertResume(
+	ctx context.Context,
+	roleID int,
+	resume *domain.Resume,
+	education []domain.EducationInfo,
+	meta domain.RevisionMeta,
+) (int, error) {
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer tx.Rollback()
 
 	resumeID, err := r.getResumeID(ctx, tx, roleID)
 	if err != nil {
-		return err
+		return 0, err
+	}
+
+	resume = canonicalSkills(resume)
+	if err := r.writeResume(ctx, tx, resumeID, resume); err != nil {
+		return 0, err
+	}
+
+	for i := range education {
+		if err := r.UpsertEducation(ctx, tx, resumeID, &education[i]); err != nil {
+			return 0, fmt.Errorf("failed to upsert educations %w", err)
+		}
 	}
 
+	revisionID, err := r.insertRevision(ctx, tx, resumeID, resume, meta, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return revisionID, tx.Commit()
+}
+
+func (r *postgresRepository) writeResume(ctx context.Context, tx *sqlx.Tx, resumeID int, resume *domain.Resume) error {
 	if err := r.dropResume(ctx, tx, resumeID); err != nil {
 		return fmt.Errorf("failed to drop existing resume data: %w", err)
 	}
@@ -111,11 +153,11 @@ func (r *postgresRepository) UpsertResume(ctx context.Context, roleID int, resum
 		return fmt.Errorf("failed to upsert projects %w", err)
 	}
 
-	if err := r.UpsertEducation(ctx, tx, resumeID, education); err != nil {
-		return fmt.Errorf("failed to upsert educations %w", err)
+	if err := r.UpsertSections(ctx, tx, resumeID, resume); err != nil {
+		return fmt.Errorf("failed to upsert additional sections %w", err)
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 func (r *postgresRepository) GetFullResume(
@@ -157,6 +199,36 @@ func (r *postgresRepository) GetFullResume(
 		return err
 	})
 
+	g.Go(func() error {
+		var err error
+		resumePayload.Certifications, err = r.GetResumeCertifications(gCtx, resumeID)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		resumePayload.Publications, err = r.GetResumePublications(gCtx, resumeID)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		resumePayload.Volunteering, err = r.GetResumeVolunteering(gCtx, resumeID)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		resumePayload.Awards, err = r.GetResumeAwards(gCtx, resumeID)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		resumePayload.Languages, err = r.GetResumeLanguages(gCtx, resumeID)
+		return err
+	})
+
 	if err := g.Wait(); err != nil {
 		return nil, err
 	}
@@ -164,6 +236,17 @@ func (r *postgresRepository) GetFullResume(
 	return &resumePayload, nil
 }
 
+func (r *postgresRepository) GetEducation(
+	ctx context.Context,
+	roleID int,
+) ([]domain.EducationInfo, error) {
+	resumeID, err := r.getResumeID(ctx, r.db, roleID)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetResumeEducation(ctx, resumeID)
+}
+
 func getIDs[T any](slice []T, getID func(T) int) []int {
 	ids := make([]int, len(slice))
 	for i, item := range slice {
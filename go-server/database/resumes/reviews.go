@@ -0,0 +1,277 @@
+package resumes
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/features/documents/models/domain"
+)
+
+var (
+	ErrReviewNotFound   = errors.New("resume review not found")
+	ErrReviewFinalized  = errors.New("resume review is already finalized")
+	ErrReviewIncomplete = errors.New("resume review still has pending suggestions")
+)
+
+// SaveReview opens a review for the role's resume, replacing any earlier
+// review. The generated resume, without its review marks, is recorded as a
+// revision under meta but isn't made current; that waits for FinalizeReview.
+func (r *postgresRepository) SaveReview(
+	ctx context.Context,
+	roleID int,
+	resume *domain.Resume,
+	event json.RawMessage,
+	meta domain.RevisionMeta,
+) (*domain.Review, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	resumeID, err := r.getResumeID(ctx, tx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	draft, _ := resume.Finalize()
+	revisionID, err := r.insertRevision(ctx, tx, resumeID, canonicalSkills(&draft), meta, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(resume)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode review: %w", err)
+	}
+
+	var row models.ResumeReview
+	query := `
+		INSERT INTO resume_reviews (resume_id, revision_id, status, content, event)
+		VALUES ($1, $2, 'pending', $3, $4)
+		ON CONFLICT (resume_id) DO UPDATE SET
+			revision_id = EXCLUDED.revision_id,
+			status = 'pending',
+			content = EXCLUDED.content,
+			event = EXCLUDED.event,
+			created_at = NOW(),
+			updated_at = NOW(),
+			finalized_at = NULL
+		RETURNING *`
+	if err := tx.GetContext(ctx, &row, query, resumeID, revisionID, content, []byte(event)); err != nil {
+		return nil, fmt.Errorf("failed to save review: %w", err)
+	}
+
+	review, err := mapReviewToDomain(&row)
+	if err != nil {
+		return nil, err
+	}
+	return review, tx.Commit()
+}
+
+func (r *postgresRepository) GetReview(ctx context.Context, roleID int) (*domain.Review, error) {
+	resumeID, err := r.getResumeID(ctx, r.db, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var row models.ResumeReview
+	if err := r.db.GetContext(ctx, &row, "SELECT * FROM resume_reviews WHERE resume_id = $1", resumeID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	return mapReviewToDomain(&row)
+}
+
+// UpdateReview applies fn to the review's working copy of the resume. The
+// review row stays locked while fn runs so concurrent verdicts aren't lost.
+func (r *postgresRepository) UpdateReview(
+	ctx context.Context,
+	roleID int,
+	fn func(resume *domain.Resume) error,
+) (*domain.Review, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	review, err := r.lockPendingReview(ctx, tx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fn(review.Resume); err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(review.Resume)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode review: %w", err)
+	}
+
+	var row models.ResumeReview
+	query := "UPDATE resume_reviews SET content = $1, updated_at = NOW() WHERE id = $2 RETURNING *"
+	if err := tx.GetContext(ctx, &row, query, content, review.ID); err != nil {
+		return nil, fmt.Errorf("failed to update review: %w", err)
+	}
+
+	updated, err := mapReviewToDomain(&row)
+	if err != nil {
+		return nil, err
+	}
+	return updated, tx.Commit()
+}
+
+// FinalizeReview closes the role's pending review and makes the reviewed
+// resume current, along with each school in education. Rejected
+// suggestions are dropped from the resume and remembered. If the user
+// rejected or edited anything the result is recorded as a new revision
+// under meta and its ID returned; otherwise it matches the revision the
+// review started from, whose ID is returned instead.
+//
+// The returned review carries the finalized resume.
+func (r *postgresRepository) FinalizeReview(
+	ctx context.Context,
+	roleID int,
+	acceptPending bool,
+	education []domain.EducationInfo,
+	meta domain.RevisionMeta,
+) (*domain.Review, int, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback()
+
+	review, err := r.lockPendingReview(ctx, tx, roleID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !acceptPending && review.Pending > 0 {
+		return nil, 0, ErrReviewIncomplete
+	}
+
+	resumeID, err := r.getResumeID(ctx, tx, roleID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	edited := review.Resume.ReviewCount(domain.ReviewEdited) > 0
+	final, rejected := review.Resume.Finalize()
+
+	stored := canonicalSkills(&final)
+	if err := r.writeResume(ctx, tx, resumeID, stored); err != nil {
+		return nil, 0, err
+	}
+	for i := range education {
+		if err := r.UpsertEducation(ctx, tx, resumeID, &education[i]); err != nil {
+			return nil, 0, fmt.Errorf("failed to upsert educations %w", err)
+		}
+	}
+
+	var revisionID int
+	if review.RevisionID != nil {
+		revisionID = *review.RevisionID
+	}
+	if edited || len(rejected) > 0 || review.RevisionID == nil {
+		if revisionID, err = r.insertRevision(ctx, tx, resumeID, stored, meta, nil); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	for _, s := range rejected {
+		query := `
+			INSERT INTO resume_rejected_suggestions (resume_id, section, text, justification)
+			VALUES ($1, $2, $3, $4)`
+		if _, err := tx.ExecContext(ctx, query, resumeID, s.Section, s.Text, models.Optional(s.Justification)); err != nil {
+			return nil, 0, fmt.Errorf("failed to record rejected suggestion: %w", err)
+		}
+	}
+
+	query := "UPDATE resume_reviews SET status = 'finalized', updated_at = NOW(), finalized_at = NOW() WHERE id = $1"
+	if _, err := tx.ExecContext(ctx, query, review.ID); err != nil {
+		return nil, 0, fmt.Errorf("failed to finalize review: %w", err)
+	}
+
+	review.State = domain.ReviewStateFinalized
+	review.Resume = &final
+	review.Pending = 0
+	return review, revisionID, tx.Commit()
+}
+
+// ListRejectedSuggestions returns the most recently rejected suggestions for
+// the role's resume, newest first.
+func (r *postgresRepository) ListRejectedSuggestions(
+	ctx context.Context,
+	roleID int,
+	limit int,
+) ([]domain.RejectedSuggestion, error) {
+	resumeID, err := r.getResumeID(ctx, r.db, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []models.ResumeRejectedSuggestion
+	query := `
+		SELECT * FROM resume_rejected_suggestions WHERE resume_id = $1
+		ORDER BY created_at DESC, id DESC LIMIT $2`
+	if err := r.db.SelectContext(ctx, &rows, query, resumeID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list rejected suggestions: %w", err)
+	}
+
+	suggestions := make([]domain.RejectedSuggestion, 0, len(rows))
+	for _, row := range rows {
+		s := domain.RejectedSuggestion{Section: row.Section, Text: row.Text}
+		if row.Justification != nil {
+			s.Justification = *row.Justification
+		}
+		suggestions = append(suggestions, s)
+	}
+	return suggestions, nil
+}
+
+func (r *postgresRepository) lockPendingReview(ctx context.Context, tx *sqlx.Tx, roleID int) (*domain.Review, error) {
+	resumeID, err := r.getResumeID(ctx, tx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var row models.ResumeReview
+	query := "SELECT * FROM resume_reviews WHERE resume_id = $1 FOR UPDATE"
+	if err := tx.GetContext(ctx, &row, query, resumeID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to lock review: %w", err)
+	}
+	if row.Status != string(domain.ReviewStatePending) {
+		return nil, ErrReviewFinalized
+	}
+	return mapReviewToDomain(&row)
+}
+
+func mapReviewToDomain(row *models.ResumeReview) (*domain.Review, error) {
+	var resume domain.Resume
+	if err := json.Unmarshal(row.Content, &resume); err != nil {
+		return nil, fmt.Errorf("failed to decode review %d: %w", row.ID, err)
+	}
+	return &domain.Review{
+		ID:          row.ID,
+		State:       domain.ReviewState(row.Status),
+		RevisionID:  row.RevisionID,
+		Pending:     resume.ReviewCount(domain.ReviewPending),
+		Resume:      &resume,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+		FinalizedAt: row.FinalizedAt,
+		Event:       row.Event,
+	}, nil
+}
@@ -0,0 +1,164 @@
+package resumes
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/features/documents/models/domain"
+)
+
+var ErrRevisionNotFound = errors.New("resume revision not found")
+
+// insertRevision snapshots resume as the next revision for resumeID. It must
+// run in the same transaction that writes the resume tables.
+func (r *postgresRepository) insertRevision(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	resumeID int,
+	resume *domain.Resume,
+	meta domain.RevisionMeta,
+	restoredFrom *int,
+) (int, error) {
+	content, err := json.Marshal(resume)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode revision: %w", err)
+	}
+
+	var report []byte
+	if !meta.Report.IsEmpty() {
+		if report, err = json.Marshal(meta.Report); err != nil {
+			return 0, fmt.Errorf("failed to encode generation report: %w", err)
+		}
+	}
+
+	// Lock the parent resume row so concurrent writers can't claim the same
+	// revision number.
+	if _, err := tx.ExecContext(ctx, "SELECT id FROM resumes WHERE id = $1 FOR UPDATE", resumeID); err != nil {
+		return 0, fmt.Errorf("failed to lock resume: %w", err)
+	}
+
+	var id int
+	query := `
+		INSERT INTO resume_revisions (resume_id, revision_number, author, provider, model, prompt_version, restored_from, content, report)
+		SELECT $1, COALESCE(MAX(revision_number), 0) + 1, $2, $3, $4, $5, $6, $7, $8
+		FROM resume_revisions WHERE resume_id = $1
+		RETURNING id`
+	err = tx.GetContext(ctx, &id, query,
+		resumeID,
+		string(meta.Author),
+		models.Optional(meta.Provider),
+		models.Optional(meta.Model),
+		models.Optional(meta.PromptVersion),
+		restoredFrom,
+		content,
+		report,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert revision: %w", err)
+	}
+	return id, nil
+}
+
+func (r *postgresRepository) ListRevisions(ctx context.Context, roleID int) ([]domain.Revision, error) {
+	resumeID, err := r.getResumeID(ctx, r.db, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []models.ResumeRevision
+	query := `
+		SELECT id, resume_id, revision_number, author, provider, model, prompt_version, restored_from, '{}'::jsonb AS content, report, created_at
+		FROM resume_revisions WHERE resume_id = $1
+		ORDER BY revision_number DESC`
+	if err := r.db.SelectContext(ctx, &rows, query, resumeID); err != nil {
+		return nil, fmt.Errorf("failed to list revisions: %w", err)
+	}
+
+	revisions := make([]domain.Revision, 0, len(rows))
+	for i := range rows {
+		revision, err := MapRevisionToDomain(&rows[i], nil)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+func (r *postgresRepository) GetRevision(ctx context.Context, roleID int, revisionID int) (*domain.Revision, error) {
+	resumeID, err := r.getResumeID(ctx, r.db, roleID)
+	if err != nil {
+		return nil, err
+	}
+	return r.getRevision(ctx, r.db, resumeID, revisionID)
+}
+
+func (r *postgresRepository) getRevision(
+	ctx context.Context,
+	q sqlx.QueryerContext,
+	resumeID int,
+	revisionID int,
+) (*domain.Revision, error) {
+	var row models.ResumeRevision
+	query := "SELECT * FROM resume_revisions WHERE id = $1 AND resume_id = $2"
+	if err := sqlx.GetContext(ctx, q, &row, query, revisionID, resumeID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("failed to get revision: %w", err)
+	}
+
+	var resume domain.Resume
+	if err := json.Unmarshal(row.Content, &resume); err != nil {
+		return nil, fmt.Errorf("failed to decode revision %d: %w", row.ID, err)
+	}
+
+	revision, err := MapRevisionToDomain(&row, &resume)
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// RestoreRevision makes an earlier revision current again. The restore is
+// itself recorded as a new user revision pointing back at its source.
+func (r *postgresRepository) RestoreRevision(ctx context.Context, roleID int, revisionID int) (*domain.Revision, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	resumeID, err := r.getResumeID(ctx, tx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := r.getRevision(ctx, tx, resumeID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.writeResume(ctx, tx, resumeID, source.Resume); err != nil {
+		return nil, err
+	}
+
+	meta := source.RevisionMeta
+	meta.Author = domain.AuthorUser
+	meta.Report = nil
+	newID, err := r.insertRevision(ctx, tx, resumeID, source.Resume, meta, &source.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	restored, err := r.getRevision(ctx, tx, resumeID, newID)
+	if err != nil {
+		return nil, err
+	}
+	return restored, tx.Commit()
+}
@@ -0,0 +1,153 @@
+package resumes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/features/documents/models/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// sectionTables are the additional section tables without child rows;
+// volunteering and its descriptions are dropped separately.
+var sectionTables = []string{"certifications", "publications", "awards", "languages"}
+
+func (r *postgresRepository) dropSections(ctx context.Context, tx *sqlx.Tx, resumeID int) error {
+	for _, table := range sectionTables {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE resume_id = $1", table), resumeID); err != nil {
+			return err
+		}
+	}
+	subqueryVol := "SELECT id FROM volunteering WHERE resume_id = $1"
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM volunteering_descriptions WHERE volunteering_id IN (%s)", subqueryVol), resumeID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM volunteering WHERE resume_id = $1", resumeID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpsertSections writes the certifications, publications, volunteering,
+// awards and languages of a resume in their current order.
+func (r *postgresRepository) UpsertSections(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	resumeID int,
+	resume *domain.Resume,
+) error {
+	if len(resume.Certifications) > 0 {
+		rows := make([]models.Certification, 0, len(resume.Certifications))
+		for i, c := range resume.Certifications {
+			rows = append(rows, models.Certification{ResumeID: resumeID, Name: c.Name, Issuer: c.Issuer, Date: c.Date, URL: models.Optional(c.URL), SortOrder: i})
+		}
+		if _, err := tx.NamedExecContext(ctx, "INSERT INTO certifications (resume_id, name, issuer, date, url, sort_order) VALUES (:resume_id, :name, :issuer, :date, :url, :sort_order)", rows); err != nil {
+			return err
+		}
+	}
+
+	if len(resume.Publications) > 0 {
+		rows := make([]models.Publication, 0, len(resume.Publications))
+		for i, p := range resume.Publications {
+			rows = append(rows, models.Publication{ResumeID: resumeID, Title: p.Title, Publisher: p.Publisher, Date: p.Date, URL: models.Optional(p.URL), Summary: models.Optional(p.Summary), SortOrder: i})
+		}
+		if _, err := tx.NamedExecContext(ctx, "INSERT INTO publications (resume_id, title, publisher, date, url, summary, sort_order) VALUES (:resume_id, :title, :publisher, :date, :url, :summary, :sort_order)", rows); err != nil {
+			return err
+		}
+	}
+
+	if len(resume.Awards) > 0 {
+		rows := make([]models.Award, 0, len(resume.Awards))
+		for i, a := range resume.Awards {
+			rows = append(rows, models.Award{ResumeID: resumeID, Title: a.Title, Awarder: a.Awarder, Date: a.Date, Summary: models.Optional(a.Summary), SortOrder: i})
+		}
+		if _, err := tx.NamedExecContext(ctx, "INSERT INTO awards (resume_id, title, awarder, date, summary, sort_order) VALUES (:resume_id, :title, :awarder, :date, :summary, :sort_order)", rows); err != nil {
+			return err
+		}
+	}
+
+	if len(resume.Languages) > 0 {
+		rows := make([]models.Language, 0, len(resume.Languages))
+		for i, l := range resume.Languages {
+			rows = append(rows, models.Language{ResumeID: resumeID, Language: l.Language, Fluency: l.Fluency, SortOrder: i})
+		}
+		if _, err := tx.NamedExecContext(ctx, "INSERT INTO languages (resume_id, language, fluency, sort_order) VALUES (:resume_id, :language, :fluency, :sort_order)", rows); err != nil {
+			return err
+		}
+	}
+
+	for i, v := range resume.Volunteering {
+		var volID int
+		volQuery := "INSERT INTO volunteering (resume_id, organization, role, start_date, end_date, sort_order) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id"
+		if err := tx.GetContext(ctx, &volID, volQuery, resumeID, v.Organization, v.Role, v.Start, v.End, i); err != nil {
+			return err
+		}
+
+		if len(v.BulletPoints) == 0 {
+			continue
+		}
+
+		var volDescs []models.VolunteeringDescription
+		for _, desc := range v.BulletPoints {
+			volDescs = append(volDescs, models.VolunteeringDescription{VolunteeringID: volID, Text: desc.Text, NewSuggestion: desc.IsNewSuggestion, JustificationForChange: &desc.JustificationForChange})
+		}
+
+		if _, err := tx.NamedExecContext(ctx, "INSERT INTO volunteering_descriptions (volunteering_id, text, new_suggestion, justification_for_change) VALUES (:volunteering_id, :text, :new_suggestion, :justification_for_change)", volDescs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *postgresRepository) GetResumeCertifications(ctx context.Context, resumeID int) ([]domain.Certification, error) {
+	var rows []models.Certification
+	if err := r.db.SelectContext(ctx, &rows, "SELECT * FROM certifications WHERE resume_id = $1 ORDER BY sort_order", resumeID); err != nil {
+		return nil, err
+	}
+	return MapCertificationsToDomain(rows), nil
+}
+
+func (r *postgresRepository) GetResumePublications(ctx context.Context, resumeID int) ([]domain.Publication, error) {
+	var rows []models.Publication
+	if err := r.db.SelectContext(ctx, &rows, "SELECT * FROM publications WHERE resume_id = $1 ORDER BY sort_order", resumeID); err != nil {
+		return nil, err
+	}
+	return MapPublicationsToDomain(rows), nil
+}
+
+func (r *postgresRepository) GetResumeAwards(ctx context.Context, resumeID int) ([]domain.Award, error) {
+	var rows []models.Award
+	if err := r.db.SelectContext(ctx, &rows, "SELECT * FROM awards WHERE resume_id = $1 ORDER BY sort_order", resumeID); err != nil {
+		return nil, err
+	}
+	return MapAwardsToDomain(rows), nil
+}
+
+func (r *postgresRepository) GetResumeLanguages(ctx context.Context, resumeID int) ([]domain.Language, error) {
+	var rows []models.Language
+	if err := r.db.SelectContext(ctx, &rows, "SELECT * FROM languages WHERE resume_id = $1 ORDER BY sort_order", resumeID); err != nil {
+		return nil, err
+	}
+	return MapLanguagesToDomain(rows), nil
+}
+
+func (r *postgresRepository) GetResumeVolunteering(ctx context.Context, resumeID int) ([]domain.Volunteering, error) {
+	var volunteering []models.Volunteering
+	if err := r.db.SelectContext(ctx, &volunteering, "SELECT * FROM volunteering WHERE resume_id = $1 ORDER BY sort_order", resumeID); err != nil {
+		return nil, err
+	}
+	if len(volunteering) == 0 {
+		return nil, nil
+	}
+
+	volIDs := getIDs(volunteering, func(v models.Volunteering) int { return v.ID })
+	var volDescs []models.VolunteeringDescription
+	query, args, _ := sqlx.In("SELECT * FROM volunteering_descriptions WHERE volunteering_id IN (?) ORDER BY id", volIDs)
+	if err := r.db.SelectContext(ctx, &volDescs, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+
+	return MapVolunteeringToDomain(volunteering, volDescs), nil
+}
@@ -5,10 +5,28 @@ import (
 
 	"github.com/ordo_meritum/database/models"
 	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/shared/libs/taxonomy"
 
 	"github.com/jmoiron/sqlx"
 )
 
+// canonicalSkills returns a copy of resume whose skill items are renamed to
+// their canonical names, with duplicates within a category dropped. The
+// resume passed in is left untouched.
+func canonicalSkills(resume *domain.Resume) *domain.Resume {
+	if len(resume.Skills) == 0 {
+		return resume
+	}
+	tax := taxonomy.Default()
+	out := *resume
+	out.Skills = make([]domain.Skills, len(resume.Skills))
+	for i, s := range resume.Skills {
+		s.SkillItem = tax.NormalizeList(s.SkillItem)
+		out.Skills[i] = s
+	}
+	return &out
+}
+
 func (r *postgresRepository) UpsertSkills(
 	ctx context.Context,
 	tx *sqlx.Tx,
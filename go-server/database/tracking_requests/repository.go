@@ -0,0 +1,138 @@
+package tracking_requests
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/features/application_tracking/models/domain"
+	"github.com/ordo_meritum/shared/contexts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+var ErrTrackingRequestNotFound = errors.New("tracking request not found")
+
+type Repository interface {
+	Create(ctx context.Context) (*domain.TrackingRequest, error)
+	Get(ctx context.Context, id int) (*domain.TrackingRequest, error)
+	Update(ctx context.Context, id int, update domain.TrackingUpdate) (*domain.TrackingRequest, error)
+	FailStale(ctx context.Context, before time.Time, code, message string) (int64, error)
+}
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+func (r *postgresRepository) Create(ctx context.Context) (*domain.TrackingRequest, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var row models.TrackingRequest
+	query := "INSERT INTO tracking_requests (firebase_uid) VALUES ($1) RETURNING *"
+	if err := r.db.GetContext(ctx, &row, query, userCtx.UID); err != nil {
+		return nil, fmt.Errorf("failed to create tracking request: %w", err)
+	}
+	t := toDomain(&row)
+	return &t, nil
+}
+
+func (r *postgresRepository) Get(ctx context.Context, id int) (*domain.TrackingRequest, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var row models.TrackingRequest
+	query := "SELECT * FROM tracking_requests WHERE id = $1 AND firebase_uid = $2"
+	if err := r.db.GetContext(ctx, &row, query, id, userCtx.UID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTrackingRequestNotFound
+		}
+		return nil, fmt.Errorf("failed to get tracking request: %w", err)
+	}
+	t := toDomain(&row)
+	return &t, nil
+}
+
+func (r *postgresRepository) Update(ctx context.Context, id int, update domain.TrackingUpdate) (*domain.TrackingRequest, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var row models.TrackingRequest
+	query := `
+		UPDATE tracking_requests SET
+			status        = $3,
+			role_id       = COALESCE($4, role_id),
+			error_code    = COALESCE($5, error_code),
+			error_message = COALESCE($6, error_message),
+			updated_at    = NOW(),
+			finished_at   = CASE WHEN $3 IN ('succeeded', 'failed') THEN NOW() ELSE finished_at END
+		WHERE id = $1 AND firebase_uid = $2
+		RETURNING *`
+	err := r.db.GetContext(ctx, &row, query,
+		id,
+		userCtx.UID,
+		string(update.Status),
+		update.RoleID,
+		models.Optional(update.ErrorCode),
+		models.Optional(update.Error),
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTrackingRequestNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update tracking request: %w", err)
+	}
+	t := toDomain(&row)
+	return &t, nil
+}
+
+// FailStale fails every user's unfinished requests not touched since before,
+// which a stopped instance left behind.
+func (r *postgresRepository) FailStale(ctx context.Context, before time.Time, code, message string) (int64, error) {
+	query := `
+		UPDATE tracking_requests SET
+			status        = 'failed',
+			error_code    = $2,
+			error_message = $3,
+			updated_at    = NOW(),
+			finished_at   = NOW()
+		WHERE status IN ('queued', 'parsing') AND updated_at < $1`
+	res, err := r.db.ExecContext(ctx, query, before, code, message)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail stale tracking requests: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+func toDomain(row *models.TrackingRequest) domain.TrackingRequest {
+	t := domain.TrackingRequest{
+		ID:         row.ID,
+		Status:     domain.TrackingStatus(row.Status),
+		RoleID:     row.RoleID,
+		CreatedAt:  row.CreatedAt,
+		UpdatedAt:  row.UpdatedAt,
+		FinishedAt: row.FinishedAt,
+	}
+	if row.ErrorCode != nil {
+		t.ErrorCode = *row.ErrorCode
+	}
+	if row.ErrorMessage != nil {
+		t.Error = *row.ErrorMessage
+	}
+	return t
+}
+
+var _ Repository = (*postgresRepository)(nil)
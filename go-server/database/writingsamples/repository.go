@@ -2,15 +2,23 @@ package writingsamples
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"log"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/ordo_meritum/database/models"
 )
 
+var ErrWritingSampleNotFound = errors.New("writing sample not found")
+
 type Repository interface {
 	CreateOrUpdate(ctx context.Context, firebaseUID string, samples []models.CandidateWritingSample) error
 	GetByFirebaseUID(ctx context.Context, firebaseUID string) ([]models.CandidateWritingSample, error)
+	Create(ctx context.Context, firebaseUID string, content string) (*models.CandidateWritingSample, error)
+	Update(ctx context.Context, firebaseUID string, id int, content string) (*models.CandidateWritingSample, error)
+	Delete(ctx context.Context, firebaseUID string, id int) error
 }
 
 type postgresRepository struct {
@@ -47,7 +55,7 @@ func (r *postgresRepository) CreateOrUpdate(ctx context.Context, firebaseUID str
 
 func (r *postgresRepository) GetByFirebaseUID(ctx context.Context, firebaseUID string) ([]models.CandidateWritingSample, error) {
 	var samples []models.CandidateWritingSample
-	query := "SELECT * FROM candidate_writing_samples WHERE firebase_uid = $1"
+	query := "SELECT * FROM candidate_writing_samples WHERE firebase_uid = $1 ORDER BY updated_at DESC, id DESC"
 	err := r.db.SelectContext(ctx, &samples, query, firebaseUID)
 	if err != nil {
 		return nil, err
@@ -56,4 +64,45 @@ func (r *postgresRepository) GetByFirebaseUID(ctx context.Context, firebaseUID s
 	return samples, nil
 }
 
+func (r *postgresRepository) Create(ctx context.Context, firebaseUID string, content string) (*models.CandidateWritingSample, error) {
+	var sample models.CandidateWritingSample
+	query := "INSERT INTO candidate_writing_samples (firebase_uid, content) VALUES ($1, $2) RETURNING *"
+	if err := r.db.GetContext(ctx, &sample, query, firebaseUID, content); err != nil {
+		return nil, fmt.Errorf("failed to create writing sample: %w", err)
+	}
+	return &sample, nil
+}
+
+func (r *postgresRepository) Update(ctx context.Context, firebaseUID string, id int, content string) (*models.CandidateWritingSample, error) {
+	var sample models.CandidateWritingSample
+	query := `
+		UPDATE candidate_writing_samples SET content = $3, updated_at = NOW()
+		WHERE id = $1 AND firebase_uid = $2
+		RETURNING *`
+	err := r.db.GetContext(ctx, &sample, query, id, firebaseUID, content)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrWritingSampleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update writing sample: %w", err)
+	}
+	return &sample, nil
+}
+
+func (r *postgresRepository) Delete(ctx context.Context, firebaseUID string, id int) error {
+	query := "DELETE FROM candidate_writing_samples WHERE id = $1 AND firebase_uid = $2"
+	res, err := r.db.ExecContext(ctx, query, id, firebaseUID)
+	if err != nil {
+		return fmt.Errorf("failed to delete writing sample: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrWritingSampleNotFound
+	}
+	return nil
+}
+
 var _ Repository = (*postgresRepository)(nil)
@@ -0,0 +1,93 @@
+package writingstyles
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/shared/contexts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+var ErrStyleProfileNotFound = errors.New("writing style profile not found")
+
+type Repository interface {
+	Get(ctx context.Context) (*domain.StyleProfile, error)
+	Save(ctx context.Context, p *domain.StyleProfile) error
+	Delete(ctx context.Context) error
+}
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+func (r *postgresRepository) Get(ctx context.Context) (*domain.StyleProfile, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	var row models.WritingStyleProfile
+	query := "SELECT * FROM writing_style_profiles WHERE firebase_uid = $1"
+	if err := r.db.GetContext(ctx, &row, query, userCtx.UID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrStyleProfileNotFound
+		}
+		return nil, fmt.Errorf("failed to get writing style profile: %w", err)
+	}
+
+	var p domain.StyleProfile
+	if err := json.Unmarshal(row.Profile, &p); err != nil {
+		return nil, fmt.Errorf("failed to decode writing style profile: %w", err)
+	}
+	p.SampleCount = row.SampleCount
+	p.SamplesUpdatedAt = row.SamplesUpdatedAt
+	return &p, nil
+}
+
+// Save replaces the user's profile with p.
+func (r *postgresRepository) Save(ctx context.Context, p *domain.StyleProfile) error {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return error_response.ErrNoUserContext
+	}
+
+	content, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to encode writing style profile: %w", err)
+	}
+	query := `
+		INSERT INTO writing_style_profiles (firebase_uid, profile, sample_count, samples_updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (firebase_uid) DO UPDATE SET
+			profile            = EXCLUDED.profile,
+			sample_count       = EXCLUDED.sample_count,
+			samples_updated_at = EXCLUDED.samples_updated_at,
+			updated_at         = NOW()`
+	if _, err := r.db.ExecContext(ctx, query, userCtx.UID, content, p.SampleCount, p.SamplesUpdatedAt); err != nil {
+		return fmt.Errorf("failed to save writing style profile: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) Delete(ctx context.Context) error {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return error_response.ErrNoUserContext
+	}
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM writing_style_profiles WHERE firebase_uid = $1", userCtx.UID); err != nil {
+		return fmt.Errorf("failed to delete writing style profile: %w", err)
+	}
+	return nil
+}
+
+var _ Repository = (*postgresRepository)(nil)
@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/ordo_meritum/database/applicationanswers"
+	"github.com/ordo_meritum/database/jobs"
+	"github.com/ordo_meritum/features/application_answers/models/requests"
+	"github.com/ordo_meritum/features/application_answers/services"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/middleware"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	"github.com/ordo_meritum/shared/webrender"
+	"github.com/rs/zerolog/log"
+)
+
+type Controller struct {
+	service *services.ApplicationAnswersService
+}
+
+func NewController(service *services.ApplicationAnswersService) *Controller {
+	return &Controller{service: service}
+}
+
+func (c *Controller) RegisterRoutes(secureRouter *mux.Router, authRouter *mux.Router) {
+	secureRouter.HandleFunc("/application-answers", c.HandleAnswerQuestions).Methods("POST")
+	authRouter.HandleFunc("/application-answers/roles/{id:[0-9]+}", c.HandleListAnswers).Methods("GET")
+	authRouter.HandleFunc("/application-answers/{id:[0-9]+}", c.HandleUpdateAnswer).Methods("PUT")
+	authRouter.HandleFunc("/application-answers/{id:[0-9]+}", c.HandleDeleteAnswer).Methods("DELETE")
+}
+
+// HandleAnswerQuestions answers a role's application questions, reusing
+// stored answers where it can.
+func (c *Controller) HandleAnswerQuestions(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	if _, ok := contexts.FromContext(r.Context()); !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var req requests.AnswersRequest
+	if webrender.DecodeJSONBody(w, r, &req) != nil {
+		return
+	}
+	if details := req.Payload.Validate(); len(details) > 0 {
+		writeValidationError(w, details)
+		return
+	}
+
+	answers, err := c.service.Answer(r.Context(), &req)
+	if err != nil {
+		writeAnswerError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, answers)
+}
+
+// HandleListAnswers returns the answers stored for a role.
+func (c *Controller) HandleListAnswers(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	roleID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if _, ok := contexts.FromContext(r.Context()); !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	answers, err := c.service.List(r.Context(), roleID)
+	if err != nil {
+		writeAnswerError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, answers)
+}
+
+func (c *Controller) HandleUpdateAnswer(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if _, ok := contexts.FromContext(r.Context()); !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var req requests.AnswerUpdateRequest
+	if webrender.DecodeJSONBody(w, r, &req) != nil {
+		return
+	}
+	if details := req.Validate(); len(details) > 0 {
+		writeValidationError(w, details)
+		return
+	}
+
+	answer, err := c.service.Update(r.Context(), id, req.Answer)
+	if err != nil {
+		writeAnswerError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, answer)
+}
+
+func (c *Controller) HandleDeleteAnswer(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if _, ok := contexts.FromContext(r.Context()); !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	if err := c.service.Delete(r.Context(), id); err != nil {
+		writeAnswerError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeValidationError(w http.ResponseWriter, details []error_response.ValidationDetail) {
+	middleware.JSON(w, http.StatusBadRequest, error_response.ErrorResponse[[]error_response.ValidationDetail]{
+		ErrorCode: error_response.BAD_REQUEST,
+		Message:   "The request failed validation.",
+		Details:   details,
+	})
+}
+
+func writeAnswerError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, applicationanswers.ErrAnswerNotFound), errors.Is(err, jobs.ErrJobNotFound):
+		middleware.JSON(w, http.StatusNotFound, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.RESOURCE_UNAVAILABLE,
+			Message:   err.Error(),
+		})
+	default:
+		log.Error().Err(err).Str("service", "application-answers-controller").Msg("Application answers request failed")
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+	}
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Answer is a written answer to one free-text question on a role's
+// application. Edited answers were changed by the user and are never
+// regenerated unless asked to.
+type Answer struct {
+	ID        int    `json:"id"`
+	RoleID    int    `json:"roleId"`
+	Question  string `json:"question"`
+	Answer    string `json:"answer"`
+	CharLimit int    `json:"charLimit"`
+	Provider  string `json:"provider,omitempty"`
+	Model     string `json:"model,omitempty"`
+	Edited    bool   `json:"edited"`
+	// Reused is set on answers served from storage rather than written for
+	// this request.
+	Reused    bool      `json:"reused,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// QuestionKey normalizes a question for matching against stored answers:
+// case, punctuation and spacing don't make two questions different.
+func QuestionKey(question string) string {
+	var builder strings.Builder
+	space := false
+	for _, r := range strings.ToLower(question) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && builder.Len() > 0 {
+				builder.WriteRune(' ')
+			}
+			builder.WriteRune(r)
+			space = false
+		default:
+			space = true
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,85 @@
+package requests
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ordo_meritum/shared/models/requests"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+const (
+	MaxQuestions      = 10
+	MaxQuestionLength = 1000
+	MinCharLimit      = 100
+	MaxCharLimit      = 5000
+	DefaultCharLimit  = 1000
+)
+
+type AnswersRequest = requests.RequestBody[AnswersPayload, AnswersOptions]
+
+type AnswersPayload struct {
+	RoleID    int        `json:"roleId"`
+	Questions []Question `json:"questions"`
+}
+
+// Question is one free-text question from the application form. CharLimit
+// is the form's limit for the answer; zero uses DefaultCharLimit.
+type Question struct {
+	Question  string `json:"question"`
+	CharLimit int    `json:"charLimit,omitempty"`
+}
+
+type AnswersOptions struct {
+	LlmProvider string `json:"llm"`
+	LlmModel    string `json:"llmModel"`
+	GetNew      bool   `json:"getNew,omitempty"`
+}
+
+// AnswerUpdateRequest replaces a stored answer with the user's own wording.
+type AnswerUpdateRequest struct {
+	Answer string `json:"answer"`
+}
+
+func (p *AnswersPayload) Validate() []error_response.ValidationDetail {
+	var details []error_response.ValidationDetail
+	if p.RoleID <= 0 {
+		details = append(details, error_response.ValidationDetail{Field: "roleId", Issue: "must be a tracked role ID"})
+	}
+	if len(p.Questions) == 0 || len(p.Questions) > MaxQuestions {
+		details = append(details, error_response.ValidationDetail{
+			Field: "questions",
+			Issue: fmt.Sprintf("must contain between 1 and %d questions", MaxQuestions),
+		})
+	}
+	for i, q := range p.Questions {
+		field := fmt.Sprintf("questions[%d]", i)
+		question := strings.TrimSpace(q.Question)
+		if question == "" {
+			details = append(details, error_response.ValidationDetail{Field: field + ".question", Issue: "cannot be empty"})
+		} else if utf8.RuneCountInString(question) > MaxQuestionLength {
+			details = append(details, error_response.ValidationDetail{
+				Field: field + ".question",
+				Issue: fmt.Sprintf("cannot exceed %d characters", MaxQuestionLength),
+			})
+		}
+		if q.CharLimit != 0 && (q.CharLimit < MinCharLimit || q.CharLimit > MaxCharLimit) {
+			details = append(details, error_response.ValidationDetail{
+				Field: field + ".charLimit",
+				Issue: fmt.Sprintf("must be between %d and %d", MinCharLimit, MaxCharLimit),
+			})
+		}
+	}
+	return details
+}
+
+func (r *AnswerUpdateRequest) Validate() []error_response.ValidationDetail {
+	if strings.TrimSpace(r.Answer) == "" {
+		return []error_response.ValidationDetail{{Field: "answer", Issue: "cannot be empty"}}
+	}
+	if utf8.RuneCountInString(r.Answer) > MaxCharLimit {
+		return []error_response.ValidationDetail{{Field: "answer", Issue: fmt.Sprintf("cannot exceed %d characters", MaxCharLimit)}}
+	}
+	return nil
+}
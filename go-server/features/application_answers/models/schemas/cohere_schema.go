@@ -0,0 +1,28 @@
+package schemas
+
+import (
+	cohere "github.com/cohere-ai/cohere-go/v2"
+)
+
+var ApplicationAnswersSchema = map[string]any{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"type":    "object",
+	"properties": map[string]any{
+		"answers": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"index":  map[string]any{"type": "integer"},
+					"answer": map[string]any{"type": "string"},
+				},
+				"required": []string{"index", "answer"},
+			},
+		},
+	},
+	"required": []string{"answers"},
+}
+
+var CohereApplicationAnswersSchemaFormat = cohere.JsonResponseFormatV2{
+	JsonSchema: ApplicationAnswersSchema,
+}
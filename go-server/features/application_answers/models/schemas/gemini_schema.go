@@ -0,0 +1,23 @@
+package schemas
+
+import (
+	"google.golang.org/genai"
+)
+
+var GeminiApplicationAnswersSchema = &genai.Schema{
+	Type: genai.TypeObject,
+	Properties: map[string]*genai.Schema{
+		"answers": {
+			Type: genai.TypeArray,
+			Items: &genai.Schema{
+				Type: genai.TypeObject,
+				Properties: map[string]*genai.Schema{
+					"index":  {Type: genai.TypeInteger},
+					"answer": {Type: genai.TypeString},
+				},
+				Required: []string{"index", "answer"},
+			},
+		},
+	},
+	Required: []string{"answers"},
+}
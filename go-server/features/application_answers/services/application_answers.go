@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ordo_meritum/database/applicationanswers"
+	"github.com/ordo_meritum/database/candidate_forms"
+	"github.com/ordo_meritum/database/jobs"
+	"github.com/ordo_meritum/database/profiles"
+	"github.com/ordo_meritum/database/resumes"
+	"github.com/ordo_meritum/database/writingsamples"
+	"github.com/ordo_meritum/features/application_answers/models/domain"
+	"github.com/ordo_meritum/features/application_answers/models/requests"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/libs/llm"
+	schemaregistry "github.com/ordo_meritum/shared/libs/llm/schema_registry"
+	"github.com/ordo_meritum/shared/templates/instructions"
+	"github.com/ordo_meritum/shared/templates/prompts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	shared_formatters "github.com/ordo_meritum/shared/utils/formatters"
+	"github.com/rs/zerolog/log"
+)
+
+var logger = log.With().
+	Str("service", "application-answers").
+	Logger()
+
+type ApplicationAnswersService struct {
+	answerRepo        applicationanswers.Repository
+	jobRepo           jobs.Repository
+	resumeRepo        resumes.Repository
+	profileRepo       profiles.Repository
+	candidateFormRepo candidate_forms.Repository
+	writingSampleRepo writingsamples.Repository
+}
+
+func NewApplicationAnswersService(
+	answerRepo applicationanswers.Repository,
+	jobRepo jobs.Repository,
+	resumeRepo resumes.Repository,
+	profileRepo profiles.Repository,
+	candidateFormRepo candidate_forms.Repository,
+	writingSampleRepo writingsamples.Repository,
+) *ApplicationAnswersService {
+	return &ApplicationAnswersService{
+		answerRepo:        answerRepo,
+		jobRepo:           jobRepo,
+		resumeRepo:        resumeRepo,
+		profileRepo:       profileRepo,
+		candidateFormRepo: candidateFormRepo,
+		writingSampleRepo: writingSampleRepo,
+	}
+}
+
+// pendingQuestion is a question that needs a new answer. Index is its
+// position in the prompt, which the LLM echoes back with the answer.
+type pendingQuestion struct {
+	Index     int
+	Question  string
+	CharLimit int
+}
+
+type llmAnswers struct {
+	Answers []struct {
+		Index  int    `json:"index"`
+		Answer string `json:"answer"`
+	} `json:"answers"`
+}
+
+// Answer returns an answer to each question, in the order asked. A stored
+// answer to the same question that fits the limit is reused unless GetNew
+// is set; the rest are written in one LLM call and stored for the role.
+func (s *ApplicationAnswersService) Answer(ctx context.Context, r *requests.AnswersRequest) ([]domain.Answer, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+	roleID := r.Payload.RoleID
+
+	j, err := s.jobRepo.GetFullJobPosting(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := map[string]domain.Answer{}
+	if !r.Options.GetNew {
+		list, err := s.answerRepo.List(ctx, roleID)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range list {
+			stored[domain.QuestionKey(a.Question)] = a
+		}
+	}
+
+	results := make([]domain.Answer, len(r.Payload.Questions))
+	// slots maps each question key to the positions in results it fills, so
+	// a question asked twice is only answered once.
+	slots := map[string][]int{}
+	var pending []pendingQuestion
+	for i, q := range r.Payload.Questions {
+		limit := q.CharLimit
+		if limit == 0 {
+			limit = requests.DefaultCharLimit
+		}
+		key := domain.QuestionKey(q.Question)
+		if a, ok := stored[key]; ok && len([]rune(a.Answer)) <= limit {
+			a.Reused = true
+			results[i] = a
+			continue
+		}
+		if _, ok := slots[key]; !ok {
+			pending = append(pending, pendingQuestion{Index: len(pending), Question: q.Question, CharLimit: limit})
+		}
+		slots[key] = append(slots[key], i)
+	}
+	if len(pending) == 0 {
+		return results, nil
+	}
+
+	written, err := s.writeAnswers(ctx, userCtx.UID, roleID, r.Options, j, pending)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := s.answerRepo.Save(ctx, roleID, written)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range saved {
+		for _, i := range slots[domain.QuestionKey(a.Question)] {
+			results[i] = a
+		}
+	}
+
+	logger.Info().
+		Str("uid", userCtx.UID).
+		Int("roleID", roleID).
+		Int("written", len(saved)).
+		Int("reused", len(r.Payload.Questions)-len(pending)).
+		Msg("Answered application questions")
+	return results, nil
+}
+
+// List returns the answers stored for the role.
+func (s *ApplicationAnswersService) List(ctx context.Context, roleID int) ([]domain.Answer, error) {
+	return s.answerRepo.List(ctx, roleID)
+}
+
+// Update replaces an answer with the user's own wording. Edited answers are
+// reused like generated ones.
+func (s *ApplicationAnswersService) Update(ctx context.Context, id int, answer string) (*domain.Answer, error) {
+	return s.answerRepo.Update(ctx, id, answer)
+}
+
+func (s *ApplicationAnswersService) Delete(ctx context.Context, id int) error {
+	return s.answerRepo.Delete(ctx, id)
+}
+
+// writeAnswers has the LLM answer the pending questions and fits each answer
+// to its question's limit.
+func (s *ApplicationAnswersService) writeAnswers(
+	ctx context.Context,
+	uid string,
+	roleID int,
+	opts requests.AnswersOptions,
+	j *jobs.FullJobPosting,
+	pending []pendingQuestion,
+) ([]domain.Answer, error) {
+	llmProvider, err := llm.GetProvider(opts.LlmProvider)
+	if err != nil {
+		return nil, fmt.Errorf("GetProvider Failed %w", err)
+	}
+
+	schema, err := schemaregistry.GetSchema(opts.LlmProvider, schemaregistry.ApplicationAnswers)
+	if err != nil {
+		return nil, fmt.Errorf("GetSchema Failed %w", err)
+	}
+
+	promptData, err := s.buildPromptData(ctx, uid, roleID, j, pending)
+	if err != nil {
+		return nil, err
+	}
+	prompt, err := shared_formatters.FormatTemplate(prompts.Prompts, "applicationanswers.txt", promptData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format prompt template: %w", err)
+	}
+
+	instructionBytes, err := instructions.Instructions.ReadFile("applicationanswers.txt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read instructions file: %w", err)
+	}
+
+	rawResponse, err := llmProvider.Generate(ctx, string(instructionBytes), prompt, schema)
+	if err != nil {
+		return nil, fmt.Errorf("LLM generation failed: %w", err)
+	}
+
+	var response llmAnswers
+	if err := json.Unmarshal([]byte(llm.FormatLLMResponse(rawResponse)), &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal LLM response: %w. Raw response: %s", err, rawResponse)
+	}
+
+	byIndex := make(map[int]string, len(response.Answers))
+	for _, a := range response.Answers {
+		byIndex[a.Index] = a.Answer
+	}
+	answers := make([]domain.Answer, 0, len(pending))
+	for _, q := range pending {
+		text, ok := byIndex[q.Index]
+		if !ok || text == "" {
+			return nil, fmt.Errorf("LLM response is missing an answer to question %d", q.Index)
+		}
+		answers = append(answers, domain.Answer{
+			Question:  q.Question,
+			Answer:    shared_formatters.FitToLimit(text, q.CharLimit),
+			CharLimit: q.CharLimit,
+			Provider:  opts.LlmProvider,
+			Model:     llm.ModelName(opts.LlmProvider),
+		})
+	}
+	return answers, nil
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ordo_meritum/database/candidate_forms"
+	"github.com/ordo_meritum/database/jobs"
+	"github.com/ordo_meritum/database/profiles"
+	job_mappers "github.com/ordo_meritum/features/application_tracking/utils/mappers"
+	"github.com/ordo_meritum/features/documents/utils/formatters"
+	"github.com/ordo_meritum/features/documents/utils/samples"
+	profile_mappers "github.com/ordo_meritum/features/profiles/utils/mappers"
+	"github.com/ordo_meritum/shared/mappers"
+	shared_formatters "github.com/ordo_meritum/shared/utils/formatters"
+)
+
+// writingSampleBudget caps, in estimated tokens, how much of the user's
+// writing goes into the prompt.
+const writingSampleBudget = 1000
+
+// buildPromptData gathers the job, with its company culture and values, the
+// user's resume for the role and, when they have them, their writing samples
+// and personality profile.
+func (s *ApplicationAnswersService) buildPromptData(
+	ctx context.Context,
+	uid string,
+	roleID int,
+	j *jobs.FullJobPosting,
+	pending []pendingQuestion,
+) (map[string]any, error) {
+	resume, err := shared_formatters.ResumeForPrompt(ctx, s.resumeRepo, roleID, func() (string, error) {
+		return s.profileForPrompt(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"JobPost":        job_mappers.NewJobDescriptionFromPost(j).FormatForLLM(),
+		"Resume":         resume,
+		"WritingSamples": s.writingSamples(ctx, uid, j, pending),
+		"Personality":    s.personality(ctx, uid),
+		"Questions":      pending,
+	}, nil
+}
+
+// profileForPrompt formats the user's profile for the prompt, or returns ""
+// if they don't have one.
+func (s *ApplicationAnswersService) profileForPrompt(ctx context.Context) (string, error) {
+	profile, err := s.profileRepo.GetProfile(ctx)
+	if errors.Is(err, profiles.ErrProfileNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	payload := profile_mappers.ToDocumentPayload(profile)
+	return formatters.FormatResumeRequestForLLMWithXML(&payload), nil
+}
+
+// writingSamples picks the stored samples closest to the job and questions.
+// They are optional, so a failure to load them is only logged.
+func (s *ApplicationAnswersService) writingSamples(
+	ctx context.Context,
+	uid string,
+	j *jobs.FullJobPosting,
+	pending []pendingQuestion,
+) string {
+	stored, err := s.writingSampleRepo.GetByFirebaseUID(ctx, uid)
+	if err != nil {
+		logger.Warn().Err(err).Str("uid", uid).Msg("Failed to load writing samples")
+		return ""
+	}
+	candidates := make([]samples.Sample, 0, len(stored))
+	for _, ws := range stored {
+		candidates = append(candidates, samples.Sample{Content: ws.Content, UpdatedAt: ws.UpdatedAt})
+	}
+
+	keywords := strings.Fields(j.JobTitle)
+	keywords = append(keywords, j.IndustryKeywords...)
+	for _, q := range pending {
+		keywords = append(keywords, strings.Fields(q.Question)...)
+	}
+
+	var builder strings.Builder
+	for _, sample := range samples.Select(candidates, keywords, writingSampleBudget) {
+		builder.WriteString("<sample>\n")
+		builder.WriteString(sample)
+		builder.WriteString("\n</sample>\n")
+	}
+	return builder.String()
+}
+
+// personality returns the user's personality profile, or "" if they haven't
+// taken the questionnaire.
+func (s *ApplicationAnswersService) personality(ctx context.Context, uid string) string {
+	ocean, disc, err := s.candidateFormRepo.GetPersonalityProfile(ctx, uid)
+	switch {
+	case errors.Is(err, candidate_forms.ErrUserNotFound):
+		return ""
+	case err != nil:
+		logger.Warn().Err(err).Str("uid", uid).Msg("Failed to load personality profile")
+		return ""
+	}
+	summary := mappers.MapDBToDTO(*ocean, *disc)
+	return summary.FormatForLLM()
+}
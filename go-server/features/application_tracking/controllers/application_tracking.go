@@ -1,16 +1,20 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/database/tracking_requests"
 	request "github.com/ordo_meritum/features/application_tracking/models/requests"
 	"github.com/ordo_meritum/features/application_tracking/services"
 	"github.com/ordo_meritum/shared/contexts"
 	"github.com/ordo_meritum/shared/middleware"
+	error_response "github.com/ordo_meritum/shared/types/errors"
 	"github.com/ordo_meritum/shared/webrender"
+	"github.com/ordo_meritum/workers"
 )
 
 type Controller struct {
@@ -24,6 +28,8 @@ func NewController(service *services.AppTrackerService) *Controller {
 func (c *Controller) RegisterRoutes(secureRouter *mux.Router, authRouter *mux.Router) {
 	secureRouter.HandleFunc("/apps/track", c.HandleTrackApplication).Methods("POST")
 	authRouter.HandleFunc("/apps/track/list", c.HandleListApplications).Methods("GET")
+	authRouter.HandleFunc("/apps/track/skills", c.HandleSkillDemand).Methods("GET")
+	authRouter.HandleFunc("/apps/track/requests/{id:[0-9]+}", c.HandleGetTrackingRequest).Methods("GET")
 	authRouter.HandleFunc("/track/{id:[0-9]+}", c.HandleGetTrackedApplication).Methods("GET")
 	authRouter.HandleFunc("/track/{id:[0-9]+}/status", c.HandleUpdateStatus).Methods("PUT")
 }
@@ -46,13 +52,51 @@ func (c *Controller) HandleTrackApplication(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	jobID, err := c.service.QueueApplicationTracking(r.Context(), requestBody)
-	if err != nil {
+	tracking, err := c.service.QueueApplicationTracking(r.Context(), requestBody)
+	switch {
+	case errors.Is(err, workers.ErrUserQueueFull):
+		middleware.JSON(w, http.StatusTooManyRequests, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.BAD_REQUEST,
+			Message:   "You already have too many job postings waiting to be tracked.",
+		})
+		return
+	case errors.Is(err, workers.ErrQueueFull), errors.Is(err, workers.ErrPoolStopped):
+		middleware.JSON(w, http.StatusServiceUnavailable, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.RESOURCE_UNAVAILABLE,
+			Message:   "The server is busy, try again later.",
+		})
+		return
+	case err != nil:
 		middleware.JSON(w, http.StatusInternalServerError, nil)
 		return
 	}
 
-	middleware.JSON(w, http.StatusCreated, jobID)
+	middleware.JSON(w, http.StatusAccepted, tracking)
+}
+
+func (c *Controller) HandleGetTrackingRequest(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	tracking, err := c.service.GetTrackingRequest(r.Context(), id)
+	if errors.Is(err, tracking_requests.ErrTrackingRequestNotFound) {
+		middleware.JSON(w, http.StatusNotFound, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.RESOURCE_UNAVAILABLE,
+			Message:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, tracking)
 }
 
 func (c *Controller) HandleListApplications(w http.ResponseWriter, r *http.Request) {
@@ -72,6 +116,23 @@ func (c *Controller) HandleListApplications(w http.ResponseWriter, r *http.Reque
 	middleware.JSON(w, http.StatusOK, applications)
 }
 
+func (c *Controller) HandleSkillDemand(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	_, ok := contexts.FromContext(r.Context())
+	if !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	demand, err := c.service.SkillDemand(r.Context())
+	if err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, demand)
+}
+
 func (c *Controller) HandleGetTrackedApplication(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
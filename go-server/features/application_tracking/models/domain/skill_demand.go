@@ -0,0 +1,10 @@
+package domain
+
+// SkillDemand is how many of the user's tracked jobs list a skill, counted
+// by its canonical name.
+type SkillDemand struct {
+	Skill    string `json:"skill"`
+	Category string `json:"category,omitempty"`
+	Parent   string `json:"parent,omitempty"`
+	Jobs     int    `json:"jobs"`
+}
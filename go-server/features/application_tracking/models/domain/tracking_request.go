@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// TrackingStatus is where a submitted job posting is in being parsed.
+type TrackingStatus string
+
+const (
+	TrackingQueued    TrackingStatus = "queued"
+	TrackingParsing   TrackingStatus = "parsing"
+	TrackingSucceeded TrackingStatus = "succeeded"
+	TrackingFailed    TrackingStatus = "failed"
+)
+
+// TrackingRequest is one job posting submitted for tracking. RoleID is set
+// once the posting has been parsed and stored.
+type TrackingRequest struct {
+	ID         int            `json:"id"`
+	Status     TrackingStatus `json:"status"`
+	RoleID     *int           `json:"roleId,omitempty"`
+	ErrorCode  string         `json:"errorCode,omitempty"`
+	Error      string         `json:"error,omitempty"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+	FinishedAt *time.Time     `json:"finishedAt,omitempty"`
+}
+
+// TrackingUpdate moves a request to Status. The other fields are only
+// written when set.
+type TrackingUpdate struct {
+	Status    TrackingStatus
+	RoleID    *int
+	ErrorCode string
+	Error     string
+}
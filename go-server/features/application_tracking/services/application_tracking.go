@@ -5,11 +5,16 @@ import (
 	_ "embed"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"go.uber.org/fx"
 
 	"github.com/ordo_meritum/database/jobs"
 	db_models "github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/database/tracking_requests"
 	"github.com/ordo_meritum/features/application_tracking/models/domain"
 	request "github.com/ordo_meritum/features/application_tracking/models/requests"
 
@@ -17,36 +22,96 @@ import (
 	"github.com/ordo_meritum/shared/embeds"
 	"github.com/ordo_meritum/shared/libs/llm"
 	schemaregistry "github.com/ordo_meritum/shared/libs/llm/schema_registry"
+	"github.com/ordo_meritum/shared/libs/taxonomy"
 	"github.com/ordo_meritum/shared/templates/instructions"
 	prompts "github.com/ordo_meritum/shared/templates/prompts"
 	error_messages "github.com/ordo_meritum/shared/utils/errors"
 	formatters "github.com/ordo_meritum/shared/utils/formatters"
+	"github.com/ordo_meritum/websocket"
+	"github.com/ordo_meritum/workers"
 )
 
 var serviceName = "application-tracking"
 
+// staleTrackingAfter is how long an unfinished request can go untouched
+// before startup treats it as abandoned by a crashed instance.
+const staleTrackingAfter = 30 * time.Minute
+
 type AppTrackerService struct {
-	jobRepo jobs.Repository
+	jobRepo      jobs.Repository
+	trackingRepo tracking_requests.Repository
+	pool         *workers.Pool
+	hub          *websocket.Hub
 }
 
-func NewAppTrackerService(jobRepo jobs.Repository) *AppTrackerService {
+func NewAppTrackerService(
+	jobRepo jobs.Repository,
+	trackingRepo tracking_requests.Repository,
+	pool *workers.Pool,
+	hub *websocket.Hub,
+) *AppTrackerService {
 	return &AppTrackerService{
-		jobRepo: jobRepo,
+		jobRepo:      jobRepo,
+		trackingRepo: trackingRepo,
+		pool:         pool,
+		hub:          hub,
 	}
 }
 
+// TrackingEvent is pushed over the websocket every time a tracking request
+// changes status.
+type TrackingEvent struct {
+	Event   string                  `json:"event"`
+	UserID  string                  `json:"user_id"`
+	Request *domain.TrackingRequest `json:"request"`
+}
+
+// QueueApplicationTracking records the posting and returns; parsing it with
+// the LLM and storing the job happen on the worker pool.
 func (s *AppTrackerService) QueueApplicationTracking(
 	ctx context.Context,
 	requestBody request.JobPostingRequest,
-) (any, error) {
+) (*domain.TrackingRequest, error) {
 	userCtx, ok := contexts.FromContext(ctx)
 	if !ok {
 		return nil, error_messages.ErrorMessage(error_messages.ERR_USER_NO_CONTEXT)
 	}
-	l := log.With().
-		Str("service", serviceName).
-		Str("uid", userCtx.UID).
-		Logger()
+	l := s.serviceLogger(userCtx.UID)
+
+	tracking, err := s.trackingRepo.Create(ctx)
+	if err != nil {
+		error_messages.ErrorLog(error_messages.ERR_DB_FAILED_TO_INSERT, err, l.Error())
+		return nil, err
+	}
+
+	err = s.pool.Submit(ctx, userCtx.UID, func(ctx context.Context) {
+		s.trackApplication(ctx, tracking.ID, requestBody)
+	})
+	if err != nil {
+		s.failTracking(ctx, tracking.ID, error_messages.ERR_QUEUE_FULL, err)
+		return nil, err
+	}
+
+	l.Info().Int("trackingID", tracking.ID).Msg("Queued application tracking")
+	return tracking, nil
+}
+
+func (s *AppTrackerService) trackApplication(
+	ctx context.Context,
+	trackingID int,
+	requestBody request.JobPostingRequest,
+) {
+	userCtx, _ := contexts.FromContext(ctx)
+	l := s.serviceLogger(userCtx.UID).With().Int("trackingID", trackingID).Logger()
+
+	if ctx.Err() != nil {
+		s.failTracking(ctx, trackingID, error_messages.ERR_WORKER_INTERRUPTED, ctx.Err())
+		return
+	}
+	if _, err := s.updateTracking(ctx, trackingID, domain.TrackingUpdate{Status: domain.TrackingParsing}); err != nil {
+		l.Error().Err(err).Msg("Failed to start application tracking")
+		return
+	}
 
 	l.Info().Msg("Starting application tracking process")
 
@@ -57,7 +122,8 @@ func (s *AppTrackerService) QueueApplicationTracking(
 
 	if err != nil {
 		error_messages.ErrorLog(error_messages.ERR_LLM_NO_CONTENT, err, l.Error())
-		return nil, err
+		s.failTracking(ctx, trackingID, error_messages.ERR_LLM_NO_CONTENT, err)
+		return
 	}
 
 	l.Info().Msg("Persisting full job posting to database...")
@@ -65,11 +131,86 @@ func (s *AppTrackerService) QueueApplicationTracking(
 	res, err := s.jobRepo.InsertFullJobPosting(ctx, requestBody.JobDescription, parsedJob, cn, parsedJob.CompanyName)
 	if err != nil {
 		error_messages.ErrorLog(error_messages.ERR_DB_FAILED_TO_INSERT, err, l.Error())
-		return nil, err
+		s.failTracking(ctx, trackingID, error_messages.ERR_DB_FAILED_TO_INSERT, err)
+		return
 	}
 
+	_, err = s.updateTracking(ctx, trackingID, domain.TrackingUpdate{
+		Status: domain.TrackingSucceeded,
+		RoleID: &res.ID,
+	})
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to record tracked job")
+		return
+	}
 	l.Info().Msg("Successfully tracked new job.")
-	return res.ID, nil
+}
+
+func (s *AppTrackerService) GetTrackingRequest(ctx context.Context, id int) (*domain.TrackingRequest, error) {
+	return s.trackingRepo.Get(ctx, id)
+}
+
+// RegisterTrackingRecovery fails, at startup, the requests a crashed instance
+// left unfinished. Like document generations, they only lived in that
+// instance's memory.
+func RegisterTrackingRecovery(lc fx.Lifecycle, s *AppTrackerService) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			n, err := s.trackingRepo.FailStale(
+				ctx,
+				time.Now().Add(-staleTrackingAfter),
+				error_messages.ERR_WORKER_INTERRUPTED,
+				error_messages.ErrorMessage(error_messages.ERR_WORKER_INTERRUPTED).Error(),
+			)
+			l := s.serviceLogger("")
+			if err != nil {
+				l.Error().Err(err).Msg("Failed to clean up stale tracking requests")
+				return nil
+			}
+			if n > 0 {
+				l.Warn().Int64("count", n).Msg("Failed tracking requests left behind by a previous run")
+			}
+			return nil
+		},
+	})
+}
+
+func (s *AppTrackerService) failTracking(ctx context.Context, id int, code string, cause error) {
+	update := domain.TrackingUpdate{Status: domain.TrackingFailed, ErrorCode: code}
+	if cause != nil {
+		update.Error = cause.Error()
+	}
+	if _, err := s.updateTracking(ctx, id, update); err != nil {
+		log.Error().Err(err).Str("service", serviceName).Int("trackingID", id).Msg("Failed to record tracking failure")
+	}
+}
+
+// updateTracking writes update and tells the user's open connections.
+func (s *AppTrackerService) updateTracking(
+	ctx context.Context,
+	id int,
+	update domain.TrackingUpdate,
+) (*domain.TrackingRequest, error) {
+	t, err := s.trackingRepo.Update(context.WithoutCancel(ctx), id, update)
+	if err != nil {
+		return nil, err
+	}
+	if userCtx, ok := contexts.FromContext(ctx); ok {
+		msg, err := json.Marshal(TrackingEvent{Event: "application_tracking", UserID: userCtx.UID, Request: t})
+		if err != nil {
+			log.Error().Err(err).Str("service", serviceName).Msg("Failed to marshal tracking event")
+		} else {
+			s.hub.SendToUser(userCtx.UID, msg)
+		}
+	}
+	return t, nil
+}
+
+func (s *AppTrackerService) serviceLogger(uid string) zerolog.Logger {
+	return log.With().
+		Str("service", serviceName).
+		Str("uid", uid).
+		Logger()
 }
 
 func (s *AppTrackerService) GetTrackedApplicationByID(
@@ -93,6 +234,59 @@ func (s *AppTrackerService) ListTrackedApplications(
 	return s.jobRepo.GetAllUserJobPostings(ctx)
 }
 
+// SkillDemand counts, for every skill listed by the user's tracked jobs, how
+// many of those jobs ask for it, most requested first. Skills are counted
+// under their canonical name, so "JS" and "JavaScript" are one skill, and
+// jobs stored before normalization are counted the same way.
+func (s *AppTrackerService) SkillDemand(ctx context.Context) ([]domain.SkillDemand, error) {
+	jobSkills, err := s.jobRepo.GetAllUserJobSkills(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tax := taxonomy.Default()
+	counts := map[string]*domain.SkillDemand{}
+	for _, j := range jobSkills {
+		var listed []string
+		for _, list := range [][]string{
+			j.ProgrammingLanguages,
+			j.FrameworksAndLibraries,
+			j.Databases,
+			j.CloudTechnologies,
+			j.Tools,
+			j.Certifications,
+		} {
+			listed = append(listed, list...)
+		}
+
+		for _, name := range tax.NormalizeList(listed) {
+			key := taxonomy.Key(name)
+			d, ok := counts[key]
+			if !ok {
+				d = &domain.SkillDemand{Skill: name}
+				if skill, known := tax.Lookup(name); known {
+					d.Category = skill.Category
+					d.Parent = skill.Parent
+				}
+				counts[key] = d
+			}
+			d.Jobs++
+		}
+	}
+
+	demand := make([]domain.SkillDemand, 0, len(counts))
+	for _, d := range counts {
+		demand = append(demand, *d)
+	}
+	sort.Slice(demand, func(i, k int) bool {
+		if demand[i].Jobs != demand[k].Jobs {
+			return demand[i].Jobs > demand[k].Jobs
+		}
+		return demand[i].Skill < demand[k].Skill
+	})
+	return demand, nil
+}
+
 func (s *AppTrackerService) parseJobDescriptionWithLLM(
 	ctx context.Context,
 	r *request.JobPostingRequest,
@@ -19,12 +19,15 @@ func NewController(service *services.CandidateFormsService) *Controller {
 	return &Controller{service: service}
 }
 
-func (c *Controller) RegisterRoutes(router *mux.Router) {
-	router.HandleFunc("/upload-questions", c.PostQuestionnare).Methods("POST")
-	// router.HandleFunc("/create-profile", c.CreateProfile).Methods("POST")
-
-	// router.HandleFunc("/writings", c.UpsertWritingSamples).Methods("POST")
-	// router.HandleFunc("/writings", c.GetWritingSamples).Methods("GET")
+func (c *Controller) RegisterRoutes(secureRouter *mux.Router, authRouter *mux.Router) {
+	secureRouter.HandleFunc("/upload-questions", c.PostQuestionnare).Methods("POST")
+	// secureRouter.HandleFunc("/create-profile", c.CreateProfile).Methods("POST")
+
+	authRouter.HandleFunc("/writings", c.HandleListWritingSamples).Methods("GET")
+	authRouter.HandleFunc("/writings", c.HandleCreateWritingSample).Methods("POST")
+	authRouter.HandleFunc("/writings", c.HandleReplaceWritingSamples).Methods("PUT")
+	authRouter.HandleFunc("/writings/{id:[0-9]+}", c.HandleUpdateWritingSample).Methods("PUT")
+	authRouter.HandleFunc("/writings/{id:[0-9]+}", c.HandleDeleteWritingSample).Methods("DELETE")
 }
 
 // func getUserID(r *http.Request) (string, error) {
@@ -96,42 +99,3 @@ func (c *Controller) PostQuestionnare(w http.ResponseWriter, r *http.Request) {
 
 // 	middleware.JSON(w, http.StatusOK, questionnaire)
 // }
-
-// func (c *Controller) UpsertWritingSamples(w http.ResponseWriter, r *http.Request) {
-// 	firebaseUID, err := getUserID(r)
-// 	if err != nil {
-// 		middleware.JSON(w, http.StatusUnauthorized, map[string]string{"error": err.Error()})
-// 		return
-// 	}
-
-// 	var payload dto.CandidateWritingPayload
-// 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-// 		middleware.JSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
-// 		return
-// 	}
-// 	defer r.Body.Close()
-
-// 	err = c.service.CreateOrUpdateWritingSamples(context.Background(), firebaseUID, &payload)
-// 	if err != nil {
-// 		middleware.JSON(w, http.StatusInternalServerError, map[string]string{"error": "Failed to save writing samples"})
-// 		return
-// 	}
-
-// 	middleware.JSON(w, http.StatusCreated, map[string]string{"message": "Writing samples saved successfully"})
-// }
-
-// func (c *Controller) GetWritingSamples(w http.ResponseWriter, r *http.Request) {
-// 	firebaseUID, err := getUserID(r)
-// 	if err != nil {
-// 		middleware.JSON(w, http.StatusUnauthorized, map[string]string{"error": err.Error()})
-// 		return
-// 	}
-
-// 	samples, err := c.service.GetWritingSamplesByUID(context.Background(), firebaseUID)
-// 	if err != nil {
-// 		middleware.JSON(w, http.StatusInternalServerError, map[string]string{"error": "Failed to retrieve writing samples"})
-// 		return
-// 	}
-
-// 	middleware.JSON(w, http.StatusOK, samples)
-// }
@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/ordo_meritum/database/writingsamples"
+	"github.com/ordo_meritum/features/candidate_forms/models/requests"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/middleware"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	"github.com/ordo_meritum/shared/webrender"
+	"github.com/rs/zerolog/log"
+)
+
+func (c *Controller) HandleListWritingSamples(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	userCtx, ok := contexts.FromContext(r.Context())
+	if !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	samples, err := c.service.ListWritingSamples(r.Context(), userCtx.UID)
+	if err != nil {
+		writeWritingSampleError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, samples)
+}
+
+func (c *Controller) HandleCreateWritingSample(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	userCtx, ok := contexts.FromContext(r.Context())
+	if !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var req requests.WritingSampleRequest
+	if webrender.DecodeJSONBody(w, r, &req) != nil {
+		return
+	}
+	if details := req.Validate(); len(details) > 0 {
+		writeValidationError(w, details)
+		return
+	}
+
+	sample, err := c.service.CreateWritingSample(r.Context(), userCtx.UID, req.Content)
+	if err != nil {
+		writeWritingSampleError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusCreated, sample)
+}
+
+// HandleReplaceWritingSamples swaps the user's samples for the ones sent.
+func (c *Controller) HandleReplaceWritingSamples(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	userCtx, ok := contexts.FromContext(r.Context())
+	if !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var req requests.WritingSamplesRequest
+	if webrender.DecodeJSONBody(w, r, &req) != nil {
+		return
+	}
+	if details := req.Validate(); len(details) > 0 {
+		writeValidationError(w, details)
+		return
+	}
+
+	samples, err := c.service.ReplaceWritingSamples(r.Context(), userCtx.UID, req.Samples)
+	if err != nil {
+		writeWritingSampleError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, samples)
+}
+
+func (c *Controller) HandleUpdateWritingSample(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	userCtx, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var req requests.WritingSampleRequest
+	if webrender.DecodeJSONBody(w, r, &req) != nil {
+		return
+	}
+	if details := req.Validate(); len(details) > 0 {
+		writeValidationError(w, details)
+		return
+	}
+
+	sample, err := c.service.UpdateWritingSample(r.Context(), userCtx.UID, id, req.Content)
+	if err != nil {
+		writeWritingSampleError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, sample)
+}
+
+func (c *Controller) HandleDeleteWritingSample(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	userCtx, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	if err := c.service.DeleteWritingSample(r.Context(), userCtx.UID, id); err != nil {
+		writeWritingSampleError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeValidationError(w http.ResponseWriter, details []error_response.ValidationDetail) {
+	middleware.JSON(w, http.StatusBadRequest, error_response.ErrorResponse[[]error_response.ValidationDetail]{
+		ErrorCode: error_response.BAD_REQUEST,
+		Message:   "The request failed validation.",
+		Details:   details,
+	})
+}
+
+func writeWritingSampleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, writingsamples.ErrWritingSampleNotFound):
+		middleware.JSON(w, http.StatusNotFound, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.RESOURCE_UNAVAILABLE,
+			Message:   err.Error(),
+		})
+	default:
+		log.Error().Err(err).Str("service", "candidate-forms-controller").Msg("Writing sample request failed")
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+	}
+}
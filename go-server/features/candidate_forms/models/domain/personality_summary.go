@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"fmt"
+	"strings"
+)
+
 type PersonalitySummary struct {
 	OCEAN OCEANProfile `json:"ocean,omitempty"`
 	DISC  DISCProfile  `json:"disc,omitempty"`
@@ -91,3 +96,42 @@ type KeyMetricInsight struct {
 	Metric ArchetypeMetrics `json:"metric"`
 	Score  int              `json:"score"`
 }
+
+// FormatForLLM renders the profile as plain text for a prompt. Categories
+// without reasoning are listed by name only.
+func (p *PersonalitySummary) FormatForLLM() string {
+	var builder strings.Builder
+
+	if len(p.OCEAN.Scores) > 0 || p.OCEAN.Summary != "" {
+		builder.WriteString("--- OCEAN (Big Five) ---\n")
+		for _, s := range p.OCEAN.Scores {
+			builder.WriteString(fmt.Sprintf("%s: %d/100", s.Category, s.Score))
+			if s.Reasoning != "" {
+				builder.WriteString(" - " + s.Reasoning)
+			}
+			builder.WriteString("\n")
+		}
+		if p.OCEAN.Summary != "" {
+			builder.WriteString("Summary: " + p.OCEAN.Summary + "\n")
+		}
+	}
+
+	if len(p.DISC.Scores) > 0 || p.DISC.Summary != "" {
+		if builder.Len() > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString("--- DISC ---\n")
+		for _, s := range p.DISC.Scores {
+			builder.WriteString(string(s.Category))
+			if s.Reasoning != "" {
+				builder.WriteString(": " + s.Reasoning)
+			}
+			builder.WriteString("\n")
+		}
+		if p.DISC.Summary != "" {
+			builder.WriteString("Summary: " + p.DISC.Summary + "\n")
+		}
+	}
+
+	return builder.String()
+}
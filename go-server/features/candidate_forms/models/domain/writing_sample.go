@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// WritingSample is a piece of the candidate's own writing, used to match
+// their voice in cover letters.
+type WritingSample struct {
+	ID        int       `json:"id"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,47 @@
+package requests
+
+import (
+	"fmt"
+	"strings"
+
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+const (
+	MaxWritingSamples      = 20
+	MaxWritingSampleLength = 20000
+)
+
+type WritingSampleRequest struct {
+	Content string `json:"content"`
+}
+
+func (r WritingSampleRequest) Validate() []error_response.ValidationDetail {
+	return validateSample("content", r.Content)
+}
+
+// WritingSamplesRequest replaces all of a user's writing samples.
+type WritingSamplesRequest struct {
+	Samples []string `json:"samples"`
+}
+
+func (r WritingSamplesRequest) Validate() []error_response.ValidationDetail {
+	if len(r.Samples) > MaxWritingSamples {
+		return []error_response.ValidationDetail{{Field: "samples", Issue: "at most 20 samples are allowed"}}
+	}
+	var details []error_response.ValidationDetail
+	for i, s := range r.Samples {
+		details = append(details, validateSample(fmt.Sprintf("samples[%d]", i), s)...)
+	}
+	return details
+}
+
+func validateSample(field, content string) []error_response.ValidationDetail {
+	switch {
+	case strings.TrimSpace(content) == "":
+		return []error_response.ValidationDetail{{Field: field, Issue: "must not be empty"}}
+	case len(content) > MaxWritingSampleLength:
+		return []error_response.ValidationDetail{{Field: field, Issue: "must be at most 20000 characters"}}
+	}
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/ordo_meritum/database/candidate_forms"
+	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/database/writingsamples"
 	"github.com/ordo_meritum/features/candidate_forms/models/domain"
 	"github.com/ordo_meritum/features/candidate_forms/models/requests"
 	"github.com/ordo_meritum/shared/mappers"
@@ -14,13 +16,16 @@ import (
 
 type CandidateFormsService struct {
 	candidateFormRepo candidate_forms.Repository
+	writingSampleRepo writingsamples.Repository
 }
 
 func NewCandidateFormService(
 	candidateFormRepo candidate_forms.Repository,
+	writingSampleRepo writingsamples.Repository,
 ) *CandidateFormsService {
 	return &CandidateFormsService{
 		candidateFormRepo: candidateFormRepo,
+		writingSampleRepo: writingSampleRepo,
 	}
 }
 
@@ -82,3 +87,58 @@ func (s *CandidateFormsService) GetPersonalityProfile(ctx context.Context, fireb
 func (s *CandidateFormsService) CreatePersonalityProfile(ctx context.Context, firebaseUID string) error {
 	return nil
 }
+
+func (s *CandidateFormsService) ListWritingSamples(ctx context.Context, firebaseUID string) ([]domain.WritingSample, error) {
+	rows, err := s.writingSampleRepo.GetByFirebaseUID(ctx, firebaseUID)
+	if err != nil {
+		return nil, err
+	}
+	samples := make([]domain.WritingSample, 0, len(rows))
+	for i := range rows {
+		samples = append(samples, toWritingSample(&rows[i]))
+	}
+	return samples, nil
+}
+
+func (s *CandidateFormsService) CreateWritingSample(ctx context.Context, firebaseUID, content string) (*domain.WritingSample, error) {
+	row, err := s.writingSampleRepo.Create(ctx, firebaseUID, content)
+	if err != nil {
+		return nil, err
+	}
+	sample := toWritingSample(row)
+	return &sample, nil
+}
+
+func (s *CandidateFormsService) UpdateWritingSample(ctx context.Context, firebaseUID string, id int, content string) (*domain.WritingSample, error) {
+	row, err := s.writingSampleRepo.Update(ctx, firebaseUID, id, content)
+	if err != nil {
+		return nil, err
+	}
+	sample := toWritingSample(row)
+	return &sample, nil
+}
+
+func (s *CandidateFormsService) DeleteWritingSample(ctx context.Context, firebaseUID string, id int) error {
+	return s.writingSampleRepo.Delete(ctx, firebaseUID, id)
+}
+
+// ReplaceWritingSamples swaps all of the user's writing samples for contents.
+func (s *CandidateFormsService) ReplaceWritingSamples(ctx context.Context, firebaseUID string, contents []string) ([]domain.WritingSample, error) {
+	rows := make([]models.CandidateWritingSample, 0, len(contents))
+	for _, c := range contents {
+		rows = append(rows, models.CandidateWritingSample{FirebaseUID: firebaseUID, Content: c})
+	}
+	if err := s.writingSampleRepo.CreateOrUpdate(ctx, firebaseUID, rows); err != nil {
+		return nil, err
+	}
+	return s.ListWritingSamples(ctx, firebaseUID)
+}
+
+func toWritingSample(row *models.CandidateWritingSample) domain.WritingSample {
+	return domain.WritingSample{
+		ID:        row.ID,
+		Content:   row.Content,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/middleware"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+func (c *Controller) HandleAnalyzeResume(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	roleID, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	revisionID, ok := parseRevisionQuery(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := c.docService.AnalyzeResume(r.Context(), roleID, revisionID)
+	if err != nil {
+		writeRevisionError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, report)
+}
+
+// parseRevisionQuery reads the optional revisionId query parameter. It writes
+// a validation error and reports false when the value isn't an ID.
+func parseRevisionQuery(w http.ResponseWriter, r *http.Request) (*int, bool) {
+	raw := r.URL.Query().Get("revisionId")
+	if raw == "" {
+		return nil, true
+	}
+	id, err := strconv.Atoi(raw)
+	if err != nil {
+		middleware.JSON(w, http.StatusBadRequest, error_response.ErrorResponse[[]error_response.ValidationDetail]{
+			ErrorCode: error_response.BAD_REQUEST,
+			Message:   "The request failed validation.",
+			Details:   []error_response.ValidationDetail{{Field: "revisionId", Issue: "must be a revision id"}},
+		})
+		return nil, false
+	}
+	return &id, true
+}
@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ordo_meritum/database/generations"
+	"github.com/ordo_meritum/features/documents/models/requests"
+	"github.com/ordo_meritum/features/documents/services"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/middleware"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	"github.com/ordo_meritum/shared/webrender"
+	"github.com/rs/zerolog/log"
+)
+
+func (c *Controller) HandleQueueBatch(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	_, ok := contexts.FromContext(r.Context())
+	if !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var requestBody requests.BatchRequest
+	if webrender.DecodeJSONBody(w, r, &requestBody) != nil {
+		return
+	}
+	if details := requestBody.Options.Validate(); len(details) > 0 {
+		middleware.JSON(w, http.StatusBadRequest, error_response.ErrorResponse[[]error_response.ValidationDetail]{
+			ErrorCode: error_response.BAD_REQUEST,
+			Message:   "Invalid batch request.",
+			Details:   details,
+		})
+		return
+	}
+
+	batch, err := c.docService.QueueBatch(r.Context(), requestBody)
+	if writeQueueError(w, err) {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to queue generation batch")
+		middleware.JSON(w, http.StatusInternalServerError, map[string]string{"error": "Failed to queue generation batch"})
+		return
+	}
+	middleware.JSON(w, http.StatusAccepted, batch)
+}
+
+func (c *Controller) HandleGetBatch(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	batch, err := c.docService.GetBatch(r.Context(), id)
+	if err != nil {
+		writeBatchError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, batch)
+}
+
+// HandleDownloadBatch sends the batch's compiled PDFs as one ZIP. The
+// archive is built in memory first so a missing file is still reported as
+// an error rather than a truncated download.
+func (c *Controller) HandleDownloadBatch(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var archive bytes.Buffer
+	if err := c.docService.WriteBatchArchive(r.Context(), id, &archive); err != nil {
+		writeBatchError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="batch-%d.zip"`, id))
+	w.WriteHeader(http.StatusOK)
+	if _, err := archive.WriteTo(w); err != nil {
+		log.Error().Err(err).Int("batchID", id).Msg("Failed to send batch archive")
+	}
+}
+
+func writeBatchError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, generations.ErrBatchNotFound):
+		middleware.JSON(w, http.StatusNotFound, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.RESOURCE_UNAVAILABLE,
+			Message:   err.Error(),
+		})
+	case errors.Is(err, services.ErrBatchNotDone), errors.Is(err, services.ErrBatchEmpty):
+		middleware.JSON(w, http.StatusConflict, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.BAD_REQUEST,
+			Message:   err.Error(),
+		})
+	default:
+		log.Error().Err(err).Str("service", "documents-controller").Msg("Batch request failed")
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+	}
+}
@@ -6,12 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
+	"github.com/ordo_meritum/database/profiles"
 	"github.com/ordo_meritum/features/documents/models/requests"
 	"github.com/ordo_meritum/features/documents/services"
 	"github.com/ordo_meritum/shared/contexts"
 	"github.com/ordo_meritum/shared/middleware"
+	error_response "github.com/ordo_meritum/shared/types/errors"
 	"github.com/rs/zerolog/log"
 )
 
@@ -23,16 +26,45 @@ func NewDocumentController(docService *services.DocumentService) *Controller {
 	return &Controller{docService: docService}
 }
 
-func (c *Controller) RegisterRoutes(router *mux.Router) {
-	router.HandleFunc("/documents/resume", c.generateDocumentHandler(c.docService.QueueResumeGeneration)).Methods("POST")
-	router.HandleFunc("/documents/cover-letter", c.generateDocumentHandler(c.docService.QueueCoverLetterGeneration)).Methods("POST")
+func (c *Controller) RegisterRoutes(secureRouter *mux.Router, authRouter *mux.Router) {
+	secureRouter.HandleFunc("/documents/resume", c.generateDocumentHandler(c.docService.QueueResumeGeneration)).Methods("POST")
+	secureRouter.HandleFunc("/documents/cover-letter", c.generateDocumentHandler(c.docService.QueueCoverLetterGeneration)).Methods("POST")
+	secureRouter.HandleFunc("/documents/cv", c.generateDocumentHandler(c.docService.QueueCVGeneration)).Methods("POST")
+	secureRouter.HandleFunc("/documents/batch", c.HandleQueueBatch).Methods("POST")
+	authRouter.HandleFunc("/documents/batches/{id:[0-9]+}", c.HandleGetBatch).Methods("GET")
+	authRouter.HandleFunc("/documents/batches/{id:[0-9]+}/archive", c.HandleDownloadBatch).Methods("GET")
+	authRouter.HandleFunc("/documents/generations", c.HandleListGenerations).Methods("GET")
+	authRouter.HandleFunc("/documents/generations/{id:[0-9]+}", c.HandleGetGeneration).Methods("GET")
+	authRouter.HandleFunc("/documents/generations/{id:[0-9]+}/cancel", c.HandleCancelGeneration).Methods("POST")
+	authRouter.HandleFunc("/documents/library", c.HandleListLibrary).Methods("GET")
+	authRouter.HandleFunc("/documents/library/{id:[0-9]+}", c.HandleGetLibraryDocument).Methods("GET")
+	authRouter.HandleFunc("/documents/library/{id:[0-9]+}", c.HandleDeleteLibraryDocument).Methods("DELETE")
+	authRouter.HandleFunc("/documents/library/{id:[0-9]+}/{artifact:pdf|changes|source}", c.HandleDownloadArtifact).Methods("GET")
+	authRouter.HandleFunc("/documents/style-profile", c.HandleGetStyleProfile).Methods("GET")
+	authRouter.HandleFunc("/documents/{id:[0-9]+}/json-resume", c.HandleExportJSONResume).Methods("GET")
+	authRouter.HandleFunc("/documents/{id:[0-9]+}/resume", c.HandleSaveResumeEdit).Methods("PUT")
+	authRouter.HandleFunc("/documents/{id:[0-9]+}/revisions", c.HandleListRevisions).Methods("GET")
+	authRouter.HandleFunc("/documents/{id:[0-9]+}/revisions/diff", c.HandleDiffRevisions).Methods("GET")
+	authRouter.HandleFunc("/documents/{id:[0-9]+}/revisions/{revisionId:[0-9]+}", c.HandleGetRevision).Methods("GET")
+	authRouter.HandleFunc("/documents/{id:[0-9]+}/revisions/{revisionId:[0-9]+}/restore", c.HandleRestoreRevision).Methods("POST")
+	authRouter.HandleFunc("/documents/{id:[0-9]+}/ats", c.HandleAnalyzeResume).Methods("GET")
+	authRouter.HandleFunc("/documents/{id:[0-9]+}/layout", c.HandleEstimateLayout).Methods("GET")
+	authRouter.HandleFunc("/documents/{id:[0-9]+}/parsability", c.HandleCheckParsability).Methods("POST")
+	authRouter.HandleFunc("/documents/{id:[0-9]+}/review", c.HandleGetReview).Methods("GET")
+	authRouter.HandleFunc("/documents/{id:[0-9]+}/review/actions", c.HandleReviewActions).Methods("POST")
+	authRouter.HandleFunc("/documents/{id:[0-9]+}/review/finalize", c.HandleFinalizeReview).Methods("POST")
+}
+
+func parseIDFromVars(r *http.Request) (int, error) {
+	idStr := mux.Vars(r)["id"]
+	return strconv.Atoi(idStr)
 }
 
 func (c *Controller) generateDocumentHandler(
 	generationFunc func(
 		ctx context.Context,
 		requestBody requests.DocumentRequest,
-	) (int, error),
+	) (*services.QueueResult, error),
 ) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		defer r.Body.Close()
@@ -48,18 +80,26 @@ func (c *Controller) generateDocumentHandler(
 			handleDecodeError(w, err)
 			return
 		}
+		if details := requestBody.Options.Validate(); len(details) > 0 {
+			middleware.JSON(w, http.StatusBadRequest, error_response.ErrorResponse[[]error_response.ValidationDetail]{
+				ErrorCode: error_response.BAD_REQUEST,
+				Message:   "Invalid document options.",
+				Details:   details,
+			})
+			return
+		}
 
-		jobID, err := generationFunc(r.Context(), requestBody)
+		result, err := generationFunc(r.Context(), requestBody)
+		if writeQueueError(w, err) {
+			return
+		}
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to queue document for generation")
 			middleware.JSON(w, http.StatusInternalServerError, map[string]string{"error": "Failed to queue document for generation"})
 			return
 		}
 
-		middleware.JSON(w, http.StatusAccepted, map[string]any{
-			"jobId":  jobID,
-			"status": "processing_queued",
-		})
+		middleware.JSON(w, http.StatusAccepted, result)
 	}
 }
 
@@ -91,7 +131,7 @@ func (c *Controller) GenerateResume(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	jobID, err := c.docService.QueueResumeGeneration(
+	result, err := c.docService.QueueResumeGeneration(
 		r.Context(),
 		requestBody,
 	)
@@ -101,10 +141,7 @@ func (c *Controller) GenerateResume(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	middleware.JSON(w, http.StatusAccepted, map[string]any{
-		"jobId":  jobID,
-		"status": "processing_queued",
-	})
+	middleware.JSON(w, http.StatusAccepted, result)
 }
 
 func (c *Controller) GenerateCoverLetter(w http.ResponseWriter, r *http.Request) {
@@ -126,7 +163,7 @@ func (c *Controller) GenerateCoverLetter(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	jobID, err := c.docService.QueueCoverLetterGeneration(
+	result, err := c.docService.QueueCoverLetterGeneration(
 		r.Context(),
 		requestBody,
 	)
@@ -136,10 +173,34 @@ func (c *Controller) GenerateCoverLetter(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	middleware.JSON(w, http.StatusAccepted, map[string]any{
-		"jobId":  jobID,
-		"status": "processing_queued",
-	})
+	middleware.JSON(w, http.StatusAccepted, result)
+}
+
+func (c *Controller) HandleExportJSONResume(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	roleID, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	resume, err := c.docService.ExportJSONResume(r.Context(), roleID)
+	if errors.Is(err, profiles.ErrProfileNotFound) {
+		middleware.JSON(w, http.StatusNotFound, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.RESOURCE_UNAVAILABLE,
+			Message:   "Create a profile before exporting a resume.",
+		})
+		return
+	}
+	if err != nil {
+		middleware.JSON(w, http.StatusNotFound, nil)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="resume.json"`)
+	middleware.JSON(w, http.StatusOK, resume)
 }
 
 func decodeDocumentRequest(r *http.Request) (requests.DocumentRequest, error) {
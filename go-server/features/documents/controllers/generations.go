@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ordo_meritum/database/generations"
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/middleware"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	"github.com/ordo_meritum/workers"
+	"github.com/rs/zerolog/log"
+)
+
+// maxGenerationLimit caps how many generations one listing returns.
+const maxGenerationLimit = 200
+
+var generationStatuses = map[domain.GenerationStatus]bool{
+	domain.GenerationQueued:        true,
+	domain.GenerationGenerating:    true,
+	domain.GenerationPendingReview: true,
+	domain.GenerationCompiling:     true,
+	domain.GenerationSucceeded:     true,
+	domain.GenerationFailed:        true,
+	domain.GenerationCancelled:     true,
+}
+
+func (c *Controller) HandleListGenerations(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	_, ok := contexts.FromContext(r.Context())
+	if !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	filter, details := parseGenerationFilter(r)
+	if len(details) > 0 {
+		middleware.JSON(w, http.StatusBadRequest, error_response.ErrorResponse[[]error_response.ValidationDetail]{
+			ErrorCode: error_response.BAD_REQUEST,
+			Message:   "Invalid generation filter.",
+			Details:   details,
+		})
+		return
+	}
+
+	list, err := c.docService.ListGenerations(r.Context(), filter)
+	if err != nil {
+		writeGenerationError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, list)
+}
+
+func (c *Controller) HandleGetGeneration(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	generation, err := c.docService.GetGeneration(r.Context(), id)
+	if err != nil {
+		writeGenerationError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, generation)
+}
+
+func (c *Controller) HandleCancelGeneration(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	generation, err := c.docService.CancelGeneration(r.Context(), id)
+	if err != nil {
+		writeGenerationError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, generation)
+}
+
+func parseGenerationFilter(r *http.Request) (domain.GenerationFilter, []error_response.ValidationDetail) {
+	var (
+		filter  domain.GenerationFilter
+		details []error_response.ValidationDetail
+	)
+	q := r.URL.Query()
+
+	if v := q.Get("roleId"); v != "" {
+		roleID, err := strconv.Atoi(v)
+		if err != nil {
+			details = append(details, error_response.ValidationDetail{Field: "roleId", Issue: "must be a role id"})
+		} else {
+			filter.RoleID = &roleID
+		}
+	}
+	filter.DocType = q.Get("docType")
+	if v := q.Get("status"); v != "" {
+		status := domain.GenerationStatus(v)
+		if !generationStatuses[status] {
+			details = append(details, error_response.ValidationDetail{Field: "status", Issue: "unknown generation status"})
+		} else {
+			filter.Status = status
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxGenerationLimit {
+			details = append(details, error_response.ValidationDetail{Field: "limit", Issue: "must be between 1 and 200"})
+		} else {
+			filter.Limit = limit
+		}
+	}
+	return filter, details
+}
+
+// writeQueueError answers a request the worker pool had no room for. It
+// reports false for any other error.
+func writeQueueError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, workers.ErrUserQueueFull):
+		middleware.JSON(w, http.StatusTooManyRequests, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.BAD_REQUEST,
+			Message:   "You already have too many documents waiting to be generated.",
+		})
+	case errors.Is(err, workers.ErrQueueFull), errors.Is(err, workers.ErrPoolStopped):
+		middleware.JSON(w, http.StatusServiceUnavailable, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.RESOURCE_UNAVAILABLE,
+			Message:   "The server is busy, try again later.",
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+func writeGenerationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, generations.ErrGenerationNotFound):
+		middleware.JSON(w, http.StatusNotFound, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.RESOURCE_UNAVAILABLE,
+			Message:   err.Error(),
+		})
+	case errors.Is(err, generations.ErrGenerationFinished):
+		middleware.JSON(w, http.StatusConflict, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.BAD_REQUEST,
+			Message:   err.Error(),
+		})
+	default:
+		log.Error().Err(err).Str("service", "documents-controller").Msg("Generation request failed")
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+	}
+}
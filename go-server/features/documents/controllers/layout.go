@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/middleware"
+)
+
+func (c *Controller) HandleEstimateLayout(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	roleID, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	revisionID, ok := parseRevisionQuery(w, r)
+	if !ok {
+		return
+	}
+
+	estimate, err := c.docService.EstimateLayout(r.Context(), roleID, revisionID, r.URL.Query().Get("template"))
+	if err != nil {
+		writeRevisionError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, estimate)
+}
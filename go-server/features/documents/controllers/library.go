@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/ordo_meritum/database/library"
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/services"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/middleware"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	"github.com/rs/zerolog/log"
+)
+
+var artifactContentTypes = map[domain.ArtifactKind]string{
+	domain.ArtifactPDF:     "application/pdf",
+	domain.ArtifactChanges: "application/json",
+	domain.ArtifactSource:  "application/x-tex",
+}
+
+func (c *Controller) HandleListLibrary(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	_, ok := contexts.FromContext(r.Context())
+	if !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	filter, details := parseLibraryFilter(r)
+	if len(details) > 0 {
+		middleware.JSON(w, http.StatusBadRequest, error_response.ErrorResponse[[]error_response.ValidationDetail]{
+			ErrorCode: error_response.BAD_REQUEST,
+			Message:   "Invalid library filter.",
+			Details:   details,
+		})
+		return
+	}
+
+	list, err := c.docService.ListLibrary(r.Context(), filter)
+	if err != nil {
+		writeLibraryError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, list)
+}
+
+func (c *Controller) HandleGetLibraryDocument(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	doc, err := c.docService.GetLibraryDocument(r.Context(), id)
+	if err != nil {
+		writeLibraryError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, doc)
+}
+
+// HandleDownloadArtifact streams one of a library document's files.
+func (c *Controller) HandleDownloadArtifact(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+	kind := domain.ArtifactKind(mux.Vars(r)["artifact"])
+
+	f, artifact, err := c.docService.OpenArtifact(r.Context(), id, kind)
+	if err != nil {
+		writeLibraryError(w, err)
+		return
+	}
+	defer f.Close()
+
+	w.Header().Set("Content-Type", artifactContentTypes[kind])
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, artifact.Name))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, f); err != nil {
+		log.Error().Err(err).Int("documentID", id).Str("artifact", string(kind)).Msg("Failed to send artifact")
+	}
+}
+
+func (c *Controller) HandleDeleteLibraryDocument(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	if _, err := c.docService.DeleteLibraryDocument(r.Context(), id); err != nil {
+		writeLibraryError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseLibraryFilter(r *http.Request) (domain.LibraryFilter, []error_response.ValidationDetail) {
+	var (
+		filter  domain.LibraryFilter
+		details []error_response.ValidationDetail
+	)
+	q := r.URL.Query()
+
+	if v := q.Get("roleId"); v != "" {
+		roleID, err := strconv.Atoi(v)
+		if err != nil {
+			details = append(details, error_response.ValidationDetail{Field: "roleId", Issue: "must be a role id"})
+		} else {
+			filter.RoleID = &roleID
+		}
+	}
+	filter.DocType = q.Get("docType")
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxGenerationLimit {
+			details = append(details, error_response.ValidationDetail{Field: "limit", Issue: "must be between 1 and 200"})
+		} else {
+			filter.Limit = limit
+		}
+	}
+	return filter, details
+}
+
+func writeLibraryError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, library.ErrDocumentNotFound), errors.Is(err, services.ErrArtifactNotFound):
+		middleware.JSON(w, http.StatusNotFound, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.RESOURCE_UNAVAILABLE,
+			Message:   err.Error(),
+		})
+	default:
+		log.Error().Err(err).Str("service", "documents-controller").Msg("Library request failed")
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+	}
+}
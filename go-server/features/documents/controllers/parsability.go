@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/ordo_meritum/features/documents/utils/parsability"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/middleware"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+// maxPDFBytes caps uploaded compiled resumes.
+const maxPDFBytes = 10 << 20
+
+func (c *Controller) HandleCheckParsability(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	roleID, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	pdf, err := io.ReadAll(io.LimitReader(r.Body, maxPDFBytes))
+	if err != nil {
+		middleware.JSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+
+	report, err := c.docService.CheckParsability(r.Context(), roleID, pdf)
+	if errors.Is(err, parsability.ErrUnreadablePDF) {
+		middleware.JSON(w, http.StatusBadRequest, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.BAD_REQUEST,
+			Message:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		writeRevisionError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ordo_meritum/database/generations"
+	"github.com/ordo_meritum/database/resumes"
+	"github.com/ordo_meritum/features/documents/models/requests"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/middleware"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	"github.com/ordo_meritum/shared/webrender"
+	"github.com/rs/zerolog/log"
+)
+
+func (c *Controller) HandleGetReview(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	roleID, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	review, err := c.docService.GetReview(r.Context(), roleID)
+	if err != nil {
+		writeReviewError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, review)
+}
+
+func (c *Controller) HandleReviewActions(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	roleID, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var body requests.ReviewRequest
+	if webrender.DecodeJSONBody(w, r, &body) != nil {
+		return
+	}
+
+	review, details, err := c.docService.ApplyReviewActions(r.Context(), roleID, body.Actions)
+	if err != nil {
+		writeReviewError(w, err)
+		return
+	}
+	if len(details) > 0 {
+		middleware.JSON(w, http.StatusBadRequest, error_response.ErrorResponse[[]error_response.ValidationDetail]{
+			ErrorCode: error_response.BAD_REQUEST,
+			Message:   "One or more review actions are invalid.",
+			Details:   details,
+		})
+		return
+	}
+	middleware.JSON(w, http.StatusOK, review)
+}
+
+func (c *Controller) HandleFinalizeReview(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	roleID, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var body requests.FinalizeReviewRequest
+	if r.ContentLength != 0 && webrender.DecodeJSONBody(w, r, &body) != nil {
+		return
+	}
+
+	result, err := c.docService.FinalizeReview(r.Context(), roleID, body.AcceptPending)
+	if err != nil {
+		writeReviewError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusAccepted, result)
+}
+
+func writeReviewError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, resumes.ErrReviewNotFound):
+		middleware.JSON(w, http.StatusNotFound, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.RESOURCE_UNAVAILABLE,
+			Message:   err.Error(),
+		})
+	case errors.Is(err, resumes.ErrReviewFinalized),
+		errors.Is(err, resumes.ErrReviewIncomplete),
+		errors.Is(err, generations.ErrGenerationFinished):
+		middleware.JSON(w, http.StatusConflict, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.BAD_REQUEST,
+			Message:   err.Error(),
+		})
+	default:
+		log.Error().Err(err).Str("service", "documents-controller").Msg("Resume review request failed")
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+	}
+}
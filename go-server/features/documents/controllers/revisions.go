@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/ordo_meritum/database/resumes"
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/middleware"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	"github.com/ordo_meritum/shared/webrender"
+	"github.com/rs/zerolog/log"
+)
+
+func parseRevisionIDFromVars(r *http.Request) (int, error) {
+	return strconv.Atoi(mux.Vars(r)["revisionId"])
+}
+
+func (c *Controller) HandleListRevisions(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	roleID, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	revisions, err := c.docService.ListRevisions(r.Context(), roleID)
+	if err != nil {
+		writeRevisionError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, revisions)
+}
+
+func (c *Controller) HandleGetRevision(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	roleID, err := parseIDFromVars(r)
+	revisionID, revErr := parseRevisionIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil || revErr != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	revision, err := c.docService.GetRevision(r.Context(), roleID, revisionID)
+	if err != nil {
+		writeRevisionError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, revision)
+}
+
+func (c *Controller) HandleDiffRevisions(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	roleID, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	fromID, fromErr := strconv.Atoi(r.URL.Query().Get("from"))
+	toID, toErr := strconv.Atoi(r.URL.Query().Get("to"))
+	if fromErr != nil || toErr != nil {
+		middleware.JSON(w, http.StatusBadRequest, error_response.ErrorResponse[[]error_response.ValidationDetail]{
+			ErrorCode: error_response.BAD_REQUEST,
+			Message:   "Both revisions to compare are required.",
+			Details: []error_response.ValidationDetail{
+				{Field: "from", Issue: "must be a revision id"},
+				{Field: "to", Issue: "must be a revision id"},
+			},
+		})
+		return
+	}
+
+	result, err := c.docService.DiffRevisions(r.Context(), roleID, fromID, toID)
+	if err != nil {
+		writeRevisionError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, result)
+}
+
+func (c *Controller) HandleRestoreRevision(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	roleID, err := parseIDFromVars(r)
+	revisionID, revErr := parseRevisionIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil || revErr != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	revision, err := c.docService.RestoreRevision(r.Context(), roleID, revisionID)
+	if err != nil {
+		writeRevisionError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusCreated, revision)
+}
+
+func (c *Controller) HandleSaveResumeEdit(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	roleID, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var resume domain.Resume
+	if webrender.DecodeJSONBody(w, r, &resume) != nil {
+		return
+	}
+
+	revision, err := c.docService.SaveResumeEdit(r.Context(), roleID, &resume)
+	if err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, map[string]string{"error": "Failed to save resume"})
+		return
+	}
+	middleware.JSON(w, http.StatusCreated, revision)
+}
+
+func writeRevisionError(w http.ResponseWriter, err error) {
+	if errors.Is(err, resumes.ErrRevisionNotFound) || errors.Is(err, resumes.ErrResumeNotFound) {
+		middleware.JSON(w, http.StatusNotFound, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.RESOURCE_UNAVAILABLE,
+			Message:   err.Error(),
+		})
+		return
+	}
+	log.Error().Err(err).Msg("Failed to load resume or revision")
+	middleware.JSON(w, http.StatusInternalServerError, nil)
+}
@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ordo_meritum/features/documents/services"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/middleware"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// HandleGetStyleProfile returns the fingerprint of the user's writing
+// samples that cover letters are matched against.
+func (c *Controller) HandleGetStyleProfile(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	_, ok := contexts.FromContext(r.Context())
+	if !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	profile, err := c.docService.StyleProfile(r.Context())
+	if errors.Is(err, services.ErrNoWritingSamples) {
+		middleware.JSON(w, http.StatusNotFound, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.RESOURCE_UNAVAILABLE,
+			Message:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("service", "documents-controller").Msg("Failed to build writing style profile")
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, profile)
+}
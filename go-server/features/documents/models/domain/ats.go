@@ -0,0 +1,37 @@
+package domain
+
+// ATSReport is a keyword coverage analysis of a resume against a job
+// posting, in the spirit of an applicant tracking system's first pass.
+// Scores are percentages.
+type ATSReport struct {
+	Score      int               `json:"score"`
+	Matched    int               `json:"matched"`
+	Total      int               `json:"total"`
+	Categories []KeywordCoverage `json:"categories"`
+}
+
+// KeywordCoverage is the coverage of one category of posting keywords, such
+// as programming languages or databases.
+type KeywordCoverage struct {
+	Category string         `json:"category"`
+	Score    int            `json:"score"`
+	Matched  []KeywordMatch `json:"matched"`
+	Missing  []string       `json:"missing"`
+}
+
+// KeywordMatch is a posting keyword found on the resume and everywhere it
+// was found.
+type KeywordMatch struct {
+	Keyword   string            `json:"keyword"`
+	Locations []KeywordLocation `json:"locations"`
+}
+
+// KeywordLocation points at a resume line. Key names the experience,
+// project or skill category; Index is the bullet, sentence or skill within
+// it.
+type KeywordLocation struct {
+	Section string `json:"section"`
+	Key     string `json:"key,omitempty"`
+	Index   int    `json:"index"`
+	Text    string `json:"text"`
+}
@@ -4,6 +4,9 @@ type CoverLetter struct {
 	CompanyProperName string          `json:"companyProperName"`
 	JobTitle          string          `json:"jobTitle"`
 	Body              CoverLetterBody `json:"body"`
+	// Structure is how the compiler lays the body out: "sections" under
+	// headings or "plain" paragraphs.
+	Structure string `json:"structure,omitempty"`
 }
 
 type CoverLetterBody struct {
@@ -0,0 +1,73 @@
+package domain
+
+// CV is the long-form academic record. Unlike a resume it isn't tailored:
+// every source entry is kept, each section is ordered newest first and the
+// LLM only polishes the wording of descriptions.
+type CV struct {
+	ResearchInterests string         `json:"researchInterests,omitempty"`
+	Appointments      []Appointment  `json:"appointments"`
+	Publications      []Publication  `json:"publications"`
+	Presentations     []Presentation `json:"presentations"`
+	Teaching          []Teaching     `json:"teaching"`
+	Committees        []Committee    `json:"committees"`
+	Grants            []Grant        `json:"grants"`
+	Awards            []Award        `json:"awards"`
+}
+
+type Appointment struct {
+	ID           string   `json:"id,omitempty"`
+	Position     string   `json:"position"`
+	Institution  string   `json:"institution"`
+	Dates        string   `json:"dates"`
+	BulletPoints []string `json:"bulletPoints"`
+}
+
+type Presentation struct {
+	ID       string `json:"id,omitempty"`
+	Title    string `json:"title"`
+	Event    string `json:"event"`
+	Location string `json:"location,omitempty"`
+	Date     string `json:"date"`
+	Invited  bool   `json:"invited,omitempty"`
+}
+
+type Teaching struct {
+	ID          string `json:"id,omitempty"`
+	Course      string `json:"course"`
+	Institution string `json:"institution"`
+	Role        string `json:"role"`
+	Dates       string `json:"dates"`
+	Description string `json:"description,omitempty"`
+}
+
+type Committee struct {
+	ID        string `json:"id,omitempty"`
+	Committee string `json:"committee"`
+	Role      string `json:"role"`
+	Dates     string `json:"dates"`
+}
+
+type Grant struct {
+	ID          string `json:"id,omitempty"`
+	Title       string `json:"title"`
+	Funder      string `json:"funder"`
+	Amount      string `json:"amount,omitempty"`
+	Role        string `json:"role,omitempty"`
+	Dates       string `json:"dates"`
+	Description string `json:"description,omitempty"`
+}
+
+// CVPolish is the LLM's answer for a CV: the rewritten research interests
+// and the rewritten text of each entry, keyed by the id it was sent with.
+type CVPolish struct {
+	ResearchInterests string          `json:"researchInterests"`
+	Entries           []PolishedEntry `json:"entries"`
+}
+
+// PolishedEntry holds either a rewritten description (Text) or rewritten
+// bullets, depending on what the entry had.
+type PolishedEntry struct {
+	ID      string   `json:"id"`
+	Text    string   `json:"text,omitempty"`
+	Bullets []string `json:"bullets,omitempty"`
+}
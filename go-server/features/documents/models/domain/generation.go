@@ -0,0 +1,114 @@
+package domain
+
+import "time"
+
+// GenerationStatus is where a document generation is in its lifecycle.
+type GenerationStatus string
+
+const (
+	GenerationQueued        GenerationStatus = "queued"
+	GenerationGenerating    GenerationStatus = "generating"
+	GenerationPendingReview GenerationStatus = "pending_review"
+	GenerationCompiling     GenerationStatus = "compiling"
+	GenerationSucceeded     GenerationStatus = "succeeded"
+	GenerationFailed        GenerationStatus = "failed"
+	GenerationCancelled     GenerationStatus = "cancelled"
+)
+
+// IsFinal reports whether a generation in this status can no longer change.
+func (s GenerationStatus) IsFinal() bool {
+	return s == GenerationSucceeded || s == GenerationFailed || s == GenerationCancelled
+}
+
+// Generation is one request to generate and compile a document. RoleID is
+// nil for documents not written for a job, such as a CV.
+type Generation struct {
+	ID          int              `json:"id"`
+	RoleID      *int             `json:"roleId,omitempty"`
+	DocType     string           `json:"docType"`
+	Status      GenerationStatus `json:"status"`
+	Provider    string           `json:"provider,omitempty"`
+	Model       string           `json:"model,omitempty"`
+	RevisionID  *int             `json:"revisionId,omitempty"`
+	ErrorCode   string           `json:"errorCode,omitempty"`
+	Error       string           `json:"error,omitempty"`
+	DownloadURL string           `json:"downloadUrl,omitempty"`
+	ChangesURL  string           `json:"changesUrl,omitempty"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   time.Time        `json:"updatedAt"`
+	StartedAt   *time.Time       `json:"startedAt,omitempty"`
+	FinishedAt  *time.Time       `json:"finishedAt,omitempty"`
+	BatchID     *int             `json:"batchId,omitempty"`
+	// StyleMatch is how close a cover letter came to the user's writing
+	// style. Nil for other documents and users without writing samples.
+	StyleMatch *StyleMatch `json:"styleMatch,omitempty"`
+}
+
+// GenerationUpdate moves a generation to Status. The other fields are only
+// written when set.
+type GenerationUpdate struct {
+	Status      GenerationStatus
+	RevisionID  *int
+	ErrorCode   string
+	Error       string
+	DownloadURL string
+	ChangesURL  string
+	StyleMatch  *StyleMatch
+}
+
+// GenerationFilter narrows a generation listing. Zero values match
+// everything; Limit defaults to 50.
+type GenerationFilter struct {
+	RoleID  *int
+	BatchID *int
+	DocType string
+	Status  GenerationStatus
+	Limit   int
+}
+
+// BatchStatus sums up the generations in a batch.
+type BatchStatus string
+
+const (
+	// BatchRunning means at least one generation is still queued, generating
+	// or compiling.
+	BatchRunning BatchStatus = "running"
+	// BatchPendingReview means nothing is running but some resumes are
+	// waiting on the user's review.
+	BatchPendingReview BatchStatus = "pending_review"
+	BatchSucceeded     BatchStatus = "succeeded"
+	// BatchPartial means everything finished and only some succeeded.
+	BatchPartial BatchStatus = "partial"
+	BatchFailed  BatchStatus = "failed"
+)
+
+// Batch is a group of generations started together.
+type Batch struct {
+	ID          int                      `json:"id"`
+	Status      BatchStatus              `json:"status"`
+	Counts      map[GenerationStatus]int `json:"counts"`
+	Generations []Generation             `json:"generations"`
+	CreatedAt   time.Time                `json:"createdAt"`
+}
+
+// Summarize sets the batch's status and counts from its generations.
+func (b *Batch) Summarize() {
+	b.Counts = make(map[GenerationStatus]int)
+	for _, g := range b.Generations {
+		b.Counts[g.Status]++
+	}
+
+	finished := b.Counts[GenerationSucceeded] + b.Counts[GenerationFailed] + b.Counts[GenerationCancelled]
+	switch {
+	case finished+b.Counts[GenerationPendingReview] < len(b.Generations):
+		b.Status = BatchRunning
+	case b.Counts[GenerationPendingReview] > 0:
+		b.Status = BatchPendingReview
+	case b.Counts[GenerationSucceeded] == len(b.Generations):
+		b.Status = BatchSucceeded
+	case b.Counts[GenerationSucceeded] > 0:
+		b.Status = BatchPartial
+	default:
+		b.Status = BatchFailed
+	}
+}
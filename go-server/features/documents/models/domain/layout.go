@@ -0,0 +1,37 @@
+package domain
+
+// LayoutEstimate is how much room a resume is predicted to take up in a
+// template, in rendered lines.
+type LayoutEstimate struct {
+	Template     string         `json:"template"`
+	Lines        int            `json:"lines"`
+	LinesPerPage int            `json:"linesPerPage"`
+	Pages        int            `json:"pages"`
+	Sections     []SectionLines `json:"sections"`
+}
+
+// SectionLines is the share of an estimate taken by one section.
+type SectionLines struct {
+	Section string `json:"section"`
+	Lines   int    `json:"lines"`
+}
+
+// FitReport records what auto-fit did to bring a resume down to its target
+// page count. CompiledPages is only set when the in-process compiler checked
+// the result.
+type FitReport struct {
+	TargetPages    int             `json:"targetPages"`
+	Before         LayoutEstimate  `json:"before"`
+	After          LayoutEstimate  `json:"after"`
+	CondensePasses int             `json:"condensePasses,omitempty"`
+	Trimmed        []TrimmedBullet `json:"trimmed,omitempty"`
+	CompiledPages  int             `json:"compiledPages,omitempty"`
+	Fits           bool            `json:"fits"`
+}
+
+// TrimmedBullet is a bullet auto-fit dropped to save space.
+type TrimmedBullet struct {
+	Section string `json:"section"`
+	Key     string `json:"key,omitempty"`
+	Text    string `json:"text"`
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// ArtifactKind names one of the files kept for a library document.
+type ArtifactKind string
+
+const (
+	ArtifactPDF     ArtifactKind = "pdf"
+	ArtifactChanges ArtifactKind = "changes"
+	ArtifactSource  ArtifactKind = "source"
+)
+
+// Artifact is one stored file of a library document. Key is where the
+// document store keeps it and never leaves the server; URL is a signed link
+// to it.
+type Artifact struct {
+	Kind ArtifactKind `json:"kind"`
+	Name string       `json:"name"`
+	Key  string       `json:"-"`
+	URL  string       `json:"url,omitempty"`
+}
+
+// LibraryDocument is a compiled document kept in the user's library, with
+// the generation and resume revision it came from. RoleID is nil for
+// documents not written for a job, such as a CV.
+type LibraryDocument struct {
+	ID           int        `json:"id"`
+	RoleID       *int       `json:"roleId,omitempty"`
+	GenerationID *int       `json:"generationId,omitempty"`
+	RevisionID   *int       `json:"revisionId,omitempty"`
+	DocType      string     `json:"docType"`
+	Template     string     `json:"template,omitempty"`
+	Artifacts    []Artifact `json:"artifacts"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+// Artifact returns the document's artifact of the given kind.
+func (d *LibraryDocument) Artifact(kind ArtifactKind) (Artifact, bool) {
+	for _, a := range d.Artifacts {
+		if a.Kind == kind {
+			return a, true
+		}
+	}
+	return Artifact{}, false
+}
+
+// LibraryFilter narrows a library listing. Zero values match everything;
+// Limit defaults to 50.
+type LibraryFilter struct {
+	RoleID  *int
+	DocType string
+	Limit   int
+}
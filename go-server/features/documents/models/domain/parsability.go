@@ -0,0 +1,64 @@
+package domain
+
+// ParsabilityReport compares the text an applicant tracking system would
+// extract from a compiled resume with the resume it was compiled from.
+// Parsable is false when a section or contact detail doesn't come through
+// intact.
+type ParsabilityReport struct {
+	Parsable         bool                `json:"parsable"`
+	Pages            int                 `json:"pages"`
+	Sections         []SectionExtraction `json:"sections"`
+	Contact          []ContactExtraction `json:"contact,omitempty"`
+	UnmappedGlyphs   []UnmappedGlyph     `json:"unmappedGlyphs,omitempty"`
+	Ligatures        int                 `json:"ligatures,omitempty"`
+	MultiColumnPages []int               `json:"multiColumnPages,omitempty"`
+	Text             string              `json:"text"`
+}
+
+// ExtractionStatus says how a piece of the resume came through extraction.
+type ExtractionStatus string
+
+const (
+	// ExtractionOK means the text was extracted word for word, in order.
+	ExtractionOK ExtractionStatus = "ok"
+	// ExtractionGarbled means the words are there but run together, out of
+	// order or partly lost.
+	ExtractionGarbled ExtractionStatus = "garbled"
+	// ExtractionMissing means little or none of the text was extracted.
+	ExtractionMissing ExtractionStatus = "missing"
+)
+
+// SectionExtraction is how one resume section came through. Coverage is
+// the percentage of the section's words found in the extracted text;
+// Problems lists the lines that didn't come through intact.
+type SectionExtraction struct {
+	Section  string           `json:"section"`
+	Status   ExtractionStatus `json:"status"`
+	Coverage int              `json:"coverage"`
+	Problems []LineExtraction `json:"problems,omitempty"`
+}
+
+// LineExtraction is a resume line that was garbled or lost. Key names the
+// experience, project or skill category it belongs to.
+type LineExtraction struct {
+	Key    string           `json:"key,omitempty"`
+	Text   string           `json:"text"`
+	Status ExtractionStatus `json:"status"`
+}
+
+// ContactExtraction is whether a contact detail can be read back out of the
+// PDF.
+type ContactExtraction struct {
+	Field string `json:"field"`
+	Value string `json:"value"`
+	Found bool   `json:"found"`
+}
+
+// UnmappedGlyph is a glyph that extracts as no text, typically an icon or a
+// character from a font without a Unicode map. Code is the character code
+// in hex.
+type UnmappedGlyph struct {
+	Font  string `json:"font"`
+	Code  string `json:"code"`
+	Count int    `json:"count"`
+}
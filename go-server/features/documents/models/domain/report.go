@@ -0,0 +1,46 @@
+package domain
+
+// GenerationReport records what the checks run on an LLM resume found and
+// repaired. It is stored with the revision the resume became.
+type GenerationReport struct {
+	LockViolations []LockViolation `json:"lockViolations,omitempty"`
+	FactIssues     []FactIssue     `json:"factIssues,omitempty"`
+	ATS            *ATSReport      `json:"ats,omitempty"`
+	Layout         *FitReport      `json:"layout,omitempty"`
+}
+
+// LockViolation is locked text the model changed, dropped or added to.
+// Original is the locked text that was restored, and is empty when the model
+// added a bullet to a locked entry; Generated is what the model produced, if
+// anything.
+type LockViolation struct {
+	Section   string `json:"section"`
+	Key       string `json:"key,omitempty"`
+	Original  string `json:"original,omitempty"`
+	Generated string `json:"generated,omitempty"`
+}
+
+// FactAction says what the verifier did about an unsupported fact.
+type FactAction string
+
+const (
+	FactRemoved   FactAction = "removed"
+	FactCorrected FactAction = "corrected"
+	FactFlagged   FactAction = "flagged"
+)
+
+// FactIssue is something in the generated resume that the source data
+// doesn't support: an employer, title, date or technology the model made up.
+// Expected is the source value a corrected field was set back to.
+type FactIssue struct {
+	Section   string     `json:"section"`
+	Key       string     `json:"key,omitempty"`
+	Field     string     `json:"field"`
+	Generated string     `json:"generated"`
+	Expected  string     `json:"expected,omitempty"`
+	Action    FactAction `json:"action"`
+}
+
+func (r *GenerationReport) IsEmpty() bool {
+	return r == nil || (len(r.LockViolations) == 0 && len(r.FactIssues) == 0 && r.ATS == nil && r.Layout == nil)
+}
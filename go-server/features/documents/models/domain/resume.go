@@ -6,22 +6,29 @@ import (
 )
 
 type Resume struct {
-	Summary     []SummaryBody `json:"summary,omitempty"`
-	Skills      []Skills      `json:"skills"`
-	Experiences []Experience  `json:"experiences"`
-	Projects    []Project     `json:"projects"`
+	Summary        []SummaryBody   `json:"summary,omitempty"`
+	Skills         []Skills        `json:"skills"`
+	Experiences    []Experience    `json:"experiences"`
+	Projects       []Project       `json:"projects"`
+	Certifications []Certification `json:"certifications,omitempty"`
+	Publications   []Publication   `json:"publications,omitempty"`
+	Volunteering   []Volunteering  `json:"volunteering,omitempty"`
+	Awards         []Award         `json:"awards,omitempty"`
+	Languages      []Language      `json:"languages,omitempty"`
 }
 
 type SummaryBody struct {
-	Sentence               string `json:"sentence"`
-	JustificationForChange string `json:"justification_for_change,omitempty"`
-	NewSuggestion          bool   `json:"is_new_suggestion,omitempty"`
+	Sentence               string       `json:"sentence"`
+	JustificationForChange string       `json:"justification_for_change,omitempty"`
+	NewSuggestion          bool         `json:"is_new_suggestion,omitempty"`
+	Review                 ReviewStatus `json:"review,omitempty"`
 }
 
 type Skills struct {
-	Category                string   `json:"category,omitempty"`
-	SkillItem               []string `json:"skill"`
-	JustificationForChanges string   `json:"justification_for_changes,omitempty"`
+	Category                string       `json:"category,omitempty"`
+	SkillItem               []string     `json:"skill"`
+	JustificationForChanges string       `json:"justification_for_changes,omitempty"`
+	Review                  ReviewStatus `json:"review,omitempty"`
 }
 
 type Experience struct {
@@ -42,9 +49,13 @@ type Project struct {
 }
 
 type BulletPoint struct {
-	Text                   string `json:"text"`
-	IsNewSuggestion        bool   `json:"is_new_suggestion"`
-	JustificationForChange string `json:"justification_for_change"`
+	Text                   string       `json:"text"`
+	IsNewSuggestion        bool         `json:"is_new_suggestion"`
+	JustificationForChange string       `json:"justification_for_change"`
+	Review                 ReviewStatus `json:"review,omitempty"`
+	// Original is the source wording of a bullet the LLM rewrote, put back
+	// if the rewrite is rejected. It is only set while under review.
+	Original string `json:"original,omitempty"`
 }
 
 func (r *Resume) FormatForLLM() string {
@@ -108,7 +119,59 @@ func (r *Resume) FormatForLLM() string {
 		sb.WriteString("\t</skills_section>\n")
 	}
 
+	if len(r.Volunteering) > 0 {
+		sb.WriteString("\t<volunteering>\n")
+		for _, v := range r.Volunteering {
+			sb.WriteString("\t\t<volunteer_role>\n")
+			sb.WriteString(fmt.Sprintf("\t\t\t<role>%s</role>\n", v.Role))
+			sb.WriteString(fmt.Sprintf("\t\t\t<organization>%s</organization>\n", v.Organization))
+			sb.WriteString(fmt.Sprintf("\t\t\t<dates>%s - %s</dates>\n", v.Start, v.End))
+			sb.WriteString("\t\t\t<volunteer_bullet_points>\n")
+			for _, point := range v.BulletPoints {
+				sb.WriteString(fmt.Sprintf("\t\t\t\t<volunteer_bullet>%s</volunteer_bullet>\n", strings.TrimSpace(point.Text)))
+			}
+			sb.WriteString("\t\t\t</volunteer_bullet_points>\n")
+			sb.WriteString("\t\t</volunteer_role>\n")
+		}
+		sb.WriteString("\t</volunteering>\n")
+	}
+
+	writeListSection(&sb, "certifications", "certification", r.Certifications, func(c Certification) string {
+		return joinNonEmpty(c.Name, c.Issuer, c.Date)
+	})
+	writeListSection(&sb, "publications", "publication", r.Publications, func(p Publication) string {
+		return joinNonEmpty(p.Title, p.Publisher, p.Date)
+	})
+	writeListSection(&sb, "awards", "award", r.Awards, func(a Award) string {
+		return joinNonEmpty(a.Title, a.Awarder, a.Date)
+	})
+	writeListSection(&sb, "languages", "language", r.Languages, func(l Language) string {
+		return joinNonEmpty(l.Language, l.Fluency)
+	})
+
 	sb.WriteString("</resume_content>")
 
 	return sb.String()
 }
+
+// writeListSection writes a section whose entries each fit on one line.
+func writeListSection[T any](sb *strings.Builder, section, entry string, items []T, line func(T) string) {
+	if len(items) == 0 {
+		return
+	}
+	sb.WriteString(fmt.Sprintf("\t<%s>\n", section))
+	for _, item := range items {
+		sb.WriteString(fmt.Sprintf("\t\t<%s>%s</%s>\n", entry, line(item), entry))
+	}
+	sb.WriteString(fmt.Sprintf("\t</%s>\n", section))
+}
+
+func joinNonEmpty(parts ...string) string {
+	kept := parts[:0]
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, ", ")
+}
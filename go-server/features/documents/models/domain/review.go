@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// ReviewStatus is the user's verdict on a single LLM suggestion. An empty
+// status means the item was carried over unchanged and needs no review.
+type ReviewStatus string
+
+const (
+	ReviewPending  ReviewStatus = "pending"
+	ReviewAccepted ReviewStatus = "accepted"
+	ReviewRejected ReviewStatus = "rejected"
+	ReviewEdited   ReviewStatus = "edited"
+)
+
+type ReviewState string
+
+const (
+	ReviewStatePending   ReviewState = "pending"
+	ReviewStateFinalized ReviewState = "finalized"
+)
+
+// Review is a generated resume waiting on the user before it is compiled.
+// Event holds the compile request that is sent once the review is finalized.
+type Review struct {
+	ID         int         `json:"id"`
+	State      ReviewState `json:"state"`
+	RevisionID *int        `json:"revisionId,omitempty"`
+	Pending    int         `json:"pending"`
+	Resume     *Resume     `json:"resume"`
+	// Report is what the post-generation checks found in the revision the
+	// review started from.
+	Report      *GenerationReport `json:"report,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+	FinalizedAt *time.Time        `json:"finalizedAt,omitempty"`
+	Event       json.RawMessage   `json:"-"`
+}
+
+// RejectedSuggestion is a suggestion the user turned down, kept so the next
+// generation can be told not to repeat it.
+type RejectedSuggestion struct {
+	Section       string `json:"section"`
+	Text          string `json:"text"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// isSuggestion reports whether the LLM added or rewrote an item.
+func isSuggestion(isNew bool, justification string) bool {
+	return isNew || justification != ""
+}
+
+// MarkForReview flags every LLM suggestion in the resume as pending.
+func (r *Resume) MarkForReview() {
+	for i := range r.Summary {
+		if s := &r.Summary[i]; isSuggestion(s.NewSuggestion, s.JustificationForChange) {
+			s.Review = ReviewPending
+		}
+	}
+	for i := range r.Skills {
+		if s := &r.Skills[i]; isSuggestion(false, s.JustificationForChanges) {
+			s.Review = ReviewPending
+		}
+	}
+	for i := range r.Experiences {
+		markBullets(r.Experiences[i].BulletPoints)
+	}
+	for i := range r.Projects {
+		markBullets(r.Projects[i].BulletPoints)
+	}
+	for i := range r.Volunteering {
+		markBullets(r.Volunteering[i].BulletPoints)
+	}
+}
+
+func markBullets(points []BulletPoint) {
+	for i := range points {
+		if p := &points[i]; isSuggestion(p.IsNewSuggestion, p.JustificationForChange) {
+			p.Review = ReviewPending
+		}
+	}
+}
+
+// ReviewCount returns how many suggestions carry the given status.
+func (r *Resume) ReviewCount(status ReviewStatus) int {
+	n := 0
+	for _, s := range r.Summary {
+		if s.Review == status {
+			n++
+		}
+	}
+	for _, s := range r.Skills {
+		if s.Review == status {
+			n++
+		}
+	}
+	for _, e := range r.Experiences {
+		n += countBullets(e.BulletPoints, status)
+	}
+	for _, p := range r.Projects {
+		n += countBullets(p.BulletPoints, status)
+	}
+	for _, v := range r.Volunteering {
+		n += countBullets(v.BulletPoints, status)
+	}
+	return n
+}
+
+func countBullets(points []BulletPoint, status ReviewStatus) int {
+	n := 0
+	for _, p := range points {
+		if p.Review == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Finalize returns the resume with rejected items dropped and review marks
+// cleared, along with the suggestions that were rejected. A rejected
+// rewrite of an existing bullet reverts to its original wording instead of
+// being dropped. Items still pending are kept, as if accepted. Sections
+// without suggestions are carried over as they are.
+func (r *Resume) Finalize() (Resume, []RejectedSuggestion) {
+	var rejected []RejectedSuggestion
+	final := *r
+	final.Summary = make([]SummaryBody, 0, len(r.Summary))
+	final.Skills = make([]Skills, 0, len(r.Skills))
+	final.Experiences = make([]Experience, 0, len(r.Experiences))
+	final.Projects = make([]Project, 0, len(r.Projects))
+	final.Volunteering = nil
+
+	for _, s := range r.Summary {
+		if s.Review == ReviewRejected {
+			rejected = append(rejected, RejectedSuggestion{Section: "summary", Text: s.Sentence, Justification: s.JustificationForChange})
+			continue
+		}
+		s.Review = ""
+		final.Summary = append(final.Summary, s)
+	}
+	for _, s := range r.Skills {
+		if s.Review == ReviewRejected {
+			rejected = append(rejected, RejectedSuggestion{Section: "skills", Text: s.Category + ": " + strings.Join(s.SkillItem, ", "), Justification: s.JustificationForChanges})
+			continue
+		}
+		s.Review = ""
+		final.Skills = append(final.Skills, s)
+	}
+	for _, e := range r.Experiences {
+		var dropped []RejectedSuggestion
+		e.BulletPoints, dropped = finalizeBullets(e.BulletPoints, "experiences")
+		rejected = append(rejected, dropped...)
+		final.Experiences = append(final.Experiences, e)
+	}
+	for _, p := range r.Projects {
+		var dropped []RejectedSuggestion
+		p.BulletPoints, dropped = finalizeBullets(p.BulletPoints, "projects")
+		rejected = append(rejected, dropped...)
+		final.Projects = append(final.Projects, p)
+	}
+	for _, v := range r.Volunteering {
+		var dropped []RejectedSuggestion
+		v.BulletPoints, dropped = finalizeBullets(v.BulletPoints, "volunteering")
+		rejected = append(rejected, dropped...)
+		final.Volunteering = append(final.Volunteering, v)
+	}
+	return final, rejected
+}
+
+func finalizeBullets(points []BulletPoint, section string) ([]BulletPoint, []RejectedSuggestion) {
+	var rejected []RejectedSuggestion
+	kept := make([]BulletPoint, 0, len(points))
+	for _, p := range points {
+		if p.Review == ReviewRejected {
+			rejected = append(rejected, RejectedSuggestion{Section: section, Text: p.Text, Justification: p.JustificationForChange})
+			if p.IsNewSuggestion || p.Original == "" {
+				continue
+			}
+			p = BulletPoint{Text: p.Original}
+		}
+		p.Review = ""
+		p.Original = ""
+		kept = append(kept, p)
+	}
+	return kept, rejected
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func reviewedResume() *Resume {
+	return &Resume{
+		Summary: []SummaryBody{
+			{Sentence: "Backend engineer.", NewSuggestion: true, Review: ReviewAccepted},
+			{Sentence: "Rejected sentence.", NewSuggestion: true, Review: ReviewRejected},
+		},
+		Skills: []Skills{
+			{Category: "Languages", SkillItem: []string{"Go", "SQL"}, JustificationForChanges: "matches the job", Review: ReviewPending},
+		},
+		Experiences: []Experience{{
+			ID: "exp-1", Company: "Acme", Position: "Engineer", Start: "Jan 2020", End: "Present",
+			BulletPoints: []BulletPoint{
+				{Text: "Kept bullet."},
+				{Text: "Rejected bullet.", IsNewSuggestion: true, Review: ReviewRejected},
+			},
+		}},
+		Projects: []Project{{
+			ID: "proj-1", Name: "Ordo", Role: "Author", Status: "active",
+			BulletPoints: []BulletPoint{{Text: "Edited bullet.", JustificationForChange: "clearer", Review: ReviewEdited}},
+		}},
+		Certifications: []Certification{{ID: "cert-1", Name: "CKA", Issuer: "CNCF", Date: "2023", URL: "https://example.com/cka"}},
+		Publications:   []Publication{{ID: "pub-1", Title: "On Queues", Publisher: "ACM", Date: "2022", URL: "https://example.com/pub", Summary: "A paper."}},
+		Volunteering: []Volunteering{{
+			ID: "vol-1", Organization: "Code Club", Role: "Mentor", Start: "2021", End: "Present",
+			BulletPoints: []BulletPoint{
+				{Text: "Kept volunteering bullet.", IsNewSuggestion: true, Review: ReviewAccepted},
+				{Text: "Rejected volunteering bullet.", IsNewSuggestion: true, Review: ReviewRejected},
+			},
+		}},
+		Awards:    []Award{{ID: "award-1", Title: "Hackathon Winner", Awarder: "Acme", Date: "2021", Summary: "First place."}},
+		Languages: []Language{{ID: "lang-1", Language: "French", Fluency: "Professional"}},
+	}
+}
+
+func TestFinalizeKeepsEverySection(t *testing.T) {
+	r := reviewedResume()
+	final, rejected := r.Finalize()
+
+	if !reflect.DeepEqual(final.Certifications, r.Certifications) {
+		t.Errorf("certifications = %+v, want %+v", final.Certifications, r.Certifications)
+	}
+	if !reflect.DeepEqual(final.Publications, r.Publications) {
+		t.Errorf("publications = %+v, want %+v", final.Publications, r.Publications)
+	}
+	if !reflect.DeepEqual(final.Awards, r.Awards) {
+		t.Errorf("awards = %+v, want %+v", final.Awards, r.Awards)
+	}
+	if !reflect.DeepEqual(final.Languages, r.Languages) {
+		t.Errorf("languages = %+v, want %+v", final.Languages, r.Languages)
+	}
+
+	wantVolunteering := []Volunteering{{
+		ID: "vol-1", Organization: "Code Club", Role: "Mentor", Start: "2021", End: "Present",
+		BulletPoints: []BulletPoint{{Text: "Kept volunteering bullet.", IsNewSuggestion: true}},
+	}}
+	if !reflect.DeepEqual(final.Volunteering, wantVolunteering) {
+		t.Errorf("volunteering = %+v, want %+v", final.Volunteering, wantVolunteering)
+	}
+
+	if len(final.Summary) != 1 || len(final.Experiences[0].BulletPoints) != 1 {
+		t.Errorf("rejected summary or experience items were kept: %+v", final)
+	}
+	if got := final.ReviewCount(ReviewPending) + final.ReviewCount(ReviewAccepted) + final.ReviewCount(ReviewEdited); got != 0 {
+		t.Errorf("finalized resume still has %d review marks", got)
+	}
+
+	sections := map[string]int{}
+	for _, s := range rejected {
+		sections[s.Section]++
+	}
+	want := map[string]int{"summary": 1, "experiences": 1, "volunteering": 1}
+	if !reflect.DeepEqual(sections, want) {
+		t.Errorf("rejected sections = %v, want %v", sections, want)
+	}
+}
+
+func TestFinalizeLeavesInputUntouched(t *testing.T) {
+	r := reviewedResume()
+	r.Finalize()
+	if !reflect.DeepEqual(r, reviewedResume()) {
+		t.Errorf("Finalize modified its receiver")
+	}
+}
+
+func TestMarkForReviewIncludesVolunteering(t *testing.T) {
+	r := &Resume{Volunteering: []Volunteering{{
+		BulletPoints: []BulletPoint{{Text: "New.", IsNewSuggestion: true}, {Text: "Unchanged."}},
+	}}}
+	r.MarkForReview()
+	if got := r.ReviewCount(ReviewPending); got != 1 {
+		t.Errorf("pending = %d, want 1", got)
+	}
+}
+
+func TestFinalizeRejectedRewriteKeepsOriginal(t *testing.T) {
+	r := &Resume{Experiences: []Experience{{
+		ID: "exp-1",
+		BulletPoints: []BulletPoint{
+			{Text: "Rewritten bullet.", JustificationForChange: "stronger verb", Original: "Original bullet.", Review: ReviewRejected},
+			{Text: "Accepted rewrite.", JustificationForChange: "shorter", Original: "Accepted original.", Review: ReviewAccepted},
+			{Text: "New bullet.", IsNewSuggestion: true, Review: ReviewRejected},
+		},
+	}}}
+
+	final, rejected := r.Finalize()
+
+	want := []BulletPoint{{Text: "Original bullet."}, {Text: "Accepted rewrite.", JustificationForChange: "shorter"}}
+	if !reflect.DeepEqual(final.Experiences[0].BulletPoints, want) {
+		t.Errorf("bullets = %+v, want %+v", final.Experiences[0].BulletPoints, want)
+	}
+	wantRejected := []RejectedSuggestion{
+		{Section: "experiences", Text: "Rewritten bullet.", Justification: "stronger verb"},
+		{Section: "experiences", Text: "New bullet."},
+	}
+	if !reflect.DeepEqual(rejected, wantRejected) {
+		t.Errorf("rejected = %+v, want %+v", rejected, wantRejected)
+	}
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+type RevisionAuthor string
+
+const (
+	AuthorLLM  RevisionAuthor = "llm"
+	AuthorUser RevisionAuthor = "user"
+)
+
+// RevisionMeta records who produced a revision and, for LLM output, how.
+type RevisionMeta struct {
+	Author        RevisionAuthor    `json:"author"`
+	Provider      string            `json:"provider,omitempty"`
+	Model         string            `json:"model,omitempty"`
+	PromptVersion string            `json:"promptVersion,omitempty"`
+	Report        *GenerationReport `json:"report,omitempty"`
+}
+
+type Revision struct {
+	ID           int       `json:"id"`
+	Number       int       `json:"number"`
+	RestoredFrom *int      `json:"restoredFrom,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+	RevisionMeta
+	// Resume is omitted when listing revisions.
+	Resume *Resume `json:"resume,omitempty"`
+}
@@ -0,0 +1,45 @@
+package domain
+
+// The sections below are picked from the source resume as they are; the LLM
+// chooses which entries fit the job and in what order. Only volunteering
+// bullets are rewritten.
+
+type Certification struct {
+	ID     string `json:"id,omitempty"`
+	Name   string `json:"name"`
+	Issuer string `json:"issuer,omitempty"`
+	Date   string `json:"date,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+type Publication struct {
+	ID        string `json:"id,omitempty"`
+	Title     string `json:"title"`
+	Publisher string `json:"publisher,omitempty"`
+	Date      string `json:"date,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Summary   string `json:"summary,omitempty"`
+}
+
+type Volunteering struct {
+	ID           string        `json:"id,omitempty"`
+	Organization string        `json:"organization"`
+	Role         string        `json:"role"`
+	Start        string        `json:"start"`
+	End          string        `json:"end"`
+	BulletPoints []BulletPoint `json:"bulletPoints"`
+}
+
+type Award struct {
+	ID      string `json:"id,omitempty"`
+	Title   string `json:"title"`
+	Awarder string `json:"awarder,omitempty"`
+	Date    string `json:"date,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+type Language struct {
+	ID       string `json:"id,omitempty"`
+	Language string `json:"language"`
+	Fluency  string `json:"fluency,omitempty"`
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StyleProfile is a stylometric fingerprint of the user's writing samples.
+// It is recomputed whenever the samples change; SampleCount and
+// SamplesUpdatedAt record which samples it was built from.
+type StyleProfile struct {
+	SampleCount      int       `json:"sampleCount"`
+	SamplesUpdatedAt time.Time `json:"samplesUpdatedAt"`
+	WordCount        int       `json:"wordCount"`
+	SentenceCount    int       `json:"sentenceCount"`
+
+	SentenceLength SentenceLengthStats `json:"sentenceLength"`
+	// ReadabilityGrade is the Flesch-Kincaid grade level.
+	ReadabilityGrade float64 `json:"readabilityGrade"`
+	// VocabularyRichness is the moving-average type-token ratio over 50-word
+	// windows, so it doesn't drop as the samples get longer.
+	VocabularyRichness float64 `json:"vocabularyRichness"`
+	// ContractionRate is contractions per 100 words.
+	ContractionRate float64 `json:"contractionRate"`
+	// PassiveVoiceRatio is the share of sentences in the passive voice.
+	PassiveVoiceRatio float64 `json:"passiveVoiceRatio"`
+	// CharacteristicPhrases are multi-word phrases the user repeats, most
+	// frequent first.
+	CharacteristicPhrases []string `json:"characteristicPhrases"`
+
+	AnalyzedAt time.Time `json:"analyzedAt"`
+}
+
+// SentenceLengthStats describes sentence lengths in words.
+type SentenceLengthStats struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stdDev"`
+	P10    float64 `json:"p10"`
+	Median float64 `json:"median"`
+	P90    float64 `json:"p90"`
+	// ShortShare and LongShare are the shares of sentences under 10 and over
+	// 25 words.
+	ShortShare float64 `json:"shortShare"`
+	LongShare  float64 `json:"longShare"`
+}
+
+// FormatForLLM describes the profile as writing instructions.
+func (p *StyleProfile) FormatForLLM() string {
+	var builder strings.Builder
+	s := p.SentenceLength
+	builder.WriteString(fmt.Sprintf(
+		"Sentence length: averages %.0f words (typically %.0f to %.0f); %.0f%% of sentences are under 10 words and %.0f%% are over 25.\n",
+		s.Mean, s.P10, s.P90, s.ShortShare*100, s.LongShare*100,
+	))
+	builder.WriteString(fmt.Sprintf("Readability: around grade %.1f (Flesch-Kincaid).\n", p.ReadabilityGrade))
+	builder.WriteString(fmt.Sprintf("Vocabulary richness: %.2f type-token ratio per 50 words.\n", p.VocabularyRichness))
+	builder.WriteString(fmt.Sprintf("Contractions: %.1f per 100 words.\n", p.ContractionRate))
+	builder.WriteString(fmt.Sprintf("Passive voice: %.0f%% of sentences.\n", p.PassiveVoiceRatio*100))
+	if len(p.CharacteristicPhrases) > 0 {
+		builder.WriteString("Characteristic phrases: \"" + strings.Join(p.CharacteristicPhrases, "\", \"") + "\"\n")
+	}
+	return builder.String()
+}
+
+// StyleMatch is how far a generated document's style is from the user's
+// StyleProfile. Distance runs from 0, indistinguishable, to 1.
+type StyleMatch struct {
+	Distance float64            `json:"distance"`
+	Metrics  []StyleMetricMatch `json:"metrics"`
+	// PhrasesUsed are the profile's characteristic phrases that appear in
+	// the document.
+	PhrasesUsed []string `json:"phrasesUsed,omitempty"`
+}
+
+// StyleMetricMatch compares one metric of the profile and the document.
+type StyleMetricMatch struct {
+	Metric   string  `json:"metric"`
+	Profile  float64 `json:"profile"`
+	Document float64 `json:"document"`
+	Distance float64 `json:"distance"`
+}
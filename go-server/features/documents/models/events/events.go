@@ -6,12 +6,31 @@ import (
 )
 
 type DocumentEvent struct {
-	JobID         int                           `json:"jobID"`
+	JobID int `json:"jobID"`
+	// GenerationID is echoed back in the compiler's result so it can be
+	// matched to its document_generations row.
+	GenerationID  int                           `json:"generationID,omitempty"`
 	UserId        string                        `json:"userID"`
 	CompanyName   string                        `json:"companyName"`
 	DocType       string                        `json:"docType"`
 	UserInfo      requests.UserInfoPayload      `json:"userInfo"`
 	EducationInfo requests.EducationInfoPayload `json:"educationInfo"`
-	Resume        domain.Resume                 `json:"resume,omitzero"`
-	CoverLetter   domain.CoverLetter            `json:"coverLetter,omitzero"`
+	// Education lists every school when there is more than one;
+	// EducationInfo still carries the first for older consumers.
+	Education   []requests.EducationInfoPayload `json:"education,omitempty"`
+	Resume      domain.Resume                   `json:"resume,omitzero"`
+	CoverLetter domain.CoverLetter              `json:"coverLetter,omitzero"`
+}
+
+// CVEvent asks the compiler for an academic CV. It has its own shape since a
+// CV isn't written for a company and carries sections a resume doesn't.
+// JobID is only set when the CV was requested from a job.
+type CVEvent struct {
+	JobID        int                             `json:"jobID"`
+	GenerationID int                             `json:"generationID,omitempty"`
+	UserId       string                          `json:"userID"`
+	DocType      string                          `json:"docType"`
+	UserInfo     requests.UserInfoPayload        `json:"userInfo"`
+	Education    []requests.EducationInfoPayload `json:"education"`
+	CV           domain.CV                       `json:"cv"`
 }
@@ -0,0 +1,96 @@
+package mocks
+
+import "github.com/ordo_meritum/features/documents/models/domain"
+
+// Provider is recorded as the LLM provider of a dry run, which writes its
+// content from this package instead of calling a model.
+const Provider = "dry-run"
+
+// Resume returns a canned tailored resume. It is the same on every call, so
+// dry runs produce the same document every time.
+func Resume() domain.Resume {
+	return domain.Resume{
+		Summary: []domain.SummaryBody{
+			{
+				Sentence:               "Skilled in full-stack development with a focus on React and Go.",
+				JustificationForChange: "Added more specific keywords.",
+				NewSuggestion:          true,
+			},
+			{
+				Sentence:               "Proven ability to lead projects from conception to completion.",
+				JustificationForChange: "Highlighted delivery experience the posting asks for.",
+				NewSuggestion:          true,
+			},
+		},
+		Skills: []domain.Skills{
+			{
+				Category:                "Programming Languages",
+				SkillItem:               []string{"Go", "TypeScript", "Python"},
+				JustificationForChanges: "Consolidated skill categories.",
+			},
+			{
+				Category:                "Frameworks & Libraries",
+				SkillItem:               []string{"React", "Node.js", "Gin", "gorilla/mux"},
+				JustificationForChanges: "Grouped the frameworks named in the posting.",
+			},
+		},
+		Experiences: []domain.Experience{
+			{
+				BulletPoints: []domain.BulletPoint{
+					{
+						Text:                   "Developed and maintained microservices using Go, improving API response times by 30%.",
+						IsNewSuggestion:        false,
+						JustificationForChange: "Kept as written.",
+					},
+					{
+						Text:                   "Engineered a new real-time notification system using WebSockets, increasing user engagement.",
+						IsNewSuggestion:        true,
+						JustificationForChange: "Quantified the impact of the achievement.",
+					},
+				},
+				Company:  "Innovate Corp",
+				ID:       "exp-1",
+				Position: "Senior Software Engineer",
+				Start:    "2021",
+				End:      "Present",
+			},
+		},
+		Projects: []domain.Project{
+			{
+				BulletPoints: []domain.BulletPoint{
+					{
+						Text:                   "Built a full-stack e-commerce platform with a React frontend and Go backend.",
+						IsNewSuggestion:        false,
+						JustificationForChange: "Kept as written.",
+					},
+				},
+				Role:   "Lead Developer",
+				ID:     "proj-1",
+				Name:   "E-Commerce Platform",
+				Status: "Completed",
+			},
+		},
+	}
+}
+
+// CoverLetter returns a canned cover letter for the given company and role.
+func CoverLetter(companyProperName, jobTitle string) domain.CoverLetter {
+	return domain.CoverLetter{
+		CompanyProperName: companyProperName,
+		JobTitle:          jobTitle,
+		Body: domain.CoverLetterBody{
+			About:           "I am writing to express my interest in the " + jobTitle + " position at " + companyProperName + ".",
+			Experience:      "In my previous role at Innovate Corp, I was responsible for key microservice development.",
+			WhatIBring:      "I bring a strong proficiency in Go and a passion for clean, efficient code.",
+			RevisionSummary: "Revised to better align with company values.",
+		},
+	}
+}
+
+// CVPolish returns a canned CV polish. It rewrites no entries, so the CV is
+// compiled from the user's own records as they are.
+func CVPolish() domain.CVPolish {
+	return domain.CVPolish{
+		ResearchInterests: "Distributed systems, programming languages and the tooling that connects them.",
+	}
+}
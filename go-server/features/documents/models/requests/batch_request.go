@@ -0,0 +1,64 @@
+package requests
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ordo_meritum/shared/models/requests"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+// MaxBatchRoles caps how many roles one batch can cover.
+const MaxBatchRoles = 10
+
+// BatchDocTypes are the documents a batch can generate, in the order they
+// are generated for each role: the cover letter is written from the resume.
+var BatchDocTypes = []string{"resume", "cover-letter"}
+
+// BatchRequest generates every document type in Options.DocTypes for every
+// role in Options.RoleIDs, sharing the payload and the rest of the options.
+type BatchRequest = requests.RequestBody[DocumentPayload, BatchOptions]
+
+// BatchOptions are DocumentOptions applied to each role. The embedded JobID
+// and DocType are ignored.
+type BatchOptions struct {
+	RoleIDs  []int    `json:"roleIds"`
+	DocTypes []string `json:"docTypes"`
+	DocumentOptions
+}
+
+func (o *BatchOptions) Validate() []error_response.ValidationDetail {
+	var details []error_response.ValidationDetail
+	switch {
+	case len(o.RoleIDs) == 0:
+		details = append(details, error_response.ValidationDetail{Field: "options.roleIds", Issue: "is required"})
+	case len(o.RoleIDs) > MaxBatchRoles:
+		details = append(details, error_response.ValidationDetail{
+			Field: "options.roleIds",
+			Issue: fmt.Sprintf("must list at most %d roles", MaxBatchRoles),
+		})
+	}
+	seen := map[int]bool{}
+	for i, id := range o.RoleIDs {
+		if id < 1 || seen[id] {
+			details = append(details, error_response.ValidationDetail{
+				Field: fmt.Sprintf("options.roleIds[%d]", i),
+				Issue: "must be a distinct role id",
+			})
+		}
+		seen[id] = true
+	}
+
+	if len(o.DocTypes) == 0 {
+		details = append(details, error_response.ValidationDetail{Field: "options.docTypes", Issue: "is required"})
+	}
+	for i, t := range o.DocTypes {
+		if !slices.Contains(BatchDocTypes, t) {
+			details = append(details, error_response.ValidationDetail{
+				Field: fmt.Sprintf("options.docTypes[%d]", i),
+				Issue: "must be resume or cover-letter",
+			})
+		}
+	}
+	return append(details, o.DocumentOptions.Validate()...)
+}
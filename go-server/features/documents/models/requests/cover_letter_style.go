@@ -0,0 +1,63 @@
+package requests
+
+import (
+	"fmt"
+	"slices"
+
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+// Tones accepted in DocumentOptions.Tone. An empty tone lets the writing
+// samples set it.
+var CoverLetterTones = []string{"professional", "warm", "enthusiastic", "confident", "conversational", "formal"}
+
+// Lengths accepted in DocumentOptions.Length. Defaults to LengthStandard.
+const (
+	LengthShort    = "short"
+	LengthStandard = "standard"
+	LengthLong     = "long"
+)
+
+// Structures accepted in DocumentOptions.Structure. StructureSections keeps
+// the About, Experience and What I Bring headings; StructurePlain lays the
+// same content out as an ordinary letter. Defaults to StructureSections.
+const (
+	StructureSections = "sections"
+	StructurePlain    = "plain"
+)
+
+// Paragraph counts accepted in DocumentOptions.Paragraphs. The opening and
+// closing take one paragraph each and the rest go to experience.
+const (
+	MinParagraphs     = 3
+	MaxParagraphs     = 6
+	DefaultParagraphs = 3
+)
+
+// Validate checks the cover letter style options.
+func (o *DocumentOptions) Validate() []error_response.ValidationDetail {
+	var details []error_response.ValidationDetail
+	if o.Tone != "" && !slices.Contains(CoverLetterTones, o.Tone) {
+		details = append(details, error_response.ValidationDetail{
+			Field: "options.tone",
+			Issue: fmt.Sprintf("must be one of %v", CoverLetterTones),
+		})
+	}
+	switch o.Length {
+	case "", LengthShort, LengthStandard, LengthLong:
+	default:
+		details = append(details, error_response.ValidationDetail{Field: "options.length", Issue: "must be short, standard or long"})
+	}
+	switch o.Structure {
+	case "", StructureSections, StructurePlain:
+	default:
+		details = append(details, error_response.ValidationDetail{Field: "options.structure", Issue: "must be sections or plain"})
+	}
+	if o.Paragraphs != 0 && (o.Paragraphs < MinParagraphs || o.Paragraphs > MaxParagraphs) {
+		details = append(details, error_response.ValidationDetail{
+			Field: "options.paragraphs",
+			Issue: fmt.Sprintf("must be between %d and %d", MinParagraphs, MaxParagraphs),
+		})
+	}
+	return details
+}
@@ -13,7 +13,25 @@ type DocumentPayload struct {
 	UserInfo       UserInfoPayload      `json:"userInfo"`
 	AdditionalInfo json.RawMessage      `json:"additionalInfo"`
 	EducationInfo  EducationInfoPayload `json:"educationInfo"`
-	Coverletter    CoverLetterPayload   `json:"coverletter,omitzero"`
+	// Education lists every school, most relevant first. Clients that send
+	// a single school can use EducationInfo alone.
+	Education   []EducationInfoPayload `json:"education,omitempty"`
+	Coverletter CoverLetterPayload     `json:"coverletter,omitzero"`
+	// CV carries the academic sections only a CV shows. Appointments,
+	// publications and awards come from Resume.
+	CV CVPayload `json:"cv,omitzero"`
+}
+
+// Educations returns every school in the payload: Education when set,
+// otherwise EducationInfo if it names a school.
+func (p *DocumentPayload) Educations() []EducationInfoPayload {
+	if len(p.Education) > 0 {
+		return p.Education
+	}
+	if p.EducationInfo.School != "" {
+		return []EducationInfoPayload{p.EducationInfo}
+	}
+	return nil
 }
 
 type DocumentOptions struct {
@@ -24,8 +42,45 @@ type DocumentOptions struct {
 	GetNew         bool     `json:"getNew,omitempty"`
 	Corrections    []string `json:"corrections,omitempty"`
 	WritingSamples []string `json:"writingSamples,omitempty"`
+	// UseProfile builds the payload from the user's stored master profile.
+	// Anything sent inline in the payload overrides the matching profile data.
+	UseProfile bool `json:"useProfile,omitempty"`
+	// VariantID narrows the profile to a named base resume. It implies
+	// UseProfile.
+	VariantID *int `json:"variantId,omitempty"`
+	// AutoSelectVariant picks the variant whose content best overlaps the
+	// job posting's keywords. Ignored when VariantID is set.
+	AutoSelectVariant bool `json:"autoSelectVariant,omitempty"`
+	// SkipReview compiles a generated resume straight away instead of holding
+	// it for the user to review suggestion by suggestion.
+	SkipReview bool `json:"skipReview,omitempty"`
+	// TargetPages turns on auto-fit: the generated resume is shortened until
+	// it is estimated to fit in this many pages. 0 leaves the length alone.
+	TargetPages int `json:"targetPages,omitempty"`
+	// FitStrategy is how auto-fit shortens the resume, FitTrim or
+	// FitCondense. Defaults to FitTrim.
+	FitStrategy string `json:"fitStrategy,omitempty"`
+	// Template names the LaTeX template the resume is laid out for.
+	Template string `json:"template,omitempty"`
+	// DryRun writes the document from canned content instead of calling the
+	// LLM. Everything else, from revisions to compilation, runs as usual, so
+	// the pipeline can be exercised without an API key.
+	DryRun bool `json:"dryRun,omitempty"`
+	// Tone, Length, Structure and Paragraphs shape a cover letter. See
+	// cover_letter_style.go for the accepted values.
+	Tone       string `json:"tone,omitempty"`
+	Length     string `json:"length,omitempty"`
+	Structure  string `json:"structure,omitempty"`
+	Paragraphs int    `json:"paragraphs,omitempty"`
 }
 
+// Strategies accepted in DocumentOptions.FitStrategy. FitCondense asks the
+// LLM to tighten the bullets first and trims whatever still doesn't fit.
+const (
+	FitTrim     = "trim"
+	FitCondense = "condense"
+)
+
 /*
 --- Request Payloads ---
 
@@ -39,28 +94,151 @@ type ResumePayload struct {
 	Skills      []SkillsPayload     `json:"skills"`
 	Experiences []ExperiencePayload `json:"experiences"`
 	Projects    []ProjectPayload    `json:"projects"`
+
+	Certifications []CertificationPayload `json:"certifications,omitempty"`
+	Publications   []PublicationPayload   `json:"publications,omitempty"`
+	Volunteering   []VolunteeringPayload  `json:"volunteering,omitempty"`
+	Awards         []AwardPayload         `json:"awards,omitempty"`
+	Languages      []LanguagePayload      `json:"languages,omitempty"`
+
+	// LockedSections names whole sections the LLM must leave untouched:
+	// "summary", "skills", "experiences" or "projects".
+	LockedSections []string `json:"lockedSections,omitempty"`
+}
+
+// Section names accepted in ResumePayload.LockedSections, and used to label
+// the other resume sections in reports.
+const (
+	SectionSummary        = "summary"
+	SectionSkills         = "skills"
+	SectionExperiences    = "experiences"
+	SectionProjects       = "projects"
+	SectionEducation      = "education"
+	SectionCertifications = "certifications"
+	SectionPublications   = "publications"
+	SectionVolunteering   = "volunteering"
+	SectionAwards         = "awards"
+	SectionLanguages      = "languages"
+)
+
+// IsLocked reports whether the named section is locked.
+func (r *ResumePayload) IsLocked(section string) bool {
+	for _, s := range r.LockedSections {
+		if s == section {
+			return true
+		}
+	}
+	return false
 }
 
 type SkillsPayload struct {
 	Skill string `json:"skill"`
 }
 
+// Locked on an experience or project keeps all of its wording as is;
+// LockedBullets locks individual bullets by index.
 type ExperiencePayload struct {
-	BulletPoints []string `json:"bulletPoints"`
-	Company      string   `json:"company"`
-	ID           string   `json:"id"`
-	Position     string   `json:"position"`
-	Years        string   `json:"years"`
+	BulletPoints  []string `json:"bulletPoints"`
+	Company       string   `json:"company"`
+	ID            string   `json:"id"`
+	Position      string   `json:"position"`
+	Years         string   `json:"years"`
+	Locked        bool     `json:"locked,omitempty"`
+	LockedBullets []int    `json:"lockedBullets,omitempty"`
 }
 
 type ProjectPayload struct {
+	BulletPoints  []string `json:"bulletPoints"`
+	Description   string   `json:"description"`
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Years         string   `json:"years"`
+	Locked        bool     `json:"locked,omitempty"`
+	LockedBullets []int    `json:"lockedBullets,omitempty"`
+}
+
+type CertificationPayload struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Issuer string `json:"issuer,omitempty"`
+	Date   string `json:"date,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+type PublicationPayload struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Publisher string `json:"publisher,omitempty"`
+	Date      string `json:"date,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Summary   string `json:"summary,omitempty"`
+}
+
+type VolunteeringPayload struct {
 	BulletPoints []string `json:"bulletPoints"`
-	Description  string   `json:"description"`
 	ID           string   `json:"id"`
-	Name         string   `json:"name"`
+	Organization string   `json:"organization"`
+	Role         string   `json:"role"`
 	Years        string   `json:"years"`
 }
 
+type AwardPayload struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Awarder string `json:"awarder,omitempty"`
+	Date    string `json:"date,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+type LanguagePayload struct {
+	ID       string `json:"id"`
+	Language string `json:"language"`
+	Fluency  string `json:"fluency,omitempty"`
+}
+
+type CVPayload struct {
+	ResearchInterests string                `json:"researchInterests,omitempty"`
+	Presentations     []PresentationPayload `json:"presentations,omitempty"`
+	Teaching          []TeachingPayload     `json:"teaching,omitempty"`
+	Committees        []CommitteePayload    `json:"committees,omitempty"`
+	Grants            []GrantPayload        `json:"grants,omitempty"`
+}
+
+type PresentationPayload struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Event    string `json:"event"`
+	Location string `json:"location,omitempty"`
+	Date     string `json:"date"`
+	Invited  bool   `json:"invited,omitempty"`
+}
+
+type TeachingPayload struct {
+	ID          string `json:"id"`
+	Course      string `json:"course"`
+	Institution string `json:"institution"`
+	Role        string `json:"role"`
+	Years       string `json:"years"`
+	Description string `json:"description,omitempty"`
+}
+
+type CommitteePayload struct {
+	ID        string `json:"id"`
+	Committee string `json:"committee"`
+	Role      string `json:"role"`
+	Years     string `json:"years"`
+}
+
+type GrantPayload struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Funder      string `json:"funder"`
+	Amount      string `json:"amount,omitempty"`
+	Role        string `json:"role,omitempty"`
+	Years       string `json:"years"`
+	Description string `json:"description,omitempty"`
+}
+
 type CoverLetterPayload struct {
 	CompanyProperName string                 `json:"companyProperName"`
 	JobTitle          string                 `json:"jobTitle"`
@@ -0,0 +1,28 @@
+package requests
+
+// ReviewAction records the user's verdict on one suggestion in a pending
+// resume review.
+//
+// Section is one of "summary", "skills", "experiences" or "projects". Index
+// picks the sentence, skill category, experience or project; Bullet picks
+// the bullet point within an experience or project. Action is "accept",
+// "reject" or "edit". Edits replace the item's text, or for skills the
+// category's list of skills.
+type ReviewAction struct {
+	Section string   `json:"section"`
+	Index   int      `json:"index"`
+	Bullet  *int     `json:"bullet,omitempty"`
+	Action  string   `json:"action"`
+	Text    string   `json:"text,omitempty"`
+	Skills  []string `json:"skills,omitempty"`
+}
+
+type ReviewRequest struct {
+	Actions []ReviewAction `json:"actions"`
+}
+
+type FinalizeReviewRequest struct {
+	// AcceptPending treats suggestions the user hasn't ruled on as accepted.
+	// Without it, finalizing a review with pending items fails.
+	AcceptPending bool `json:"acceptPending,omitempty"`
+}
@@ -116,6 +116,86 @@ var CohereResumeSchema = map[string]any{
 				},
 			},
 		},
+		"volunteering": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"organization": map[string]any{
+						"type": "string",
+					},
+					"role": map[string]any{
+						"type": "string",
+					},
+					"start": map[string]any{
+						"type": "string",
+					},
+					"end": map[string]any{
+						"type": "string",
+					},
+					"bulletPoints": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type": "object",
+							"properties": map[string]any{
+								"text": map[string]any{
+									"type": "string",
+								},
+								"is_new_suggestion": map[string]any{
+									"type": "boolean",
+								},
+								"justification_for_change": map[string]any{
+									"type": "string",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"certifications": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name":   map[string]any{"type": "string"},
+					"issuer": map[string]any{"type": "string"},
+					"date":   map[string]any{"type": "string"},
+				},
+			},
+		},
+		"publications": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"title":     map[string]any{"type": "string"},
+					"publisher": map[string]any{"type": "string"},
+					"date":      map[string]any{"type": "string"},
+				},
+			},
+		},
+		"awards": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"title":   map[string]any{"type": "string"},
+					"awarder": map[string]any{"type": "string"},
+					"date":    map[string]any{"type": "string"},
+				},
+			},
+		},
+		"languages": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"language": map[string]any{"type": "string"},
+					"fluency":  map[string]any{"type": "string"},
+				},
+			},
+		},
 	},
 	"required": []string{"resume", "summary", "experiences", "projects", "skills"},
 }
@@ -139,3 +219,31 @@ var CoverLetterSchema = map[string]any{
 var CohereCoverLetterSchemaFormat = cohere.JsonResponseFormatV2{
 	JsonSchema: CoverLetterSchema,
 }
+
+var CVSchema = map[string]any{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"type":    "object",
+	"properties": map[string]any{
+		"researchInterests": map[string]any{"type": "string"},
+		"entries": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"id":   map[string]any{"type": "string"},
+					"text": map[string]any{"type": "string"},
+					"bullets": map[string]any{
+						"type":  "array",
+						"items": map[string]any{"type": "string"},
+					},
+				},
+				"required": []string{"id"},
+			},
+		},
+	},
+	"required": []string{"entries"},
+}
+
+var CohereCVSchemaFormat = cohere.JsonResponseFormatV2{
+	JsonSchema: CVSchema,
+}
@@ -77,6 +77,72 @@ var GeminiResumeSchema = &genai.Schema{
 				},
 			},
 		},
+		"volunteering": {
+			Type: genai.TypeArray,
+			Items: &genai.Schema{
+				Type: genai.TypeObject,
+				Properties: map[string]*genai.Schema{
+					"organization": {Type: genai.TypeString},
+					"role":         {Type: genai.TypeString},
+					"start":        {Type: genai.TypeString},
+					"end":          {Type: genai.TypeString},
+					"bulletPoints": {
+						Type: genai.TypeArray,
+						Items: &genai.Schema{
+							Type: genai.TypeObject,
+							Properties: map[string]*genai.Schema{
+								"text":                     {Type: genai.TypeString},
+								"is_new_suggestion":        {Type: genai.TypeBoolean},
+								"justification_for_change": {Type: genai.TypeString},
+							},
+						},
+					},
+				},
+			},
+		},
+		"certifications": {
+			Type: genai.TypeArray,
+			Items: &genai.Schema{
+				Type: genai.TypeObject,
+				Properties: map[string]*genai.Schema{
+					"name":   {Type: genai.TypeString},
+					"issuer": {Type: genai.TypeString},
+					"date":   {Type: genai.TypeString},
+				},
+			},
+		},
+		"publications": {
+			Type: genai.TypeArray,
+			Items: &genai.Schema{
+				Type: genai.TypeObject,
+				Properties: map[string]*genai.Schema{
+					"title":     {Type: genai.TypeString},
+					"publisher": {Type: genai.TypeString},
+					"date":      {Type: genai.TypeString},
+				},
+			},
+		},
+		"awards": {
+			Type: genai.TypeArray,
+			Items: &genai.Schema{
+				Type: genai.TypeObject,
+				Properties: map[string]*genai.Schema{
+					"title":   {Type: genai.TypeString},
+					"awarder": {Type: genai.TypeString},
+					"date":    {Type: genai.TypeString},
+				},
+			},
+		},
+		"languages": {
+			Type: genai.TypeArray,
+			Items: &genai.Schema{
+				Type: genai.TypeObject,
+				Properties: map[string]*genai.Schema{
+					"language": {Type: genai.TypeString},
+					"fluency":  {Type: genai.TypeString},
+				},
+			},
+		},
 	},
 	Required: []string{"summary", "skills", "experiences", "projects"},
 }
@@ -99,3 +165,28 @@ var GeminiCoverLetterSchema = &genai.Schema{
 	},
 	Required: []string{"about", "experience", "whatIBring", "revisionSummary"},
 }
+
+var GeminiCVSchema = &genai.Schema{
+	Type: genai.TypeObject,
+	Properties: map[string]*genai.Schema{
+		"researchInterests": {
+			Type: genai.TypeString,
+		},
+		"entries": {
+			Type: genai.TypeArray,
+			Items: &genai.Schema{
+				Type: genai.TypeObject,
+				Properties: map[string]*genai.Schema{
+					"id":   {Type: genai.TypeString},
+					"text": {Type: genai.TypeString},
+					"bullets": {
+						Type:  genai.TypeArray,
+						Items: &genai.Schema{Type: genai.TypeString},
+					},
+				},
+				Required: []string{"id"},
+			},
+		},
+	},
+	Required: []string{"entries"},
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/utils/ats"
+)
+
+// AnalyzeResume scores the keyword coverage of a resume against the role's
+// job posting. It analyzes the given revision, or the latest one when
+// revisionID is nil.
+func (s *DocumentService) AnalyzeResume(ctx context.Context, roleID int, revisionID *int) (*domain.ATSReport, error) {
+	j, err := s.jobRepo.GetFullJobPosting(ctx, roleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job posting: %w", err)
+	}
+
+	resume, err := s.resumeForAnalysis(ctx, roleID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := ats.Analyze(resume, j)
+	return &report, nil
+}
+
+// resumeForAnalysis prefers revisions over the resume tables since only
+// revisions keep the summary.
+func (s *DocumentService) resumeForAnalysis(ctx context.Context, roleID int, revisionID *int) (*domain.Resume, error) {
+	if revisionID == nil {
+		revisions, err := s.resumeRepo.ListRevisions(ctx, roleID)
+		if err != nil {
+			return nil, err
+		}
+		if len(revisions) == 0 {
+			return s.resumeRepo.GetFullResume(ctx, roleID)
+		}
+		revisionID = &revisions[0].ID
+	}
+
+	revision, err := s.resumeRepo.GetRevision(ctx, roleID, *revisionID)
+	if err != nil {
+		return nil, err
+	}
+	return revision.Resume, nil
+}
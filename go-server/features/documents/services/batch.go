@@ -0,0 +1,235 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/models/requests"
+	"github.com/ordo_meritum/shared/contexts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+var (
+	// ErrBatchNotDone is returned when archiving a batch that still has
+	// generations running or waiting on review.
+	ErrBatchNotDone = errors.New("batch is not done yet")
+	// ErrBatchEmpty is returned when archiving a batch none of whose
+	// documents compiled.
+	ErrBatchEmpty = errors.New("batch has no compiled documents")
+)
+
+// BatchEvent is pushed over the websocket every time a generation in a
+// batch changes status.
+type BatchEvent struct {
+	Event  string        `json:"event"`
+	UserID string        `json:"user_id"`
+	Batch  *domain.Batch `json:"batch"`
+}
+
+// QueueBatch queues every requested document for every role. Each role's
+// documents go to the worker pool as one task, resume first, so its cover
+// letter is written from the freshly tailored resume. Roles the pool has no
+// room for are recorded as failed; the batch is only refused, and removed,
+// when none fit.
+func (s *DocumentService) QueueBatch(ctx context.Context, r requests.BatchRequest) (*domain.Batch, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+	l := logger.With().Str("uid", userCtx.UID).Logger()
+
+	s.applyDryRun(&r.Options.DocumentOptions)
+
+	var docTypes []string
+	for _, docType := range requests.BatchDocTypes {
+		if slices.Contains(r.Options.DocTypes, docType) {
+			docTypes = append(docTypes, docType)
+		}
+	}
+
+	roles := make([][]generationSpec, 0, len(r.Options.RoleIDs))
+	for _, roleID := range r.Options.RoleIDs {
+		specs := make([]generationSpec, 0, len(docTypes))
+		for _, docType := range docTypes {
+			request, err := batchDocumentRequest(r, roleID, docType)
+			if err != nil {
+				return nil, err
+			}
+			specs = append(specs, generationSpec{
+				roleID:   &roleID,
+				docType:  docType,
+				provider: r.Options.LlmProvider,
+				run: func(ctx context.Context, generationID int) {
+					s.generateDocument(ctx, generationID, request, docType)
+				},
+			})
+		}
+		roles = append(roles, specs)
+	}
+
+	batch, err := s.generationRepo.CreateBatch(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to create generation batch")
+		return nil, err
+	}
+	l = l.With().Int("batchID", batch.ID).Logger()
+
+	var firstErr error
+	queued := 0
+	for i, specs := range roles {
+		if _, err := s.enqueueGenerations(ctx, &batch.ID, specs); err != nil {
+			l.Warn().Err(err).Int("jobID", r.Options.RoleIDs[i]).Msg("Failed to queue batch role")
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		queued++
+	}
+	if queued == 0 {
+		// The failed rows are kept on their own; don't leave a batch
+		// behind with nothing queued in it.
+		if err := s.generationRepo.DeleteBatch(ctx, batch.ID); err != nil {
+			l.Error().Err(err).Msg("Failed to delete empty generation batch")
+		}
+		return nil, firstErr
+	}
+
+	l.Info().Int("roles", queued).Msg("Queued generation batch")
+	return s.GetBatch(ctx, batch.ID)
+}
+
+// GetBatch returns the batch with its generations and overall status.
+func (s *DocumentService) GetBatch(ctx context.Context, id int) (*domain.Batch, error) {
+	batch, err := s.getBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.signGenerations(ctx, batch.Generations)
+	return batch, nil
+}
+
+// getBatch is GetBatch with the generations' storage keys left as they are.
+func (s *DocumentService) getBatch(ctx context.Context, id int) (*domain.Batch, error) {
+	batch, err := s.generationRepo.GetBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	batch.Generations, err = s.generationRepo.List(ctx, domain.GenerationFilter{
+		BatchID: &id,
+		Limit:   requests.MaxBatchRoles * len(requests.BatchDocTypes),
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(batch.Generations)
+	batch.Summarize()
+	return batch, nil
+}
+
+// WriteBatchArchive writes a ZIP of every document in the batch that
+// compiled. Failed and cancelled documents are left out; the batch must have
+// nothing left running or waiting on review.
+func (s *DocumentService) WriteBatchArchive(ctx context.Context, id int, w io.Writer) error {
+	batch, err := s.getBatch(ctx, id)
+	if err != nil {
+		return err
+	}
+	if batch.Status == domain.BatchRunning || batch.Status == domain.BatchPendingReview {
+		return ErrBatchNotDone
+	}
+	if batch.Counts[domain.GenerationSucceeded] == 0 {
+		return ErrBatchEmpty
+	}
+
+	archive := zip.NewWriter(w)
+	companies := map[int]string{}
+	for _, g := range batch.Generations {
+		if g.Status != domain.GenerationSucceeded || g.DownloadURL == "" {
+			continue
+		}
+		name := fmt.Sprintf("%s.pdf", g.DocType)
+		if g.RoleID != nil {
+			company, ok := companies[*g.RoleID]
+			if !ok {
+				company = s.archiveCompanyName(ctx, *g.RoleID)
+				companies[*g.RoleID] = company
+			}
+			name = fmt.Sprintf("%s-%d-%s.pdf", company, *g.RoleID, g.DocType)
+		}
+		if err := s.addArchiveFile(ctx, archive, name, g.DownloadURL); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// archiveCompanyName returns the role's company name made safe to use in a
+// ZIP entry name: anything but letters, digits, '.', '_' and '-' becomes '-',
+// so a name can't add directories or climb out of the archive.
+func (s *DocumentService) archiveCompanyName(ctx context.Context, roleID int) string {
+	j, err := s.jobRepo.GetFullJobPosting(ctx, roleID)
+	if err != nil {
+		return "role"
+	}
+	return archiveSafeName(j.CompanyName)
+}
+
+func archiveSafeName(name string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '.', r == '_', r == '-':
+			return r
+		}
+		return '-'
+	}, name)
+	safe = strings.Trim(safe, ".-")
+	if safe == "" {
+		return "role"
+	}
+	return safe
+}
+
+func (s *DocumentService) addArchiveFile(ctx context.Context, archive *zip.Writer, name, key string) error {
+	f, err := s.store.Open(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer f.Close()
+
+	entry, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+	if _, err := io.Copy(entry, f); err != nil {
+		return fmt.Errorf("failed to write %s to archive: %w", name, err)
+	}
+	return nil
+}
+
+// batchDocumentRequest builds one role's request from the batch. Each gets
+// its own copy of the payload since generation edits it in place.
+func batchDocumentRequest(r requests.BatchRequest, roleID int, docType string) (requests.DocumentRequest, error) {
+	raw, err := json.Marshal(r.Payload)
+	if err != nil {
+		return requests.DocumentRequest{}, fmt.Errorf("failed to copy batch payload: %w", err)
+	}
+	var payload requests.DocumentPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return requests.DocumentRequest{}, fmt.Errorf("failed to copy batch payload: %w", err)
+	}
+
+	opts := r.Options.DocumentOptions
+	opts.JobID = roleID
+	opts.DocType = docType
+	opts.Corrections = slices.Clone(opts.Corrections)
+	return requests.DocumentRequest{Payload: payload, Options: opts}, nil
+}
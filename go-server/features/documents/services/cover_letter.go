@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ordo_meritum/database/candidate_forms"
+	"github.com/ordo_meritum/database/jobs"
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/models/requests"
+	"github.com/ordo_meritum/features/documents/utils/samples"
+	"github.com/ordo_meritum/shared/mappers"
+)
+
+// writingSampleBudget caps, in estimated tokens, how much of the user's
+// writing goes into a cover letter prompt.
+const writingSampleBudget = 1500
+
+// coverLetterLimits are the character limits for the About, Experience and
+// What I Bring sections at each length.
+var coverLetterLimits = map[string][3]int{
+	requests.LengthShort:    {250, 600, 450},
+	requests.LengthStandard: {400, 1000, 800},
+	requests.LengthLong:     {550, 1500, 1100},
+}
+
+// coverLetterVoice is what the prompt knows about how the user writes and
+// who they are. Style is nil when the user has no stored writing samples.
+type coverLetterVoice struct {
+	WritingSamples []string
+	Style          *domain.StyleProfile
+	Personality    string
+}
+
+// loadCoverLetterVoice gathers the user's writing samples, stored and sent
+// with the request, the style profile of the stored ones and their
+// personality profile. None is required, so failing to load one is logged
+// and the letter is written without it.
+func (s *DocumentService) loadCoverLetterVoice(
+	ctx context.Context,
+	uid string,
+	j *jobs.FullJobPosting,
+	opts requests.DocumentOptions,
+) coverLetterVoice {
+	var voice coverLetterVoice
+
+	var candidates []samples.Sample
+	stored, samplesErr := s.writingSampleRepo.GetByFirebaseUID(ctx, uid)
+	if samplesErr != nil {
+		logger.Warn().Err(samplesErr).Str("uid", uid).Msg("Failed to load writing samples")
+	}
+	for _, ws := range stored {
+		candidates = append(candidates, samples.Sample{Content: ws.Content, UpdatedAt: ws.UpdatedAt})
+	}
+	for _, content := range opts.WritingSamples {
+		candidates = append(candidates, samples.Sample{Content: content})
+	}
+	keywords := append(jobKeywords(j), strings.Fields(j.JobTitle)...)
+	voice.WritingSamples = samples.Select(candidates, keywords, writingSampleBudget)
+
+	if samplesErr == nil {
+		style, err := s.styleProfileFor(ctx, stored)
+		switch {
+		case errors.Is(err, ErrNoWritingSamples):
+		case err != nil:
+			logger.Warn().Err(err).Str("uid", uid).Msg("Failed to build writing style profile")
+		default:
+			voice.Style = style
+		}
+	}
+
+	ocean, disc, err := s.candidateFormRepo.GetPersonalityProfile(ctx, uid)
+	switch {
+	case errors.Is(err, candidate_forms.ErrUserNotFound):
+	case err != nil:
+		logger.Warn().Err(err).Str("uid", uid).Msg("Failed to load personality profile")
+	default:
+		summary := mappers.MapDBToDTO(*ocean, *disc)
+		voice.Personality = summary.FormatForLLM()
+	}
+
+	return voice
+}
+
+// coverLetterStyle is the tone, length and structure a cover letter is
+// written in, with defaults filled in.
+type coverLetterStyle struct {
+	Tone                 string
+	Structure            string
+	Paragraphs           int
+	ExperienceParagraphs int
+	AboutLimit           int
+	ExperienceLimit      int
+	WhatIBringLimit      int
+}
+
+func newCoverLetterStyle(opts requests.DocumentOptions) coverLetterStyle {
+	limits, ok := coverLetterLimits[opts.Length]
+	if !ok {
+		limits = coverLetterLimits[requests.LengthStandard]
+	}
+	style := coverLetterStyle{
+		Tone:            opts.Tone,
+		Structure:       opts.Structure,
+		Paragraphs:      opts.Paragraphs,
+		AboutLimit:      limits[0],
+		ExperienceLimit: limits[1],
+		WhatIBringLimit: limits[2],
+	}
+	if style.Structure == "" {
+		style.Structure = requests.StructureSections
+	}
+	if style.Paragraphs == 0 {
+		style.Paragraphs = requests.DefaultParagraphs
+	}
+	style.ExperienceParagraphs = style.Paragraphs - 2
+	return style
+}
+
+// formatWritingSamples wraps each sample in tags so the LLM can tell where
+// one ends and the next begins.
+func formatWritingSamples(list []string) string {
+	var builder strings.Builder
+	for _, sample := range list {
+		builder.WriteString("<sample>\n")
+		builder.WriteString(sample)
+		builder.WriteString("\n</sample>\n")
+	}
+	return builder.String()
+}
+
+func formatWritingStyle(style *domain.StyleProfile) string {
+	if style == nil {
+		return ""
+	}
+	return style.FormatForLLM()
+}
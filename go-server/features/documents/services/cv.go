@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/models/events"
+	"github.com/ordo_meritum/features/documents/models/mocks"
+	"github.com/ordo_meritum/features/documents/models/requests"
+	"github.com/ordo_meritum/features/documents/utils/cv"
+	"github.com/ordo_meritum/features/documents/utils/layout"
+	"github.com/ordo_meritum/shared/contexts"
+	schemaregistry "github.com/ordo_meritum/shared/libs/llm/schema_registry"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	error_messages "github.com/ordo_meritum/shared/utils/errors"
+	shared_formatters "github.com/ordo_meritum/shared/utils/formatters"
+)
+
+// QueueCVGeneration queues the user's academic record to be polished and
+// compiled as a CV. Nothing is tailored or trimmed, so no job posting is
+// needed; Options.JobID is passed through when the client sends one.
+func (s *DocumentService) QueueCVGeneration(
+	ctx context.Context,
+	requestBody requests.DocumentRequest,
+) (*QueueResult, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+	l := s.serviceLogger(userCtx.UID, requestBody.Options.JobID, "cv")
+	s.applyDryRun(&requestBody.Options)
+
+	var roleID *int
+	if requestBody.Options.JobID != 0 {
+		roleID = &requestBody.Options.JobID
+	}
+	result, err := s.enqueueGeneration(ctx, generationSpec{
+		roleID:   roleID,
+		docType:  "cv",
+		provider: requestBody.Options.LlmProvider,
+		run: func(ctx context.Context, generationID int) {
+			s.generateCV(ctx, generationID, requestBody)
+		},
+	})
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to queue cv generation")
+		return nil, err
+	}
+	l.Info().Int("generationID", result.GenerationID).Msg("Queued cv generation")
+	return result, nil
+}
+
+func (s *DocumentService) generateCV(ctx context.Context, generationID int, requestBody requests.DocumentRequest) {
+	userCtx, _ := contexts.FromContext(ctx)
+	l := s.serviceLogger(userCtx.UID, requestBody.Options.JobID, "cv").
+		With().Int("generationID", generationID).Logger()
+	l.Info().Msg("Starting cv generation process")
+
+	if opts := requestBody.Options; opts.UseProfile || opts.VariantID != nil {
+		if err := s.applyProfile(ctx, &requestBody); err != nil {
+			l.Error().Err(err).Msg("Failed to build payload from profile")
+			s.failGeneration(ctx, generationID, error_messages.ERR_DB_FAILED_TO_GET, err)
+			return
+		}
+	}
+	normalizePayloadDates(&requestBody.Payload, layout.Lookup(requestBody.Options.Template).Dates)
+
+	event, err := s.updateCVWithLLM(ctx, &requestBody)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to update cv with LLM")
+		s.failGeneration(ctx, generationID, error_messages.ERR_LLM_NO_CONTENT, err)
+		return
+	}
+	event.GenerationID = generationID
+
+	if err := s.publishGeneration(ctx, generationID, event.JobID, event, domain.GenerationUpdate{}); err != nil {
+		l.Error().Err(err).Msg("Error writing to Kafka")
+		return
+	}
+	l.Info().Msg("Successfully queued cv for compilation")
+}
+
+func (s *DocumentService) updateCVWithLLM(
+	ctx context.Context,
+	r *requests.DocumentRequest,
+) (*events.CVEvent, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	promptData, err := buildCVPromptData(&r.Payload, r.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	var polish domain.CVPolish
+	if r.Options.DryRun {
+		polish = mocks.CVPolish()
+	} else {
+		err = s.generateLLMContent(
+			ctx,
+			r.Options.LlmProvider,
+			"cv.txt",
+			promptData,
+			schemaregistry.CV,
+			&polish,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &events.CVEvent{
+		JobID:     r.Options.JobID,
+		UserId:    userCtx.UID,
+		DocType:   "cv",
+		UserInfo:  r.Payload.UserInfo,
+		Education: r.Payload.Educations(),
+		CV:        cv.Assemble(&r.Payload, &polish),
+	}, nil
+}
+
+func buildCVPromptData(payload *requests.DocumentPayload, opts requests.DocumentOptions) (map[string]any, error) {
+	additionalInfo := ""
+	if len(payload.AdditionalInfo) > 0 {
+		var err error
+		additionalInfo, err = shared_formatters.FormatAboutForLLMWithXML(payload.AdditionalInfo)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return map[string]any{
+		"CV":             cv.FormatForLLM(payload),
+		"AdditionalInfo": additionalInfo,
+		"Corrections":    strings.Join(opts.Corrections, "\n- "),
+	}, nil
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/models/requests"
+	"github.com/ordo_meritum/shared/libs/dates"
+)
+
+// normalizePayloadDates rewrites the date ranges the user sent in the
+// template's style, so the LLM, the fact checks and the compiled resume all
+// see "Jan. 2021 - Present" whether the user wrote "01/2021 - now" or
+// "Jan 2021 -". Dates that don't parse are left as written.
+func normalizePayloadDates(p *requests.DocumentPayload, style dates.Style) {
+	for i := range p.Resume.Experiences {
+		p.Resume.Experiences[i].Years = dates.Normalize(p.Resume.Experiences[i].Years, style)
+	}
+	for i := range p.Resume.Projects {
+		p.Resume.Projects[i].Years = dates.Normalize(p.Resume.Projects[i].Years, style)
+	}
+	for i := range p.Resume.Volunteering {
+		p.Resume.Volunteering[i].Years = dates.Normalize(p.Resume.Volunteering[i].Years, style)
+	}
+	for i := range p.CV.Teaching {
+		p.CV.Teaching[i].Years = dates.Normalize(p.CV.Teaching[i].Years, style)
+	}
+	for i := range p.CV.Committees {
+		p.CV.Committees[i].Years = dates.Normalize(p.CV.Committees[i].Years, style)
+	}
+	for i := range p.CV.Grants {
+		p.CV.Grants[i].Years = dates.Normalize(p.CV.Grants[i].Years, style)
+	}
+	p.EducationInfo.StartEnd = dates.Normalize(p.EducationInfo.StartEnd, style)
+	for i := range p.Education {
+		p.Education[i].StartEnd = dates.Normalize(p.Education[i].StartEnd, style)
+	}
+}
+
+// normalizeResumeDates does the same for the dates in a generated resume.
+func normalizeResumeDates(resume *domain.Resume, style dates.Style) {
+	for i := range resume.Experiences {
+		e := &resume.Experiences[i]
+		e.Start = dates.NormalizeDate(e.Start, style)
+		e.End = dates.NormalizeDate(e.End, style)
+	}
+	for i := range resume.Volunteering {
+		v := &resume.Volunteering[i]
+		v.Start = dates.NormalizeDate(v.Start, style)
+		v.End = dates.NormalizeDate(v.End, style)
+	}
+}
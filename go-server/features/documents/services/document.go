@@ -8,21 +8,35 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ordo_meritum/database/candidate_forms"
+	"github.com/ordo_meritum/database/generations"
 	"github.com/ordo_meritum/database/jobs"
+	"github.com/ordo_meritum/database/library"
+	"github.com/ordo_meritum/database/profiles"
 	"github.com/ordo_meritum/database/resumes"
+	"github.com/ordo_meritum/database/writingsamples"
+	"github.com/ordo_meritum/database/writingstyles"
 	apps_mappers "github.com/ordo_meritum/features/application_tracking/utils/mappers"
 	"github.com/ordo_meritum/features/documents/models/domain"
 	"github.com/ordo_meritum/features/documents/models/events"
+	"github.com/ordo_meritum/features/documents/models/mocks"
 	"github.com/ordo_meritum/features/documents/models/requests"
+	"github.com/ordo_meritum/features/documents/utils/ats"
 	"github.com/ordo_meritum/features/documents/utils/formatters"
+	"github.com/ordo_meritum/features/documents/utils/layout"
+	"github.com/ordo_meritum/features/documents/utils/verify"
 	"github.com/ordo_meritum/shared/contexts"
 	"github.com/ordo_meritum/shared/libs/llm"
 	schemaregistry "github.com/ordo_meritum/shared/libs/llm/schema_registry"
+	"github.com/ordo_meritum/shared/templates"
 	"github.com/ordo_meritum/shared/templates/instructions"
 	"github.com/ordo_meritum/shared/templates/prompts"
 	error_response "github.com/ordo_meritum/shared/types/errors"
 	error_messages "github.com/ordo_meritum/shared/utils/errors"
 	shared_formatters "github.com/ordo_meritum/shared/utils/formatters"
+	"github.com/ordo_meritum/storage"
+	"github.com/ordo_meritum/websocket"
+	"github.com/ordo_meritum/workers"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -34,84 +48,216 @@ var logger = log.With().
 	Logger()
 
 type DocumentService struct {
-	jobRepo     jobs.Repository
-	resumeRepo  resumes.Repository
-	LatexWriter *kafka.Writer
+	jobRepo        jobs.Repository
+	resumeRepo     resumes.Repository
+	profileRepo    profiles.Repository
+	generationRepo generations.Repository
+	libraryRepo    library.Repository
+	// writingSampleRepo and candidateFormRepo give cover letters the user's
+	// own voice.
+	writingSampleRepo writingsamples.Repository
+	candidateFormRepo candidate_forms.Repository
+	styleRepo         writingstyles.Repository
+	LatexWriter       *kafka.Writer
+	pool              *workers.Pool
+	hub               *websocket.Hub
+	store             storage.Store
+	signer            *storage.URLSigner
+	dryRun            bool
+
+	running *runningGenerations
 }
 
 func NewDocumentService(
 	jobRepo jobs.Repository,
 	resumeRepo resumes.Repository,
+	profileRepo profiles.Repository,
+	generationRepo generations.Repository,
+	libraryRepo library.Repository,
+	writingSampleRepo writingsamples.Repository,
+	candidateFormRepo candidate_forms.Repository,
+	styleRepo writingstyles.Repository,
 	latexWriter *kafka.Writer,
+	pool *workers.Pool,
+	hub *websocket.Hub,
+	store storage.Store,
+	signer *storage.URLSigner,
 ) *DocumentService {
 	return &DocumentService{
-		jobRepo:     jobRepo,
-		resumeRepo:  resumeRepo,
-		LatexWriter: latexWriter,
+		jobRepo:           jobRepo,
+		resumeRepo:        resumeRepo,
+		profileRepo:       profileRepo,
+		generationRepo:    generationRepo,
+		libraryRepo:       libraryRepo,
+		writingSampleRepo: writingSampleRepo,
+		candidateFormRepo: candidateFormRepo,
+		styleRepo:         styleRepo,
+		LatexWriter:       latexWriter,
+		pool:              pool,
+		hub:               hub,
+		store:             store,
+		signer:            signer,
+		dryRun:            dryRunFromEnv(),
+		running:           newRunningGenerations(),
 	}
 }
 
+// StatusProcessingQueued is reported once a document is on its way to the
+// compiler.
+const StatusProcessingQueued = "processing_queued"
+
+// QueueResult answers a generation request. JobID is the role the document
+// is for; GenerationID is the document_generations row tracking it, whose
+// progress is pushed over the websocket.
+type QueueResult struct {
+	JobID        int    `json:"jobId"`
+	GenerationID int    `json:"generationId,omitempty"`
+	Status       string `json:"status"`
+	RevisionID   int    `json:"revisionId,omitempty"`
+}
+
 func (s *DocumentService) QueueResumeGeneration(
 	ctx context.Context,
 	requestBody requests.DocumentRequest,
-) (int, error) {
+) (*QueueResult, error) {
 	return s.queueDocumentGeneration(ctx, requestBody, "resume")
 }
 
 func (s *DocumentService) QueueCoverLetterGeneration(
 	ctx context.Context,
 	requestBody requests.DocumentRequest,
-) (int, error) {
+) (*QueueResult, error) {
 	return s.queueDocumentGeneration(ctx, requestBody, "cover-letter")
 }
 
+// queueDocumentGeneration records the request and returns; the LLM call,
+// review and compilation happen on the worker pool.
 func (s *DocumentService) queueDocumentGeneration(
 	ctx context.Context,
 	requestBody requests.DocumentRequest,
 	docType string,
-) (int, error) {
+) (*QueueResult, error) {
 	userCtx, ok := contexts.FromContext(ctx)
 	if !ok {
-		return 0, error_response.ErrNoUserContext
+		return nil, error_response.ErrNoUserContext
 	}
 	l := s.serviceLogger(userCtx.UID, requestBody.Options.JobID, docType)
+	s.applyDryRun(&requestBody.Options)
+
+	roleID := requestBody.Options.JobID
+	result, err := s.enqueueGeneration(ctx, generationSpec{
+		roleID:   &roleID,
+		docType:  docType,
+		provider: requestBody.Options.LlmProvider,
+		run: func(ctx context.Context, generationID int) {
+			s.generateDocument(ctx, generationID, requestBody, docType)
+		},
+	})
+	if err != nil {
+		l.Error().Err(err).Msgf("Failed to queue %s generation", docType)
+		return nil, err
+	}
+	l.Info().Int("generationID", result.GenerationID).Msgf("Queued %s generation", docType)
+	return result, nil
+}
+
+func (s *DocumentService) generateDocument(
+	ctx context.Context,
+	generationID int,
+	requestBody requests.DocumentRequest,
+	docType string,
+) {
+	userCtx, _ := contexts.FromContext(ctx)
+	l := s.serviceLogger(userCtx.UID, requestBody.Options.JobID, docType).
+		With().Int("generationID", generationID).Logger()
 	l.Info().Msgf("Starting %s generation process", docType)
 
-	// MOCK
-	// kafkaRequest := mocks.GetMockDocumentEvent(uid, requestBody.Options.JobID, "cover-letter")
+	if opts := requestBody.Options; opts.UseProfile || opts.VariantID != nil || opts.AutoSelectVariant {
+		if err := s.applyProfile(ctx, &requestBody); err != nil {
+			l.Error().Err(err).Msg("Failed to build payload from profile")
+			s.failGeneration(ctx, generationID, error_messages.ERR_DB_FAILED_TO_GET, err)
+			return
+		}
+	}
+	normalizePayloadDates(&requestBody.Payload, layout.Lookup(requestBody.Options.Template).Dates)
+
 	var kafkaRequest *events.DocumentEvent
-	var err *error_messages.ErrorBody
+	var revisionID *int
+	var styleMatch *domain.StyleMatch
 	if docType == "resume" {
-		kafkaRequest, err = s.updateResumeWithLLM(ctx, &requestBody)
+		requestBody.Options.Corrections = append(
+			requestBody.Options.Corrections,
+			s.rejectedCorrections(ctx, requestBody.Options.JobID)...,
+		)
+
+		generated, errBody := s.updateResumeWithLLM(ctx, &requestBody)
+		if errBody != nil {
+			error_messages.ErrorLog(errBody.ErrCode, errBody.ErrMsg, l.Error())
+			s.failGeneration(ctx, generationID, errBody.ErrCode, errBody.ErrMsg)
+			return
+		}
+		kafkaRequest = generated.event
+		kafkaRequest.GenerationID = generationID
+
+		if !requestBody.Options.SkipReview {
+			review, err := s.openReview(ctx, kafkaRequest, generated.meta)
+			if err != nil {
+				l.Error().Err(err).Msg("Failed to open resume review")
+				s.failGeneration(ctx, generationID, error_messages.ERR_DB_FAILED_TO_UPSERT, err)
+				return
+			}
+			if review != nil {
+				err := s.advanceGeneration(ctx, generationID, domain.GenerationUpdate{
+					Status:     domain.GenerationPendingReview,
+					RevisionID: review.RevisionID,
+				})
+				if err != nil {
+					l.Warn().Err(err).Msg("Failed to mark generation as pending review")
+					return
+				}
+				l.Info().Msg("Resume is waiting on review")
+				return
+			}
+		}
+
+		id, err := s.resumeRepo.UpsertResume(ctx, kafkaRequest.JobID, &kafkaRequest.Resume, generated.education, generated.meta)
 		if err != nil {
-			error_messages.ErrorLog(err.ErrCode, err.ErrMsg, logger.Error())
-			return 0, err.ErrMsg
+			error_messages.ErrorLog(error_messages.ERR_DB_FAILED_TO_UPSERT, err, l.Error())
+			s.failGeneration(ctx, generationID, error_messages.ERR_DB_FAILED_TO_UPSERT, err)
+			return
 		}
+		revisionID = &id
 	} else {
 		currentResume, err := s.resumeRepo.GetFullResume(ctx, requestBody.Options.JobID)
 		if err != nil {
 			l.Error().Err(err).Msgf("Failed to update %s with LLM", docType)
-			return 0, err
+			s.failGeneration(ctx, generationID, error_messages.ERR_DB_FAILED_TO_GET, err)
+			return
 		}
-		kafkaRequest, err = s.updateCoverLetterWithLLM(ctx, &requestBody, currentResume)
+		generated, err := s.updateCoverLetterWithLLM(ctx, &requestBody, currentResume)
 		if err != nil {
 			l.Error().Err(err).Msgf("Failed to update %s with LLM", docType)
-			return 0, err
+			s.failGeneration(ctx, generationID, error_messages.ERR_LLM_NO_CONTENT, err)
+			return
 		}
+		kafkaRequest = generated.event
+		kafkaRequest.GenerationID = generationID
+		styleMatch = generated.styleMatch
 	}
 
-	if err := s.sendKafkaMessage(ctx, kafkaRequest); err != nil {
+	update := domain.GenerationUpdate{RevisionID: revisionID, StyleMatch: styleMatch}
+	if err := s.publishGeneration(ctx, generationID, kafkaRequest.JobID, kafkaRequest, update); err != nil {
 		l.Error().Err(err).Msg("Error writing to Kafka")
-		return 0, err
+		return
 	}
-
 	l.Info().Msgf("Successfully queued %s for compilation", docType)
-	return kafkaRequest.JobID, nil
 }
 
+// sendKafkaMessage queues an event for the LaTeX compiler, keyed by job.
 func (s *DocumentService) sendKafkaMessage(
 	ctx context.Context,
-	event *events.DocumentEvent,
+	jobID int,
+	event any,
 ) error {
 	messageBytes, err := json.Marshal(event)
 	if err != nil {
@@ -122,7 +268,7 @@ func (s *DocumentService) sendKafkaMessage(
 	defer cancel()
 
 	err = s.LatexWriter.WriteMessages(kafkaCtx, kafka.Message{
-		Key:   []byte(strconv.Itoa(event.JobID)),
+		Key:   []byte(strconv.Itoa(jobID)),
 		Value: messageBytes,
 	})
 	if err != nil {
@@ -131,10 +277,21 @@ func (s *DocumentService) sendKafkaMessage(
 	return nil
 }
 
+// generatedResume is the outcome of one LLM pass over a resume, not yet
+// stored. meta carries the report to store with its revision.
+type generatedResume struct {
+	event     *events.DocumentEvent
+	education []domain.EducationInfo
+	meta      domain.RevisionMeta
+}
+
+// updateResumeWithLLM tailors the resume to the job and checks the output
+// against the source data. The caller decides whether the result goes
+// straight to the resume or waits on review.
 func (s *DocumentService) updateResumeWithLLM(
 	ctx context.Context,
 	r *requests.DocumentRequest,
-) (*events.DocumentEvent, *error_messages.ErrorBody) {
+) (*generatedResume, *error_messages.ErrorBody) {
 
 	userCtx, ok := contexts.FromContext(ctx)
 	if !ok {
@@ -146,50 +303,91 @@ func (s *DocumentService) updateResumeWithLLM(
 		return nil, &error_messages.ErrorBody{ErrCode: error_messages.ERR_DB_FAILED_TO_GET, ErrMsg: err}
 	}
 
-	promptData, err := buildResumePromptData(j, &r.Payload)
+	promptData, err := buildResumePromptData(j, &r.Payload, r.Options)
 	if err != nil {
 		return nil, &error_messages.ErrorBody{ErrCode: error_messages.ERR_LLM_PROMPT_FORMATTING, ErrMsg: err}
 	}
 
-	e := r.Payload.EducationInfo
-	education, err := formatters.NewEducationInfoFromPayload(&e)
+	education, err := formatters.NewEducationListFromPayload(&r.Payload)
 	if err != nil {
 		return nil, &error_messages.ErrorBody{ErrCode: error_messages.ERR_INVALID_REQUEST_FORMAT, ErrMsg: err}
 	}
 
 	var llmResume domain.Resume
-	err = s.generateLLMContent(
-		ctx,
-		r.Options.LlmProvider,
-		"resume.txt",
-		promptData,
-		schemaregistry.Resume,
-		&llmResume,
-	)
-	if err != nil {
-		return nil, &error_messages.ErrorBody{ErrCode: error_messages.ERR_LLM_NO_CONTENT, ErrMsg: err}
-	}
-
-	if err := s.resumeRepo.UpsertResume(ctx, r.Options.JobID, &llmResume, education); err != nil {
-		return nil, &error_messages.ErrorBody{ErrCode: error_messages.ERR_DB_FAILED_TO_UPSERT, ErrMsg: err}
+	if r.Options.DryRun {
+		llmResume = mocks.Resume()
+	} else {
+		err = s.generateLLMContent(
+			ctx,
+			r.Options.LlmProvider,
+			"resume.txt",
+			promptData,
+			schemaregistry.Resume,
+			&llmResume,
+		)
+		if err != nil {
+			return nil, &error_messages.ErrorBody{ErrCode: error_messages.ERR_LLM_NO_CONTENT, ErrMsg: err}
+		}
 	}
 
-	return &events.DocumentEvent{
+	report := &domain.GenerationReport{
+		LockViolations: verify.EnforceLocks(&r.Payload, &llmResume),
+	}
+	if len(report.LockViolations) > 0 {
+		logger.Warn().
+			Str("uid", userCtx.UID).
+			Int("jobID", r.Options.JobID).
+			Int("violations", len(report.LockViolations)).
+			Msg("LLM changed locked resume content; restored original wording")
+	}
+	report.FactIssues = verify.CheckFacts(&r.Payload, &llmResume)
+	if len(report.FactIssues) > 0 {
+		logger.Warn().
+			Str("uid", userCtx.UID).
+			Int("jobID", r.Options.JobID).
+			Int("issues", len(report.FactIssues)).
+			Msg("LLM resume contains facts not found in the source data")
+	}
+	normalizeResumeDates(&llmResume, layout.Lookup(r.Options.Template).Dates)
+	if r.Options.TargetPages > 0 {
+		s.fitResume(ctx, r, j, &llmResume, education, report)
+	}
+	verify.MarkRewrites(&r.Payload, &llmResume)
+	coverage := ats.Analyze(&llmResume, j)
+	report.ATS = &coverage
+
+	meta := domain.RevisionMeta{
+		Author:        domain.AuthorLLM,
+		Provider:      r.Options.LlmProvider,
+		Model:         llm.ModelName(r.Options.LlmProvider),
+		PromptVersion: templates.Version("resume.txt"),
+		Report:        report,
+	}
+	event := &events.DocumentEvent{
 		JobID:         r.Options.JobID,
 		UserId:        userCtx.UID,
 		CompanyName:   j.CompanyName,
 		DocType:       "resume",
 		UserInfo:      r.Payload.UserInfo,
-		EducationInfo: r.Payload.EducationInfo,
+		EducationInfo: firstEducation(&r.Payload),
+		Education:     r.Payload.Educations(),
 		Resume:        llmResume,
-	}, nil
+	}
+	return &generatedResume{event: event, education: education, meta: meta}, nil
+}
+
+// generatedCoverLetter is a written cover letter ready for the compiler.
+// styleMatch is nil when the user has no writing style profile.
+type generatedCoverLetter struct {
+	event      *events.DocumentEvent
+	styleMatch *domain.StyleMatch
 }
 
 func (s *DocumentService) updateCoverLetterWithLLM(
 	ctx context.Context,
 	r *requests.DocumentRequest,
 	currentResume *domain.Resume,
-) (*events.DocumentEvent, error) {
+) (*generatedCoverLetter, error) {
 	userCtx, ok := contexts.FromContext(ctx)
 	if !ok {
 		return nil, error_response.ErrNoUserContext
@@ -202,32 +400,75 @@ func (s *DocumentService) updateCoverLetterWithLLM(
 		return nil, fmt.Errorf("GetFullJobPosting Failed %w", err)
 	}
 
+	voice := s.loadCoverLetterVoice(ctx, userCtx.UID, j, r.Options)
+
+	var llmCoverLetter domain.CoverLetterBody
+	if r.Options.DryRun {
+		llmCoverLetter = mocks.CoverLetter(j.CompanyProperName, j.JobTitle).Body
+	} else {
+		llmCoverLetter, err = s.writeCoverLetterBody(ctx, r, j, currentResume, voice)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	coverLetterPayload := domain.CoverLetter{
+		CompanyProperName: j.CompanyProperName,
+		JobTitle:          j.JobTitle,
+		Body:              llmCoverLetter,
+		Structure:         newCoverLetterStyle(r.Options).Structure,
+	}
+
+	load := events.DocumentEvent{
+		JobID:         jobID,
+		UserId:        userCtx.UID,
+		CompanyName:   j.CompanyName,
+		DocType:       "cover-letter",
+		UserInfo:      r.Payload.UserInfo,
+		EducationInfo: firstEducation(&r.Payload),
+		Education:     r.Payload.Educations(),
+		CoverLetter:   coverLetterPayload,
+	}
+	return &generatedCoverLetter{
+		event:      &load,
+		styleMatch: scoreCoverLetterStyle(voice.Style, llmCoverLetter),
+	}, nil
+}
+
+// writeCoverLetterBody has the LLM write the cover letter for the job.
+func (s *DocumentService) writeCoverLetterBody(
+	ctx context.Context,
+	r *requests.DocumentRequest,
+	j *jobs.FullJobPosting,
+	currentResume *domain.Resume,
+	voice coverLetterVoice,
+) (domain.CoverLetterBody, error) {
 	llmProvider, err := llm.GetProvider(r.Options.LlmProvider)
 	if err != nil {
-		return nil, fmt.Errorf("GetProvider Failed %w", err)
+		return domain.CoverLetterBody{}, fmt.Errorf("GetProvider Failed %w", err)
 	}
 
 	schema, err := schemaregistry.GetSchema(r.Options.LlmProvider, schemaregistry.Coverletter)
 	if err != nil {
 		error_messages.ErrorLog(error_messages.ERR_INVALID_SCHEMA, err, logger.Error())
-		return nil, fmt.Errorf("GetSchema Failed %w", err)
+		return domain.CoverLetterBody{}, fmt.Errorf("GetSchema Failed %w", err)
 	}
 
-	promptData, err := buildCoverLetterPromptData(j, &r.Payload, r.Options, currentResume)
+	promptData, err := buildCoverLetterPromptData(j, &r.Payload, r.Options, currentResume, voice)
 	if err != nil {
 		error_messages.ErrorLog(error_messages.ERR_LLM_PROMPT_FORMATTING, err, logger.Error())
-		return nil, fmt.Errorf("failed to build cover letter prompt data: %w", err)
+		return domain.CoverLetterBody{}, fmt.Errorf("failed to build cover letter prompt data: %w", err)
 	}
 	prompt, err := shared_formatters.FormatTemplate(prompts.Prompts, "coverletter.txt", promptData)
 	if err != nil {
 		error_messages.ErrorLog(error_messages.ERR_LLM_PROMPT_FORMATTING, err, logger.Error())
-		return nil, fmt.Errorf("failed to format prompt template: %w", err)
+		return domain.CoverLetterBody{}, fmt.Errorf("failed to format prompt template: %w", err)
 	}
 
 	instructions, err := instructions.Instructions.ReadFile("coverletter.txt")
 	if err != nil {
 		error_messages.ErrorLog(error_messages.ERR_LLM_INSTRUCTION_FORMATTING, err, logger.Error())
-		return nil, fmt.Errorf("failed to read instructions file: %w", err)
+		return domain.CoverLetterBody{}, fmt.Errorf("failed to read instructions file: %w", err)
 	}
 
 	rawResponse, err := llmProvider.Generate(
@@ -237,32 +478,16 @@ func (s *DocumentService) updateCoverLetterWithLLM(
 		schema,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("LLM generation failed: %w", err)
+		return domain.CoverLetterBody{}, fmt.Errorf("LLM generation failed: %w", err)
 	}
 
 	cleanedJSON := llm.FormatLLMResponse(rawResponse)
-	var llmCoverLetter domain.CoverLetterBody
-	if err := json.Unmarshal([]byte(cleanedJSON), &llmCoverLetter); err != nil {
+	var body domain.CoverLetterBody
+	if err := json.Unmarshal([]byte(cleanedJSON), &body); err != nil {
 		error_messages.ErrorLog(error_messages.ERR_LLM_MALFORMED_RESPONSE, err, logger.Error())
-		return nil, fmt.Errorf("failed to unmarshal LLM resume response: %w. Raw response: %s", err, rawResponse)
-	}
-
-	coverLetterPayload := domain.CoverLetter{
-		CompanyProperName: j.CompanyProperName,
-		JobTitle:          j.JobTitle,
-		Body:              llmCoverLetter,
+		return domain.CoverLetterBody{}, fmt.Errorf("failed to unmarshal LLM resume response: %w. Raw response: %s", err, rawResponse)
 	}
-
-	load := events.DocumentEvent{
-		JobID:         jobID,
-		UserId:        userCtx.UID,
-		CompanyName:   j.CompanyName,
-		DocType:       "cover-letter",
-		UserInfo:      r.Payload.UserInfo,
-		EducationInfo: r.Payload.EducationInfo,
-		CoverLetter:   coverLetterPayload,
-	}
-	return &load, nil
+	return body, nil
 }
 
 func (s *DocumentService) generateLLMContent(
@@ -311,19 +536,31 @@ func (s *DocumentService) generateLLMContent(
 func buildResumePromptData(
 	j *jobs.FullJobPosting,
 	payload *requests.DocumentPayload,
+	opts requests.DocumentOptions,
 ) (map[string]any, error) {
-	additionalInfo, err := shared_formatters.FormatAboutForLLMWithXML(payload.AdditionalInfo)
-	if err != nil {
-		return nil, err
+	additionalInfo := ""
+	if len(payload.AdditionalInfo) > 0 {
+		var err error
+		additionalInfo, err = shared_formatters.FormatAboutForLLMWithXML(payload.AdditionalInfo)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return map[string]any{
 		"JobPost":        shared_formatters.FormatJobPostForLLM(*j),
 		"Resume":         formatters.FormatResumeRequestForLLMWithXML(payload),
 		"AdditionalInfo": additionalInfo,
+		"Corrections":    strings.Join(opts.Corrections, "\n- "),
 	}, nil
 }
 
-func buildCoverLetterPromptData(j *jobs.FullJobPosting, payload *requests.DocumentPayload, opts requests.DocumentOptions, resume *domain.Resume) (map[string]any, error) {
+func buildCoverLetterPromptData(
+	j *jobs.FullJobPosting,
+	payload *requests.DocumentPayload,
+	opts requests.DocumentOptions,
+	resume *domain.Resume,
+	voice coverLetterVoice,
+) (map[string]any, error) {
 	additionalInfo := ""
 	var err error
 	if payload.AdditionalInfo != nil {
@@ -336,14 +573,34 @@ func buildCoverLetterPromptData(j *jobs.FullJobPosting, payload *requests.Docume
 	jobPost := apps_mappers.NewJobDescriptionFromPost(j)
 	return map[string]any{
 		"JobPost":        jobPost.FormatForLLM(),
-		"Education":      payload.EducationInfo.FormatForLLM(),
+		"Education":      formatEducations(payload),
 		"Resume":         resume.FormatForLLM(),
 		"AdditionalInfo": additionalInfo,
 		"Corrections":    strings.Join(opts.Corrections, "\n- "),
-		"WritingSamples": strings.Join(opts.WritingSamples, "\n- "),
+		"WritingSamples": formatWritingSamples(voice.WritingSamples),
+		"WritingStyle":   formatWritingStyle(voice.Style),
+		"Personality":    voice.Personality,
+		"Style":          newCoverLetterStyle(opts),
 	}, nil
 }
 
+// firstEducation is the school sent in an event's single-school field.
+func firstEducation(payload *requests.DocumentPayload) requests.EducationInfoPayload {
+	if schools := payload.Educations(); len(schools) > 0 {
+		return schools[0]
+	}
+	return requests.EducationInfoPayload{}
+}
+
+func formatEducations(payload *requests.DocumentPayload) string {
+	schools := payload.Educations()
+	parts := make([]string, 0, len(schools))
+	for i := range schools {
+		parts = append(parts, schools[i].FormatForLLM())
+	}
+	return strings.Join(parts, "\n")
+}
+
 func (s *DocumentService) serviceLogger(
 	uid string,
 	jobID int,
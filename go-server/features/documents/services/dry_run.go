@@ -0,0 +1,40 @@
+package services
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/ordo_meritum/features/documents/models/mocks"
+	"github.com/ordo_meritum/features/documents/models/requests"
+)
+
+// dryRunFromEnv reads DOCUMENTS_DRY_RUN, which makes every generation a dry
+// run. It is meant for CI and for frontend work against a server with no
+// LLM access.
+func dryRunFromEnv() bool {
+	v := os.Getenv("DOCUMENTS_DRY_RUN")
+	if v == "" {
+		return false
+	}
+	on, err := strconv.ParseBool(v)
+	if err != nil {
+		logger.Warn().Str("value", v).Msg("Ignoring invalid DOCUMENTS_DRY_RUN")
+		return false
+	}
+	if on {
+		logger.Warn().Msg("Dry-run mode is on; documents are written from canned content")
+	}
+	return on
+}
+
+// applyDryRun turns opts into a dry run when the server is forced into one,
+// and records a dry run's provider as mocks.Provider. The secure router
+// still wants an API key header, but a dry run never uses it.
+func (s *DocumentService) applyDryRun(opts *requests.DocumentOptions) {
+	if s.dryRun {
+		opts.DryRun = true
+	}
+	if opts.DryRun {
+		opts.LlmProvider = mocks.Provider
+	}
+}
@@ -0,0 +1,344 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ordo_meritum/database/generations"
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/libs/llm"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	error_messages "github.com/ordo_meritum/shared/utils/errors"
+	"go.uber.org/fx"
+)
+
+// runningGenerations holds the cancel funcs of the generations this instance
+// is working on, so a cancel request can abort the LLM call.
+type runningGenerations struct {
+	mu      sync.Mutex
+	cancels map[int]context.CancelFunc
+}
+
+func newRunningGenerations() *runningGenerations {
+	return &runningGenerations{cancels: make(map[int]context.CancelFunc)}
+}
+
+// track derives a cancellable context for generation id. The returned func
+// must be called once the generation stops running.
+func (r *runningGenerations) track(ctx context.Context, id int) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	r.mu.Lock()
+	r.cancels[id] = cancel
+	r.mu.Unlock()
+	return ctx, func() {
+		r.mu.Lock()
+		delete(r.cancels, id)
+		r.mu.Unlock()
+		cancel()
+	}
+}
+
+func (r *runningGenerations) cancel(id int) {
+	r.mu.Lock()
+	cancel, ok := r.cancels[id]
+	r.mu.Unlock()
+	if ok {
+		cancel()
+	}
+}
+
+const (
+	// staleGenerationAfter is how long a queued or generating row can go
+	// untouched before it is treated as abandoned by a crashed instance.
+	staleGenerationAfter = 30 * time.Minute
+	// staleCompilationAfter is how long a compiling row waits on the
+	// compiler's result before it is failed. Compiling takes seconds, so
+	// this is much shorter than the LLM timeout.
+	staleCompilationAfter = 10 * time.Minute
+	// staleSweepInterval is how often the sweep runs.
+	staleSweepInterval = 5 * time.Minute
+)
+
+// GenerationEvent is pushed over the websocket every time a generation
+// changes status.
+type GenerationEvent struct {
+	Event      string             `json:"event"`
+	UserID     string             `json:"user_id"`
+	Generation *domain.Generation `json:"generation"`
+}
+
+func (s *DocumentService) GetGeneration(ctx context.Context, id int) (*domain.Generation, error) {
+	g, err := s.generationRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &s.signGenerations(ctx, []domain.Generation{*g})[0], nil
+}
+
+func (s *DocumentService) ListGenerations(ctx context.Context, filter domain.GenerationFilter) ([]domain.Generation, error) {
+	list, err := s.generationRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.signGenerations(ctx, list), nil
+}
+
+// CancelGeneration marks a generation cancelled and aborts its LLM call if
+// it is running. A queued generation is skipped when its turn comes. A
+// document already handed to the compiler is still compiled, but its result
+// is no longer recorded.
+func (s *DocumentService) CancelGeneration(ctx context.Context, id int) (*domain.Generation, error) {
+	g, err := s.updateGeneration(ctx, id, domain.GenerationUpdate{Status: domain.GenerationCancelled})
+	if err != nil {
+		return nil, err
+	}
+	s.running.cancel(id)
+	return &s.signGenerations(ctx, []domain.Generation{*g})[0], nil
+}
+
+// CompleteGeneration records the compiler's result for a generation. The
+// update's URLs are storage keys, as returned by StoreCompiledDocument.
+func (s *DocumentService) CompleteGeneration(ctx context.Context, id int, update domain.GenerationUpdate) error {
+	_, err := s.updateGeneration(ctx, id, update)
+	if errors.Is(err, generations.ErrGenerationFinished) {
+		return nil
+	}
+	return err
+}
+
+// RegisterGenerationRecovery periodically fails the generations nothing
+// will finish: queued or generating rows a crashed instance left behind,
+// whose requests only lived in its memory along with the user's API key, and
+// compiling rows whose result never came back. It runs at startup and then
+// every staleSweepInterval until shutdown.
+func RegisterGenerationRecovery(lc fx.Lifecycle, s *DocumentService) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				ticker := time.NewTicker(staleSweepInterval)
+				defer ticker.Stop()
+				for {
+					s.failStaleGenerations(ctx)
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
+
+func (s *DocumentService) failStaleGenerations(ctx context.Context) {
+	sweeps := []struct {
+		statuses []domain.GenerationStatus
+		after    time.Duration
+		code     string
+	}{
+		{
+			[]domain.GenerationStatus{domain.GenerationQueued, domain.GenerationGenerating},
+			staleGenerationAfter,
+			error_messages.ERR_WORKER_INTERRUPTED,
+		},
+		{
+			[]domain.GenerationStatus{domain.GenerationCompiling},
+			staleCompilationAfter,
+			error_messages.ERR_COMPILATION_TIMEOUT,
+		},
+	}
+	for _, sweep := range sweeps {
+		n, err := s.generationRepo.FailStale(
+			ctx,
+			sweep.statuses,
+			time.Now().Add(-sweep.after),
+			sweep.code,
+			error_messages.ErrorMessage(sweep.code).Error(),
+		)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Error().Err(err).Str("code", sweep.code).Msg("Failed to clean up stale generations")
+			}
+			continue
+		}
+		if n > 0 {
+			logger.Warn().Int64("count", n).Str("code", sweep.code).Msg("Failed stale generations")
+		}
+	}
+}
+
+// generationSpec is one document to generate. run does the work once the
+// generation's turn comes, with the generation's id and a context carrying
+// the request's user, which CancelGeneration cancels.
+type generationSpec struct {
+	roleID   *int
+	docType  string
+	provider string
+	run      func(ctx context.Context, id int)
+}
+
+// enqueueGeneration records a queued generation and hands it to the worker
+// pool.
+func (s *DocumentService) enqueueGeneration(ctx context.Context, spec generationSpec) (*QueueResult, error) {
+	ids, err := s.enqueueGenerations(ctx, nil, []generationSpec{spec})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &QueueResult{GenerationID: ids[0], Status: string(domain.GenerationQueued)}
+	if spec.roleID != nil {
+		result.JobID = *spec.roleID
+	}
+	return result, nil
+}
+
+// enqueueGenerations records specs as queued generations and hands them to
+// the worker pool as one task, so they run one after another in order.
+func (s *DocumentService) enqueueGenerations(
+	ctx context.Context,
+	batchID *int,
+	specs []generationSpec,
+) ([]int, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+
+	ids := make([]int, 0, len(specs))
+	failAll := func(code string, cause error) {
+		for _, id := range ids {
+			s.failGeneration(ctx, id, code, cause)
+		}
+	}
+	for _, spec := range specs {
+		id, err := s.generationRepo.Create(ctx, &domain.Generation{
+			RoleID:   spec.roleID,
+			DocType:  spec.docType,
+			Status:   domain.GenerationQueued,
+			Provider: spec.provider,
+			Model:    llm.ModelName(spec.provider),
+			BatchID:  batchID,
+		})
+		if err != nil {
+			failAll(error_messages.ERR_DB_FAILED_TO_INSERT, err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	err := s.pool.Submit(ctx, userCtx.UID, func(ctx context.Context) {
+		for i, spec := range specs {
+			s.runGeneration(ctx, ids[i], spec.run)
+		}
+	})
+	if err != nil {
+		failAll(error_messages.ERR_QUEUE_FULL, err)
+		return nil, err
+	}
+	return ids, nil
+}
+
+// runGeneration marks a queued generation as generating and runs it. A
+// generation cancelled while it waited is skipped.
+func (s *DocumentService) runGeneration(ctx context.Context, id int, run func(ctx context.Context, id int)) {
+	if ctx.Err() != nil {
+		s.failGeneration(ctx, id, error_messages.ERR_WORKER_INTERRUPTED, ctx.Err())
+		return
+	}
+	if err := s.advanceGeneration(ctx, id, domain.GenerationUpdate{Status: domain.GenerationGenerating}); err != nil {
+		if !errors.Is(err, generations.ErrGenerationFinished) {
+			logger.Error().Err(err).Int("generationID", id).Msg("Failed to start generation")
+		}
+		return
+	}
+	genCtx, done := s.running.track(ctx, id)
+	defer done()
+	run(genCtx, id)
+}
+
+// advanceGeneration moves a running generation on. It returns
+// ErrGenerationFinished when the generation was cancelled in the meantime,
+// in which case the caller must stop.
+func (s *DocumentService) advanceGeneration(ctx context.Context, id int, update domain.GenerationUpdate) error {
+	_, err := s.updateGeneration(context.WithoutCancel(ctx), id, update)
+	return err
+}
+
+// publishGeneration hands a generated document to the compiler. The
+// generation is marked compiling first, along with anything else in update,
+// so one cancelled in the meantime is never sent.
+func (s *DocumentService) publishGeneration(ctx context.Context, id int, jobID int, event any, update domain.GenerationUpdate) error {
+	update.Status = domain.GenerationCompiling
+	if err := s.advanceGeneration(ctx, id, update); err != nil {
+		return err
+	}
+	if err := s.sendKafkaMessage(ctx, jobID, event); err != nil {
+		s.failGeneration(ctx, id, error_messages.ERR_KAFKA_FAILED_TO_PUBLISH, err)
+		return err
+	}
+	return nil
+}
+
+// failGeneration records why a generation stopped. A generation stopped by
+// CancelGeneration is already finished, so it stays cancelled.
+func (s *DocumentService) failGeneration(ctx context.Context, id int, code string, cause error) {
+	update := domain.GenerationUpdate{Status: domain.GenerationFailed, ErrorCode: code}
+	if cause != nil {
+		update.Error = cause.Error()
+	}
+	if err := s.advanceGeneration(ctx, id, update); err != nil && !errors.Is(err, generations.ErrGenerationFinished) {
+		logger.Error().Err(err).Int("generationID", id).Msg("Failed to record generation failure")
+	}
+}
+
+// updateGeneration writes update and tells the user's open connections.
+func (s *DocumentService) updateGeneration(
+	ctx context.Context,
+	id int,
+	update domain.GenerationUpdate,
+) (*domain.Generation, error) {
+	g, err := s.generationRepo.Update(ctx, id, update)
+	if err != nil {
+		return nil, err
+	}
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return g, nil
+	}
+	signed := s.signGeneration(userCtx.UID, *g)
+	s.notify(userCtx.UID, GenerationEvent{Event: "generation", UserID: userCtx.UID, Generation: &signed})
+	if g.BatchID != nil {
+		if batch, err := s.GetBatch(ctx, *g.BatchID); err != nil {
+			logger.Error().Err(err).Int("batchID", *g.BatchID).Msg("Failed to summarize batch")
+		} else {
+			s.notify(userCtx.UID, BatchEvent{Event: "batch", UserID: userCtx.UID, Batch: batch})
+		}
+	}
+	return g, nil
+}
+
+// notify pushes event to the user's open connections.
+func (s *DocumentService) notify(uid string, event any) {
+	msg, err := json.Marshal(event)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to marshal websocket event")
+		return
+	}
+	s.hub.SendToUser(uid, msg)
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ordo_meritum/database/profiles"
+	"github.com/ordo_meritum/features/documents/utils/jsonresume"
+	profile_mappers "github.com/ordo_meritum/features/profiles/utils/mappers"
+	"github.com/ordo_meritum/shared/contexts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+// ExportJSONResume returns the tailored resume stored for a role in JSON
+// Resume format, with the contact details from the user's profile so the
+// file validates and can be imported again. It returns
+// profiles.ErrProfileNotFound when the user has no profile.
+func (s *DocumentService) ExportJSONResume(
+	ctx context.Context,
+	roleID int,
+) (*jsonresume.Resume, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+	l := s.serviceLogger(userCtx.UID, roleID, "json-resume")
+
+	resume, err := s.resumeRepo.GetFullResume(ctx, roleID)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to get resume for export")
+		return nil, fmt.Errorf("failed to get resume: %w", err)
+	}
+
+	education, err := s.resumeRepo.GetEducation(ctx, roleID)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to get education for export")
+		return nil, fmt.Errorf("failed to get education: %w", err)
+	}
+
+	profile, err := s.profileRepo.GetProfile(ctx)
+	if err != nil {
+		if !errors.Is(err, profiles.ErrProfileNotFound) {
+			l.Error().Err(err).Msg("Failed to get profile for export")
+		}
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+	userInfo := profile_mappers.ToUserInfoPayload(&profile.Contact)
+
+	return jsonresume.FromDomainResume(resume, &userInfo, education), nil
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ordo_meritum/database/profiles"
+	"github.com/ordo_meritum/database/resumes"
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/models/requests"
+	"github.com/ordo_meritum/features/documents/utils/jsonresume"
+	profile_domain "github.com/ordo_meritum/features/profiles/models/domain"
+	"github.com/ordo_meritum/shared/contexts"
+)
+
+// exportResumeRepo serves one role's resume and education; every other
+// method panics through the nil embedded interface.
+type exportResumeRepo struct {
+	resumes.Repository
+	resume    *domain.Resume
+	education []domain.EducationInfo
+}
+
+func (r *exportResumeRepo) GetFullResume(context.Context, int) (*domain.Resume, error) {
+	return r.resume, nil
+}
+
+func (r *exportResumeRepo) GetEducation(context.Context, int) ([]domain.EducationInfo, error) {
+	return r.education, nil
+}
+
+type exportProfileRepo struct {
+	profiles.Repository
+	profile *profile_domain.Profile
+}
+
+func (r *exportProfileRepo) GetProfile(context.Context) (*profile_domain.Profile, error) {
+	if r.profile == nil {
+		return nil, profiles.ErrProfileNotFound
+	}
+	return r.profile, nil
+}
+
+func TestExportJSONResumeRoundTrip(t *testing.T) {
+	coursework := "Algorithms, Databases"
+	resume := &domain.Resume{
+		Summary: []domain.SummaryBody{{Sentence: "Backend engineer."}, {Sentence: "Ships Go services."}},
+		Skills:  []domain.Skills{{Category: "Languages", SkillItem: []string{"Go", "SQL"}}},
+		Experiences: []domain.Experience{{
+			ID: "exp-1", Company: "Acme", Position: "Engineer", Start: "Jan. 2021", End: "Present",
+			BulletPoints: []domain.BulletPoint{{Text: "Built billing."}},
+		}},
+		Projects: []domain.Project{{
+			ID: "proj-1", Name: "ordo", Role: "Maintainer",
+			BulletPoints: []domain.BulletPoint{{Text: "Parses resumes."}},
+		}},
+		Certifications: []domain.Certification{{ID: "cert-1", Name: "CKA", Issuer: "CNCF", Date: "Mar. 2022"}},
+		Publications:   []domain.Publication{{ID: "pub-1", Title: "Parsing", Publisher: "ACM", Date: "2019"}},
+		Volunteering: []domain.Volunteering{{
+			ID: "vol-1", Organization: "Code Club", Role: "Mentor", Start: "2017", End: "2019",
+			BulletPoints: []domain.BulletPoint{{Text: "Mentored students."}},
+		}},
+		Awards:    []domain.Award{{ID: "award-1", Title: "Hackathon", Awarder: "HackTO", Date: "Nov. 2016"}},
+		Languages: []domain.Language{{ID: "lang-1", Language: "French", Fluency: "Professional"}},
+	}
+	education := []domain.EducationInfo{{
+		School: "University of Toronto", Degree: "BSc", Location: "Toronto, ON",
+		StartEnd: "Sep. 2014 - Apr. 2018", CourseWork: &coursework,
+	}}
+	contact := profile_domain.Contact{
+		FirstName: "Mary Ann", LastName: "Smith", CurrentLocation: "Toronto, ON",
+		Email: "mary@example.com", Github: "https://github.com/maryann", Mobile: "+1 555 0100",
+	}
+
+	s := &DocumentService{
+		resumeRepo:  &exportResumeRepo{resume: resume, education: education},
+		profileRepo: &exportProfileRepo{profile: &profile_domain.Profile{Contact: contact}},
+	}
+	ctx := context.WithValue(context.Background(), contexts.UserContextKey, &contexts.UserContext{UID: "uid"})
+
+	exported, err := s.ExportJSONResume(ctx, 1)
+	if err != nil {
+		t.Fatalf("ExportJSONResume: %v", err)
+	}
+	raw, err := json.Marshal(exported)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var file jsonresume.Resume
+	if err := json.Unmarshal(raw, &file); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if details := file.Validate(); len(details) > 0 {
+		t.Fatalf("exported resume fails validation: %+v", details)
+	}
+
+	got := file.ToDocumentPayload()
+	want := requests.DocumentPayload{
+		UserInfo: requests.UserInfoPayload{
+			FirstName: "Mary Ann", LastName: "Smith", CurrentLocation: "Toronto, ON",
+			Email: "mary@example.com", Github: "https://github.com/maryann", Mobile: "+1 555 0100",
+			Summary: "Backend engineer. Ships Go services.",
+		},
+		Resume: requests.ResumePayload{
+			Skills: []requests.SkillsPayload{{Skill: "Go"}, {Skill: "SQL"}},
+			Experiences: []requests.ExperiencePayload{{
+				ID: "exp-1", Company: "Acme", Position: "Engineer", Years: "Jan. 2021 - Present",
+				BulletPoints: []string{"Built billing."},
+			}},
+			Projects: []requests.ProjectPayload{{
+				ID: "proj-1", Name: "ordo", Description: "Maintainer",
+				BulletPoints: []string{"Parses resumes."},
+			}},
+			Certifications: []requests.CertificationPayload{{ID: "cert-1", Name: "CKA", Issuer: "CNCF", Date: "Mar. 2022"}},
+			Publications:   []requests.PublicationPayload{{ID: "pub-1", Title: "Parsing", Publisher: "ACM", Date: "2019"}},
+			Volunteering: []requests.VolunteeringPayload{{
+				ID: "vol-1", Organization: "Code Club", Role: "Mentor", Years: "2017 - 2019",
+				BulletPoints: []string{"Mentored students."},
+			}},
+			Awards:    []requests.AwardPayload{{ID: "award-1", Title: "Hackathon", Awarder: "HackTO", Date: "Nov. 2016"}},
+			Languages: []requests.LanguagePayload{{ID: "lang-1", Language: "French", Fluency: "Professional"}},
+		},
+	}
+	want.Education = []requests.EducationInfoPayload{{
+		School: "University of Toronto", Degree: "BSc", Location: "Toronto, ON",
+		StartEnd: "Sep. 2014 - Apr. 2018", CourseWork: &coursework,
+	}}
+	want.EducationInfo = want.Education[0]
+
+	if !reflect.DeepEqual(got, want) {
+		gotJSON, _ := json.MarshalIndent(got, "", "  ")
+		wantJSON, _ := json.MarshalIndent(want, "", "  ")
+		t.Errorf("round trip changed the resume\ngot:  %s\nwant: %s", gotJSON, wantJSON)
+	}
+}
+
+func TestExportJSONResumeNeedsProfile(t *testing.T) {
+	s := &DocumentService{
+		resumeRepo:  &exportResumeRepo{resume: &domain.Resume{}},
+		profileRepo: &exportProfileRepo{},
+	}
+	ctx := context.WithValue(context.Background(), contexts.UserContextKey, &contexts.UserContext{UID: "uid"})
+	if _, err := s.ExportJSONResume(ctx, 1); !errors.Is(err, profiles.ErrProfileNotFound) {
+		t.Errorf("error = %v, want ErrProfileNotFound", err)
+	}
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ordo_meritum/database/jobs"
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/models/requests"
+	"github.com/ordo_meritum/features/documents/utils/latex"
+	"github.com/ordo_meritum/features/documents/utils/layout"
+	"github.com/ordo_meritum/features/documents/utils/verify"
+	schemaregistry "github.com/ordo_meritum/shared/libs/llm/schema_registry"
+	shared_formatters "github.com/ordo_meritum/shared/utils/formatters"
+)
+
+// maxCondensePasses caps the LLM rounds auto-fit spends condensing before it
+// falls back to trimming.
+const maxCondensePasses = 2
+
+// maxCompileChecks caps how often auto-fit compiles the resume to check the
+// estimate against the real page count.
+const maxCompileChecks = 3
+
+// EstimateLayout predicts how many lines and pages a resume takes up in the
+// named template. It estimates the given revision, or the latest one when
+// revisionID is nil.
+func (s *DocumentService) EstimateLayout(
+	ctx context.Context,
+	roleID int,
+	revisionID *int,
+	template string,
+) (*domain.LayoutEstimate, error) {
+	resume, err := s.resumeForAnalysis(ctx, roleID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	education, err := s.resumeRepo.GetEducation(ctx, roleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get education: %w", err)
+	}
+
+	est := layout.Estimate(layout.Lookup(template), resume, education)
+	return &est, nil
+}
+
+// fitResume shortens a generated resume until it is estimated to fit in the
+// requested number of pages. With FitCondense the LLM tightens the bullets
+// first; whatever still runs long is trimmed, lowest-priority bullets first
+// and locked bullets never. When the in-process compiler is available, the
+// compiled page count has the final word.
+func (s *DocumentService) fitResume(
+	ctx context.Context,
+	r *requests.DocumentRequest,
+	j *jobs.FullJobPosting,
+	resume *domain.Resume,
+	education []domain.EducationInfo,
+	report *domain.GenerationReport,
+) {
+	opts := r.Options
+	t := layout.Lookup(opts.Template)
+	maxLines := opts.TargetPages * t.LinesPerPage
+	fit := &domain.FitReport{
+		TargetPages: opts.TargetPages,
+		Before:      layout.Estimate(t, resume, education),
+	}
+	report.Layout = fit
+
+	// A dry run has no LLM to condense with, so it only trims.
+	if opts.FitStrategy == requests.FitCondense && !opts.DryRun {
+		for pass := 0; pass < maxCondensePasses; pass++ {
+			overflow := layout.Estimate(t, resume, education).Lines - maxLines
+			if overflow <= 0 {
+				break
+			}
+			condensed, err := s.condenseResume(ctx, r, j, resume, t, overflow)
+			if err != nil {
+				logger.Warn().Err(err).Int("jobID", opts.JobID).Msg("Failed to condense resume; trimming instead")
+				break
+			}
+			report.LockViolations = append(report.LockViolations, verify.EnforceLocks(&r.Payload, condensed)...)
+			report.FactIssues = append(report.FactIssues, verify.CheckFacts(&r.Payload, condensed)...)
+			*resume = *condensed
+			fit.CondensePasses++
+		}
+	}
+
+	trim := layout.TrimOptions{
+		MaxLines: maxLines,
+		Keywords: jobKeywords(j),
+		Keep:     verify.LockedBullets(&r.Payload),
+	}
+	fit.Trimmed = layout.Trim(t, resume, education, trim)
+
+	if dir, ok := latex.InProcessTemplateDir(); ok {
+		user := domain.UserInfo(r.Payload.UserInfo)
+		for check := 1; ; check++ {
+			pdf, err := latex.CompileDocument(dir, latex.ResumeDocument(user, education, resume))
+			if err != nil {
+				logger.Warn().Err(err).Int("jobID", opts.JobID).Msg("Failed to compile resume for page check")
+				break
+			}
+			pages, err := latex.PageCount(pdf)
+			if err != nil {
+				logger.Warn().Err(err).Int("jobID", opts.JobID).Msg("Failed to count compiled resume pages")
+				break
+			}
+			fit.CompiledPages = pages
+			if pages <= opts.TargetPages || check == maxCompileChecks {
+				break
+			}
+
+			// The estimate ran short, so tighten the budget and trim again.
+			trim.MaxLines = min(trim.MaxLines, layout.Estimate(t, resume, education).Lines) - t.LinesPerPage/10
+			more := layout.Trim(t, resume, education, trim)
+			if len(more) == 0 {
+				break
+			}
+			fit.Trimmed = append(fit.Trimmed, more...)
+		}
+	}
+
+	fit.After = layout.Estimate(t, resume, education)
+	if fit.CompiledPages > 0 {
+		fit.Fits = fit.CompiledPages <= opts.TargetPages
+	} else {
+		fit.Fits = fit.After.Pages <= opts.TargetPages
+	}
+	if !fit.Fits {
+		logger.Warn().
+			Int("jobID", opts.JobID).
+			Int("targetPages", opts.TargetPages).
+			Int("estimatedPages", fit.After.Pages).
+			Msg("Resume still runs past its target length after auto-fit")
+	}
+}
+
+// condenseResume asks the LLM to shorten the resume's bullets by about
+// overflow lines.
+func (s *DocumentService) condenseResume(
+	ctx context.Context,
+	r *requests.DocumentRequest,
+	j *jobs.FullJobPosting,
+	resume *domain.Resume,
+	t layout.Template,
+	overflow int,
+) (*domain.Resume, error) {
+	promptData := map[string]any{
+		"Resume":        resume.FormatForLLM(),
+		"JobPost":       shared_formatters.FormatJobPostForLLM(*j),
+		"TargetPages":   r.Options.TargetPages,
+		"OverflowLines": overflow,
+		"BulletChars":   t.BulletChars,
+	}
+
+	var condensed domain.Resume
+	err := s.generateLLMContent(
+		ctx,
+		r.Options.LlmProvider,
+		"condense.txt",
+		promptData,
+		schemaregistry.Resume,
+		&condensed,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &condensed, nil
+}
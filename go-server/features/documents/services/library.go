@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/ordo_meritum/database/generations"
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/shared/contexts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	"github.com/ordo_meritum/storage"
+)
+
+var (
+	// ErrArtifactNotFound is returned when a library document has no
+	// artifact of the requested kind.
+	ErrArtifactNotFound = errors.New("document has no such artifact")
+	// ErrMissingPDF is returned for a compile result that reports success
+	// but names no PDF; a library document without one can't be downloaded.
+	ErrMissingPDF = errors.New("compiled document has no PDF")
+)
+
+// CompiledFiles is what the compiler wrote for one generation. Paths other
+// than PDF are empty when the compiler didn't produce that file.
+type CompiledFiles struct {
+	DocType  string
+	Template string
+	PDF      string
+	Changes  string
+	Source   string
+}
+
+// artifactFiles is how each artifact is named and typed in the store.
+var artifactFiles = []struct {
+	kind        domain.ArtifactKind
+	suffix      string
+	contentType string
+}{
+	{domain.ArtifactPDF, ".pdf", "application/pdf"},
+	{domain.ArtifactChanges, "-changes.json", "application/json"},
+	{domain.ArtifactSource, ".tex", "application/x-tex"},
+}
+
+// RecordCompiledDocument moves a generation's compiled files into the
+// document store, adds them to the user's library and marks the generation
+// succeeded. A generation cancelled while it compiled returns
+// ErrGenerationFinished and nothing is kept, as does a result without a
+// PDF, which returns ErrMissingPDF.
+func (s *DocumentService) RecordCompiledDocument(
+	ctx context.Context,
+	generationID int,
+	files CompiledFiles,
+) (*domain.LibraryDocument, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+	g, err := s.generationRepo.Get(ctx, generationID)
+	if err != nil {
+		return nil, err
+	}
+	if g.Status.IsFinal() {
+		return nil, generations.ErrGenerationFinished
+	}
+	if files.PDF == "" {
+		return nil, ErrMissingPDF
+	}
+
+	docType := files.DocType
+	if docType == "" {
+		docType = g.DocType
+	}
+	paths := map[domain.ArtifactKind]string{
+		domain.ArtifactPDF:     files.PDF,
+		domain.ArtifactChanges: files.Changes,
+		domain.ArtifactSource:  files.Source,
+	}
+
+	doc := &domain.LibraryDocument{
+		RoleID:       g.RoleID,
+		GenerationID: &g.ID,
+		RevisionID:   g.RevisionID,
+		DocType:      docType,
+		Template:     files.Template,
+	}
+	for _, f := range artifactFiles {
+		filePath := paths[f.kind]
+		if filePath == "" {
+			continue
+		}
+		key := storage.DocumentKey(userCtx.UID, g.ID, docType+f.suffix)
+		if err := s.storeFile(ctx, key, filePath, f.contentType); err != nil {
+			s.deleteArtifacts(ctx, doc.Artifacts)
+			return nil, err
+		}
+		doc.Artifacts = append(doc.Artifacts, domain.Artifact{Kind: f.kind, Key: key})
+	}
+
+	artifacts := doc.Artifacts
+	doc, err = s.libraryRepo.Create(ctx, doc)
+	if err != nil {
+		s.deleteArtifacts(ctx, artifacts)
+		return nil, err
+	}
+
+	update := domain.GenerationUpdate{Status: domain.GenerationSucceeded}
+	if pdf, ok := doc.Artifact(domain.ArtifactPDF); ok {
+		update.DownloadURL = pdf.Key
+	}
+	if changes, ok := doc.Artifact(domain.ArtifactChanges); ok {
+		update.ChangesURL = changes.Key
+	}
+	if _, err := s.updateGeneration(ctx, g.ID, update); err != nil {
+		// Cancelled while it was being stored.
+		if _, delErr := s.DeleteLibraryDocument(ctx, doc.ID); delErr != nil {
+			logger.Error().Err(delErr).Int("documentID", doc.ID).Msg("Failed to discard library document")
+		}
+		return nil, err
+	}
+
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			logger.Warn().Err(err).Str("path", p).Msg("Failed to remove compiler output")
+		}
+	}
+
+	signed := s.signDocument(userCtx.UID, *doc)
+	return &signed, nil
+}
+
+func (s *DocumentService) ListLibrary(ctx context.Context, filter domain.LibraryFilter) ([]domain.LibraryDocument, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+	list, err := s.libraryRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	for i := range list {
+		list[i] = s.signDocument(userCtx.UID, list[i])
+	}
+	return list, nil
+}
+
+func (s *DocumentService) GetLibraryDocument(ctx context.Context, id int) (*domain.LibraryDocument, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+	doc, err := s.libraryRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	signed := s.signDocument(userCtx.UID, *doc)
+	return &signed, nil
+}
+
+// OpenArtifact opens one of a library document's stored files. The caller
+// must close it.
+func (s *DocumentService) OpenArtifact(
+	ctx context.Context,
+	id int,
+	kind domain.ArtifactKind,
+) (io.ReadCloser, *domain.Artifact, error) {
+	doc, err := s.libraryRepo.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	artifact, ok := doc.Artifact(kind)
+	if !ok {
+		return nil, nil, ErrArtifactNotFound
+	}
+	f, err := s.store.Open(ctx, artifact.Key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, ErrArtifactNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, &artifact, nil
+}
+
+// DeleteLibraryDocument removes a document from the library along with its
+// stored files.
+func (s *DocumentService) DeleteLibraryDocument(ctx context.Context, id int) (*domain.LibraryDocument, error) {
+	doc, err := s.libraryRepo.Delete(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.deleteArtifacts(ctx, doc.Artifacts)
+	return doc, nil
+}
+
+// deleteArtifacts removes stored files. A file left behind is only logged,
+// since nothing points at it any more.
+func (s *DocumentService) deleteArtifacts(ctx context.Context, artifacts []domain.Artifact) {
+	for _, a := range artifacts {
+		if err := s.store.Delete(ctx, a.Key); err != nil {
+			logger.Error().Err(err).Str("key", a.Key).Msg("Failed to delete stored artifact")
+		}
+	}
+}
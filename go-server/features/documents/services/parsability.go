@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ordo_meritum/database/profiles"
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/utils/parsability"
+	profile_mappers "github.com/ordo_meritum/features/profiles/utils/mappers"
+)
+
+// CheckParsability extracts the text of a compiled resume PDF the way an
+// applicant tracking system would and compares it with the latest resume
+// stored for the role. Contact details are checked against the user's
+// profile and skipped when they have none.
+func (s *DocumentService) CheckParsability(ctx context.Context, roleID int, pdf []byte) (*domain.ParsabilityReport, error) {
+	resume, err := s.resumeForAnalysis(ctx, roleID, nil)
+	if err != nil {
+		return nil, err
+	}
+	education, err := s.resumeRepo.GetEducation(ctx, roleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get education: %w", err)
+	}
+
+	var user domain.UserInfo
+	profile, err := s.profileRepo.GetProfile(ctx)
+	switch {
+	case err == nil:
+		user = domain.UserInfo(profile_mappers.ToUserInfoPayload(&profile.Contact))
+	case !errors.Is(err, profiles.ErrProfileNotFound):
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	return parsability.Check(pdf, user, education, resume)
+}
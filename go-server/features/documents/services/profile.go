@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ordo_meritum/database/jobs"
+	"github.com/ordo_meritum/features/documents/models/requests"
+	profile_domain "github.com/ordo_meritum/features/profiles/models/domain"
+	profile_mappers "github.com/ordo_meritum/features/profiles/utils/mappers"
+	"github.com/ordo_meritum/features/profiles/utils/matching"
+)
+
+// applyProfile replaces the request payload with the user's stored profile,
+// layering whatever the client sent inline on top as overrides.
+func (s *DocumentService) applyProfile(ctx context.Context, r *requests.DocumentRequest) error {
+	profile, err := s.profileRepo.GetProfile(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	variant, err := s.resolveVariant(ctx, r.Options, profile)
+	if err != nil {
+		return err
+	}
+	if variant != nil {
+		profile = variant.Apply(profile)
+	}
+
+	base := profile_mappers.ToDocumentPayload(profile)
+	merged, err := mergePayloadOverrides(base, r.Payload)
+	if err != nil {
+		return err
+	}
+	r.Payload = merged
+	return nil
+}
+
+// resolveVariant returns the variant the options ask for, or nil to use the
+// whole profile. Auto-selection falls back to the whole profile when the user
+// has no variants.
+func (s *DocumentService) resolveVariant(
+	ctx context.Context,
+	opts requests.DocumentOptions,
+	profile *profile_domain.Profile,
+) (*profile_domain.Variant, error) {
+	if opts.VariantID != nil {
+		variant, err := s.profileRepo.GetVariant(ctx, *opts.VariantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get variant %d: %w", *opts.VariantID, err)
+		}
+		return variant, nil
+	}
+	if !opts.AutoSelectVariant {
+		return nil, nil
+	}
+
+	variants, err := s.profileRepo.ListVariants(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variants: %w", err)
+	}
+	j, err := s.jobRepo.GetFullJobPosting(ctx, opts.JobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job posting: %w", err)
+	}
+
+	best, _, ok := matching.SelectVariant(profile, variants, jobKeywords(j))
+	if !ok {
+		return nil, nil
+	}
+	logger.Info().
+		Int("jobID", opts.JobID).
+		Str("variant", best.Variant.Name).
+		Float64("score", best.Score).
+		Msg("Auto-selected profile variant")
+	return &best.Variant, nil
+}
+
+// jobKeywords collects the posting's concrete skill terms. Free-text
+// requirements are left out since they rarely match word for word.
+func jobKeywords(j *jobs.FullJobPosting) []string {
+	var keywords []string
+	for _, list := range [][]string{
+		j.ProgrammingLanguages,
+		j.FrameworksAndLibraries,
+		j.Databases,
+		j.CloudTechnologies,
+		j.Tools,
+		j.IndustryKeywords,
+		j.Certifications,
+	} {
+		keywords = append(keywords, list...)
+	}
+	return keywords
+}
+
+// mergePayloadOverrides applies inline overrides to a profile payload:
+//   - contact fields override one by one when non-empty
+//   - education is replaced when a school or a list of schools is given
+//   - skills, experiences, projects, the additional sections and locked
+//     sections are replaced when present in the request, so an explicit
+//     empty list drops the section
+//   - additional info keys override the profile's sections of the same title
+func mergePayloadOverrides(base, override requests.DocumentPayload) (requests.DocumentPayload, error) {
+	merged := base
+	merged.Coverletter = override.Coverletter
+	merged.CV = override.CV
+
+	u, o := &merged.UserInfo, override.UserInfo
+	for _, f := range []struct {
+		dst *string
+		src string
+	}{
+		{&u.FirstName, o.FirstName},
+		{&u.LastName, o.LastName},
+		{&u.CurrentLocation, o.CurrentLocation},
+		{&u.Email, o.Email},
+		{&u.Github, o.Github},
+		{&u.Linkedin, o.Linkedin},
+		{&u.Mobile, o.Mobile},
+		{&u.Summary, o.Summary},
+	} {
+		if f.src != "" {
+			*f.dst = f.src
+		}
+	}
+
+	if override.Education != nil {
+		merged.Education = override.Education
+		merged.EducationInfo = requests.EducationInfoPayload{}
+	} else if override.EducationInfo.School != "" {
+		merged.EducationInfo = override.EducationInfo
+		merged.Education = nil
+	}
+
+	if override.Resume.Skills != nil {
+		merged.Resume.Skills = override.Resume.Skills
+	}
+	if override.Resume.Experiences != nil {
+		merged.Resume.Experiences = override.Resume.Experiences
+	}
+	if override.Resume.Projects != nil {
+		merged.Resume.Projects = override.Resume.Projects
+	}
+	if override.Resume.Certifications != nil {
+		merged.Resume.Certifications = override.Resume.Certifications
+	}
+	if override.Resume.Publications != nil {
+		merged.Resume.Publications = override.Resume.Publications
+	}
+	if override.Resume.Volunteering != nil {
+		merged.Resume.Volunteering = override.Resume.Volunteering
+	}
+	if override.Resume.Awards != nil {
+		merged.Resume.Awards = override.Resume.Awards
+	}
+	if override.Resume.Languages != nil {
+		merged.Resume.Languages = override.Resume.Languages
+	}
+	if override.Resume.LockedSections != nil {
+		merged.Resume.LockedSections = override.Resume.LockedSections
+	}
+
+	additionalInfo, err := mergeAdditionalInfo(base.AdditionalInfo, override.AdditionalInfo)
+	if err != nil {
+		return requests.DocumentPayload{}, err
+	}
+	merged.AdditionalInfo = additionalInfo
+
+	return merged, nil
+}
+
+func mergeAdditionalInfo(base, override json.RawMessage) (json.RawMessage, error) {
+	if isEmptyJSON(override) {
+		return base, nil
+	}
+	if isEmptyJSON(base) {
+		return override, nil
+	}
+
+	var sections, overrides map[string]string
+	if err := json.Unmarshal(base, &sections); err != nil {
+		return nil, fmt.Errorf("failed to read profile additional info: %w", err)
+	}
+	if err := json.Unmarshal(override, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to read additional info override: %w", err)
+	}
+	for k, v := range overrides {
+		sections[k] = v
+	}
+	return json.Marshal(sections)
+}
+
+func isEmptyJSON(raw json.RawMessage) bool {
+	return len(raw) == 0 || string(raw) == "null"
+}
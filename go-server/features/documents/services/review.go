@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ordo_meritum/database/generations"
+	"github.com/ordo_meritum/database/resumes"
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/models/events"
+	"github.com/ordo_meritum/features/documents/models/requests"
+	"github.com/ordo_meritum/features/documents/utils/formatters"
+	"github.com/ordo_meritum/shared/contexts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+// maxCorrections caps how many past rejections are fed back to the LLM.
+const maxCorrections = 20
+
+// errInvalidReviewActions aborts a review update whose actions failed
+// validation; the details are returned to the caller separately.
+var errInvalidReviewActions = errors.New("invalid review actions")
+
+// openReview holds a freshly generated resume for review instead of
+// compiling it. The resume is kept as a revision but the role's current
+// resume is left alone until the review is finalized. It returns nil when
+// the LLM suggested nothing to review. A review still pending for the role
+// is replaced, and the generation waiting on it is cancelled.
+func (s *DocumentService) openReview(ctx context.Context, event *events.DocumentEvent, meta domain.RevisionMeta) (*domain.Review, error) {
+	resume := event.Resume
+	resume.MarkForReview()
+	if resume.ReviewCount(domain.ReviewPending) == 0 {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode document event: %w", err)
+	}
+	superseded := s.pendingReviewGeneration(ctx, event.JobID)
+	review, err := s.resumeRepo.SaveReview(ctx, event.JobID, &resume, raw, meta)
+	if err != nil {
+		return nil, err
+	}
+	if superseded != 0 && superseded != event.GenerationID {
+		_, err := s.CancelGeneration(ctx, superseded)
+		if err != nil && !errors.Is(err, generations.ErrGenerationFinished) {
+			logger.Error().Err(err).Int("generationID", superseded).Msg("Failed to cancel superseded review's generation")
+		}
+	}
+	return review, nil
+}
+
+// pendingReviewGeneration returns the generation waiting on the role's
+// pending review, or 0 when there is none.
+func (s *DocumentService) pendingReviewGeneration(ctx context.Context, roleID int) int {
+	review, err := s.resumeRepo.GetReview(ctx, roleID)
+	if err != nil || review.State != domain.ReviewStatePending {
+		if err != nil && !errors.Is(err, resumes.ErrReviewNotFound) {
+			logger.Warn().Err(err).Int("jobID", roleID).Msg("Failed to load pending review")
+		}
+		return 0
+	}
+	var event events.DocumentEvent
+	if err := json.Unmarshal(review.Event, &event); err != nil {
+		return 0
+	}
+	return event.GenerationID
+}
+
+// rejectedCorrections turns the user's past rejections for this role into
+// corrections for the prompt. Failing to load them shouldn't block
+// generation, so errors are only logged.
+func (s *DocumentService) rejectedCorrections(ctx context.Context, roleID int) []string {
+	rejected, err := s.resumeRepo.ListRejectedSuggestions(ctx, roleID, maxCorrections)
+	if err != nil {
+		logger.Warn().Err(err).Int("jobID", roleID).Msg("Failed to load rejected suggestions")
+		return nil
+	}
+
+	corrections := make([]string, 0, len(rejected))
+	for _, r := range rejected {
+		corrections = append(corrections, fmt.Sprintf("Rejected %s suggestion: %q", r.Section, r.Text))
+	}
+	return corrections
+}
+
+// GetReview returns the role's review along with the generation report of
+// the revision it started from.
+func (s *DocumentService) GetReview(ctx context.Context, roleID int) (*domain.Review, error) {
+	review, err := s.resumeRepo.GetReview(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if review.RevisionID != nil {
+		revision, err := s.resumeRepo.GetRevision(ctx, roleID, *review.RevisionID)
+		if err != nil && !errors.Is(err, resumes.ErrRevisionNotFound) {
+			return nil, err
+		}
+		if revision != nil {
+			review.Report = revision.Report
+		}
+	}
+	return review, nil
+}
+
+// ApplyReviewActions records the user's verdicts on a pending review. Either
+// every action applies or none do.
+func (s *DocumentService) ApplyReviewActions(
+	ctx context.Context,
+	roleID int,
+	actions []requests.ReviewAction,
+) (*domain.Review, []error_response.ValidationDetail, error) {
+	if len(actions) == 0 {
+		return nil, []error_response.ValidationDetail{{Field: "actions", Issue: "at least one action is required"}}, nil
+	}
+
+	var details []error_response.ValidationDetail
+	review, err := s.resumeRepo.UpdateReview(ctx, roleID, func(resume *domain.Resume) error {
+		for i, a := range actions {
+			if issue := applyReviewAction(resume, a); issue != "" {
+				details = append(details, error_response.ValidationDetail{
+					Field: fmt.Sprintf("actions[%d]", i),
+					Issue: issue,
+				})
+			}
+		}
+		if len(details) > 0 {
+			return errInvalidReviewActions
+		}
+		return nil
+	})
+	if errors.Is(err, errInvalidReviewActions) {
+		return nil, details, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return review, nil, nil
+}
+
+// FinalizeReview makes the reviewed resume current and sends it to be
+// compiled. A review whose generation was cancelled is refused with
+// ErrGenerationFinished; one cancelled while finalizing is kept but not
+// compiled.
+func (s *DocumentService) FinalizeReview(ctx context.Context, roleID int, acceptPending bool) (*QueueResult, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+	l := s.serviceLogger(userCtx.UID, roleID, "resume")
+
+	if id := s.pendingReviewGeneration(ctx, roleID); id != 0 {
+		g, err := s.generationRepo.Get(ctx, id)
+		if err != nil && !errors.Is(err, generations.ErrGenerationNotFound) {
+			l.Error().Err(err).Msg("Failed to load review's generation")
+			return nil, err
+		}
+		if g != nil && g.Status.IsFinal() {
+			return nil, generations.ErrGenerationFinished
+		}
+	}
+
+	pending, err := s.resumeRepo.GetReview(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	var event events.DocumentEvent
+	if err := json.Unmarshal(pending.Event, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode document event: %w", err)
+	}
+	education, err := formatters.NewEducationListFromPayload(&requests.DocumentPayload{
+		EducationInfo: event.EducationInfo,
+		Education:     event.Education,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	review, revisionID, err := s.resumeRepo.FinalizeReview(ctx, roleID, acceptPending, education, domain.RevisionMeta{Author: domain.AuthorUser})
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to finalize resume review")
+		return nil, err
+	}
+	event.Resume = *review.Resume
+
+	result := &QueueResult{
+		JobID:        event.JobID,
+		GenerationID: event.GenerationID,
+		Status:       StatusProcessingQueued,
+		RevisionID:   revisionID,
+	}
+	if event.GenerationID != 0 {
+		err = s.publishGeneration(ctx, event.GenerationID, event.JobID, &event, domain.GenerationUpdate{RevisionID: &revisionID})
+		if errors.Is(err, generations.ErrGenerationFinished) {
+			// Cancelled after the check above. The reviewed resume is
+			// already saved as a revision; there's just nothing to compile.
+			l.Info().Int("revisionID", revisionID).Msg("Generation finished during review; skipped compilation")
+			result.Status = string(domain.GenerationCancelled)
+			return result, nil
+		}
+	} else {
+		err = s.sendKafkaMessage(ctx, event.JobID, &event)
+	}
+	if err != nil {
+		l.Error().Err(err).Msg("Error writing to Kafka")
+		return nil, err
+	}
+
+	l.Info().Int("revisionID", revisionID).Msg("Successfully queued reviewed resume for compilation")
+	return result, nil
+}
+
+// applyReviewAction applies one verdict to the resume, returning a
+// description of the problem if the action is invalid.
+func applyReviewAction(resume *domain.Resume, a requests.ReviewAction) string {
+	var status domain.ReviewStatus
+	switch a.Action {
+	case "accept":
+		status = domain.ReviewAccepted
+	case "reject":
+		status = domain.ReviewRejected
+	case "edit":
+		status = domain.ReviewEdited
+	default:
+		return "action must be accept, reject or edit"
+	}
+
+	text := strings.TrimSpace(a.Text)
+	if status == domain.ReviewEdited && a.Section != "skills" && text == "" {
+		return "text is required when editing"
+	}
+
+	switch a.Section {
+	case "summary":
+		if a.Index < 0 || a.Index >= len(resume.Summary) {
+			return "index is out of range"
+		}
+		s := &resume.Summary[a.Index]
+		s.Review = status
+		if status == domain.ReviewEdited {
+			s.Sentence = text
+		}
+	case "skills":
+		if a.Index < 0 || a.Index >= len(resume.Skills) {
+			return "index is out of range"
+		}
+		if status == domain.ReviewEdited && len(a.Skills) == 0 {
+			return "skills are required when editing a skill category"
+		}
+		s := &resume.Skills[a.Index]
+		s.Review = status
+		if status == domain.ReviewEdited {
+			s.SkillItem = a.Skills
+			if text != "" {
+				s.Category = text
+			}
+		}
+	case "experiences", "projects", "volunteering":
+		var points []domain.BulletPoint
+		switch a.Section {
+		case "experiences":
+			if a.Index < 0 || a.Index >= len(resume.Experiences) {
+				return "index is out of range"
+			}
+			points = resume.Experiences[a.Index].BulletPoints
+		case "projects":
+			if a.Index < 0 || a.Index >= len(resume.Projects) {
+				return "index is out of range"
+			}
+			points = resume.Projects[a.Index].BulletPoints
+		default:
+			if a.Index < 0 || a.Index >= len(resume.Volunteering) {
+				return "index is out of range"
+			}
+			points = resume.Volunteering[a.Index].BulletPoints
+		}
+		if a.Bullet == nil || *a.Bullet < 0 || *a.Bullet >= len(points) {
+			return "bullet is missing or out of range"
+		}
+		p := &points[*a.Bullet]
+		p.Review = status
+		if status == domain.ReviewEdited {
+			p.Text = text
+		}
+	default:
+		return "section must be summary, skills, experiences, projects or volunteering"
+	}
+	return ""
+}
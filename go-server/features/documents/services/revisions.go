@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/utils/diff"
+	"github.com/ordo_meritum/shared/contexts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+// RevisionDiff pairs a diff with the revisions it was taken between.
+type RevisionDiff struct {
+	From domain.Revision `json:"from"`
+	To   domain.Revision `json:"to"`
+	Diff diff.ResumeDiff `json:"diff"`
+}
+
+func (s *DocumentService) ListRevisions(ctx context.Context, roleID int) ([]domain.Revision, error) {
+	return s.resumeRepo.ListRevisions(ctx, roleID)
+}
+
+func (s *DocumentService) GetRevision(ctx context.Context, roleID, revisionID int) (*domain.Revision, error) {
+	return s.resumeRepo.GetRevision(ctx, roleID, revisionID)
+}
+
+func (s *DocumentService) DiffRevisions(ctx context.Context, roleID, fromID, toID int) (*RevisionDiff, error) {
+	from, err := s.resumeRepo.GetRevision(ctx, roleID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.resumeRepo.GetRevision(ctx, roleID, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	d := diff.Resumes(from.Resume, to.Resume)
+	from.Resume, to.Resume = nil, nil
+	return &RevisionDiff{From: *from, To: *to, Diff: d}, nil
+}
+
+func (s *DocumentService) RestoreRevision(ctx context.Context, roleID, revisionID int) (*domain.Revision, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+	l := s.serviceLogger(userCtx.UID, roleID, "resume")
+
+	revision, err := s.resumeRepo.RestoreRevision(ctx, roleID, revisionID)
+	if err != nil {
+		l.Error().Err(err).Int("revisionID", revisionID).Msg("Failed to restore revision")
+		return nil, err
+	}
+	l.Info().Int("revisionID", revisionID).Int("newRevision", revision.Number).Msg("Restored resume revision")
+	return revision, nil
+}
+
+// SaveResumeEdit stores a user-edited resume as the current version.
+func (s *DocumentService) SaveResumeEdit(ctx context.Context, roleID int, resume *domain.Resume) (*domain.Revision, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+	l := s.serviceLogger(userCtx.UID, roleID, "resume")
+
+	revisionID, err := s.resumeRepo.UpsertResume(ctx, roleID, resume, nil, domain.RevisionMeta{Author: domain.AuthorUser})
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to save resume edit")
+		return nil, err
+	}
+	return s.resumeRepo.GetRevision(ctx, roleID, revisionID)
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/storage"
+)
+
+// storeFile copies a file the compiler wrote into the document store.
+func (s *DocumentService) storeFile(ctx context.Context, key, filePath, contentType string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path.Base(filePath), err)
+	}
+	defer f.Close()
+	if err := s.store.Put(ctx, key, f, contentType); err != nil {
+		return fmt.Errorf("failed to store %s: %w", key, err)
+	}
+	return nil
+}
+
+// DownloadURL returns a signed link to a stored document for uid. Anything
+// uid doesn't own, including file paths recorded before documents were
+// stored, gets no link.
+func (s *DocumentService) DownloadURL(uid, key string) string {
+	if owner, ok := storage.KeyOwner(key); !ok || owner != uid {
+		return ""
+	}
+	return s.signer.URL(uid, key)
+}
+
+// signGeneration returns a copy of g with its storage keys swapped for
+// signed links.
+func (s *DocumentService) signGeneration(uid string, g domain.Generation) domain.Generation {
+	g.DownloadURL = s.DownloadURL(uid, g.DownloadURL)
+	g.ChangesURL = s.DownloadURL(uid, g.ChangesURL)
+	return g
+}
+
+func (s *DocumentService) signGenerations(ctx context.Context, list []domain.Generation) []domain.Generation {
+	userCtx, ok := contexts.FromContext(ctx)
+	for i := range list {
+		if !ok {
+			list[i].DownloadURL, list[i].ChangesURL = "", ""
+			continue
+		}
+		list[i] = s.signGeneration(userCtx.UID, list[i])
+	}
+	return list
+}
+
+// signDocument returns a copy of d with a signed link on every artifact.
+func (s *DocumentService) signDocument(uid string, d domain.LibraryDocument) domain.LibraryDocument {
+	d.Artifacts = slices.Clone(d.Artifacts)
+	for i := range d.Artifacts {
+		d.Artifacts[i].URL = s.DownloadURL(uid, d.Artifacts[i].Key)
+	}
+	return d
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/database/writingstyles"
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/utils/stylometry"
+	"github.com/ordo_meritum/shared/contexts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+// ErrNoWritingSamples is returned for a style profile when the user has no
+// writing samples to build it from.
+var ErrNoWritingSamples = errors.New("no writing samples to analyze")
+
+// StyleProfile returns the fingerprint of the user's stored writing
+// samples, rebuilding it if the samples changed since it was stored.
+func (s *DocumentService) StyleProfile(ctx context.Context) (*domain.StyleProfile, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+	stored, err := s.writingSampleRepo.GetByFirebaseUID(ctx, userCtx.UID)
+	if err != nil {
+		return nil, err
+	}
+	return s.styleProfileFor(ctx, stored)
+}
+
+// styleProfileFor returns the profile for samples, the user's stored
+// writing samples. The stored profile is reused while it was built from the
+// same number of samples with the same latest edit; otherwise it is rebuilt
+// and saved. Failing to save is only logged, since the profile is still
+// good for this request.
+func (s *DocumentService) styleProfileFor(ctx context.Context, samples []models.CandidateWritingSample) (*domain.StyleProfile, error) {
+	if len(samples) == 0 {
+		if err := s.styleRepo.Delete(ctx); err != nil {
+			logger.Warn().Err(err).Msg("Failed to delete writing style profile")
+		}
+		return nil, ErrNoWritingSamples
+	}
+
+	var latest time.Time
+	texts := make([]string, 0, len(samples))
+	for _, ws := range samples {
+		texts = append(texts, ws.Content)
+		if ws.UpdatedAt.After(latest) {
+			latest = ws.UpdatedAt
+		}
+	}
+
+	existing, err := s.styleRepo.Get(ctx)
+	switch {
+	case err == nil && existing.SampleCount == len(samples) && existing.SamplesUpdatedAt.Equal(latest):
+		return existing, nil
+	case err != nil && !errors.Is(err, writingstyles.ErrStyleProfileNotFound):
+		logger.Warn().Err(err).Msg("Failed to load writing style profile, rebuilding it")
+	}
+
+	profile := stylometry.Analyze(texts)
+	profile.SamplesUpdatedAt = latest
+	profile.AnalyzedAt = time.Now().UTC()
+	if err := s.styleRepo.Save(ctx, &profile); err != nil {
+		logger.Warn().Err(err).Msg("Failed to save writing style profile")
+	}
+	return &profile, nil
+}
+
+// scoreCoverLetterStyle measures how far a cover letter's style is from
+// profile. It returns nil when there is no profile to compare against.
+func scoreCoverLetterStyle(profile *domain.StyleProfile, body domain.CoverLetterBody) *domain.StyleMatch {
+	if profile == nil {
+		return nil
+	}
+	match := stylometry.Compare(profile, body.About+"\n\n"+body.Experience+"\n\n"+body.WhatIBring)
+	return &match
+}
@@ -0,0 +1,188 @@
+package ats
+
+import (
+	"strings"
+
+	"github.com/ordo_meritum/database/jobs"
+	"github.com/ordo_meritum/features/documents/models/domain"
+)
+
+// minRequirementCoverage is the share of a free-text requirement's terms
+// that must appear on the resume for the requirement to count as met.
+const minRequirementCoverage = 0.6
+
+// line is one piece of resume text a keyword can be found in.
+type line struct {
+	loc   domain.KeywordLocation
+	terms []string
+}
+
+// Analyze scores how well the resume covers the posting's keywords. Listed
+// keywords such as tools or databases must appear as a whole; free-text
+// requirements are met when most of their terms appear in a single line.
+func Analyze(resume *domain.Resume, j *jobs.FullJobPosting) domain.ATSReport {
+	lines := resumeLines(resume)
+
+	categories := []struct {
+		name     string
+		keywords []string
+		partial  bool
+	}{
+		{"requirements", j.Requirements, true},
+		{"programming_languages", j.ProgrammingLanguages, false},
+		{"frameworks_and_libraries", j.FrameworksAndLibraries, false},
+		{"databases", j.Databases, false},
+		{"cloud_technologies", j.CloudTechnologies, false},
+		{"tools", j.Tools, false},
+		{"certifications", j.Certifications, false},
+	}
+
+	report := domain.ATSReport{Categories: make([]domain.KeywordCoverage, 0, len(categories))}
+	for _, c := range categories {
+		coverage := domain.KeywordCoverage{
+			Category: c.name,
+			Matched:  []domain.KeywordMatch{},
+			Missing:  []string{},
+		}
+		seen := make(map[string]bool)
+		for _, k := range c.keywords {
+			needle := terms(k)
+			key := strings.Join(needle, " ")
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			var locations []domain.KeywordLocation
+			if c.partial {
+				locations = findRequirement(lines, needle)
+			} else {
+				locations = findPhrase(lines, needle)
+			}
+			if len(locations) > 0 {
+				coverage.Matched = append(coverage.Matched, domain.KeywordMatch{Keyword: k, Locations: locations})
+			} else {
+				coverage.Missing = append(coverage.Missing, k)
+			}
+		}
+
+		total := len(coverage.Matched) + len(coverage.Missing)
+		if total == 0 {
+			continue
+		}
+		coverage.Score = percent(len(coverage.Matched), total)
+		report.Matched += len(coverage.Matched)
+		report.Total += total
+		report.Categories = append(report.Categories, coverage)
+	}
+
+	report.Score = percent(report.Matched, report.Total)
+	return report
+}
+
+func findPhrase(lines []line, needle []string) []domain.KeywordLocation {
+	var locations []domain.KeywordLocation
+	for _, l := range lines {
+		if containsSeq(l.terms, needle) {
+			locations = append(locations, l.loc)
+		}
+	}
+	return locations
+}
+
+// findRequirement matches a free-text requirement against each line,
+// ignoring filler words such as "experience" or "strong".
+func findRequirement(lines []line, needle []string) []domain.KeywordLocation {
+	var significant []string
+	for _, t := range needle {
+		if !stopStems[t] {
+			significant = append(significant, t)
+		}
+	}
+	if len(significant) == 0 {
+		return nil
+	}
+
+	var locations []domain.KeywordLocation
+	for _, l := range lines {
+		have := make(map[string]bool, len(l.terms))
+		for _, t := range l.terms {
+			have[t] = true
+		}
+		hits := 0
+		for _, t := range significant {
+			if have[t] {
+				hits++
+			}
+		}
+		if float64(hits)/float64(len(significant)) >= minRequirementCoverage {
+			locations = append(locations, l.loc)
+		}
+	}
+	return locations
+}
+
+func containsSeq(haystack, needle []string) bool {
+	if len(needle) == 0 || len(needle) > len(haystack) {
+		return false
+	}
+outer:
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		for j := range needle {
+			if haystack[i+j] != needle[j] {
+				continue outer
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func resumeLines(r *domain.Resume) []line {
+	var lines []line
+	add := func(section, key string, index int, text string) {
+		lines = append(lines, line{
+			loc:   domain.KeywordLocation{Section: section, Key: key, Index: index, Text: text},
+			terms: terms(text),
+		})
+	}
+
+	for i, s := range r.Summary {
+		add("summary", "", i, s.Sentence)
+	}
+	for _, e := range r.Experiences {
+		key := strings.Trim(e.Company+" - "+e.Position, " -")
+		for i, b := range e.BulletPoints {
+			add("experiences", key, i, b.Text)
+		}
+	}
+	for _, p := range r.Projects {
+		for i, b := range p.BulletPoints {
+			add("projects", p.Name, i, b.Text)
+		}
+	}
+	for _, s := range r.Skills {
+		for i, item := range s.SkillItem {
+			add("skills", s.Category, i, item)
+		}
+	}
+	for _, v := range r.Volunteering {
+		for i, b := range v.BulletPoints {
+			add("volunteering", v.Organization, i, b.Text)
+		}
+	}
+	for i, c := range r.Certifications {
+		add("certifications", "", i, c.Name)
+	}
+	for i, l := range r.Languages {
+		add("languages", "", i, l.Language)
+	}
+	return lines
+}
+
+func percent(n, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return n * 100 / total
+}
@@ -0,0 +1,63 @@
+package ats
+
+import (
+	"strings"
+
+	"github.com/ordo_meritum/shared/libs/taxonomy"
+)
+
+// stopwords are dropped when breaking free-text requirements into terms.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "or": true, "the": true, "of": true,
+	"in": true, "on": true, "for": true, "to": true, "with": true, "as": true,
+	"at": true, "by": true, "is": true, "are": true, "be": true, "from": true,
+	"experience": true, "experienced": true, "knowledge": true, "ability": true,
+	"strong": true, "proficiency": true, "proficient": true, "familiarity": true,
+	"familiar": true, "understanding": true, "year": true, "years": true,
+	"plus": true, "using": true, "working": true, "work": true, "skills": true,
+	"skill": true, "good": true, "excellent": true, "solid": true, "including": true,
+	"other": true, "such": true, "like": true, "e.g": true, "etc": true,
+}
+
+// stopStems holds the stopwords in stemmed form, as terms returns them.
+var stopStems = func() map[string]bool {
+	stems := make(map[string]bool, len(stopwords))
+	for w := range stopwords {
+		stems[stem(w)] = true
+	}
+	return stems
+}()
+
+// terms normalizes text into comparable terms: skill aliases, including
+// multi-word ones, are resolved to their canonical names through the skill
+// taxonomy, and every word is stemmed.
+func terms(s string) []string {
+	ws := taxonomy.Words(taxonomy.Default().CanonicalText(s))
+	for i, w := range ws {
+		ws[i] = stem(w)
+	}
+	return ws
+}
+
+// stem strips common English suffixes so "deploying", "deployed" and
+// "deployments" compare equal. Words with symbols or digits are technology
+// names and are left alone, as are short words.
+func stem(w string) string {
+	if len(w) <= 3 || strings.ContainsAny(w, "+#./0123456789") {
+		return w
+	}
+	for _, suffix := range []string{"ations", "ation", "ments", "ment", "ings", "ing", "ies", "ers", "er", "ed", "s"} {
+		if strings.HasSuffix(w, suffix) && len(w)-len(suffix) >= 3 {
+			w = strings.TrimSuffix(w, suffix)
+			if suffix == "ies" {
+				w += "y"
+			}
+			break
+		}
+	}
+	// "service" and "services" both end up as "servic".
+	if len(w) > 4 && strings.HasSuffix(w, "e") {
+		w = strings.TrimSuffix(w, "e")
+	}
+	return w
+}
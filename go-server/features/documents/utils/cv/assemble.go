@@ -0,0 +1,140 @@
+package cv
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/models/requests"
+	"github.com/ordo_meritum/shared/libs/dates"
+)
+
+// Assemble builds the CV from the source payload, taking the LLM's wording
+// where it returned some. Nothing is ever dropped or shortened: an entry the
+// LLM skipped, or whose bullet count it changed, keeps the source text.
+func Assemble(payload *requests.DocumentPayload, polish *domain.CVPolish) domain.CV {
+	polished := make(map[string]domain.PolishedEntry, len(polish.Entries))
+	for _, e := range polish.Entries {
+		polished[e.ID] = e
+	}
+	text := func(section string, i int, source string) string {
+		if e, ok := polished[entryID(section, i)]; ok && strings.TrimSpace(source) != "" && strings.TrimSpace(e.Text) != "" {
+			return strings.TrimSpace(e.Text)
+		}
+		return source
+	}
+
+	cv := domain.CV{ResearchInterests: payload.CV.ResearchInterests}
+	if strings.TrimSpace(cv.ResearchInterests) != "" && strings.TrimSpace(polish.ResearchInterests) != "" {
+		cv.ResearchInterests = strings.TrimSpace(polish.ResearchInterests)
+	}
+
+	for i, exp := range payload.Resume.Experiences {
+		bullets := append([]string{}, exp.BulletPoints...)
+		if e, ok := polished[entryID(sectionAppointment, i)]; ok && len(e.Bullets) == len(bullets) {
+			bullets = e.Bullets
+		}
+		cv.Appointments = append(cv.Appointments, domain.Appointment{
+			ID:           exp.ID,
+			Position:     exp.Position,
+			Institution:  exp.Company,
+			Dates:        exp.Years,
+			BulletPoints: bullets,
+		})
+	}
+	for i, p := range payload.Resume.Publications {
+		cv.Publications = append(cv.Publications, domain.Publication{
+			ID:        p.ID,
+			Title:     p.Title,
+			Publisher: p.Publisher,
+			Date:      p.Date,
+			URL:       p.URL,
+			Summary:   text(sectionPublication, i, p.Summary),
+		})
+	}
+	for _, p := range payload.CV.Presentations {
+		cv.Presentations = append(cv.Presentations, domain.Presentation{
+			ID:       p.ID,
+			Title:    p.Title,
+			Event:    p.Event,
+			Location: p.Location,
+			Date:     p.Date,
+			Invited:  p.Invited,
+		})
+	}
+	for i, t := range payload.CV.Teaching {
+		cv.Teaching = append(cv.Teaching, domain.Teaching{
+			ID:          t.ID,
+			Course:      t.Course,
+			Institution: t.Institution,
+			Role:        t.Role,
+			Dates:       t.Years,
+			Description: text(sectionTeaching, i, t.Description),
+		})
+	}
+	for _, c := range payload.CV.Committees {
+		cv.Committees = append(cv.Committees, domain.Committee{
+			ID:        c.ID,
+			Committee: c.Committee,
+			Role:      c.Role,
+			Dates:     c.Years,
+		})
+	}
+	for i, g := range payload.CV.Grants {
+		cv.Grants = append(cv.Grants, domain.Grant{
+			ID:          g.ID,
+			Title:       g.Title,
+			Funder:      g.Funder,
+			Amount:      g.Amount,
+			Role:        g.Role,
+			Dates:       g.Years,
+			Description: text(sectionGrant, i, g.Description),
+		})
+	}
+	for _, a := range payload.Resume.Awards {
+		cv.Awards = append(cv.Awards, domain.Award{
+			ID:      a.ID,
+			Title:   a.Title,
+			Awarder: a.Awarder,
+			Date:    a.Date,
+			Summary: a.Summary,
+		})
+	}
+
+	newestFirst(cv.Appointments, func(a domain.Appointment) string { return a.Dates })
+	newestFirst(cv.Publications, func(p domain.Publication) string { return p.Date })
+	newestFirst(cv.Presentations, func(p domain.Presentation) string { return p.Date })
+	newestFirst(cv.Teaching, func(t domain.Teaching) string { return t.Dates })
+	newestFirst(cv.Committees, func(c domain.Committee) string { return c.Dates })
+	newestFirst(cv.Grants, func(g domain.Grant) string { return g.Dates })
+	newestFirst(cv.Awards, func(a domain.Award) string { return a.Date })
+	return cv
+}
+
+// newestFirst orders entries by when they ended, ongoing ones first.
+// Entries whose dates don't parse keep their order after the rest.
+func newestFirst[T any](entries []T, date func(T) string) {
+	slices.SortStableFunc(entries, func(a, b T) int {
+		return recency(date(b)).Compare(recency(date(a)))
+	})
+}
+
+// ongoing sorts after every real date.
+var ongoing = time.Unix(math.MaxInt32, 0).UTC()
+
+// recency is the point an entry is sorted by: its end, its start when it
+// has no end, and the zero time when the text isn't a date.
+func recency(s string) time.Time {
+	r, err := dates.Parse(s)
+	switch {
+	case err != nil:
+		return time.Time{}
+	case r.Ongoing:
+		return ongoing
+	case !r.End.IsZero():
+		return r.End.Time()
+	}
+	return r.Start.Time()
+}
@@ -0,0 +1,93 @@
+// Package cv builds academic CVs. A CV keeps everything the user sent; the
+// LLM pass only rewrites descriptions, and Assemble puts the source entries
+// back together around whatever wording it returned.
+package cv
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ordo_meritum/features/documents/models/requests"
+)
+
+// Sections that carry text the LLM may polish. The id sent with each entry
+// is the section name and the entry's position in the payload.
+const (
+	sectionAppointment = "appointment"
+	sectionPublication = "publication"
+	sectionTeaching    = "teaching"
+	sectionGrant       = "grant"
+)
+
+func entryID(section string, i int) string {
+	return fmt.Sprintf("%s-%d", section, i)
+}
+
+// FormatForLLM lists the text a CV's LLM pass may rewrite. Titles, venues
+// and dates are included for context only; entries with nothing to rewrite
+// are left out.
+func FormatForLLM(payload *requests.DocumentPayload) string {
+	var sb strings.Builder
+	sb.WriteString("<cv_content>\n")
+
+	if interests := strings.TrimSpace(payload.CV.ResearchInterests); interests != "" {
+		sb.WriteString(fmt.Sprintf("\t<research_interests>%s</research_interests>\n", interests))
+	}
+
+	if len(payload.Resume.Experiences) > 0 {
+		sb.WriteString("\t<appointments>\n")
+		for i, exp := range payload.Resume.Experiences {
+			if len(exp.BulletPoints) == 0 {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("\t\t<appointment id=%q>\n", entryID(sectionAppointment, i)))
+			sb.WriteString(fmt.Sprintf("\t\t\t<position>%s</position>\n", exp.Position))
+			sb.WriteString(fmt.Sprintf("\t\t\t<institution>%s</institution>\n", exp.Company))
+			sb.WriteString("\t\t\t<bullets>\n")
+			for _, point := range exp.BulletPoints {
+				sb.WriteString(fmt.Sprintf("\t\t\t\t<bullet>%s</bullet>\n", strings.TrimSpace(point)))
+			}
+			sb.WriteString("\t\t\t</bullets>\n")
+			sb.WriteString("\t\t</appointment>\n")
+		}
+		sb.WriteString("\t</appointments>\n")
+	}
+
+	writeDescriptions(&sb, "publications", sectionPublication, len(payload.Resume.Publications), func(i int) (string, string) {
+		p := payload.Resume.Publications[i]
+		return p.Title, p.Summary
+	})
+	writeDescriptions(&sb, "teaching", sectionTeaching, len(payload.CV.Teaching), func(i int) (string, string) {
+		t := payload.CV.Teaching[i]
+		return t.Course, t.Description
+	})
+	writeDescriptions(&sb, "grants", sectionGrant, len(payload.CV.Grants), func(i int) (string, string) {
+		g := payload.CV.Grants[i]
+		return g.Title, g.Description
+	})
+
+	sb.WriteString("</cv_content>")
+	return sb.String()
+}
+
+// writeDescriptions writes one block of entries that have a single
+// description to polish.
+func writeDescriptions(sb *strings.Builder, tag, section string, n int, entry func(int) (title, text string)) {
+	var body strings.Builder
+	for i := range n {
+		title, text := entry(i)
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		body.WriteString(fmt.Sprintf("\t\t<entry id=%q>\n", entryID(section, i)))
+		body.WriteString(fmt.Sprintf("\t\t\t<title>%s</title>\n", title))
+		body.WriteString(fmt.Sprintf("\t\t\t<text>%s</text>\n", strings.TrimSpace(text)))
+		body.WriteString("\t\t</entry>\n")
+	}
+	if body.Len() == 0 {
+		return
+	}
+	sb.WriteString(fmt.Sprintf("\t<%s>\n", tag))
+	sb.WriteString(body.String())
+	sb.WriteString(fmt.Sprintf("\t</%s>\n", tag))
+}
@@ -0,0 +1,275 @@
+package diff
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+)
+
+type Op string
+
+const (
+	OpEqual  Op = "equal"
+	OpInsert Op = "insert"
+	OpDelete Op = "delete"
+)
+
+type Status string
+
+const (
+	StatusAdded    Status = "added"
+	StatusRemoved  Status = "removed"
+	StatusModified Status = "modified"
+)
+
+// LineChange is one bullet, sentence or skill in a diff.
+type LineChange struct {
+	Op   Op     `json:"op"`
+	Text string `json:"text"`
+}
+
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// SectionDiff describes how one experience, project or skill category
+// changed. Unchanged sections are left out of ResumeDiff entirely.
+type SectionDiff struct {
+	Key     string        `json:"key"`
+	Status  Status        `json:"status"`
+	Fields  []FieldChange `json:"fields,omitempty"`
+	Changes []LineChange  `json:"changes,omitempty"`
+}
+
+type ResumeDiff struct {
+	Summary     []LineChange  `json:"summary,omitempty"`
+	Experiences []SectionDiff `json:"experiences,omitempty"`
+	Projects    []SectionDiff `json:"projects,omitempty"`
+	Skills      []SectionDiff `json:"skills,omitempty"`
+	// Volunteering is the only additional section the LLM rewrites.
+	Volunteering []SectionDiff `json:"volunteering,omitempty"`
+}
+
+// section is the common shape the resume's sections are reduced to before
+// diffing. Sections are matched on id when both sides carry one, otherwise
+// on key.
+type section struct {
+	id     string
+	key    string
+	fields map[string]string
+	lines  []string
+}
+
+// Resumes compares two resumes bullet by bullet.
+func Resumes(from, to *domain.Resume) ResumeDiff {
+	var d ResumeDiff
+
+	if changes := Lines(summaryLines(from), summaryLines(to)); hasChanges(changes) {
+		d.Summary = changes
+	}
+	d.Experiences = sections(experienceSections(from), experienceSections(to))
+	d.Projects = sections(projectSections(from), projectSections(to))
+	d.Skills = sections(skillSections(from), skillSections(to))
+	d.Volunteering = sections(volunteeringSections(from), volunteeringSections(to))
+	return d
+}
+
+// Lines diffs two lists of lines using their longest common subsequence.
+func Lines(a, b []string) []LineChange {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if normalize(a[i]) == normalize(b[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	changes := make([]LineChange, 0, max(n, m))
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case normalize(a[i]) == normalize(b[j]):
+			changes = append(changes, LineChange{Op: OpEqual, Text: b[j]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			changes = append(changes, LineChange{Op: OpDelete, Text: a[i]})
+			i++
+		default:
+			changes = append(changes, LineChange{Op: OpInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		changes = append(changes, LineChange{Op: OpDelete, Text: a[i]})
+	}
+	for ; j < m; j++ {
+		changes = append(changes, LineChange{Op: OpInsert, Text: b[j]})
+	}
+	return changes
+}
+
+func sections(from, to []section) []SectionDiff {
+	var diffs []SectionDiff
+	matched := make([]bool, len(to))
+
+	for _, f := range from {
+		idx := -1
+		for j, t := range to {
+			if !matched[j] && sameSection(f, t) {
+				idx = j
+				break
+			}
+		}
+		if idx < 0 {
+			diffs = append(diffs, SectionDiff{Key: f.key, Status: StatusRemoved, Changes: Lines(f.lines, nil)})
+			continue
+		}
+		matched[idx] = true
+
+		t := to[idx]
+		fields := fieldChanges(f.fields, t.fields)
+		changes := Lines(f.lines, t.lines)
+		if len(fields) > 0 || hasChanges(changes) {
+			diffs = append(diffs, SectionDiff{Key: t.key, Status: StatusModified, Fields: fields, Changes: changes})
+		}
+	}
+
+	for j, t := range to {
+		if !matched[j] {
+			diffs = append(diffs, SectionDiff{Key: t.key, Status: StatusAdded, Changes: Lines(nil, t.lines)})
+		}
+	}
+	return diffs
+}
+
+func sameSection(a, b section) bool {
+	if a.id != "" && b.id != "" {
+		return a.id == b.id
+	}
+	return normalize(a.key) == normalize(b.key)
+}
+
+// fieldChanges compares every field either side has, in alphabetical order.
+func fieldChanges(from, to map[string]string) []FieldChange {
+	fields := make([]string, 0, len(from)+len(to))
+	for field := range from {
+		fields = append(fields, field)
+	}
+	for field := range to {
+		if _, ok := from[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	var changes []FieldChange
+	for _, field := range fields {
+		f, inFrom := from[field]
+		t, inTo := to[field]
+		if (inFrom || inTo) && f != t {
+			changes = append(changes, FieldChange{Field: field, From: f, To: t})
+		}
+	}
+	return changes
+}
+
+func summaryLines(r *domain.Resume) []string {
+	lines := make([]string, 0, len(r.Summary))
+	for _, s := range r.Summary {
+		lines = append(lines, s.Sentence)
+	}
+	return lines
+}
+
+func experienceSections(r *domain.Resume) []section {
+	out := make([]section, 0, len(r.Experiences))
+	for _, e := range r.Experiences {
+		out = append(out, section{
+			id:  e.ID,
+			key: strings.TrimSuffix(strings.TrimPrefix(e.Company+" - "+e.Position, " - "), " - "),
+			fields: map[string]string{
+				"company":  e.Company,
+				"position": e.Position,
+				"start":    e.Start,
+				"end":      e.End,
+			},
+			lines: bulletLines(e.BulletPoints),
+		})
+	}
+	return out
+}
+
+func projectSections(r *domain.Resume) []section {
+	out := make([]section, 0, len(r.Projects))
+	for _, p := range r.Projects {
+		out = append(out, section{
+			id:  p.ID,
+			key: p.Name,
+			fields: map[string]string{
+				"name":   p.Name,
+				"role":   p.Role,
+				"status": p.Status,
+			},
+			lines: bulletLines(p.BulletPoints),
+		})
+	}
+	return out
+}
+
+func volunteeringSections(r *domain.Resume) []section {
+	out := make([]section, 0, len(r.Volunteering))
+	for _, v := range r.Volunteering {
+		out = append(out, section{
+			id:  v.ID,
+			key: v.Organization,
+			fields: map[string]string{
+				"organization": v.Organization,
+				"role":         v.Role,
+				"start":        v.Start,
+				"end":          v.End,
+			},
+			lines: bulletLines(v.BulletPoints),
+		})
+	}
+	return out
+}
+
+func skillSections(r *domain.Resume) []section {
+	out := make([]section, 0, len(r.Skills))
+	for _, s := range r.Skills {
+		out = append(out, section{key: s.Category, lines: s.SkillItem})
+	}
+	return out
+}
+
+func bulletLines(points []domain.BulletPoint) []string {
+	lines := make([]string, 0, len(points))
+	for _, p := range points {
+		lines = append(lines, p.Text)
+	}
+	return lines
+}
+
+func hasChanges(changes []LineChange) bool {
+	for _, c := range changes {
+		if c.Op != OpEqual {
+			return true
+		}
+	}
+	return false
+}
+
+func normalize(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
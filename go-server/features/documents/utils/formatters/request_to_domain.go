@@ -31,3 +31,21 @@ func NewEducationInfoFromPayload(payload *requests.EducationInfoPayload) (*domai
 		Honors:     payload.Honors,
 	}, nil
 }
+
+// NewEducationListFromPayload converts every school in the payload. At least
+// one is required.
+func NewEducationListFromPayload(payload *requests.DocumentPayload) ([]domain.EducationInfo, error) {
+	schools := payload.Educations()
+	if len(schools) == 0 {
+		return nil, errors.New("school is a required field")
+	}
+	education := make([]domain.EducationInfo, 0, len(schools))
+	for i := range schools {
+		e, err := NewEducationInfoFromPayload(&schools[i])
+		if err != nil {
+			return nil, err
+		}
+		education = append(education, *e)
+	}
+	return education, nil
+}
@@ -2,6 +2,7 @@ package formatters
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/ordo_meritum/features/documents/models/requests"
@@ -14,16 +15,23 @@ func FormatResumeRequestForLLMWithXML(request *requests.DocumentPayload) string
 
 	sb.WriteString("<resume_content>\n")
 
+	if summary := strings.TrimSpace(request.UserInfo.Summary); summary != "" {
+		sb.WriteString(fmt.Sprintf("\t<summary%s>%s</summary>\n", lockedAttr(payload.IsLocked(requests.SectionSummary)), summary))
+	}
+
 	if len(payload.Experiences) > 0 {
 		sb.WriteString("\t<experiences>\n")
+		sectionLocked := payload.IsLocked(requests.SectionExperiences)
 		for _, exp := range payload.Experiences {
-			sb.WriteString("\t\t<job>\n")
+			entryLocked := exp.Locked || sectionLocked
+			sb.WriteString(fmt.Sprintf("\t\t<job%s>\n", lockedAttr(entryLocked)))
 			sb.WriteString(fmt.Sprintf("\t\t\t<position>%s</position>\n", exp.Position))
 			sb.WriteString(fmt.Sprintf("\t\t\t<company>%s</company>\n", exp.Company))
 			sb.WriteString(fmt.Sprintf("\t\t\t<dates>%s</dates>\n", exp.Years))
 			sb.WriteString("\t\t\t<experience_bullet_points>\n")
-			for _, point := range exp.BulletPoints {
-				sb.WriteString(fmt.Sprintf("\t\t\t\t<experience_bullet>%s</experience_bullet>\n", strings.TrimSpace(point)))
+			for i, point := range exp.BulletPoints {
+				locked := !entryLocked && slices.Contains(exp.LockedBullets, i)
+				sb.WriteString(fmt.Sprintf("\t\t\t\t<experience_bullet%s>%s</experience_bullet>\n", lockedAttr(locked), strings.TrimSpace(point)))
 			}
 			sb.WriteString("\t\t\t</experience_bullet_points>\n")
 			sb.WriteString("\t\t</job>\n")
@@ -33,13 +41,16 @@ func FormatResumeRequestForLLMWithXML(request *requests.DocumentPayload) string
 
 	if len(payload.Projects) > 0 {
 		sb.WriteString("\t<personal_projects>\n")
+		sectionLocked := payload.IsLocked(requests.SectionProjects)
 		for _, proj := range payload.Projects {
-			sb.WriteString("\t\t<project>\n")
+			entryLocked := proj.Locked || sectionLocked
+			sb.WriteString(fmt.Sprintf("\t\t<project%s>\n", lockedAttr(entryLocked)))
 			sb.WriteString(fmt.Sprintf("\t\t\t<project_name>%s</project_name>\n", proj.Name))
 			sb.WriteString(fmt.Sprintf("\t\t\t<candidate_role_in_project>%s</candidate_role_in_projec>\n", proj.Description))
 			sb.WriteString("\t\t\t<project_bullet_points>\n")
-			for _, point := range proj.BulletPoints {
-				sb.WriteString(fmt.Sprintf("\t\t\t\t<project_bullet>%s</project_bullet>\n", strings.TrimSpace(point)))
+			for i, point := range proj.BulletPoints {
+				locked := !entryLocked && slices.Contains(proj.LockedBullets, i)
+				sb.WriteString(fmt.Sprintf("\t\t\t\t<project_bullet%s>%s</project_bullet>\n", lockedAttr(locked), strings.TrimSpace(point)))
 			}
 			sb.WriteString("\t\t\t</project_bullet_points>\n")
 			sb.WriteString("\t\t</project>\n")
@@ -49,7 +60,7 @@ func FormatResumeRequestForLLMWithXML(request *requests.DocumentPayload) string
 
 	if len(payload.Skills) > 0 {
 		sb.WriteString("\t<skills_section>\n")
-		sb.WriteString("\t\t<skill_list>\n")
+		sb.WriteString(fmt.Sprintf("\t\t<skill_list%s>\n", lockedAttr(payload.IsLocked(requests.SectionSkills))))
 		for _, s := range payload.Skills {
 			sb.WriteString(fmt.Sprintf("\t\t\t<skill>%s</skill>\n", strings.TrimSpace(s.Skill)))
 		}
@@ -57,7 +68,64 @@ func FormatResumeRequestForLLMWithXML(request *requests.DocumentPayload) string
 		sb.WriteString("\t\t</skill_list>\n")
 		sb.WriteString("\t</skills_section>\n")
 	}
+
+	if len(payload.Volunteering) > 0 {
+		sb.WriteString("\t<volunteering>\n")
+		for _, v := range payload.Volunteering {
+			sb.WriteString("\t\t<volunteer_role>\n")
+			sb.WriteString(fmt.Sprintf("\t\t\t<role>%s</role>\n", v.Role))
+			sb.WriteString(fmt.Sprintf("\t\t\t<organization>%s</organization>\n", v.Organization))
+			sb.WriteString(fmt.Sprintf("\t\t\t<dates>%s</dates>\n", v.Years))
+			sb.WriteString("\t\t\t<volunteer_bullet_points>\n")
+			for _, point := range v.BulletPoints {
+				sb.WriteString(fmt.Sprintf("\t\t\t\t<volunteer_bullet>%s</volunteer_bullet>\n", strings.TrimSpace(point)))
+			}
+			sb.WriteString("\t\t\t</volunteer_bullet_points>\n")
+			sb.WriteString("\t\t</volunteer_role>\n")
+		}
+		sb.WriteString("\t</volunteering>\n")
+	}
+
+	if len(payload.Certifications) > 0 {
+		sb.WriteString("\t<certifications>\n")
+		for _, c := range payload.Certifications {
+			sb.WriteString(fmt.Sprintf("\t\t<certification><name>%s</name><issuer>%s</issuer><date>%s</date></certification>\n", c.Name, c.Issuer, c.Date))
+		}
+		sb.WriteString("\t</certifications>\n")
+	}
+
+	if len(payload.Publications) > 0 {
+		sb.WriteString("\t<publications>\n")
+		for _, p := range payload.Publications {
+			sb.WriteString(fmt.Sprintf("\t\t<publication><title>%s</title><publisher>%s</publisher><date>%s</date></publication>\n", p.Title, p.Publisher, p.Date))
+		}
+		sb.WriteString("\t</publications>\n")
+	}
+
+	if len(payload.Awards) > 0 {
+		sb.WriteString("\t<awards>\n")
+		for _, a := range payload.Awards {
+			sb.WriteString(fmt.Sprintf("\t\t<award><title>%s</title><awarder>%s</awarder><date>%s</date></award>\n", a.Title, a.Awarder, a.Date))
+		}
+		sb.WriteString("\t</awards>\n")
+	}
+
+	if len(payload.Languages) > 0 {
+		sb.WriteString("\t<languages>\n")
+		for _, l := range payload.Languages {
+			sb.WriteString(fmt.Sprintf("\t\t<language><name>%s</name><fluency>%s</fluency></language>\n", l.Language, l.Fluency))
+		}
+		sb.WriteString("\t</languages>\n")
+	}
 	sb.WriteString("</resume_content>")
 
 	return sb.String()
 }
+
+// lockedAttr marks an element whose wording the LLM must copy verbatim.
+func lockedAttr(locked bool) string {
+	if locked {
+		return ` locked="true"`
+	}
+	return ""
+}
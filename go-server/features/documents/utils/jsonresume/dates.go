@@ -0,0 +1,76 @@
+package jsonresume
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ordo_meritum/shared/libs/dates"
+)
+
+var isoDatePattern = regexp.MustCompile(`^\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\d|3[01]))?)?$`)
+
+// splitYears converts a payload date range such as "Jan. 2020 - Present" into
+// ISO 8601 start and end dates. An empty end date means the range is ongoing.
+// The original string is returned as raw whenever the ISO dates would not
+// format back to exactly the same text, so imports stay lossless.
+func splitYears(years string) (start, end, raw string) {
+	trimmed := strings.TrimSpace(years)
+	if trimmed == "" {
+		return "", "", ""
+	}
+
+	start, end, ok := parseRange(trimmed)
+	if !ok || joinYears(start, end) != years {
+		return start, end, years
+	}
+	return start, end, ""
+}
+
+// splitDate is splitYears for a single date such as an award's.
+func splitDate(date string) (iso, raw string) {
+	trimmed := strings.TrimSpace(date)
+	if trimmed == "" {
+		return "", ""
+	}
+	d, err := dates.ParseDate(trimmed)
+	if err != nil || d.IsZero() {
+		return "", date
+	}
+	iso = d.ISO()
+	if formatDate(iso) != date {
+		return iso, date
+	}
+	return iso, ""
+}
+
+// joinYears is the inverse of splitYears for dates without a raw override.
+func joinYears(start, end string) string {
+	if start == "" && end == "" {
+		return ""
+	}
+	if end == "" {
+		return formatDate(start) + " - Present"
+	}
+	return formatDate(start) + " - " + formatDate(end)
+}
+
+func parseRange(s string) (string, string, bool) {
+	r, err := dates.Parse(s)
+	switch {
+	case err != nil || r.Start.IsZero():
+		return "", "", false
+	case r.Ongoing:
+		return r.Start.ISO(), "", true
+	case r.End.IsZero():
+		return r.Start.ISO(), "", false
+	}
+	return r.Start.ISO(), r.End.ISO(), true
+}
+
+func formatDate(iso string) string {
+	d, err := dates.ParseDate(iso)
+	if err != nil {
+		return iso
+	}
+	return d.Format(dates.DefaultStyle)
+}
@@ -0,0 +1,292 @@
+package jsonresume
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/models/requests"
+)
+
+// FromDocumentPayload converts the client's base profile into a JSON Resume.
+// ToDocumentPayload reverses it without loss for every field we support.
+func FromDocumentPayload(p *requests.DocumentPayload) *Resume {
+	r := &Resume{
+		Schema: SchemaURL,
+		Basics: basicsFromUserInfo(&p.UserInfo),
+	}
+
+	for _, exp := range p.Resume.Experiences {
+		start, end, raw := splitYears(exp.Years)
+		r.Work = append(r.Work, Work{
+			Name:       exp.Company,
+			Position:   exp.Position,
+			StartDate:  start,
+			EndDate:    end,
+			Highlights: exp.BulletPoints,
+			Ext:        (&Extension{ID: exp.ID, Dates: raw}).orNil(),
+		})
+	}
+
+	for _, proj := range p.Resume.Projects {
+		start, end, raw := splitYears(proj.Years)
+		r.Projects = append(r.Projects, Project{
+			Name:        proj.Name,
+			Description: proj.Description,
+			Highlights:  proj.BulletPoints,
+			StartDate:   start,
+			EndDate:     end,
+			Ext:         (&Extension{ID: proj.ID, Dates: raw}).orNil(),
+		})
+	}
+
+	for _, s := range p.Resume.Skills {
+		r.Skills = append(r.Skills, Skill{Name: s.Skill})
+	}
+
+	for _, e := range p.Educations() {
+		r.Education = append(r.Education, FromEducationInfo(&e))
+	}
+
+	for _, v := range p.Resume.Volunteering {
+		start, end, raw := splitYears(v.Years)
+		r.Volunteer = append(r.Volunteer, Volunteer{
+			Organization: v.Organization,
+			Position:     v.Role,
+			StartDate:    start,
+			EndDate:      end,
+			Highlights:   v.BulletPoints,
+			Ext:          (&Extension{ID: v.ID, Dates: raw}).orNil(),
+		})
+	}
+
+	for _, a := range p.Resume.Awards {
+		date, raw := splitDate(a.Date)
+		r.Awards = append(r.Awards, Award{
+			Title:   a.Title,
+			Date:    date,
+			Awarder: a.Awarder,
+			Summary: a.Summary,
+			Ext:     (&Extension{ID: a.ID, Dates: raw}).orNil(),
+		})
+	}
+
+	for _, c := range p.Resume.Certifications {
+		date, raw := splitDate(c.Date)
+		r.Certificates = append(r.Certificates, Certificate{
+			Name:   c.Name,
+			Date:   date,
+			Issuer: c.Issuer,
+			URL:    c.URL,
+			Ext:    (&Extension{ID: c.ID, Dates: raw}).orNil(),
+		})
+	}
+
+	for _, pub := range p.Resume.Publications {
+		date, raw := splitDate(pub.Date)
+		r.Publications = append(r.Publications, Publication{
+			Name:        pub.Title,
+			Publisher:   pub.Publisher,
+			ReleaseDate: date,
+			URL:         pub.URL,
+			Summary:     pub.Summary,
+			Ext:         (&Extension{ID: pub.ID, Dates: raw}).orNil(),
+		})
+	}
+
+	for _, l := range p.Resume.Languages {
+		r.Languages = append(r.Languages, Language{
+			Language: l.Language,
+			Fluency:  l.Fluency,
+			Ext:      (&Extension{ID: l.ID}).orNil(),
+		})
+	}
+
+	return r
+}
+
+// FromDomainResume converts a tailored resume into a JSON Resume. Contact
+// details are optional since tailored resumes don't carry them.
+func FromDomainResume(
+	resume *domain.Resume,
+	userInfo *requests.UserInfoPayload,
+	education []domain.EducationInfo,
+) *Resume {
+	r := &Resume{
+		Schema: SchemaURL,
+		Meta:   &Meta{LastModified: time.Now().UTC().Format(time.RFC3339)},
+	}
+	if userInfo != nil {
+		r.Basics = basicsFromUserInfo(userInfo)
+	}
+
+	var summary []string
+	for _, s := range resume.Summary {
+		summary = append(summary, strings.TrimSpace(s.Sentence))
+	}
+	if len(summary) > 0 {
+		r.Basics.Summary = strings.Join(summary, " ")
+	}
+
+	for _, exp := range resume.Experiences {
+		start, end, raw := splitYears(joinDomainDates(exp.Start, exp.End))
+		r.Work = append(r.Work, Work{
+			Name:       exp.Company,
+			Position:   exp.Position,
+			StartDate:  start,
+			EndDate:    end,
+			Highlights: bulletTexts(exp.BulletPoints),
+			Ext:        (&Extension{ID: exp.ID, Dates: raw}).orNil(),
+		})
+	}
+
+	for _, proj := range resume.Projects {
+		p := Project{
+			Name:       proj.Name,
+			Highlights: bulletTexts(proj.BulletPoints),
+			Ext:        (&Extension{ID: proj.ID}).orNil(),
+		}
+		if proj.Role != "" {
+			p.Roles = []string{proj.Role}
+		}
+		r.Projects = append(r.Projects, p)
+	}
+
+	for _, s := range resume.Skills {
+		r.Skills = append(r.Skills, Skill{Name: s.Category, Keywords: s.SkillItem})
+	}
+
+	for _, v := range resume.Volunteering {
+		start, end, raw := splitYears(joinDomainDates(v.Start, v.End))
+		r.Volunteer = append(r.Volunteer, Volunteer{
+			Organization: v.Organization,
+			Position:     v.Role,
+			StartDate:    start,
+			EndDate:      end,
+			Highlights:   bulletTexts(v.BulletPoints),
+			Ext:          (&Extension{ID: v.ID, Dates: raw}).orNil(),
+		})
+	}
+
+	for _, a := range resume.Awards {
+		date, raw := splitDate(a.Date)
+		r.Awards = append(r.Awards, Award{Title: a.Title, Date: date, Awarder: a.Awarder, Summary: a.Summary, Ext: (&Extension{ID: a.ID, Dates: raw}).orNil()})
+	}
+	for _, c := range resume.Certifications {
+		date, raw := splitDate(c.Date)
+		r.Certificates = append(r.Certificates, Certificate{Name: c.Name, Date: date, Issuer: c.Issuer, URL: c.URL, Ext: (&Extension{ID: c.ID, Dates: raw}).orNil()})
+	}
+	for _, pub := range resume.Publications {
+		date, raw := splitDate(pub.Date)
+		r.Publications = append(r.Publications, Publication{Name: pub.Title, Publisher: pub.Publisher, ReleaseDate: date, URL: pub.URL, Summary: pub.Summary, Ext: (&Extension{ID: pub.ID, Dates: raw}).orNil()})
+	}
+	for _, l := range resume.Languages {
+		r.Languages = append(r.Languages, Language{Language: l.Language, Fluency: l.Fluency, Ext: (&Extension{ID: l.ID}).orNil()})
+	}
+
+	for _, e := range education {
+		r.Education = append(r.Education, educationFromInfo(
+			e.School, e.Degree, e.Location, e.StartEnd, e.CourseWork, e.GPA, e.Honors,
+		))
+	}
+
+	return r
+}
+
+// FromEducationInfo converts a single education entry.
+func FromEducationInfo(e *requests.EducationInfoPayload) Education {
+	return educationFromInfo(e.School, e.Degree, e.Location, e.StartEnd, e.CourseWork, e.GPA, e.Honors)
+}
+
+func basicsFromUserInfo(u *requests.UserInfoPayload) Basics {
+	b := Basics{
+		Name:    strings.TrimSpace(u.FirstName + " " + u.LastName),
+		Email:   u.Email,
+		Phone:   u.Mobile,
+		Summary: u.Summary,
+	}
+
+	ext := &Extension{}
+	if first, last := splitName(b.Name); first != u.FirstName || last != u.LastName {
+		ext.FirstName, ext.LastName = u.FirstName, u.LastName
+	}
+
+	if u.CurrentLocation != "" {
+		city, region, _ := strings.Cut(u.CurrentLocation, ", ")
+		b.Location = &Location{City: city, Region: region}
+		if joinLocation(b.Location) != u.CurrentLocation {
+			ext.Location = u.CurrentLocation
+		}
+	}
+	b.Ext = ext.orNil()
+
+	if u.Github != "" {
+		b.Profiles = append(b.Profiles, Profile{Network: "GitHub", URL: u.Github})
+	}
+	if u.Linkedin != "" {
+		b.Profiles = append(b.Profiles, Profile{Network: "LinkedIn", URL: u.Linkedin})
+	}
+
+	return b
+}
+
+func educationFromInfo(
+	school, degree, location, startEnd string,
+	coursework *string,
+	gpa *float64,
+	honors *string,
+) Education {
+	start, end, raw := splitYears(startEnd)
+	e := Education{
+		Institution: school,
+		StudyType:   degree,
+		StartDate:   start,
+		EndDate:     end,
+	}
+	ext := &Extension{Dates: raw, Location: location}
+
+	if gpa != nil {
+		e.Score = strconv.FormatFloat(*gpa, 'f', -1, 64)
+	}
+	if honors != nil {
+		ext.Honors = *honors
+	}
+	if coursework != nil && *coursework != "" {
+		e.Courses = splitCourses(*coursework)
+		if strings.Join(e.Courses, ", ") != *coursework {
+			ext.CourseWork = *coursework
+		}
+	}
+
+	e.Ext = ext.orNil()
+	return e
+}
+
+func joinDomainDates(start, end string) string {
+	if start == "" && end == "" {
+		return ""
+	}
+	if end == "" {
+		return start
+	}
+	return start + " - " + end
+}
+
+func bulletTexts(points []domain.BulletPoint) []string {
+	texts := make([]string, 0, len(points))
+	for _, p := range points {
+		texts = append(texts, p.Text)
+	}
+	return texts
+}
+
+func splitCourses(coursework string) []string {
+	var courses []string
+	for _, c := range strings.Split(coursework, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			courses = append(courses, c)
+		}
+	}
+	return courses
+}
@@ -0,0 +1,236 @@
+package jsonresume
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ordo_meritum/features/documents/models/requests"
+)
+
+// ToDocumentPayload maps a JSON Resume onto the payload the generation
+// endpoints expect. Every school goes in Education; the first is also set
+// as EducationInfo for clients that read a single school.
+func (r *Resume) ToDocumentPayload() requests.DocumentPayload {
+	payload := requests.DocumentPayload{
+		Resume:   r.ToResumePayload(),
+		UserInfo: r.ToUserInfoPayload(),
+	}
+	for i := range r.Education {
+		payload.Education = append(payload.Education, r.Education[i].ToEducationInfoPayload())
+	}
+	if len(payload.Education) > 0 {
+		payload.EducationInfo = payload.Education[0]
+	}
+	return payload
+}
+
+func (r *Resume) ToUserInfoPayload() requests.UserInfoPayload {
+	b := r.Basics
+	first, last := splitName(b.Name)
+	u := requests.UserInfoPayload{
+		FirstName: first,
+		LastName:  last,
+		Email:     b.Email,
+		Mobile:    b.Phone,
+		Summary:   b.Summary,
+	}
+
+	if b.Location != nil {
+		u.CurrentLocation = joinLocation(b.Location)
+	}
+
+	if !b.Ext.isEmpty() {
+		if b.Ext.FirstName != "" || b.Ext.LastName != "" {
+			u.FirstName, u.LastName = b.Ext.FirstName, b.Ext.LastName
+		}
+		if b.Ext.Location != "" {
+			u.CurrentLocation = b.Ext.Location
+		}
+	}
+
+	for _, p := range b.Profiles {
+		link := p.URL
+		if link == "" {
+			link = p.Username
+		}
+		switch strings.ToLower(p.Network) {
+		case "github":
+			u.Github = link
+		case "linkedin":
+			u.Linkedin = link
+		}
+	}
+
+	return u
+}
+
+func (r *Resume) ToResumePayload() requests.ResumePayload {
+	var payload requests.ResumePayload
+
+	for _, w := range r.Work {
+		exp := requests.ExperiencePayload{
+			BulletPoints: w.Highlights,
+			Company:      w.Name,
+			Position:     w.Position,
+			Years:        joinYears(w.StartDate, w.EndDate),
+		}
+		if w.Ext != nil {
+			exp.ID = w.Ext.ID
+			if w.Ext.Dates != "" {
+				exp.Years = w.Ext.Dates
+			}
+		}
+		payload.Experiences = append(payload.Experiences, exp)
+	}
+
+	for _, p := range r.Projects {
+		proj := requests.ProjectPayload{
+			BulletPoints: p.Highlights,
+			Description:  p.Description,
+			Name:         p.Name,
+			Years:        joinYears(p.StartDate, p.EndDate),
+		}
+		if proj.Description == "" && len(p.Roles) > 0 {
+			proj.Description = strings.Join(p.Roles, ", ")
+		}
+		if p.Ext != nil {
+			proj.ID = p.Ext.ID
+			if p.Ext.Dates != "" {
+				proj.Years = p.Ext.Dates
+			}
+		}
+		payload.Projects = append(payload.Projects, proj)
+	}
+
+	for _, s := range r.Skills {
+		if len(s.Keywords) == 0 {
+			payload.Skills = append(payload.Skills, requests.SkillsPayload{Skill: s.Name})
+			continue
+		}
+		for _, k := range s.Keywords {
+			payload.Skills = append(payload.Skills, requests.SkillsPayload{Skill: k})
+		}
+	}
+
+	for _, v := range r.Volunteer {
+		vol := requests.VolunteeringPayload{
+			BulletPoints: v.Highlights,
+			Organization: v.Organization,
+			Role:         v.Position,
+			Years:        joinYears(v.StartDate, v.EndDate),
+		}
+		if v.Ext != nil {
+			vol.ID = v.Ext.ID
+			if v.Ext.Dates != "" {
+				vol.Years = v.Ext.Dates
+			}
+		}
+		payload.Volunteering = append(payload.Volunteering, vol)
+	}
+
+	for _, a := range r.Awards {
+		award := requests.AwardPayload{Title: a.Title, Awarder: a.Awarder, Date: joinDate(a.Date, a.Ext), Summary: a.Summary}
+		if a.Ext != nil {
+			award.ID = a.Ext.ID
+		}
+		payload.Awards = append(payload.Awards, award)
+	}
+
+	for _, c := range r.Certificates {
+		cert := requests.CertificationPayload{Name: c.Name, Issuer: c.Issuer, Date: joinDate(c.Date, c.Ext), URL: c.URL}
+		if c.Ext != nil {
+			cert.ID = c.Ext.ID
+		}
+		payload.Certifications = append(payload.Certifications, cert)
+	}
+
+	for _, p := range r.Publications {
+		pub := requests.PublicationPayload{Title: p.Name, Publisher: p.Publisher, Date: joinDate(p.ReleaseDate, p.Ext), URL: p.URL, Summary: p.Summary}
+		if p.Ext != nil {
+			pub.ID = p.Ext.ID
+		}
+		payload.Publications = append(payload.Publications, pub)
+	}
+
+	for _, l := range r.Languages {
+		lang := requests.LanguagePayload{Language: l.Language, Fluency: l.Fluency}
+		if l.Ext != nil {
+			lang.ID = l.Ext.ID
+		}
+		payload.Languages = append(payload.Languages, lang)
+	}
+
+	return payload
+}
+
+// joinDate formats an ISO date for the payload unless the extension kept
+// the original wording.
+func joinDate(iso string, ext *Extension) string {
+	if ext != nil && ext.Dates != "" {
+		return ext.Dates
+	}
+	if iso == "" {
+		return ""
+	}
+	return formatDate(iso)
+}
+
+func (e *Education) ToEducationInfoPayload() requests.EducationInfoPayload {
+	info := requests.EducationInfoPayload{
+		Degree:   e.degree(),
+		School:   e.Institution,
+		StartEnd: joinYears(e.StartDate, e.EndDate),
+	}
+
+	if len(e.Courses) > 0 {
+		coursework := strings.Join(e.Courses, ", ")
+		info.CourseWork = &coursework
+	}
+	if gpa, err := strconv.ParseFloat(e.Score, 64); err == nil {
+		info.GPA = &gpa
+	}
+
+	if e.Ext != nil {
+		info.Location = e.Ext.Location
+		if e.Ext.Dates != "" {
+			info.StartEnd = e.Ext.Dates
+		}
+		if e.Ext.CourseWork != "" {
+			info.CourseWork = &e.Ext.CourseWork
+		}
+		if e.Ext.Honors != "" {
+			info.Honors = &e.Ext.Honors
+		}
+	}
+
+	return info
+}
+
+func (e *Education) degree() string {
+	switch {
+	case e.StudyType != "" && e.Area != "":
+		return e.StudyType + " in " + e.Area
+	case e.StudyType != "":
+		return e.StudyType
+	default:
+		return e.Area
+	}
+}
+
+func splitName(name string) (string, string) {
+	first, last, _ := strings.Cut(strings.TrimSpace(name), " ")
+	return first, strings.TrimSpace(last)
+}
+
+func joinLocation(l *Location) string {
+	parts := make([]string, 0, 2)
+	for _, p := range []string{l.City, l.Region} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	if len(parts) == 0 {
+		return l.CountryCode
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,131 @@
+package jsonresume
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ordo_meritum/features/documents/models/requests"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// fullPayload fills every section the JSON Resume export supports, with
+// values chosen to need the x-ordo extension: a two-word first name, a
+// location that isn't "City, Region", dates in non-default styles and
+// coursework that doesn't rejoin with ", ".
+func fullPayload() requests.DocumentPayload {
+	education := []requests.EducationInfoPayload{
+		{
+			CourseWork: ptr("Algorithms,Databases ,Compilers"),
+			Degree:     "BSc Computer Science",
+			Location:   "Toronto, ON",
+			School:     "University of Toronto",
+			StartEnd:   "Sept 2014 - Apr 2018",
+			GPA:        ptr(3.85),
+			Honors:     ptr("Dean's List"),
+		},
+		{
+			CourseWork: ptr("Distributed Systems, Machine Learning"),
+			Degree:     "MSc",
+			Location:   "Waterloo",
+			School:     "University of Waterloo",
+			StartEnd:   "Sep. 2018 - May 2020",
+		},
+	}
+	return requests.DocumentPayload{
+		UserInfo: requests.UserInfoPayload{
+			FirstName:       "Mary Ann",
+			LastName:        "Smith",
+			CurrentLocation: "Remote (EU)",
+			Email:           "mary@example.com",
+			Github:          "https://github.com/maryann",
+			Linkedin:        "https://linkedin.com/in/maryann",
+			Mobile:          "+1 555 0100",
+			Summary:         "Backend engineer.",
+		},
+		EducationInfo: education[0],
+		Education:     education,
+		Resume: requests.ResumePayload{
+			Skills: []requests.SkillsPayload{{Skill: "Go"}, {Skill: "PostgreSQL"}},
+			Experiences: []requests.ExperiencePayload{
+				{
+					BulletPoints: []string{"Built the billing service.", "Cut p99 latency by 40%."},
+					Company:      "Acme",
+					ID:           "exp-1",
+					Position:     "Senior Engineer",
+					Years:        "Jan. 2021 - Present",
+				},
+				{
+					BulletPoints: []string{"Shipped the mobile API."},
+					Company:      "Initech",
+					ID:           "exp-2",
+					Position:     "Engineer",
+					Years:        "03/2018 – 12/2020",
+				},
+			},
+			Projects: []requests.ProjectPayload{{
+				BulletPoints: []string{"Parses 10k resumes a day."},
+				Description:  "Resume parser",
+				ID:           "proj-1",
+				Name:         "ordo",
+				Years:        "Summer 2020",
+			}},
+			Certifications: []requests.CertificationPayload{{
+				ID:     "cert-1",
+				Name:   "CKA",
+				Issuer: "CNCF",
+				Date:   "Mar. 2022",
+				URL:    "https://example.com/cka",
+			}},
+			Publications: []requests.PublicationPayload{{
+				ID:        "pub-1",
+				Title:     "Fast Resume Parsing",
+				Publisher: "ACM",
+				Date:      "2019",
+				URL:       "https://example.com/paper",
+				Summary:   "A parser.",
+			}},
+			Volunteering: []requests.VolunteeringPayload{{
+				BulletPoints: []string{"Mentored 12 students."},
+				ID:           "vol-1",
+				Organization: "Code Club",
+				Role:         "Mentor",
+				Years:        "2017 - 2019",
+			}},
+			Awards: []requests.AwardPayload{{
+				ID:      "award-1",
+				Title:   "Hackathon Winner",
+				Awarder: "HackTO",
+				Date:    "Nov 2016",
+				Summary: "First place.",
+			}},
+			Languages: []requests.LanguagePayload{
+				{ID: "lang-1", Language: "English", Fluency: "Native"},
+				{ID: "lang-2", Language: "French", Fluency: "Professional"},
+			},
+		},
+	}
+}
+
+func TestDocumentPayloadRoundTrip(t *testing.T) {
+	want := fullPayload()
+
+	raw, err := json.Marshal(FromDocumentPayload(&want))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var exported Resume
+	if err := json.Unmarshal(raw, &exported); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	got := exported.ToDocumentPayload()
+
+	if !reflect.DeepEqual(got, want) {
+		gotJSON, _ := json.MarshalIndent(got, "", "  ")
+		wantJSON, _ := json.MarshalIndent(want, "", "  ")
+		t.Errorf("round trip changed the payload\ngot:  %s\nwant: %s", gotJSON, wantJSON)
+	}
+}
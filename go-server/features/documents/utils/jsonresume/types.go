@@ -0,0 +1,168 @@
+package jsonresume
+
+// SchemaURL is the jsonresume.org schema our exports declare.
+const SchemaURL = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+/*
+--- JSON Resume ---
+
+These structs mirror the subset of the jsonresume.org schema that maps onto
+our own payloads. Anything we need to round-trip that the schema has no home
+for (payload IDs, free-form date strings, etc.) goes under the "x-ordo"
+extension key, which JSON Resume tooling ignores.
+*/
+
+type Resume struct {
+	Schema    string      `json:"$schema,omitempty"`
+	Basics    Basics      `json:"basics"`
+	Work      []Work      `json:"work,omitempty"`
+	Education []Education `json:"education,omitempty"`
+	Skills    []Skill     `json:"skills,omitempty"`
+	Projects  []Project   `json:"projects,omitempty"`
+
+	Volunteer    []Volunteer   `json:"volunteer,omitempty"`
+	Awards       []Award       `json:"awards,omitempty"`
+	Certificates []Certificate `json:"certificates,omitempty"`
+	Publications []Publication `json:"publications,omitempty"`
+	Languages    []Language    `json:"languages,omitempty"`
+
+	Meta *Meta `json:"meta,omitempty"`
+}
+
+type Basics struct {
+	Name     string     `json:"name"`
+	Label    string     `json:"label,omitempty"`
+	Email    string     `json:"email,omitempty"`
+	Phone    string     `json:"phone,omitempty"`
+	URL      string     `json:"url,omitempty"`
+	Summary  string     `json:"summary,omitempty"`
+	Location *Location  `json:"location,omitempty"`
+	Profiles []Profile  `json:"profiles,omitempty"`
+	Ext      *Extension `json:"x-ordo,omitempty"`
+}
+
+type Location struct {
+	Address     string `json:"address,omitempty"`
+	PostalCode  string `json:"postalCode,omitempty"`
+	City        string `json:"city,omitempty"`
+	CountryCode string `json:"countryCode,omitempty"`
+	Region      string `json:"region,omitempty"`
+}
+
+type Profile struct {
+	Network  string `json:"network"`
+	Username string `json:"username,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+type Work struct {
+	Name       string     `json:"name"`
+	Position   string     `json:"position"`
+	URL        string     `json:"url,omitempty"`
+	StartDate  string     `json:"startDate,omitempty"`
+	EndDate    string     `json:"endDate,omitempty"`
+	Summary    string     `json:"summary,omitempty"`
+	Highlights []string   `json:"highlights,omitempty"`
+	Ext        *Extension `json:"x-ordo,omitempty"`
+}
+
+type Education struct {
+	Institution string     `json:"institution"`
+	URL         string     `json:"url,omitempty"`
+	Area        string     `json:"area,omitempty"`
+	StudyType   string     `json:"studyType,omitempty"`
+	StartDate   string     `json:"startDate,omitempty"`
+	EndDate     string     `json:"endDate,omitempty"`
+	Score       string     `json:"score,omitempty"`
+	Courses     []string   `json:"courses,omitempty"`
+	Ext         *Extension `json:"x-ordo,omitempty"`
+}
+
+type Skill struct {
+	Name     string   `json:"name"`
+	Level    string   `json:"level,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+type Project struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Highlights  []string   `json:"highlights,omitempty"`
+	Keywords    []string   `json:"keywords,omitempty"`
+	StartDate   string     `json:"startDate,omitempty"`
+	EndDate     string     `json:"endDate,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	Roles       []string   `json:"roles,omitempty"`
+	Ext         *Extension `json:"x-ordo,omitempty"`
+}
+
+type Volunteer struct {
+	Organization string     `json:"organization"`
+	Position     string     `json:"position,omitempty"`
+	URL          string     `json:"url,omitempty"`
+	StartDate    string     `json:"startDate,omitempty"`
+	EndDate      string     `json:"endDate,omitempty"`
+	Summary      string     `json:"summary,omitempty"`
+	Highlights   []string   `json:"highlights,omitempty"`
+	Ext          *Extension `json:"x-ordo,omitempty"`
+}
+
+type Award struct {
+	Title   string     `json:"title"`
+	Date    string     `json:"date,omitempty"`
+	Awarder string     `json:"awarder,omitempty"`
+	Summary string     `json:"summary,omitempty"`
+	Ext     *Extension `json:"x-ordo,omitempty"`
+}
+
+type Certificate struct {
+	Name   string     `json:"name"`
+	Date   string     `json:"date,omitempty"`
+	Issuer string     `json:"issuer,omitempty"`
+	URL    string     `json:"url,omitempty"`
+	Ext    *Extension `json:"x-ordo,omitempty"`
+}
+
+type Publication struct {
+	Name        string     `json:"name"`
+	Publisher   string     `json:"publisher,omitempty"`
+	ReleaseDate string     `json:"releaseDate,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	Summary     string     `json:"summary,omitempty"`
+	Ext         *Extension `json:"x-ordo,omitempty"`
+}
+
+type Language struct {
+	Language string     `json:"language"`
+	Fluency  string     `json:"fluency,omitempty"`
+	Ext      *Extension `json:"x-ordo,omitempty"`
+}
+
+type Meta struct {
+	Canonical    string `json:"canonical,omitempty"`
+	Version      string `json:"version,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// Extension holds the values that have no lossless home in the JSON Resume
+// schema. Only the fields needed to reproduce the original payload are set.
+type Extension struct {
+	ID         string `json:"id,omitempty"`
+	FirstName  string `json:"firstName,omitempty"`
+	LastName   string `json:"lastName,omitempty"`
+	Location   string `json:"location,omitempty"`
+	Dates      string `json:"dates,omitempty"`
+	CourseWork string `json:"coursework,omitempty"`
+	Honors     string `json:"honors,omitempty"`
+}
+
+func (e *Extension) isEmpty() bool {
+	return e == nil || *e == Extension{}
+}
+
+func (e *Extension) orNil() *Extension {
+	if e.isEmpty() {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,110 @@
+package jsonresume
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+// Validate checks the fields our payloads depend on. It is deliberately
+// narrower than the full JSON Resume schema: anything we ignore on import is
+// not validated either.
+func (r *Resume) Validate() []error_response.ValidationDetail {
+	var details []error_response.ValidationDetail
+	add := func(field, issue string) {
+		details = append(details, error_response.ValidationDetail{Field: field, Issue: issue})
+	}
+	checkDate := func(field, value string) {
+		if value != "" && !isoDatePattern.MatchString(value) {
+			add(field, "must be an ISO 8601 date (YYYY, YYYY-MM or YYYY-MM-DD)")
+		}
+	}
+
+	if strings.TrimSpace(r.Basics.Name) == "" {
+		add("basics.name", "is required")
+	}
+	if r.Basics.Email != "" {
+		if _, err := mail.ParseAddress(r.Basics.Email); err != nil {
+			add("basics.email", "is not a valid email address")
+		}
+	}
+
+	for i, w := range r.Work {
+		prefix := fmt.Sprintf("work[%d]", i)
+		if strings.TrimSpace(w.Name) == "" {
+			add(prefix+".name", "is required")
+		}
+		if strings.TrimSpace(w.Position) == "" {
+			add(prefix+".position", "is required")
+		}
+		checkDate(prefix+".startDate", w.StartDate)
+		checkDate(prefix+".endDate", w.EndDate)
+	}
+
+	for i, e := range r.Education {
+		prefix := fmt.Sprintf("education[%d]", i)
+		if strings.TrimSpace(e.Institution) == "" {
+			add(prefix+".institution", "is required")
+		}
+		checkDate(prefix+".startDate", e.StartDate)
+		checkDate(prefix+".endDate", e.EndDate)
+	}
+
+	for i, p := range r.Projects {
+		prefix := fmt.Sprintf("projects[%d]", i)
+		if strings.TrimSpace(p.Name) == "" {
+			add(prefix+".name", "is required")
+		}
+		checkDate(prefix+".startDate", p.StartDate)
+		checkDate(prefix+".endDate", p.EndDate)
+	}
+
+	for i, s := range r.Skills {
+		if strings.TrimSpace(s.Name) == "" && len(s.Keywords) == 0 {
+			add(fmt.Sprintf("skills[%d].name", i), "is required when keywords are empty")
+		}
+	}
+
+	for i, v := range r.Volunteer {
+		prefix := fmt.Sprintf("volunteer[%d]", i)
+		if strings.TrimSpace(v.Organization) == "" {
+			add(prefix+".organization", "is required")
+		}
+		checkDate(prefix+".startDate", v.StartDate)
+		checkDate(prefix+".endDate", v.EndDate)
+	}
+
+	for i, a := range r.Awards {
+		prefix := fmt.Sprintf("awards[%d]", i)
+		if strings.TrimSpace(a.Title) == "" {
+			add(prefix+".title", "is required")
+		}
+		checkDate(prefix+".date", a.Date)
+	}
+
+	for i, c := range r.Certificates {
+		prefix := fmt.Sprintf("certificates[%d]", i)
+		if strings.TrimSpace(c.Name) == "" {
+			add(prefix+".name", "is required")
+		}
+		checkDate(prefix+".date", c.Date)
+	}
+
+	for i, p := range r.Publications {
+		prefix := fmt.Sprintf("publications[%d]", i)
+		if strings.TrimSpace(p.Name) == "" {
+			add(prefix+".name", "is required")
+		}
+		checkDate(prefix+".releaseDate", p.ReleaseDate)
+	}
+
+	for i, l := range r.Languages {
+		if strings.TrimSpace(l.Language) == "" {
+			add(fmt.Sprintf("languages[%d].language", i), "is required")
+		}
+	}
+
+	return details
+}
@@ -53,3 +53,37 @@ func CopyTemplateAssets(sourceDir, destDir string) error {
 		return err
 	})
 }
+
+// InProcessTemplateDir returns the template directory the in-process
+// compiler builds with, read from LATEX_TEMPLATE_DIR. ok is false when that
+// isn't set or pdflatex isn't installed; compilation is then left to the
+// documents service.
+func InProcessTemplateDir() (dir string, ok bool) {
+	dir = os.Getenv("LATEX_TEMPLATE_DIR")
+	if dir == "" {
+		return "", false
+	}
+	if _, err := exec.LookPath("pdflatex"); err != nil {
+		return "", false
+	}
+	return dir, true
+}
+
+// CompileDocument builds doc in a scratch copy of templateDir and returns the
+// PDF.
+func CompileDocument(templateDir, doc string) ([]byte, error) {
+	workspace, err := os.MkdirTemp("", "resume-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+	defer os.RemoveAll(workspace)
+
+	if err := CopyTemplateAssets(templateDir, workspace); err != nil {
+		return nil, fmt.Errorf("failed to copy template assets: %w", err)
+	}
+	texPath := filepath.Join(workspace, "resume.tex")
+	if err := os.WriteFile(texPath, []byte(doc), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write resume source: %w", err)
+	}
+	return CompileToPDF(texPath)
+}
@@ -1 +1,30 @@
 package latex
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+)
+
+// CreateHeader renders the Awesome-CV personal information block. Contact
+// fields that are empty are left out.
+func CreateHeader(user domain.UserInfo) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\\name{%s}{%s}\n", EscapeChars(user.FirstName), EscapeChars(user.LastName))
+	for _, f := range []struct {
+		command string
+		value   string
+	}{
+		{"address", user.CurrentLocation},
+		{"mobile", user.Mobile},
+		{"email", user.Email},
+		{"github", user.Github},
+		{"linkedin", user.Linkedin},
+	} {
+		if f.value != "" {
+			fmt.Fprintf(&b, "\\%s{%s}\n", f.command, EscapeChars(f.value))
+		}
+	}
+	return b.String()
+}
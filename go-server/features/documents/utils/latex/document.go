@@ -0,0 +1,62 @@
+package latex
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+)
+
+// documentPreamble matches the class options and margins of the bundled
+// resume templates, so a document built here breaks pages where theirs do.
+const documentPreamble = `\documentclass[10pt, a4paper]{awesome-cv}
+\geometry{left=1.4cm, top=.8cm, right=1.4cm, bottom=1.8cm, footskip=.5cm}
+\fontdir[fonts/]
+`
+
+// ResumeDocument renders a complete resume the in-process compiler can build
+// from a template directory's class file and fonts alone.
+func ResumeDocument(user domain.UserInfo, education []domain.EducationInfo, resume *domain.Resume) string {
+	var b bytes.Buffer
+	b.WriteString(documentPreamble)
+	b.WriteString(CreateHeader(user))
+	b.WriteString("\\begin{document}\n\\makecvheader\n")
+	b.WriteString(summarySection(resume.Summary))
+	b.WriteString(educationSection(education))
+	b.WriteString(experienceSection(resume.Experiences))
+	b.WriteString(skillsSection(resume.Skills))
+	b.WriteString(projectsSection(resume.Projects))
+	b.WriteString(additionalSections(resume))
+	b.WriteString("\\end{document}\n")
+	return b.String()
+}
+
+func educationSection(education []domain.EducationInfo) string {
+	if len(education) == 0 {
+		return ""
+	}
+	var b bytes.Buffer
+	b.WriteString("\\cvsection{Education}\n\\begin{cventries}\n")
+	for _, e := range education {
+		var items string
+		if e.Honors != nil && *e.Honors != "" {
+			items += fmt.Sprintf("\n    \\item{%s}", EscapeChars(*e.Honors))
+		}
+		if e.CourseWork != nil && *e.CourseWork != "" {
+			items += fmt.Sprintf("\n    \\item{%s}", EscapeChars(*e.CourseWork))
+		}
+		if items != "" {
+			items = "\\begin{cvitems}" + items + "\n    \\end{cvitems}"
+		}
+		b.WriteString(fmt.Sprintf(`\cventry
+  {%s}
+  {%s}
+  {%s}
+  {%s}
+  {%s}`+"\n",
+			EscapeChars(e.Degree), EscapeChars(e.School),
+			EscapeChars(e.Location), EscapeChars(e.StartEnd), items))
+	}
+	b.WriteString("\\end{cventries}\n")
+	return b.String()
+}
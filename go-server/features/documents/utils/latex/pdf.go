@@ -0,0 +1,69 @@
+package latex
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"regexp"
+)
+
+// maxStreamSize caps how much of one compressed stream PageCount inflates.
+const maxStreamSize = 10 << 20
+
+var (
+	// pageObject matches a page's dictionary but not the /Pages tree nodes.
+	pageObject  = regexp.MustCompile(`/Type\s*/Page\b`)
+	streamStart = regexp.MustCompile(`stream\r?\n`)
+)
+
+// PageCount counts the pages in a PDF. Page objects are looked for both in
+// the file itself and in its compressed object streams, which pdflatex uses
+// by default.
+func PageCount(pdf []byte) (int, error) {
+	if !bytes.HasPrefix(pdf, []byte("%PDF-")) {
+		return 0, errors.New("not a PDF file")
+	}
+
+	count := 0
+	rest := pdf
+	for {
+		loc := streamStart.FindIndex(rest)
+		if loc == nil {
+			break
+		}
+		count += len(pageObject.FindAllIndex(rest[:loc[0]], -1))
+		rest = rest[loc[1]:]
+		end := bytes.Index(rest, []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		stream := rest[:end]
+		if inflated, ok := inflate(stream); ok {
+			stream = inflated
+		}
+		count += len(pageObject.FindAllIndex(stream, -1))
+		rest = rest[end+len("endstream"):]
+	}
+	count += len(pageObject.FindAllIndex(rest, -1))
+
+	if count == 0 {
+		return 0, errors.New("no pages found in PDF")
+	}
+	return count, nil
+}
+
+// inflate decompresses a FlateDecode stream. Streams that aren't compressed
+// that way report false and are searched as they are.
+func inflate(stream []byte) ([]byte, bool) {
+	r, err := zlib.NewReader(bytes.NewReader(stream))
+	if err != nil {
+		return nil, false
+	}
+	defer r.Close()
+	out, err := io.ReadAll(io.LimitReader(r, maxStreamSize))
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, false
+	}
+	return out, true
+}
@@ -14,6 +14,7 @@ func GenerateResumeSections(resume *domain.Resume) string {
 	sections.WriteString(experienceSection(resume.Experiences))
 	sections.WriteString(skillsSection(resume.Skills))
 	sections.WriteString(projectsSection(resume.Projects))
+	sections.WriteString(additionalSections(resume))
 	return sections.String()
 }
 
@@ -102,3 +103,103 @@ func projectsSection(projects []domain.Project) string {
 	b.WriteString("\\end{cventries}\n")
 	return b.String()
 }
+
+// additionalSections renders the optional sections in the order the
+// templates place them after projects.
+func additionalSections(resume *domain.Resume) string {
+	var b bytes.Buffer
+	b.WriteString(volunteeringSection(resume.Volunteering))
+	b.WriteString(certificationsSection(resume.Certifications))
+	b.WriteString(publicationsSection(resume.Publications))
+	b.WriteString(awardsSection(resume.Awards))
+	b.WriteString(languagesSection(resume.Languages))
+	return b.String()
+}
+
+func volunteeringSection(volunteering []domain.Volunteering) string {
+	if len(volunteering) == 0 {
+		return ""
+	}
+	var b bytes.Buffer
+	b.WriteString("\\cvsection{Volunteering}\n\\begin{cventries}\n")
+	for _, v := range volunteering {
+		var descItems string
+		for _, item := range v.BulletPoints {
+			descItems += fmt.Sprintf("\n    \\item{%s}", EscapeChars(item.Text))
+		}
+		if descItems != "" {
+			descItems = "\\begin{cvitems}" + descItems + "\n    \\end{cvitems}"
+		}
+		dates := EscapeChars(v.Start)
+		if v.End != "" {
+			dates += " -- " + EscapeChars(v.End)
+		}
+		entry := fmt.Sprintf(`\cventry
+  {%s}
+  {%s}
+  {}
+  {%s}
+  {%s}`+"\n",
+			EscapeChars(v.Role), EscapeChars(v.Organization), dates, descItems)
+		b.WriteString(entry)
+	}
+	b.WriteString("\\end{cventries}\n")
+	return b.String()
+}
+
+func certificationsSection(certifications []domain.Certification) string {
+	if len(certifications) == 0 {
+		return ""
+	}
+	var b bytes.Buffer
+	b.WriteString("\\cvsection{Certifications}\n\\begin{cvhonors}\n")
+	for _, c := range certifications {
+		b.WriteString(honor(c.Name, c.Issuer, c.Date))
+	}
+	b.WriteString("\\end{cvhonors}\n")
+	return b.String()
+}
+
+func publicationsSection(publications []domain.Publication) string {
+	if len(publications) == 0 {
+		return ""
+	}
+	var b bytes.Buffer
+	b.WriteString("\\cvsection{Publications}\n\\begin{cvhonors}\n")
+	for _, p := range publications {
+		b.WriteString(honor(p.Title, p.Publisher, p.Date))
+	}
+	b.WriteString("\\end{cvhonors}\n")
+	return b.String()
+}
+
+func awardsSection(awards []domain.Award) string {
+	if len(awards) == 0 {
+		return ""
+	}
+	var b bytes.Buffer
+	b.WriteString("\\cvsection{Awards}\n\\begin{cvhonors}\n")
+	for _, a := range awards {
+		b.WriteString(honor(a.Title, a.Awarder, a.Date))
+	}
+	b.WriteString("\\end{cvhonors}\n")
+	return b.String()
+}
+
+func languagesSection(languages []domain.Language) string {
+	if len(languages) == 0 {
+		return ""
+	}
+	var b bytes.Buffer
+	b.WriteString("\\cvsection{Languages}\n\\begin{cvskills}\n")
+	for _, l := range languages {
+		b.WriteString(fmt.Sprintf("\\cvskill\n\t{%s}\n\t{%s}\n", EscapeChars(l.Language), EscapeChars(l.Fluency)))
+	}
+	b.WriteString("\\end{cvskills}\n")
+	return b.String()
+}
+
+// honor is one line of a cvhonors list: what it is, who gave it and when.
+func honor(title, from, date string) string {
+	return fmt.Sprintf("\\cvhonor\n  {%s}\n  {%s}\n  {}\n  {%s}\n", EscapeChars(title), EscapeChars(from), EscapeChars(date))
+}
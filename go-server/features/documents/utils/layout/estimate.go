@@ -0,0 +1,154 @@
+package layout
+
+import (
+	"strings"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+)
+
+// Estimate predicts how many lines the resume takes up when rendered in t,
+// section by section.
+func Estimate(t Template, resume *domain.Resume, education []domain.EducationInfo) domain.LayoutEstimate {
+	est := domain.LayoutEstimate{
+		Template:     t.Name,
+		LinesPerPage: t.LinesPerPage,
+		Sections:     []domain.SectionLines{{Section: "header", Lines: t.HeaderLines}},
+	}
+
+	if len(resume.Summary) > 0 {
+		sentences := make([]string, 0, len(resume.Summary))
+		for _, s := range resume.Summary {
+			sentences = append(sentences, s.Sentence)
+		}
+		est.Sections = append(est.Sections, domain.SectionLines{
+			Section: "summary",
+			Lines:   t.SectionLines + wrappedLines(strings.Join(sentences, " "), t.TextChars),
+		})
+	}
+
+	if len(education) > 0 {
+		lines := t.SectionLines
+		for _, e := range education {
+			lines += t.EntryLines
+			if e.CourseWork != nil {
+				lines += wrappedLines(*e.CourseWork, t.TextChars)
+			}
+			if e.Honors != nil {
+				lines += wrappedLines(*e.Honors, t.TextChars)
+			}
+		}
+		est.Sections = append(est.Sections, domain.SectionLines{Section: "education", Lines: lines})
+	}
+
+	if len(resume.Experiences) > 0 {
+		lines := t.SectionLines
+		for _, e := range resume.Experiences {
+			lines += t.EntryLines + bulletLines(t, e.BulletPoints)
+		}
+		est.Sections = append(est.Sections, domain.SectionLines{Section: "experiences", Lines: lines})
+	}
+
+	if len(resume.Skills) > 0 {
+		lines := t.SectionLines
+		for _, s := range resume.Skills {
+			lines += wrappedLines(s.Category+"  "+strings.Join(s.SkillItem, ", "), t.SkillChars)
+		}
+		est.Sections = append(est.Sections, domain.SectionLines{Section: "skills", Lines: lines})
+	}
+
+	if len(resume.Projects) > 0 {
+		lines := t.SectionLines
+		for _, p := range resume.Projects {
+			lines += t.EntryLines + bulletLines(t, p.BulletPoints)
+		}
+		est.Sections = append(est.Sections, domain.SectionLines{Section: "projects", Lines: lines})
+	}
+
+	if len(resume.Volunteering) > 0 {
+		lines := t.SectionLines
+		for _, v := range resume.Volunteering {
+			lines += t.EntryLines + bulletLines(t, v.BulletPoints)
+		}
+		est.Sections = append(est.Sections, domain.SectionLines{Section: "volunteering", Lines: lines})
+	}
+
+	// The list sections print one line per entry unless it wraps.
+	listSection := func(name string, entries []string) {
+		if len(entries) == 0 {
+			return
+		}
+		lines := t.SectionLines
+		for _, e := range entries {
+			lines += wrappedLines(e, t.TextChars)
+		}
+		est.Sections = append(est.Sections, domain.SectionLines{Section: name, Lines: lines})
+	}
+	var entries []string
+	for _, c := range resume.Certifications {
+		entries = append(entries, c.Name+"  "+c.Issuer+"  "+c.Date)
+	}
+	listSection("certifications", entries)
+	entries = nil
+	for _, p := range resume.Publications {
+		entries = append(entries, p.Title+"  "+p.Publisher+"  "+p.Date)
+	}
+	listSection("publications", entries)
+	entries = nil
+	for _, a := range resume.Awards {
+		entries = append(entries, a.Title+"  "+a.Awarder+"  "+a.Date)
+	}
+	listSection("awards", entries)
+	entries = nil
+	for _, l := range resume.Languages {
+		entries = append(entries, l.Language+"  "+l.Fluency)
+	}
+	listSection("languages", entries)
+
+	for _, s := range est.Sections {
+		est.Lines += s.Lines
+	}
+	est.Pages = pages(est.Lines, t.LinesPerPage)
+	return est
+}
+
+func bulletLines(t Template, bullets []domain.BulletPoint) int {
+	lines := 0
+	for _, b := range bullets {
+		lines += wrappedLines(b.Text, t.BulletChars)
+	}
+	return lines
+}
+
+// wrappedLines counts the lines text fills when wrapped word by word at
+// width characters. Blank text takes no lines.
+func wrappedLines(text string, width int) int {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return 0
+	}
+	lines, used := 1, 0
+	for _, w := range words {
+		switch {
+		case used == 0:
+			used = len(w)
+		case used+1+len(w) <= width:
+			used += 1 + len(w)
+		default:
+			lines++
+			used = len(w)
+		}
+		// A word longer than a line spills over on its own.
+		for used > width {
+			lines++
+			used -= width
+		}
+	}
+	return lines
+}
+
+func pages(lines, perPage int) int {
+	if lines <= 0 || perPage <= 0 {
+		return 0
+	}
+	return (lines + perPage - 1) / perPage
+}
@@ -0,0 +1,87 @@
+package layout
+
+import "github.com/ordo_meritum/shared/libs/dates"
+
+// Template holds the measurements the estimator needs for one LaTeX
+// template. Line counts are in body lines; taller elements such as section
+// titles are rounded up to whole lines.
+type Template struct {
+	Name         string
+	LinesPerPage int
+	// HeaderLines is the name and contact block at the top of the first page.
+	HeaderLines int
+	// SectionLines is a section title with the space around it.
+	SectionLines int
+	// EntryLines is the title, organization and dates of one experience,
+	// project or school.
+	EntryLines int
+	// TextChars is how many characters fit on a line of running text, such
+	// as the summary; BulletChars and SkillChars do the same for bullets and
+	// skill rows.
+	TextChars   int
+	BulletChars int
+	SkillChars  int
+	// Dates is how the template prints date ranges.
+	Dates dates.Style
+}
+
+// DefaultTemplate is the template the documents service compiles resumes
+// with.
+const DefaultTemplate = "original-template"
+
+// originalDates prints ranges the way the original template's partials
+// spell them out, e.g. "Jun. 2012 - PRESENT".
+var originalDates = dates.Style{MonthLayout: "Jan. 2006", Separator: " - ", Ongoing: "PRESENT"}
+
+// harvardDates follows the harvard template's partials, which abbreviate
+// months the same way ("Jul. 2023 - Aug. 2024") and also mark ongoing
+// entries "PRESENT". It is kept separate so either template can change
+// without the other.
+var harvardDates = dates.Style{MonthLayout: "Jan. 2006", Separator: " - ", Ongoing: "PRESENT"}
+
+// awesomeCV is the original template: Awesome-CV at 10pt on A4 with the
+// margins both bundled templates use.
+var awesomeCV = Template{
+	LinesPerPage: 61,
+	HeaderLines:  8,
+	SectionLines: 3,
+	EntryLines:   3,
+	TextChars:    100,
+	BulletChars:  110,
+	SkillChars:   100,
+	Dates:        originalDates,
+}
+
+// harvard is the harvard template's Awesome-CV variant on the same page:
+// Arial instead of Calibri fits fewer characters on a line, body text is
+// set at 1em instead of 1.2em so more lines fit on a page, and the larger
+// name, larger section titles and wider section skips take up more of them.
+var harvard = Template{
+	LinesPerPage: 73,
+	HeaderLines:  11,
+	SectionLines: 5,
+	EntryLines:   4,
+	TextChars:    88,
+	BulletChars:  97,
+	SkillChars:   88,
+	Dates:        harvardDates,
+}
+
+var templates = map[string]Template{
+	"original-template": named("original-template", awesomeCV),
+	"harvard-template":  named("harvard-template", harvard),
+}
+
+func named(name string, t Template) Template {
+	t.Name = name
+	return t
+}
+
+// Lookup returns the named template's measurements, falling back to the
+// default template for an empty or unknown name.
+func Lookup(name string) Template {
+	if t, ok := templates[name]; ok {
+		return t
+	}
+	return templates[DefaultTemplate]
+}
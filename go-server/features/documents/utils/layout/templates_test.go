@@ -0,0 +1,37 @@
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+)
+
+func TestTemplatesMeasureTheSameResumeDifferently(t *testing.T) {
+	// A 100-character bullet fits on one line of the original template but
+	// wraps in the harvard template's wider font.
+	bullet := domain.BulletPoint{Text: strings.TrimSpace(strings.Repeat("word ", 20)[:99]) + "."}
+	resume := &domain.Resume{
+		Skills: []domain.Skills{{Category: "Languages", SkillItem: []string{"Go", "SQL", "Python"}}},
+		Experiences: []domain.Experience{{
+			Company: "Acme", Position: "Engineer",
+			BulletPoints: []domain.BulletPoint{bullet, bullet, bullet},
+		}},
+	}
+
+	original := Estimate(Lookup("original-template"), resume, nil)
+	harvard := Estimate(Lookup("harvard-template"), resume, nil)
+
+	if original.Lines == harvard.Lines {
+		t.Fatalf("both templates estimate %d lines", original.Lines)
+	}
+	if got := bulletLines(Lookup("original-template"), []domain.BulletPoint{bullet}); got != 1 {
+		t.Errorf("original bullet lines = %d, want 1", got)
+	}
+	if got := bulletLines(Lookup("harvard-template"), []domain.BulletPoint{bullet}); got != 2 {
+		t.Errorf("harvard bullet lines = %d, want 2", got)
+	}
+	if original.LinesPerPage == harvard.LinesPerPage {
+		t.Errorf("both templates fit %d lines on a page", original.LinesPerPage)
+	}
+}
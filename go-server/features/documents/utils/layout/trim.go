@@ -0,0 +1,130 @@
+package layout
+
+import (
+	"strings"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/models/requests"
+	"github.com/ordo_meritum/shared/libs/taxonomy"
+)
+
+// minBullets is how many bullets Trim leaves on every experience and
+// project, so no entry is reduced to a bare title.
+const minBullets = 1
+
+// projectPenalty ranks project bullets below experience bullets of the same
+// relevance, since work history matters more to most readers.
+const projectPenalty = 2
+
+// TrimOptions controls how far Trim goes and what it may drop.
+type TrimOptions struct {
+	// MaxLines is the space the resume has to fit in.
+	MaxLines int
+	// Keywords are the posting's skills. Bullets that mention fewer of them
+	// are dropped first.
+	Keywords []string
+	// Keep reports bullets that must stay, such as locked ones. May be nil.
+	Keep func(text string) bool
+}
+
+// candidate is a bullet Trim could drop.
+type candidate struct {
+	section string
+	entry   int
+	bullet  int
+	score   int
+}
+
+// Trim drops the lowest-priority bullets from the resume until its estimate
+// fits in opts.MaxLines or nothing more may go, and returns what it dropped.
+func Trim(t Template, resume *domain.Resume, education []domain.EducationInfo, opts TrimOptions) []domain.TrimmedBullet {
+	keywords := keywordKeys(opts.Keywords)
+	var trimmed []domain.TrimmedBullet
+	for Estimate(t, resume, education).Lines > opts.MaxLines {
+		c, ok := lowestPriority(resume, keywords, opts.Keep)
+		if !ok {
+			break
+		}
+		trimmed = append(trimmed, c.remove(resume))
+	}
+	return trimmed
+}
+
+// lowestPriority picks the bullet to drop next: the one that mentions the
+// fewest posting keywords, with later bullets, later entries and projects
+// going before earlier ones.
+func lowestPriority(resume *domain.Resume, keywords []string, keep func(string) bool) (candidate, bool) {
+	var best candidate
+	found := false
+	consider := func(section string, entry int, bullets []domain.BulletPoint, penalty int) {
+		if len(bullets) <= minBullets {
+			return
+		}
+		for i, b := range bullets {
+			if keep != nil && keep(b.Text) {
+				continue
+			}
+			c := candidate{
+				section: section,
+				entry:   entry,
+				bullet:  i,
+				score:   4*keywordHits(b.Text, keywords) - i - entry - penalty,
+			}
+			if !found || c.score <= best.score {
+				best, found = c, true
+			}
+		}
+	}
+
+	for i, e := range resume.Experiences {
+		consider(requests.SectionExperiences, i, e.BulletPoints, 0)
+	}
+	for i, p := range resume.Projects {
+		consider(requests.SectionProjects, i, p.BulletPoints, projectPenalty)
+	}
+	return best, found
+}
+
+func (c candidate) remove(resume *domain.Resume) domain.TrimmedBullet {
+	var bullets *[]domain.BulletPoint
+	var key string
+	if c.section == requests.SectionExperiences {
+		e := &resume.Experiences[c.entry]
+		bullets, key = &e.BulletPoints, strings.Trim(e.Company+" - "+e.Position, " -")
+	} else {
+		p := &resume.Projects[c.entry]
+		bullets, key = &p.BulletPoints, p.Name
+	}
+
+	text := (*bullets)[c.bullet].Text
+	*bullets = append((*bullets)[:c.bullet:c.bullet], (*bullets)[c.bullet+1:]...)
+	return domain.TrimmedBullet{Section: c.section, Key: key, Text: text}
+}
+
+// keywordKeys canonicalizes the keywords once so each bullet only has to be
+// canonicalized itself.
+func keywordKeys(keywords []string) []string {
+	tax := taxonomy.Default()
+	seen := make(map[string]bool, len(keywords))
+	keys := make([]string, 0, len(keywords))
+	for _, k := range keywords {
+		key := tax.CanonicalText(k)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func keywordHits(text string, keywords []string) int {
+	haystack := " " + taxonomy.Default().CanonicalText(text) + " "
+	hits := 0
+	for _, k := range keywords {
+		if strings.Contains(haystack, " "+k+" ") {
+			hits++
+		}
+	}
+	return hits
+}
@@ -0,0 +1,320 @@
+// Package parsability checks that a compiled resume PDF reads back, through
+// plain text extraction, as the resume it was compiled from.
+package parsability
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/models/requests"
+	"github.com/ordo_meritum/features/documents/utils/pdftext"
+)
+
+// ErrUnreadablePDF is returned when the document can't be parsed as a PDF.
+var ErrUnreadablePDF = errors.New("unreadable PDF")
+
+// minGarbledCoverage is the share of a line's words that must be extracted
+// for it to count as garbled rather than missing.
+const minGarbledCoverage = 0.5
+
+// nationalDigits is how many trailing digits of a phone number are compared.
+const nationalDigits = 10
+
+// sourceLine is a line of the resume the extracted text should contain.
+type sourceLine struct {
+	key  string
+	text string
+}
+
+type section struct {
+	name  string
+	lines []sourceLine
+}
+
+// Check extracts the text of a compiled resume and compares it, section by
+// section, with the resume and contact details it was compiled from.
+func Check(pdf []byte, user domain.UserInfo, education []domain.EducationInfo, resume *domain.Resume) (*domain.ParsabilityReport, error) {
+	extracted, err := pdftext.Extract(pdf)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnreadablePDF, err)
+	}
+
+	text := extracted.Text()
+	haystack := newHaystack(text)
+	report := &domain.ParsabilityReport{
+		Parsable:  true,
+		Pages:     len(extracted.Pages),
+		Ligatures: extracted.Ligatures,
+		Text:      text,
+	}
+	for i, p := range extracted.Pages {
+		if p.MultiColumn {
+			report.MultiColumnPages = append(report.MultiColumnPages, i+1)
+		}
+	}
+	for _, u := range extracted.Unmapped {
+		report.UnmappedGlyphs = append(report.UnmappedGlyphs, domain.UnmappedGlyph{
+			Font:  fontName(u.Font),
+			Code:  fmt.Sprintf("%02X", u.Code),
+			Count: u.Count,
+		})
+	}
+
+	for _, s := range sections(education, resume) {
+		result := haystack.section(s)
+		if result.Status != domain.ExtractionOK {
+			report.Parsable = false
+		}
+		report.Sections = append(report.Sections, result)
+	}
+
+	for _, c := range haystack.contact(user) {
+		if !c.Found {
+			report.Parsable = false
+		}
+		report.Contact = append(report.Contact, c)
+	}
+	return report, nil
+}
+
+func sections(education []domain.EducationInfo, resume *domain.Resume) []section {
+	var out []section
+
+	if len(resume.Summary) > 0 {
+		s := section{name: requests.SectionSummary}
+		for _, sentence := range resume.Summary {
+			s.lines = append(s.lines, sourceLine{text: sentence.Sentence})
+		}
+		out = append(out, s)
+	}
+
+	if len(education) > 0 {
+		s := section{name: requests.SectionEducation}
+		for _, e := range education {
+			s.lines = append(s.lines, sourceLine{key: e.School, text: e.School}, sourceLine{key: e.School, text: e.Degree})
+		}
+		out = append(out, s)
+	}
+
+	if len(resume.Experiences) > 0 {
+		s := section{name: requests.SectionExperiences}
+		for _, e := range resume.Experiences {
+			key := strings.Trim(e.Company+" - "+e.Position, " -")
+			s.lines = append(s.lines, sourceLine{key: key, text: e.Position}, sourceLine{key: key, text: e.Company})
+			for _, b := range e.BulletPoints {
+				s.lines = append(s.lines, sourceLine{key: key, text: b.Text})
+			}
+		}
+		out = append(out, s)
+	}
+
+	if len(resume.Skills) > 0 {
+		s := section{name: requests.SectionSkills}
+		for _, sk := range resume.Skills {
+			for _, item := range sk.SkillItem {
+				s.lines = append(s.lines, sourceLine{key: sk.Category, text: item})
+			}
+		}
+		out = append(out, s)
+	}
+
+	if len(resume.Projects) > 0 {
+		s := section{name: requests.SectionProjects}
+		for _, p := range resume.Projects {
+			s.lines = append(s.lines, sourceLine{key: p.Name, text: p.Name})
+			for _, b := range p.BulletPoints {
+				s.lines = append(s.lines, sourceLine{key: p.Name, text: b.Text})
+			}
+		}
+		out = append(out, s)
+	}
+
+	if len(resume.Volunteering) > 0 {
+		s := section{name: requests.SectionVolunteering}
+		for _, v := range resume.Volunteering {
+			s.lines = append(s.lines, sourceLine{key: v.Organization, text: v.Role}, sourceLine{key: v.Organization, text: v.Organization})
+			for _, b := range v.BulletPoints {
+				s.lines = append(s.lines, sourceLine{key: v.Organization, text: b.Text})
+			}
+		}
+		out = append(out, s)
+	}
+
+	out = appendList(out, requests.SectionCertifications, resume.Certifications, func(c domain.Certification) string { return c.Name })
+	out = appendList(out, requests.SectionPublications, resume.Publications, func(p domain.Publication) string { return p.Title })
+	out = appendList(out, requests.SectionAwards, resume.Awards, func(a domain.Award) string { return a.Title })
+	out = appendList(out, requests.SectionLanguages, resume.Languages, func(l domain.Language) string { return l.Language })
+	return out
+}
+
+// appendList adds a section whose entries are checked by name alone.
+func appendList[T any](out []section, name string, items []T, title func(T) string) []section {
+	if len(items) == 0 {
+		return out
+	}
+	s := section{name: name}
+	for _, item := range items {
+		t := title(item)
+		s.lines = append(s.lines, sourceLine{key: t, text: t})
+	}
+	return append(out, s)
+}
+
+// haystack is the extracted text in the forms lines are looked up in.
+type haystack struct {
+	text    string // normalized, space padded
+	compact string // normalized without spaces
+	digits  string
+	words   map[string]bool
+}
+
+func newHaystack(text string) *haystack {
+	norm := normalize(dehyphenate(text))
+	h := &haystack{
+		text:    " " + norm + " ",
+		compact: strings.ReplaceAll(norm, " ", ""),
+		digits:  digitsOnly(text),
+		words:   map[string]bool{},
+	}
+	for _, w := range strings.Fields(norm) {
+		h.words[w] = true
+	}
+	return h
+}
+
+func (h *haystack) section(s section) domain.SectionExtraction {
+	result := domain.SectionExtraction{Section: s.name, Status: domain.ExtractionOK}
+	found, total, missing, checked := 0, 0, 0, 0
+	for _, l := range s.lines {
+		norm := normalize(l.text)
+		if norm == "" {
+			continue
+		}
+		checked++
+		words := strings.Fields(norm)
+		hits := 0
+		for _, w := range words {
+			if h.words[w] {
+				hits++
+			}
+		}
+		found += hits
+		total += len(words)
+
+		status := h.line(norm, float64(hits)/float64(len(words)))
+		if status == domain.ExtractionOK {
+			continue
+		}
+		if status == domain.ExtractionMissing {
+			missing++
+		}
+		result.Status = domain.ExtractionGarbled
+		result.Problems = append(result.Problems, domain.LineExtraction{Key: l.key, Text: l.text, Status: status})
+	}
+
+	if total > 0 {
+		result.Coverage = found * 100 / total
+	}
+	if checked > 0 && missing == checked {
+		result.Status = domain.ExtractionMissing
+	}
+	return result
+}
+
+// line grades one normalized resume line: intact if it appears word for
+// word, garbled if it appears with its spacing lost or enough of its words
+// appear elsewhere, and missing otherwise.
+func (h *haystack) line(norm string, coverage float64) domain.ExtractionStatus {
+	switch {
+	case strings.Contains(h.text, " "+norm+" "):
+		return domain.ExtractionOK
+	case strings.Contains(h.compact, strings.ReplaceAll(norm, " ", "")),
+		coverage >= minGarbledCoverage:
+		return domain.ExtractionGarbled
+	}
+	return domain.ExtractionMissing
+}
+
+func (h *haystack) contact(user domain.UserInfo) []domain.ContactExtraction {
+	var out []domain.ContactExtraction
+	add := func(field, value string, found bool) {
+		if strings.TrimSpace(value) != "" {
+			out = append(out, domain.ContactExtraction{Field: field, Value: value, Found: found})
+		}
+	}
+
+	fullName := strings.TrimSpace(user.FirstName + " " + user.LastName)
+	add("name", fullName, h.containsAll(fullName))
+	add("email", user.Email, strings.Contains(h.compact, strings.ReplaceAll(normalize(user.Email), " ", "")))
+	if d := digitsOnly(user.Mobile); d != "" {
+		// Templates often print the number without its country code.
+		if len(d) > nationalDigits {
+			d = d[len(d)-nationalDigits:]
+		}
+		add("mobile", user.Mobile, strings.Contains(h.digits, d))
+	}
+	add("location", user.CurrentLocation, h.containsAll(user.CurrentLocation))
+	add("github", user.Github, h.containsAll(handle(user.Github)))
+	add("linkedin", user.Linkedin, h.containsAll(handle(user.Linkedin)))
+	return out
+}
+
+// containsAll reports whether every word of value was extracted, which
+// tolerates templates that split a name or address across styled runs.
+func (h *haystack) containsAll(value string) bool {
+	words := strings.Fields(normalize(value))
+	for _, w := range words {
+		if !h.words[w] {
+			return false
+		}
+	}
+	return len(words) > 0
+}
+
+// handle reduces a profile URL to the user name templates print.
+func handle(profile string) string {
+	profile = strings.TrimRight(strings.TrimSpace(profile), "/")
+	if i := strings.LastIndexByte(profile, '/'); i >= 0 {
+		return profile[i+1:]
+	}
+	return profile
+}
+
+var lineBreakHyphen = regexp.MustCompile(`(\pL)[-‐‑]\n(\p{Ll})`)
+
+// dehyphenate joins words LaTeX hyphenated across a line break.
+func dehyphenate(text string) string {
+	return lineBreakHyphen.ReplaceAllString(text, "$1$2")
+}
+
+// normalize lowercases s and reduces it to words, keeping the symbols that
+// are part of technology names (C++, C#). Typographic quotes and dashes,
+// which LaTeX substitutes for their ASCII forms, compare equal to them.
+func normalize(s string) string {
+	s = strings.ToLower(s)
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	}), " ")
+}
+
+func digitsOnly(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// fontName drops the six-letter subset tag, as in "ABCDEF+FontAwesome".
+func fontName(font string) string {
+	if i := strings.IndexByte(font, '+'); i == 6 {
+		return font[i+1:]
+	}
+	return font
+}
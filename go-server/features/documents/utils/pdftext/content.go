@@ -0,0 +1,327 @@
+package pdftext
+
+import (
+	"bytes"
+	"math"
+	"strings"
+)
+
+// maxFormDepth limits how deeply form XObjects are followed.
+const maxFormDepth = 4
+
+// spaceAdjustment is how far, in thousandths of an em, a TJ adjustment has
+// to move the pen back before it counts as a space between words.
+const spaceAdjustment = 100
+
+type matrix [6]float64
+
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+func (m matrix) mul(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[1]*n[2],
+		m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2],
+		m[2]*n[1] + m[3]*n[3],
+		m[4]*n[0] + m[5]*n[2] + n[4],
+		m[4]*n[1] + m[5]*n[3] + n[5],
+	}
+}
+
+func translate(x, y float64) matrix {
+	return matrix{1, 0, 0, 1, x, y}
+}
+
+// span is a run of text shown in one go, in user space.
+type span struct {
+	x, y, end float64
+	size      float64
+	text      string
+}
+
+// unmappedGlyph is a glyph that produced no usable text.
+type unmappedGlyph struct {
+	font string
+	code uint32
+}
+
+type textState struct {
+	ctm       matrix
+	font      *font
+	size      float64
+	charSpace float64
+	wordSpace float64
+	scale     float64
+	leading   float64
+	rise      float64
+}
+
+// interpreter runs a page's content stream and collects the text it shows.
+type interpreter struct {
+	doc      *document
+	fonts    map[int]*font
+	spans    []span
+	unmapped []unmappedGlyph
+}
+
+func (in *interpreter) run(content []byte, resources dict, ctm matrix, depth int) {
+	state := textState{ctm: ctm, scale: 1}
+	var stack []textState
+	var tm, tlm matrix
+	var operands []any
+
+	l := &lexer{data: content}
+	for {
+		tok, err := l.token()
+		if err != nil {
+			if err == errEOF {
+				return
+			}
+			operands = operands[:0]
+			continue
+		}
+		op, ok := tok.(keyword)
+		if !ok {
+			if v, err := l.complete(tok, false); err == nil {
+				operands = append(operands, v)
+			}
+			continue
+		}
+
+		switch op {
+		case "q":
+			stack = append(stack, state)
+		case "Q":
+			if n := len(stack); n > 0 {
+				state, stack = stack[n-1], stack[:n-1]
+			}
+		case "cm":
+			if m, ok := matrixOperand(operands); ok {
+				state.ctm = m.mul(state.ctm)
+			}
+		case "BT":
+			tm, tlm = identity, identity
+		case "Tf":
+			if len(operands) == 2 {
+				if n, ok := operands[0].(name); ok {
+					state.font = in.font(resources, n)
+				}
+				state.size, _ = operands[1].(float64)
+			}
+		case "Tc":
+			state.charSpace = lastNumber(operands)
+		case "Tw":
+			state.wordSpace = lastNumber(operands)
+		case "Tz":
+			state.scale = lastNumber(operands) / 100
+		case "TL":
+			state.leading = lastNumber(operands)
+		case "Ts":
+			state.rise = lastNumber(operands)
+		case "Td", "TD":
+			if len(operands) == 2 {
+				tx, _ := operands[0].(float64)
+				ty, _ := operands[1].(float64)
+				if op == "TD" {
+					state.leading = -ty
+				}
+				tlm = translate(tx, ty).mul(tlm)
+				tm = tlm
+			}
+		case "Tm":
+			if m, ok := matrixOperand(operands); ok {
+				tm, tlm = m, m
+			}
+		case "T*":
+			tlm = translate(0, -state.leading).mul(tlm)
+			tm = tlm
+		case "Tj":
+			if s, ok := lastString(operands); ok {
+				in.show(&state, &tm, s)
+			}
+		case "'", "\"":
+			if op == "\"" && len(operands) == 3 {
+				state.wordSpace, _ = operands[0].(float64)
+				state.charSpace, _ = operands[1].(float64)
+			}
+			tlm = translate(0, -state.leading).mul(tlm)
+			tm = tlm
+			if s, ok := lastString(operands); ok {
+				in.show(&state, &tm, s)
+			}
+		case "TJ":
+			if len(operands) > 0 {
+				if arr, ok := operands[len(operands)-1].(array); ok {
+					in.showArray(&state, &tm, arr)
+				}
+			}
+		case "Do":
+			if len(operands) > 0 && depth < maxFormDepth {
+				if n, ok := operands[0].(name); ok {
+					in.form(resources, n, state.ctm, depth)
+				}
+			}
+		case "BI":
+			skipInlineImage(l)
+		}
+		operands = operands[:0]
+	}
+}
+
+func (in *interpreter) font(resources dict, n name) *font {
+	fonts := in.doc.dict(resources["Font"])
+	if fonts == nil {
+		return in.doc.loadFont(nil)
+	}
+	// Only shared fonts are cached; a font defined inline belongs to one
+	// resource dictionary and its name may mean something else elsewhere.
+	r, shared := fonts[n].(ref)
+	if f, ok := in.fonts[r.num]; shared && ok {
+		return f
+	}
+	f := in.doc.loadFont(fonts[n])
+	if shared {
+		in.fonts[r.num] = f
+	}
+	return f
+}
+
+// form runs a form XObject, which is how some templates place logos and
+// icons.
+func (in *interpreter) form(resources dict, n name, ctm matrix, depth int) {
+	xobjects := in.doc.dict(resources["XObject"])
+	if xobjects == nil {
+		return
+	}
+	s, ok := in.doc.resolve(xobjects[n]).(*stream)
+	if !ok || s.dict["Subtype"] != name("Form") {
+		return
+	}
+	data, err := in.doc.decode(s)
+	if err != nil {
+		return
+	}
+	if m, ok := matrixOperand(in.doc.array(s.dict["Matrix"])); ok {
+		ctm = m.mul(ctm)
+	}
+	formResources := in.doc.dict(s.dict["Resources"])
+	if formResources == nil {
+		formResources = resources
+	}
+	in.run(data, formResources, ctm, depth+1)
+}
+
+func (in *interpreter) showArray(state *textState, tm *matrix, arr array) {
+	for _, item := range arr {
+		switch v := item.(type) {
+		case []byte:
+			in.show(state, tm, v)
+		case float64:
+			shift := -v / 1000 * state.size * state.scale
+			*tm = translate(shift, 0).mul(*tm)
+			if v <= -spaceAdjustment && len(in.spans) > 0 {
+				last := &in.spans[len(in.spans)-1]
+				if !strings.HasSuffix(last.text, " ") {
+					last.text += " "
+				}
+			}
+		}
+	}
+}
+
+func (in *interpreter) show(state *textState, tm *matrix, s []byte) {
+	f := state.font
+	if f == nil {
+		f = in.doc.loadFont(nil)
+		state.font = f
+	}
+
+	start := state.rendering(*tm)
+	sp := span{x: start[4], y: start[5], size: fontHeight(state, *tm)}
+	var text strings.Builder
+	for _, g := range f.glyphs(s) {
+		if g.mapped {
+			text.WriteString(g.text)
+		} else {
+			in.unmapped = append(in.unmapped, unmappedGlyph{font: f.name, code: g.code})
+		}
+		advance := g.width*state.size + state.charSpace
+		if g.code == ' ' && !f.composite {
+			advance += state.wordSpace
+		}
+		*tm = translate(advance*state.scale, 0).mul(*tm)
+	}
+	sp.end = state.rendering(*tm)[4]
+	sp.text = text.String()
+	if sp.text != "" {
+		in.spans = append(in.spans, sp)
+	}
+}
+
+// rendering returns the text rendering matrix, which places the next glyph
+// in user space.
+func (s *textState) rendering(tm matrix) matrix {
+	return matrix{s.size * s.scale, 0, 0, s.size, 0, s.rise}.mul(tm).mul(s.ctm)
+}
+
+func fontHeight(s *textState, tm matrix) float64 {
+	m := s.rendering(tm)
+	h := math.Hypot(m[2], m[3])
+	if h == 0 {
+		return 1
+	}
+	return h
+}
+
+func matrixOperand(operands []any) (matrix, bool) {
+	if len(operands) < 6 {
+		return matrix{}, false
+	}
+	var m matrix
+	for i, v := range operands[len(operands)-6:] {
+		n, ok := v.(float64)
+		if !ok {
+			return matrix{}, false
+		}
+		m[i] = n
+	}
+	return m, true
+}
+
+func lastNumber(operands []any) float64 {
+	if len(operands) == 0 {
+		return 0
+	}
+	n, _ := operands[len(operands)-1].(float64)
+	return n
+}
+
+func lastString(operands []any) ([]byte, bool) {
+	if len(operands) == 0 {
+		return nil, false
+	}
+	s, ok := operands[len(operands)-1].([]byte)
+	return s, ok
+}
+
+// skipInlineImage moves past an inline image's binary data to its EI.
+func skipInlineImage(l *lexer) {
+	id := bytes.Index(l.data[l.pos:], []byte("ID"))
+	if id < 0 {
+		l.pos = len(l.data)
+		return
+	}
+	l.pos += id + 2
+	for l.pos < len(l.data) {
+		ei := bytes.Index(l.data[l.pos:], []byte("EI"))
+		if ei < 0 {
+			l.pos = len(l.data)
+			return
+		}
+		at := l.pos + ei
+		l.pos = at + 2
+		if at > 0 && isSpace(l.data[at-1]) && (l.pos >= len(l.data) || isSpace(l.data[l.pos])) {
+			return
+		}
+	}
+}
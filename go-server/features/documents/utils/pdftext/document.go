@@ -0,0 +1,254 @@
+package pdftext
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/ascii85"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+)
+
+// maxDecodedSize caps how far a single stream is inflated.
+const maxDecodedSize = 32 << 20
+
+// maxResolveDepth stops reference chains that loop.
+const maxResolveDepth = 16
+
+var objectHeader = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+
+// document holds every object found in a PDF, by object number. Objects
+// are located by scanning for their headers rather than through the xref
+// table, so files with broken offsets still parse.
+type document struct {
+	objects map[int]any
+}
+
+func parseDocument(data []byte) (*document, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, " \r\n\t"), []byte("%PDF-")) {
+		return nil, errors.New("not a PDF file")
+	}
+
+	d := &document{objects: map[int]any{}}
+	next := 0
+	for _, m := range objectHeader.FindAllSubmatchIndex(data, -1) {
+		if m[0] < next {
+			continue // inside the previous object's stream
+		}
+		num := atoi(data[m[2]:m[3]])
+		l := &lexer{data: data, pos: m[1]}
+		obj, err := l.object(true)
+		if err != nil {
+			continue
+		}
+		if dct, ok := obj.(dict); ok {
+			if s, end, ok := readStream(l, dct); ok {
+				obj = s
+				l.pos = end
+			}
+		}
+		d.objects[num] = obj
+		next = l.pos
+	}
+	if len(d.objects) == 0 {
+		return nil, errors.New("no objects found in PDF")
+	}
+
+	d.expandObjectStreams()
+	return d, nil
+}
+
+func atoi(b []byte) int {
+	n := 0
+	for _, c := range b {
+		n = n*10 + int(c-'0')
+	}
+	return n
+}
+
+// readStream reads the stream that follows dct, if there is one. It trusts
+// /Length only when "endstream" follows where it says.
+func readStream(l *lexer, dct dict) (*stream, int, bool) {
+	save := l.pos
+	tok, err := l.token()
+	if err != nil || tok != keyword("stream") {
+		l.pos = save
+		return nil, 0, false
+	}
+	start := l.pos
+	if start < len(l.data) && l.data[start] == '\r' {
+		start++
+	}
+	if start < len(l.data) && l.data[start] == '\n' {
+		start++
+	}
+
+	if n, ok := dct["Length"].(float64); ok {
+		end := start + int(n)
+		if end <= len(l.data) {
+			rest := bytes.TrimLeft(l.data[end:min(end+16, len(l.data))], " \r\n\t")
+			if bytes.HasPrefix(rest, []byte("endstream")) {
+				return &stream{dict: dct, raw: l.data[start:end]}, end, true
+			}
+		}
+	}
+	end := bytes.Index(l.data[start:], []byte("endstream"))
+	if end < 0 {
+		return &stream{dict: dct, raw: l.data[start:]}, len(l.data), true
+	}
+	raw := bytes.TrimRight(l.data[start:start+end], "\r\n")
+	return &stream{dict: dct, raw: raw}, start + end, true
+}
+
+// expandObjectStreams adds the objects packed into /ObjStm streams, which
+// pdflatex and most modern writers use for everything but streams.
+func (d *document) expandObjectStreams() {
+	nums := make([]int, 0, len(d.objects))
+	for num := range d.objects {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+
+	for _, num := range nums {
+		s, ok := d.objects[num].(*stream)
+		if !ok || s.dict["Type"] != name("ObjStm") {
+			continue
+		}
+		data, err := d.decode(s)
+		if err != nil {
+			continue
+		}
+		n, _ := d.resolve(s.dict["N"]).(float64)
+		first, _ := d.resolve(s.dict["First"]).(float64)
+		if int(first) > len(data) {
+			continue
+		}
+
+		header := &lexer{data: data[:int(first)]}
+		for i := 0; i < int(n); i++ {
+			objNum, err1 := header.token()
+			offset, err2 := header.token()
+			on, ok1 := objNum.(float64)
+			off, ok2 := offset.(float64)
+			if err1 != nil || err2 != nil || !ok1 || !ok2 {
+				break
+			}
+			if _, defined := d.objects[int(on)]; defined {
+				continue
+			}
+			body := &lexer{data: data, pos: int(first) + int(off)}
+			if obj, err := body.object(true); err == nil {
+				d.objects[int(on)] = obj
+			}
+		}
+	}
+}
+
+// resolve follows indirect references to the object they point at.
+func (d *document) resolve(v any) any {
+	for i := 0; i < maxResolveDepth; i++ {
+		r, ok := v.(ref)
+		if !ok {
+			return v
+		}
+		v = d.objects[r.num]
+	}
+	return nil
+}
+
+func (d *document) dict(v any) dict {
+	switch t := d.resolve(v).(type) {
+	case dict:
+		return t
+	case *stream:
+		return t.dict
+	}
+	return nil
+}
+
+func (d *document) array(v any) array {
+	a, _ := d.resolve(v).(array)
+	return a
+}
+
+func (d *document) number(v any) (float64, bool) {
+	n, ok := d.resolve(v).(float64)
+	return n, ok
+}
+
+// decode applies the stream's filters. Only the filters text and object
+// streams use are supported; image filters are an error.
+func (d *document) decode(s *stream) ([]byte, error) {
+	var filters []any
+	switch f := d.resolve(s.dict["Filter"]).(type) {
+	case name:
+		filters = []any{f}
+	case array:
+		filters = f
+	}
+
+	data := s.raw
+	for _, f := range filters {
+		var err error
+		switch d.resolve(f) {
+		case name("FlateDecode"):
+			data, err = inflate(data)
+		case name("ASCIIHexDecode"):
+			data, err = decodeHex(data)
+		case name("ASCII85Decode"):
+			data, err = decode85(data)
+		default:
+			err = fmt.Errorf("unsupported filter %v", f)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+func inflate(data []byte) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	out, err := io.ReadAll(io.LimitReader(r, maxDecodedSize))
+	// Writers often leave off the checksum; what was inflated is still good.
+	if err != nil && len(out) == 0 {
+		return nil, err
+	}
+	return out, nil
+}
+
+func decodeHex(data []byte) ([]byte, error) {
+	var digits []byte
+	for _, c := range data {
+		if c == '>' {
+			break
+		}
+		if !isSpace(c) {
+			digits = append(digits, c)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	return hex.DecodeString(string(digits))
+}
+
+func decode85(data []byte) ([]byte, error) {
+	data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("<~"))
+	if end := bytes.Index(data, []byte("~>")); end >= 0 {
+		data = data[:end]
+	}
+	out := make([]byte, 4*len(data)/5+4)
+	n, _, err := ascii85.Decode(out, data, true)
+	if err != nil {
+		return nil, err
+	}
+	return out[:n], nil
+}
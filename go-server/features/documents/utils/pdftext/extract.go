@@ -0,0 +1,92 @@
+// Package pdftext extracts the text of a PDF in reading order, the way an
+// applicant tracking system would, and records what got in the way: glyphs
+// that map to no text, ligatures and multi-column pages.
+package pdftext
+
+import (
+	"sort"
+	"strings"
+)
+
+// Result is the text extracted from a PDF.
+type Result struct {
+	Pages []Page
+	// Unmapped lists the glyphs that produced no readable text, such as icon
+	// font symbols or glyphs from fonts without a /ToUnicode map.
+	Unmapped []Unmapped
+	// Ligatures counts ligature characters, such as "ﬁ", that were
+	// expanded back into their letters. ATS parsers often don't.
+	Ligatures int
+}
+
+// Page is one page's text, a line per entry.
+type Page struct {
+	Lines       []string
+	MultiColumn bool
+}
+
+// Unmapped is a glyph code that produced no text, and how often it was
+// shown.
+type Unmapped struct {
+	Font  string
+	Code  uint32
+	Count int
+}
+
+// ligatures are the presentation forms expanded back into letters.
+var ligatures = strings.NewReplacer(
+	"ﬀ", "ff", "ﬁ", "fi", "ﬂ", "fl", "ﬃ", "ffi", "ﬄ", "ffl", "ﬅ", "st", "ﬆ", "st",
+)
+
+// Extract reads the text of every page of a PDF.
+func Extract(pdf []byte) (*Result, error) {
+	doc, err := parseDocument(pdf)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{}
+	unmapped := map[unmappedGlyph]int{}
+	fonts := map[int]*font{}
+	for _, p := range doc.pages() {
+		in := &interpreter{doc: doc, fonts: fonts}
+		in.run(p.contents, p.resources, identity, 0)
+
+		for i := range in.spans {
+			for _, r := range in.spans[i].text {
+				if r >= 'ﬀ' && r <= 'ﬆ' {
+					res.Ligatures++
+				}
+			}
+			in.spans[i].text = ligatures.Replace(in.spans[i].text)
+		}
+		for _, g := range in.unmapped {
+			unmapped[g]++
+		}
+
+		lines, columns := readingOrder(in.spans, p.box)
+		res.Pages = append(res.Pages, Page{Lines: lines, MultiColumn: columns})
+	}
+
+	for g, n := range unmapped {
+		res.Unmapped = append(res.Unmapped, Unmapped{Font: g.font, Code: g.code, Count: n})
+	}
+	sort.Slice(res.Unmapped, func(i, j int) bool {
+		a, b := res.Unmapped[i], res.Unmapped[j]
+		if a.Font != b.Font {
+			return a.Font < b.Font
+		}
+		return a.Code < b.Code
+	})
+	return res, nil
+}
+
+// Text joins the pages' lines, one per line, with a blank line between
+// pages.
+func (r *Result) Text() string {
+	pages := make([]string, 0, len(r.Pages))
+	for _, p := range r.Pages {
+		pages = append(pages, strings.Join(p.Lines, "\n"))
+	}
+	return strings.Join(pages, "\n\n")
+}
@@ -0,0 +1,438 @@
+package pdftext
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// defaultGlyphWidth is used for glyphs whose width the font doesn't give,
+// in text space units.
+const defaultGlyphWidth = 0.5
+
+// glyph is one character code shown with a font.
+type glyph struct {
+	code   uint32
+	text   string
+	width  float64
+	mapped bool
+}
+
+// font decodes the strings shown with one PDF font into text and widths.
+type font struct {
+	name      string
+	composite bool
+	cmap      *cmap
+	encoding  map[uint32]string // simple fonts: code -> text, from /Differences
+	unknown   map[uint32]bool   // simple fonts: codes named by unknown glyphs
+	widths    map[uint32]float64
+	missing   float64
+}
+
+func (d *document) loadFont(v any) *font {
+	fd := d.dict(v)
+	if fd == nil {
+		return &font{name: "unknown", missing: defaultGlyphWidth}
+	}
+	f := &font{
+		widths:  map[uint32]float64{},
+		missing: defaultGlyphWidth,
+	}
+	if n, ok := d.resolve(fd["BaseFont"]).(name); ok {
+		f.name = string(n)
+	} else if n, ok := d.resolve(fd["Name"]).(name); ok {
+		f.name = string(n)
+	}
+	if s, ok := d.resolve(fd["ToUnicode"]).(*stream); ok {
+		if data, err := d.decode(s); err == nil {
+			f.cmap = parseCMap(data)
+		}
+	}
+
+	scale := 0.001
+	if fd["Subtype"] == name("Type3") {
+		if m := d.array(fd["FontMatrix"]); len(m) > 0 {
+			if n, ok := d.number(m[0]); ok {
+				scale = n
+			}
+		}
+	}
+
+	if fd["Subtype"] == name("Type0") {
+		f.composite = true
+		if kids := d.array(fd["DescendantFonts"]); len(kids) > 0 {
+			d.loadCIDWidths(f, d.dict(kids[0]))
+		}
+		return f
+	}
+
+	first, _ := d.number(fd["FirstChar"])
+	for i, w := range d.array(fd["Widths"]) {
+		if n, ok := d.number(w); ok {
+			f.widths[uint32(first)+uint32(i)] = n * scale
+		}
+	}
+	if desc := d.dict(fd["FontDescriptor"]); desc != nil {
+		if n, ok := d.number(desc["MissingWidth"]); ok && n > 0 {
+			f.missing = n * scale
+		}
+	}
+	d.loadDifferences(f, d.resolve(fd["Encoding"]))
+	return f
+}
+
+func (d *document) loadCIDWidths(f *font, cid dict) {
+	if cid == nil {
+		return
+	}
+	f.missing = 1.0
+	if n, ok := d.number(cid["DW"]); ok {
+		f.missing = n / 1000
+	}
+	w := d.array(cid["W"])
+	for i := 0; i+1 < len(w); {
+		start, ok := d.number(w[i])
+		if !ok {
+			return
+		}
+		if list, ok := d.resolve(w[i+1]).(array); ok {
+			for j, x := range list {
+				if n, ok := d.number(x); ok {
+					f.widths[uint32(start)+uint32(j)] = n / 1000
+				}
+			}
+			i += 2
+			continue
+		}
+		if i+2 >= len(w) {
+			return
+		}
+		end, ok1 := d.number(w[i+1])
+		width, ok2 := d.number(w[i+2])
+		if !ok1 || !ok2 {
+			return
+		}
+		for c := uint32(start); c <= uint32(end) && c-uint32(start) < 1<<16; c++ {
+			f.widths[c] = width / 1000
+		}
+		i += 3
+	}
+}
+
+// loadDifferences reads the glyph names an /Encoding dictionary assigns to
+// codes. Base encodings are treated as Latin-1, which covers the ASCII
+// range every one of them shares.
+func (d *document) loadDifferences(f *font, enc any) {
+	ed, ok := enc.(dict)
+	if !ok {
+		return
+	}
+	f.encoding = map[uint32]string{}
+	f.unknown = map[uint32]bool{}
+	code := uint32(0)
+	for _, item := range d.array(ed["Differences"]) {
+		switch v := d.resolve(item).(type) {
+		case float64:
+			code = uint32(v)
+		case name:
+			if text, ok := glyphText(string(v)); ok {
+				f.encoding[code] = text
+			} else {
+				f.unknown[code] = true
+			}
+			code++
+		}
+	}
+}
+
+// glyphs splits a shown string into character codes and decodes each.
+func (f *font) glyphs(s []byte) []glyph {
+	var out []glyph
+	for i := 0; i < len(s); {
+		n := f.codeLength(s[i:])
+		var code uint32
+		for _, b := range s[i : i+n] {
+			code = code<<8 | uint32(b)
+		}
+		i += n
+
+		g := glyph{code: code, width: f.missing}
+		if w, ok := f.widths[code]; ok {
+			g.width = w
+		}
+		g.text, g.mapped = f.text(code, n)
+		out = append(out, g)
+	}
+	return out
+}
+
+func (f *font) codeLength(s []byte) int {
+	if f.cmap != nil {
+		if n := f.cmap.codeLength(s); n > 0 {
+			return n
+		}
+	}
+	if f.composite && len(s) >= 2 {
+		return 2
+	}
+	return 1
+}
+
+func (f *font) text(code uint32, n int) (string, bool) {
+	if f.cmap != nil {
+		if text, ok := f.cmap.chars[code]; ok {
+			return text, readable(text)
+		}
+	}
+	if f.composite {
+		return "", false
+	}
+	if text, ok := f.encoding[code]; ok {
+		return text, readable(text)
+	}
+	if f.unknown[code] || n != 1 {
+		return "", false
+	}
+	switch {
+	case code >= 0x20 && code < 0x7f, code >= 0xa0:
+		return string(rune(code)), true
+	case code >= 0x80 && code < 0xa0:
+		if r := winAnsiHigh[code-0x80]; r != 0 {
+			return string(r), true
+		}
+	}
+	return "", false
+}
+
+// readable reports whether mapped text is real text rather than a private
+// use code point, which is how icon fonts map their glyphs, or U+FFFD.
+func readable(text string) bool {
+	if text == "" {
+		return false
+	}
+	for _, r := range text {
+		if (r >= 0xe000 && r <= 0xf8ff) || r >= 0xf0000 || r == 0xfffd {
+			return false
+		}
+	}
+	return true
+}
+
+// winAnsiHigh holds the WinAnsiEncoding characters at 0x80-0x9f, where it
+// departs from Latin-1.
+var winAnsiHigh = [32]rune{
+	'€', 0, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0, 'Ž', 0,
+	0, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0, 'ž', 'Ÿ',
+}
+
+// cmap is a parsed /ToUnicode CMap.
+type cmap struct {
+	spaces []codeSpace
+	chars  map[uint32]string
+}
+
+type codeSpace struct {
+	length    int
+	low, high uint32
+}
+
+func parseCMap(data []byte) *cmap {
+	c := &cmap{chars: map[uint32]string{}}
+	l := &lexer{data: data}
+	var operands []any
+	for {
+		tok, err := l.token()
+		if err != nil {
+			break
+		}
+		if tok == delimiter("[") {
+			if arr, err := l.complete(tok, false); err == nil {
+				operands = append(operands, arr)
+			}
+			continue
+		}
+		kw, ok := tok.(keyword)
+		if !ok {
+			operands = append(operands, tok)
+			continue
+		}
+		switch kw {
+		case "endcodespacerange":
+			for i := 0; i+1 < len(operands); i += 2 {
+				lo, ok1 := operands[i].([]byte)
+				hi, ok2 := operands[i+1].([]byte)
+				if ok1 && ok2 && len(lo) > 0 && len(lo) <= 4 {
+					c.spaces = append(c.spaces, codeSpace{length: len(lo), low: bytesToCode(lo), high: bytesToCode(hi)})
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].([]byte)
+				dst, ok2 := operands[i+1].([]byte)
+				if ok1 && ok2 {
+					c.chars[bytesToCode(src)] = utf16Text(dst)
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				lo, ok1 := operands[i].([]byte)
+				hi, ok2 := operands[i+1].([]byte)
+				if !ok1 || !ok2 {
+					continue
+				}
+				start, end := bytesToCode(lo), bytesToCode(hi)
+				if end < start || end-start > 1<<16 {
+					continue
+				}
+				switch dst := operands[i+2].(type) {
+				case []byte:
+					c.addRange(start, end, dst)
+				case array:
+					for j, item := range dst {
+						if b, ok := item.([]byte); ok && start+uint32(j) <= end {
+							c.chars[start+uint32(j)] = utf16Text(b)
+						}
+					}
+				}
+			}
+		}
+		if strings.HasPrefix(string(kw), "end") || strings.HasPrefix(string(kw), "begin") {
+			operands = operands[:0]
+		}
+	}
+	return c
+}
+
+// addRange maps start..end to consecutive characters, incrementing the last
+// UTF-16 unit of dst.
+func (c *cmap) addRange(start, end uint32, dst []byte) {
+	if len(dst) < 2 {
+		return
+	}
+	units := make([]uint16, len(dst)/2)
+	for i := range units {
+		units[i] = uint16(dst[2*i])<<8 | uint16(dst[2*i+1])
+	}
+	for code := start; code <= end; code++ {
+		c.chars[code] = string(utf16.Decode(units))
+		units[len(units)-1]++
+	}
+}
+
+func (c *cmap) codeLength(s []byte) int {
+	for n := 1; n <= 4 && n <= len(s); n++ {
+		code := bytesToCode(s[:n])
+		for _, sp := range c.spaces {
+			if sp.length == n && code >= sp.low && code <= sp.high {
+				return n
+			}
+		}
+	}
+	return 0
+}
+
+func bytesToCode(b []byte) uint32 {
+	var code uint32
+	for _, x := range b {
+		code = code<<8 | uint32(x)
+	}
+	return code
+}
+
+func utf16Text(b []byte) string {
+	if len(b)%2 == 1 {
+		return string(b)
+	}
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+	}
+	return string(utf16.Decode(units))
+}
+
+// glyphText maps an Adobe glyph name to its text: uniXXXX and uXXXX names,
+// single letters and digits, the common punctuation and ligature names, and
+// accented letters. Suffixes such as ".sc" are ignored, and "f_f_i" style
+// ligature names are joined.
+func glyphText(glyphName string) (string, bool) {
+	if i := strings.IndexByte(glyphName, '.'); i > 0 {
+		glyphName = glyphName[:i]
+	}
+	if strings.Contains(glyphName, "_") {
+		var b strings.Builder
+		for _, part := range strings.Split(glyphName, "_") {
+			t, ok := glyphText(part)
+			if !ok {
+				return "", false
+			}
+			b.WriteString(t)
+		}
+		return b.String(), true
+	}
+
+	if hexes, ok := strings.CutPrefix(glyphName, "uni"); ok && len(hexes) >= 4 && len(hexes)%4 == 0 {
+		var units []uint16
+		for i := 0; i < len(hexes); i += 4 {
+			v, err := strconv.ParseUint(hexes[i:i+4], 16, 16)
+			if err != nil {
+				return "", false
+			}
+			units = append(units, uint16(v))
+		}
+		return string(utf16.Decode(units)), true
+	}
+	if hexes, ok := strings.CutPrefix(glyphName, "u"); ok && len(hexes) >= 4 && len(hexes) <= 6 {
+		if v, err := strconv.ParseUint(hexes, 16, 32); err == nil {
+			return string(rune(v)), true
+		}
+	}
+
+	if len(glyphName) == 1 {
+		c := glyphName[0]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+			return glyphName, true
+		}
+	}
+	if text, ok := glyphNames[glyphName]; ok {
+		return text, true
+	}
+	for accent, mark := range accents {
+		if base, ok := strings.CutSuffix(glyphName, accent); ok && len(base) == 1 {
+			return base + mark, true
+		}
+	}
+	return "", false
+}
+
+var accents = map[string]string{
+	"acute":      "́",
+	"grave":      "̀",
+	"circumflex": "̂",
+	"dieresis":   "̈",
+	"tilde":      "̃",
+	"ring":       "̊",
+	"cedilla":    "̧",
+	"caron":      "̌",
+}
+
+var glyphNames = map[string]string{
+	"zero": "0", "one": "1", "two": "2", "three": "3", "four": "4",
+	"five": "5", "six": "6", "seven": "7", "eight": "8", "nine": "9",
+	"space": " ", "exclam": "!", "quotedbl": "\"", "numbersign": "#",
+	"dollar": "$", "percent": "%", "ampersand": "&", "quotesingle": "'",
+	"quoteright": "’", "quoteleft": "‘", "parenleft": "(", "parenright": ")",
+	"asterisk": "*", "plus": "+", "comma": ",", "hyphen": "-", "period": ".",
+	"slash": "/", "colon": ":", "semicolon": ";", "less": "<", "equal": "=",
+	"greater": ">", "question": "?", "at": "@", "bracketleft": "[",
+	"backslash": "\\", "bracketright": "]", "asciicircum": "^",
+	"underscore": "_", "grave": "`", "braceleft": "{", "bar": "|",
+	"braceright": "}", "asciitilde": "~", "endash": "–", "emdash": "—",
+	"quotedblleft": "“", "quotedblright": "”", "quotesinglbase": "‚",
+	"quotedblbase": "„", "bullet": "•", "ellipsis": "…", "minus": "−",
+	"periodcentered": "·", "dotlessi": "ı", "copyright": "©",
+	"registered": "®", "trademark": "™", "degree": "°", "multiply": "×",
+	"section": "§", "paragraph": "¶", "dagger": "†", "daggerdbl": "‡",
+	"fi": "ﬁ", "fl": "ﬂ", "ff": "ﬀ", "ffi": "ﬃ", "ffl": "ﬄ",
+	"germandbls": "ß", "ae": "æ", "AE": "Æ", "oe": "œ", "OE": "Œ",
+	"oslash": "ø", "Oslash": "Ø", "visiblespace": "␣", "nbspace": " ",
+}
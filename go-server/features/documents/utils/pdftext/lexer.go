@@ -0,0 +1,301 @@
+package pdftext
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// The PDF object types the parser produces. Numbers are float64, strings are
+// []byte, booleans are bool and null is nil.
+type (
+	name    string
+	keyword string
+	dict    map[name]any
+	array   []any
+	ref     struct{ num, gen int }
+	stream  struct {
+		dict dict
+		raw  []byte
+	}
+)
+
+// delimiter is one of [ ] << >> { }, returned by lexer.token.
+type delimiter string
+
+var errEOF = errors.New("unexpected end of data")
+
+// lexer reads PDF tokens and objects from a byte slice, both for the file
+// body and for content streams.
+type lexer struct {
+	data []byte
+	pos  int
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isDelim(c byte) bool {
+	return bytes.IndexByte([]byte("()<>[]{}/%"), c) >= 0
+}
+
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case isSpace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// token returns the next number, name, string, keyword or delimiter.
+func (l *lexer) token() (any, error) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, errEOF
+	}
+
+	c := l.data[l.pos]
+	switch c {
+	case '[', ']', '{', '}':
+		l.pos++
+		return delimiter(c), nil
+	case '<':
+		if l.peek(1) == '<' {
+			l.pos += 2
+			return delimiter("<<"), nil
+		}
+		return l.hexString()
+	case '>':
+		if l.peek(1) == '>' {
+			l.pos += 2
+			return delimiter(">>"), nil
+		}
+		l.pos++
+		return nil, fmt.Errorf("stray '>' at offset %d", l.pos-1)
+	case '(':
+		return l.literalString()
+	case '/':
+		return l.name(), nil
+	}
+
+	start := l.pos
+	for l.pos < len(l.data) && !isSpace(l.data[l.pos]) && !isDelim(l.data[l.pos]) {
+		l.pos++
+	}
+	if l.pos == start {
+		l.pos++
+		return nil, fmt.Errorf("unexpected %q at offset %d", c, start)
+	}
+	word := string(l.data[start:l.pos])
+	if n, err := strconv.ParseFloat(word, 64); err == nil {
+		return n, nil
+	}
+	return keyword(word), nil
+}
+
+func (l *lexer) peek(offset int) byte {
+	if l.pos+offset < len(l.data) {
+		return l.data[l.pos+offset]
+	}
+	return 0
+}
+
+func (l *lexer) name() name {
+	l.pos++ // the slash
+	var b []byte
+	for l.pos < len(l.data) && !isSpace(l.data[l.pos]) && !isDelim(l.data[l.pos]) {
+		c := l.data[l.pos]
+		if c == '#' && l.pos+2 < len(l.data) {
+			if v, err := strconv.ParseUint(string(l.data[l.pos+1:l.pos+3]), 16, 8); err == nil {
+				b = append(b, byte(v))
+				l.pos += 3
+				continue
+			}
+		}
+		b = append(b, c)
+		l.pos++
+	}
+	return name(b)
+}
+
+func (l *lexer) hexString() ([]byte, error) {
+	l.pos++ // the '<'
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		if c := l.data[l.pos]; !isSpace(c) {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	if l.pos >= len(l.data) {
+		return nil, errEOF
+	}
+	l.pos++ // the '>'
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	out := make([]byte, 0, len(digits)/2)
+	for i := 0; i < len(digits); i += 2 {
+		v, err := strconv.ParseUint(string(digits[i:i+2]), 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("bad hex string: %w", err)
+		}
+		out = append(out, byte(v))
+	}
+	return out, nil
+}
+
+func (l *lexer) literalString() ([]byte, error) {
+	l.pos++ // the '('
+	var out []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return out, nil
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return nil, errEOF
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				if l.peek(0) == '\n' {
+					l.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(v)
+				} else {
+					c = e
+				}
+			}
+		}
+		out = append(out, c)
+	}
+	return nil, errEOF
+}
+
+// object reads one complete object. Indirect references are recognized
+// only when refs is true, since content streams have none.
+func (l *lexer) object(refs bool) (any, error) {
+	tok, err := l.token()
+	if err != nil {
+		return nil, err
+	}
+	return l.complete(tok, refs)
+}
+
+// complete finishes the object tok starts, reading arrays, dictionaries and
+// references to their end.
+func (l *lexer) complete(tok any, refs bool) (any, error) {
+	switch t := tok.(type) {
+	case delimiter:
+		switch t {
+		case "[":
+			var arr array
+			for {
+				next, err := l.token()
+				if err != nil {
+					return nil, err
+				}
+				if next == delimiter("]") {
+					return arr, nil
+				}
+				v, err := l.complete(next, refs)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, v)
+			}
+		case "<<":
+			d := dict{}
+			for {
+				next, err := l.token()
+				if err != nil {
+					return nil, err
+				}
+				if next == delimiter(">>") {
+					return d, nil
+				}
+				key, ok := next.(name)
+				if !ok {
+					return nil, fmt.Errorf("dictionary key is %T, not a name", next)
+				}
+				v, err := l.object(refs)
+				if err != nil {
+					return nil, err
+				}
+				d[key] = v
+			}
+		}
+		return t, nil
+	case float64:
+		if refs {
+			if r, ok := l.reference(t); ok {
+				return r, nil
+			}
+		}
+		return t, nil
+	case keyword:
+		switch t {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+	}
+	return tok, nil
+}
+
+// reference tries to read "gen R" after the object number num, rewinding
+// when that isn't what follows.
+func (l *lexer) reference(num float64) (ref, bool) {
+	start := l.pos
+	gen, err := l.token()
+	if g, ok := gen.(float64); err == nil && ok {
+		if r, err := l.token(); err == nil && r == keyword("R") {
+			return ref{num: int(num), gen: int(g)}, true
+		}
+	}
+	l.pos = start
+	return ref{}, false
+}
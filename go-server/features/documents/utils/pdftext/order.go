@@ -0,0 +1,150 @@
+package pdftext
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// wordGap is the gap between two runs of text, relative to the font size,
+// above which they are taken to be separate words.
+const wordGap = 0.15
+
+// Column detection looks for a vertical gutter that at most maxCrossing of
+// the lines run across while at least minSplit of them have text on both
+// sides of it. Runs of text closer than columnGap font sizes apart count as
+// one stretch of text, so the gaps between words are never gutters.
+const (
+	columnGap      = 1.5
+	minColumnLines = 6
+	maxCrossing    = 0.05
+	minSplit       = 0.3
+)
+
+type line struct {
+	y, size float64
+	spans   []span
+}
+
+// readingOrder arranges a page's spans into lines of text, top to bottom
+// and left to right. A page laid out in two columns is read one column
+// after the other, and reported as such.
+func readingOrder(spans []span, box [4]float64) ([]string, bool) {
+	lines := groupLines(spans)
+	gutter, ok := findGutter(lines, box)
+	if !ok {
+		return lineTexts(lines), false
+	}
+
+	var left, right []span
+	for _, s := range spans {
+		if (s.x+s.end)/2 < gutter {
+			left = append(left, s)
+		} else {
+			right = append(right, s)
+		}
+	}
+	return append(lineTexts(groupLines(left)), lineTexts(groupLines(right))...), true
+}
+
+// groupLines collects spans that share a baseline, give or take half the
+// font size, into lines ordered top to bottom.
+func groupLines(spans []span) []line {
+	sorted := append([]span(nil), spans...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].y > sorted[j].y })
+
+	var lines []line
+	for _, s := range sorted {
+		if n := len(lines); n > 0 {
+			l := &lines[n-1]
+			if math.Abs(l.y-s.y) <= 0.5*math.Max(l.size, s.size) {
+				l.spans = append(l.spans, s)
+				l.size = math.Max(l.size, s.size)
+				continue
+			}
+		}
+		lines = append(lines, line{y: s.y, size: s.size, spans: []span{s}})
+	}
+	for i := range lines {
+		sort.SliceStable(lines[i].spans, func(a, b int) bool { return lines[i].spans[a].x < lines[i].spans[b].x })
+	}
+	return lines
+}
+
+func lineTexts(lines []line) []string {
+	out := make([]string, 0, len(lines))
+	for _, l := range lines {
+		var b strings.Builder
+		for i, s := range l.spans {
+			if i > 0 {
+				prev := l.spans[i-1]
+				written := b.String()
+				if s.x-prev.end > wordGap*l.size &&
+					!strings.HasSuffix(written, " ") && !strings.HasPrefix(s.text, " ") {
+					b.WriteByte(' ')
+				}
+			}
+			b.WriteString(s.text)
+		}
+		if text := strings.Join(strings.Fields(b.String()), " "); text != "" {
+			out = append(out, text)
+		}
+	}
+	return out
+}
+
+// findGutter looks for the x position of a column gutter in the middle half
+// of the page.
+func findGutter(lines []line, box [4]float64) (float64, bool) {
+	if len(lines) < minColumnLines {
+		return 0, false
+	}
+	segments := make([][][2]float64, len(lines))
+	for i, l := range lines {
+		segments[i] = stretches(l)
+	}
+
+	width := box[2] - box[0]
+	best, bestSplit := 0.0, 0
+	for frac := 0.25; frac <= 0.75; frac += 0.01 {
+		x := box[0] + frac*width
+		crossing, split := 0, 0
+		for _, segs := range segments {
+			var hasLeft, hasRight, crosses bool
+			for _, seg := range segs {
+				switch {
+				case seg[0] < x-1 && seg[1] > x+1:
+					crosses = true
+				case seg[1] <= x:
+					hasLeft = true
+				default:
+					hasRight = true
+				}
+			}
+			if crosses {
+				crossing++
+			} else if hasLeft && hasRight {
+				split++
+			}
+		}
+		n := float64(len(lines))
+		if float64(crossing) <= maxCrossing*n && float64(split) >= minSplit*n && split > bestSplit {
+			best, bestSplit = x, split
+		}
+	}
+	return best, bestSplit > 0
+}
+
+// stretches merges a line's spans into the stretches of text they form,
+// as [start, end] pairs.
+func stretches(l line) [][2]float64 {
+	var out [][2]float64
+	for _, s := range l.spans {
+		if n := len(out); n > 0 && s.x-out[n-1][1] < columnGap*l.size {
+			out[n-1][1] = math.Max(out[n-1][1], s.end)
+			continue
+		}
+		out = append(out, [2]float64{s.x, s.end})
+	}
+	return out
+}
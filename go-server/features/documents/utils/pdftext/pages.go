@@ -0,0 +1,111 @@
+package pdftext
+
+import "sort"
+
+// maxTreeDepth stops page trees that loop back on themselves.
+const maxTreeDepth = 32
+
+// letterBox is used for pages that don't say how big they are.
+var letterBox = [4]float64{0, 0, 612, 792}
+
+// page is one page's content and the resources it draws with.
+type page struct {
+	resources dict
+	contents  []byte
+	box       [4]float64
+}
+
+// pages returns the document's pages in order. It walks the page tree from
+// the catalog and falls back to every page object, in object order, when
+// the tree can't be found.
+func (d *document) pages() []page {
+	var out []page
+	var walk func(node dict, resources dict, box [4]float64, depth int)
+	walk = func(node dict, resources dict, box [4]float64, depth int) {
+		if node == nil || depth > maxTreeDepth {
+			return
+		}
+		if r := d.dict(node["Resources"]); r != nil {
+			resources = r
+		}
+		if b, ok := d.box(node["MediaBox"]); ok {
+			box = b
+		}
+
+		if node["Type"] == name("Pages") || node["Kids"] != nil {
+			for _, kid := range d.array(node["Kids"]) {
+				walk(d.dict(kid), resources, box, depth+1)
+			}
+			return
+		}
+		out = append(out, d.page(node, resources, box))
+	}
+
+	if root := d.pageTreeRoot(); root != nil {
+		walk(root, nil, letterBox, 0)
+	}
+	if len(out) > 0 {
+		return out
+	}
+
+	nums := make([]int, 0, len(d.objects))
+	for num := range d.objects {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+	for _, num := range nums {
+		if node := d.dict(d.objects[num]); node != nil && node["Type"] == name("Page") {
+			box, ok := d.box(node["MediaBox"])
+			if !ok {
+				box = letterBox
+			}
+			out = append(out, d.page(node, d.dict(node["Resources"]), box))
+		}
+	}
+	return out
+}
+
+func (d *document) pageTreeRoot() dict {
+	for _, obj := range d.objects {
+		if catalog := d.dict(obj); catalog != nil && catalog["Type"] == name("Catalog") {
+			if root := d.dict(catalog["Pages"]); root != nil {
+				return root
+			}
+		}
+	}
+	return nil
+}
+
+func (d *document) page(node dict, resources dict, box [4]float64) page {
+	p := page{resources: resources, box: box}
+	contents := d.resolve(node["Contents"])
+	parts, ok := contents.(array)
+	if !ok {
+		parts = array{contents}
+	}
+	for _, part := range parts {
+		if s, ok := d.resolve(part).(*stream); ok {
+			if data, err := d.decode(s); err == nil {
+				p.contents = append(p.contents, data...)
+				p.contents = append(p.contents, '\n')
+			}
+		}
+	}
+	return p
+}
+
+func (d *document) box(v any) ([4]float64, bool) {
+	a := d.array(v)
+	if len(a) != 4 {
+		return [4]float64{}, false
+	}
+	var b [4]float64
+	for i, x := range a {
+		n, ok := d.number(x)
+		if !ok {
+			return [4]float64{}, false
+		}
+		b[i] = n
+	}
+	return b, true
+}
@@ -0,0 +1,84 @@
+// Package samples picks which of a candidate's writing samples go into a
+// prompt.
+package samples
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ordo_meritum/features/profiles/utils/matching"
+)
+
+// charsPerToken is the rough ratio used to estimate tokens from text.
+const charsPerToken = 4
+
+// Sample is one piece of writing to choose from.
+type Sample struct {
+	Content   string
+	UpdatedAt time.Time
+}
+
+// EstimateTokens approximates how many tokens text costs in a prompt.
+func EstimateTokens(text string) int {
+	return (len(text) + charsPerToken - 1) / charsPerToken
+}
+
+// Select returns the samples that best match keywords, best first, keeping
+// their combined size within budget tokens. Ties go to the newer sample.
+// When even the best sample is over budget it is cut to fit, so a user with
+// samples always gets at least one.
+func Select(candidates []Sample, keywords []string, budget int) []string {
+	type ranked struct {
+		Sample
+		score float64
+	}
+	list := make([]ranked, 0, len(candidates))
+	for _, s := range candidates {
+		content := strings.TrimSpace(s.Content)
+		if content == "" {
+			continue
+		}
+		score, _ := matching.KeywordOverlap(keywords, content)
+		list = append(list, ranked{Sample{content, s.UpdatedAt}, score})
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].score != list[j].score {
+			return list[i].score > list[j].score
+		}
+		return list[i].UpdatedAt.After(list[j].UpdatedAt)
+	})
+
+	var (
+		selected []string
+		used     int
+	)
+	for _, s := range list {
+		cost := EstimateTokens(s.Content)
+		if used+cost <= budget {
+			selected = append(selected, s.Content)
+			used += cost
+		}
+	}
+	if len(selected) == 0 && len(list) > 0 && budget > 0 {
+		selected = append(selected, truncate(list[0].Content, budget*charsPerToken))
+	}
+	return selected
+}
+
+// truncate cuts text to at most limit bytes, backing up to the last word
+// boundary.
+func truncate(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	if i := strings.LastIndexAny(text[:cut], " \n\t"); i > 0 {
+		cut = i
+	}
+	return strings.TrimSpace(text[:cut]) + " ..."
+}
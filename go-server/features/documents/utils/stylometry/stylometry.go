@@ -0,0 +1,408 @@
+// Package stylometry fingerprints how someone writes: sentence lengths,
+// readability, vocabulary, contractions, passive voice and the phrases they
+// keep coming back to. It is deterministic, so the same samples always give
+// the same profile and a document can be scored against it.
+package stylometry
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+)
+
+const (
+	// ttrWindow is the window for the moving-average type-token ratio.
+	ttrWindow = 50
+	// shortSentence and longSentence bound SentenceLengthStats.ShortShare
+	// and LongShare, in words.
+	shortSentence = 10
+	longSentence  = 25
+	// maxPhrases caps CharacteristicPhrases.
+	maxPhrases = 10
+)
+
+var (
+	wordPattern = regexp.MustCompile(`[A-Za-z0-9]+(?:'[A-Za-z]+)*`)
+	// sentenceEnd splits after terminal punctuation, optionally followed by
+	// closing quotes or brackets, and at blank lines.
+	sentenceEnd = regexp.MustCompile(`[.!?]+["')\]]*\s+|\n\s*\n`)
+	// abbreviation matches periods that don't end a sentence.
+	abbreviation = regexp.MustCompile(`(?i)\b(?:e\.g|i\.e|etc|vs|mr|mrs|ms|dr|prof|inc|ltd|jr|sr|st)\.`)
+)
+
+// Analyze builds a profile from texts. SampleCount is len(texts); the caller
+// sets SamplesUpdatedAt and AnalyzedAt.
+func Analyze(texts []string) domain.StyleProfile {
+	p := domain.StyleProfile{SampleCount: len(texts)}
+
+	var (
+		lengths    []float64
+		allWords   []string
+		syllables  int
+		passive    int
+		contracted int
+		phrases    = map[string]int{}
+		phraseDocs = map[string]map[int]bool{}
+	)
+	for i, text := range texts {
+		for _, sentence := range splitSentences(text) {
+			words := tokenize(sentence)
+			if len(words) == 0 {
+				continue
+			}
+			lengths = append(lengths, float64(len(words)))
+			allWords = append(allWords, words...)
+			for _, w := range words {
+				syllables += countSyllables(w)
+				if isContraction(w) {
+					contracted++
+				}
+			}
+			if isPassive(words) {
+				passive++
+			}
+			for _, phrase := range ngrams(words) {
+				phrases[phrase]++
+				if phraseDocs[phrase] == nil {
+					phraseDocs[phrase] = map[int]bool{}
+				}
+				phraseDocs[phrase][i] = true
+			}
+		}
+	}
+
+	p.WordCount = len(allWords)
+	p.SentenceCount = len(lengths)
+	if p.WordCount == 0 {
+		return p
+	}
+
+	p.SentenceLength = sentenceStats(lengths)
+	p.ReadabilityGrade = round(
+		0.39*float64(p.WordCount)/float64(p.SentenceCount)+11.8*float64(syllables)/float64(p.WordCount)-15.59, 1)
+	p.VocabularyRichness = round(movingTTR(allWords), 3)
+	p.ContractionRate = round(float64(contracted)*100/float64(p.WordCount), 2)
+	p.PassiveVoiceRatio = round(float64(passive)/float64(p.SentenceCount), 3)
+	p.CharacteristicPhrases = characteristicPhrases(phrases, phraseDocs, len(texts))
+	return p
+}
+
+// metricScales is how far apart a metric can be before it counts as fully
+// different. They are set so a distance of 1 is clearly audible in the
+// prose, not just measurable.
+var metricScales = []struct {
+	name  string
+	value func(*domain.StyleProfile) float64
+	scale float64
+}{
+	{"sentenceLengthMean", func(p *domain.StyleProfile) float64 { return p.SentenceLength.Mean }, 8},
+	{"sentenceLengthSpread", func(p *domain.StyleProfile) float64 { return p.SentenceLength.StdDev }, 6},
+	{"readabilityGrade", func(p *domain.StyleProfile) float64 { return p.ReadabilityGrade }, 4},
+	{"vocabularyRichness", func(p *domain.StyleProfile) float64 { return p.VocabularyRichness }, 0.15},
+	{"contractionRate", func(p *domain.StyleProfile) float64 { return p.ContractionRate }, 2},
+	{"passiveVoiceRatio", func(p *domain.StyleProfile) float64 { return p.PassiveVoiceRatio }, 0.2},
+}
+
+// Compare scores text against profile. Each metric's distance is its
+// difference over the metric's scale, capped at 1; the overall distance is
+// their mean.
+func Compare(profile *domain.StyleProfile, text string) domain.StyleMatch {
+	doc := Analyze([]string{text})
+
+	var match domain.StyleMatch
+	total := 0.0
+	for _, m := range metricScales {
+		want, got := m.value(profile), m.value(&doc)
+		d := math.Min(1, math.Abs(want-got)/m.scale)
+		match.Metrics = append(match.Metrics, domain.StyleMetricMatch{
+			Metric:   m.name,
+			Profile:  want,
+			Document: got,
+			Distance: round(d, 3),
+		})
+		total += d
+	}
+	match.Distance = round(total/float64(len(metricScales)), 3)
+
+	haystack := " " + strings.Join(tokenize(text), " ") + " "
+	for _, phrase := range profile.CharacteristicPhrases {
+		if strings.Contains(haystack, " "+phrase+" ") {
+			match.PhrasesUsed = append(match.PhrasesUsed, phrase)
+		}
+	}
+	return match
+}
+
+func splitSentences(text string) []string {
+	// Hide abbreviation periods so they don't end a sentence.
+	masked := abbreviation.ReplaceAllStringFunc(normalizeQuotes(text), func(m string) string {
+		return strings.ReplaceAll(m, ".", "\x00")
+	})
+
+	var sentences []string
+	for _, s := range sentenceEnd.Split(masked, -1) {
+		s = strings.TrimSpace(strings.ReplaceAll(s, "\x00", "."))
+		if s != "" {
+			sentences = append(sentences, s)
+		}
+	}
+	return sentences
+}
+
+// tokenize returns the lowercased words of text.
+func tokenize(text string) []string {
+	words := wordPattern.FindAllString(normalizeQuotes(text), -1)
+	for i := range words {
+		words[i] = strings.ToLower(words[i])
+	}
+	return words
+}
+
+func normalizeQuotes(text string) string {
+	return strings.NewReplacer("’", "'", "‘", "'", "“", `"`, "”", `"`).Replace(text)
+}
+
+// countSyllables estimates syllables by counting vowel groups, dropping a
+// silent final e. Every word has at least one.
+func countSyllables(word string) int {
+	word = strings.SplitN(word, "'", 2)[0]
+	if word == "" {
+		return 1
+	}
+	count := 0
+	prevVowel := false
+	for _, r := range word {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !prevVowel {
+			count++
+		}
+		prevVowel = vowel
+	}
+	if strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") && count > 1 {
+		count--
+	}
+	if count == 0 {
+		return 1
+	}
+	return count
+}
+
+// contractionSuffixes are always contractions; 's is only one after the
+// words in sContractions, since otherwise it is usually a possessive.
+var (
+	contractionSuffixes = []string{"n't", "'re", "'ve", "'ll", "'d", "'m"}
+	sContractions       = map[string]bool{
+		"it": true, "that": true, "there": true, "here": true, "what": true,
+		"who": true, "let": true, "he": true, "she": true, "where": true,
+	}
+)
+
+func isContraction(word string) bool {
+	for _, suffix := range contractionSuffixes {
+		if strings.HasSuffix(word, suffix) {
+			return true
+		}
+	}
+	if base, ok := strings.CutSuffix(word, "'s"); ok {
+		return sContractions[base]
+	}
+	return false
+}
+
+var (
+	beForms = map[string]bool{
+		"am": true, "is": true, "are": true, "was": true, "were": true,
+		"be": true, "been": true, "being": true,
+	}
+	irregularParticiples = map[string]bool{
+		"built": true, "brought": true, "begun": true, "chosen": true, "done": true,
+		"drawn": true, "driven": true, "found": true, "given": true, "grown": true,
+		"held": true, "known": true, "led": true, "made": true, "paid": true,
+		"seen": true, "sent": true, "shown": true, "taken": true, "taught": true,
+		"told": true, "won": true, "written": true, "kept": true, "left": true,
+		"met": true, "set": true, "spent": true, "understood": true, "run": true,
+	}
+)
+
+// isPassive reports whether a sentence has a form of "to be" followed, after
+// at most two adverbs, by a past participle.
+func isPassive(words []string) bool {
+	for i, w := range words {
+		if !beForms[w] {
+			continue
+		}
+		for j := i + 1; j < len(words) && j <= i+3; j++ {
+			next := words[j]
+			if irregularParticiples[next] || (strings.HasSuffix(next, "ed") && len(next) > 3) {
+				return true
+			}
+			if !strings.HasSuffix(next, "ly") && next != "not" {
+				break
+			}
+		}
+	}
+	return false
+}
+
+// stopwords can't make up a phrase on their own.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "the": true, "and": true, "or": true, "but": true,
+	"of": true, "to": true, "in": true, "on": true, "at": true, "for": true,
+	"with": true, "by": true, "from": true, "as": true, "is": true, "are": true,
+	"was": true, "were": true, "be": true, "been": true, "it": true, "its": true,
+	"this": true, "that": true, "these": true, "those": true, "i": true, "me": true,
+	"my": true, "we": true, "our": true, "you": true, "your": true, "he": true,
+	"she": true, "they": true, "them": true, "their": true, "have": true,
+	"has": true, "had": true, "do": true, "did": true, "so": true, "if": true,
+	"not": true, "can": true, "will": true, "would": true, "could": true,
+}
+
+// ngrams returns the 2- to 4-word phrases in a sentence that contain at
+// least one content word and don't end on a stopword.
+func ngrams(words []string) []string {
+	var out []string
+	for n := 2; n <= 4; n++ {
+		for i := 0; i+n <= len(words); i++ {
+			gram := words[i : i+n]
+			if stopwords[gram[n-1]] {
+				continue
+			}
+			content := false
+			for _, w := range gram {
+				if !stopwords[w] {
+					content = true
+					break
+				}
+			}
+			if content {
+				out = append(out, strings.Join(gram, " "))
+			}
+		}
+	}
+	return out
+}
+
+// characteristicPhrases picks the phrases repeated across the samples, or
+// within the only sample. A phrase is dropped when a longer phrase
+// containing it is used just as often, since it adds nothing.
+func characteristicPhrases(counts map[string]int, docs map[string]map[int]bool, sampleCount int) []string {
+	type phrase struct {
+		text  string
+		count int
+		words int
+	}
+	var candidates []phrase
+	for text, count := range counts {
+		if count < 2 || (sampleCount > 1 && len(docs[text]) < 2) {
+			continue
+		}
+		candidates = append(candidates, phrase{text, count, strings.Count(text, " ") + 1})
+	}
+
+	var kept []phrase
+	for _, c := range candidates {
+		subsumed := false
+		for _, other := range candidates {
+			if other.words > c.words && other.count >= c.count && strings.Contains(" "+other.text+" ", " "+c.text+" ") {
+				subsumed = true
+				break
+			}
+		}
+		if !subsumed {
+			kept = append(kept, c)
+		}
+	}
+
+	sort.Slice(kept, func(i, j int) bool {
+		si, sj := kept[i].count*kept[i].words, kept[j].count*kept[j].words
+		if si != sj {
+			return si > sj
+		}
+		return kept[i].text < kept[j].text
+	})
+	out := make([]string, 0, min(len(kept), maxPhrases))
+	for i := 0; i < len(kept) && i < maxPhrases; i++ {
+		out = append(out, kept[i].text)
+	}
+	return out
+}
+
+func sentenceStats(lengths []float64) domain.SentenceLengthStats {
+	sorted := append([]float64(nil), lengths...)
+	sort.Float64s(sorted)
+
+	var sum, short, long float64
+	for _, l := range sorted {
+		sum += l
+		if l < shortSentence {
+			short++
+		}
+		if l > longSentence {
+			long++
+		}
+	}
+	n := float64(len(sorted))
+	mean := sum / n
+	var variance float64
+	for _, l := range sorted {
+		variance += (l - mean) * (l - mean)
+	}
+
+	return domain.SentenceLengthStats{
+		Mean:       round(mean, 1),
+		StdDev:     round(math.Sqrt(variance/n), 1),
+		P10:        percentile(sorted, 0.1),
+		Median:     percentile(sorted, 0.5),
+		P90:        percentile(sorted, 0.9),
+		ShortShare: round(short/n, 3),
+		LongShare:  round(long/n, 3),
+	}
+}
+
+// percentile interpolates linearly between the closest ranks of sorted.
+func percentile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	return round(sorted[lo]+(sorted[hi]-sorted[lo])*(pos-float64(lo)), 1)
+}
+
+// movingTTR averages the type-token ratio over every ttrWindow-word window,
+// falling back to the plain ratio for shorter texts.
+func movingTTR(words []string) float64 {
+	if len(words) <= ttrWindow {
+		return float64(distinct(words)) / float64(len(words))
+	}
+	counts := map[string]int{}
+	for _, w := range words[:ttrWindow] {
+		counts[w]++
+	}
+	total := float64(len(counts))
+	windows := 1
+	for i := ttrWindow; i < len(words); i++ {
+		out := words[i-ttrWindow]
+		if counts[out]--; counts[out] == 0 {
+			delete(counts, out)
+		}
+		counts[words[i]]++
+		total += float64(len(counts))
+		windows++
+	}
+	return total / float64(windows) / ttrWindow
+}
+
+func distinct(words []string) int {
+	seen := make(map[string]bool, len(words))
+	for _, w := range words {
+		seen[w] = true
+	}
+	return len(seen)
+}
+
+func round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
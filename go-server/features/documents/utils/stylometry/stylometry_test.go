@@ -0,0 +1,148 @@
+package stylometry
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+)
+
+// sentence is n one-syllable words ending in a period.
+func sentence(n int) string {
+	return strings.TrimSpace(strings.Repeat("word ", n)) + "."
+}
+
+func TestSentenceLengthDistribution(t *testing.T) {
+	text := strings.Join([]string{sentence(4), sentence(8), sentence(12), sentence(30)}, " ")
+
+	p := Analyze([]string{text})
+	if p.SentenceCount != 4 || p.WordCount != 54 {
+		t.Fatalf("counts = %d sentences, %d words, want 4 and 54", p.SentenceCount, p.WordCount)
+	}
+	want := domain.SentenceLengthStats{
+		Mean:       13.5,
+		StdDev:     9.9,
+		P10:        5.2,
+		Median:     10,
+		P90:        24.6,
+		ShortShare: 0.5,
+		LongShare:  0.25,
+	}
+	if p.SentenceLength != want {
+		t.Errorf("SentenceLength = %+v, want %+v", p.SentenceLength, want)
+	}
+}
+
+func TestSplitSentencesKeepsAbbreviations(t *testing.T) {
+	got := splitSentences("We met Dr. Smith, e.g. at lunch. Then we left!\n\nNew paragraph")
+	want := []string{"We met Dr. Smith, e.g. at lunch", "Then we left", "New paragraph"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("splitSentences = %q, want %q", got, want)
+	}
+}
+
+func TestContractionRate(t *testing.T) {
+	// 11 words; don't, it's and we'll are contractions, John's is a
+	// possessive.
+	p := Analyze([]string{"I don’t think it's over. We'll see what John's plan is."})
+	if p.WordCount != 11 {
+		t.Fatalf("WordCount = %d, want 11", p.WordCount)
+	}
+	if p.ContractionRate != 27.27 {
+		t.Errorf("ContractionRate = %v, want 27.27", p.ContractionRate)
+	}
+}
+
+func TestIsContraction(t *testing.T) {
+	tests := map[string]bool{
+		"don't": true, "we'll": true, "i'm": true, "they've": true, "you'd": true,
+		"it's": true, "that's": true, "john's": false, "company's": false, "dont": false,
+	}
+	for word, want := range tests {
+		if got := isContraction(word); got != want {
+			t.Errorf("isContraction(%q) = %v, want %v", word, got, want)
+		}
+	}
+}
+
+func TestPassiveVoiceRatio(t *testing.T) {
+	p := Analyze([]string{
+		"The report was written by the team. The team wrote the report. " +
+			"The bug was quickly fixed. She is happy.",
+	})
+	if p.PassiveVoiceRatio != 0.5 {
+		t.Errorf("PassiveVoiceRatio = %v, want 0.5", p.PassiveVoiceRatio)
+	}
+}
+
+func TestIsPassive(t *testing.T) {
+	tests := map[string]bool{
+		"The report was written by the team":  true,
+		"The bug was quickly fixed":           true,
+		"The house was not painted":           true,
+		"Results were carefully checked":      true,
+		"The team wrote the report":           false,
+		"She is happy":                        false,
+		"It was red":                          false,
+		"He is always very happy and relaxed": false,
+	}
+	for s, want := range tests {
+		if got := isPassive(tokenize(s)); got != want {
+			t.Errorf("isPassive(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestCompareSameTextHasNoDistance(t *testing.T) {
+	text := "I built the billing service. It's fast, and it was designed to scale. We shipped it in March."
+	p := Analyze([]string{text})
+
+	match := Compare(&p, text)
+	if match.Distance != 0 {
+		t.Errorf("Distance = %v, want 0", match.Distance)
+	}
+	for _, m := range match.Metrics {
+		if m.Distance != 0 {
+			t.Errorf("%s distance = %v, want 0", m.Metric, m.Distance)
+		}
+	}
+}
+
+func TestCompareDistance(t *testing.T) {
+	profile := domain.StyleProfile{
+		SentenceLength:        domain.SentenceLengthStats{Mean: 12, StdDev: 3},
+		ReadabilityGrade:      -0.2,
+		VocabularyRichness:    0.325,
+		ContractionRate:       10,
+		PassiveVoiceRatio:     0,
+		CharacteristicPhrases: []string{"word word", "billing service"},
+	}
+
+	// One four-word sentence: mean 4, spread 0, grade -2.2, richness 0.25,
+	// no contractions or passive voice.
+	match := Compare(&profile, sentence(4))
+
+	want := map[string]float64{
+		"sentenceLengthMean":   1, // 8 apart on a scale of 8
+		"sentenceLengthSpread": 0.5,
+		"readabilityGrade":     0.5,
+		"vocabularyRichness":   0.5,
+		"contractionRate":      1, // capped
+		"passiveVoiceRatio":    0,
+	}
+	if len(match.Metrics) != len(want) {
+		t.Fatalf("got %d metrics, want %d", len(match.Metrics), len(want))
+	}
+	for _, m := range match.Metrics {
+		if m.Distance != want[m.Metric] {
+			t.Errorf("%s distance = %v, want %v", m.Metric, m.Distance, want[m.Metric])
+		}
+	}
+	if match.Distance != 0.583 {
+		t.Errorf("Distance = %v, want 0.583", match.Distance)
+	}
+	if !reflect.DeepEqual(match.PhrasesUsed, []string{"word word"}) {
+		t.Errorf("PhrasesUsed = %q, want [word word]", match.PhrasesUsed)
+	}
+}
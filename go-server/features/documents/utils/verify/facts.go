@@ -0,0 +1,370 @@
+package verify
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/models/requests"
+	"github.com/ordo_meritum/shared/libs/dates"
+	"github.com/ordo_meritum/shared/libs/taxonomy"
+)
+
+var yearPattern = regexp.MustCompile(`\b(19|20)\d{2}\b`)
+
+// genericSkillWords are words that show up in skill phrases without naming
+// a technology, e.g. "REST APIs" or "Agile Methodology". A phrase made only
+// of these is never flagged.
+var genericSkillWords = map[string]bool{
+	"and": true, "or": true, "of": true, "with": true, "the": true,
+	"api": true, "apis": true, "design": true, "development": true,
+	"methodology": true, "methodologies": true, "practices": true,
+	"principles": true, "programming": true, "testing": true, "tools": true,
+	"systems": true, "services": true, "framework": true, "frameworks": true,
+}
+
+// CheckFacts compares the generated resume with the source payload and
+// repairs what it can:
+//   - experiences at unknown companies and unknown projects are removed
+//   - a changed job title or date range is set back to the source value
+//   - skills in the skills section that appear nowhere in the source are
+//     removed
+//   - certifications, publications, awards, languages and volunteering
+//     roles must come from the source and are reset to its values
+//   - unknown technologies in bullet points are only flagged, since the
+//     bullet may be otherwise sound and the user can reject it in review
+func CheckFacts(payload *requests.DocumentPayload, resume *domain.Resume) []domain.FactIssue {
+	var issues []domain.FactIssue
+	corpus := newCorpus(payload)
+
+	issues = append(issues, checkExperiences(payload.Resume.Experiences, resume)...)
+	issues = append(issues, checkProjects(payload.Resume.Projects, resume)...)
+	issues = append(issues, checkSkills(corpus, resume)...)
+	issues = append(issues, checkSections(&payload.Resume, resume)...)
+
+	for _, e := range resume.Experiences {
+		issues = append(issues, checkBullets(corpus, requests.SectionExperiences, experienceKey(e.Company, e.Position), e.BulletPoints)...)
+	}
+	for _, p := range resume.Projects {
+		issues = append(issues, checkBullets(corpus, requests.SectionProjects, p.Name, p.BulletPoints)...)
+	}
+	for _, v := range resume.Volunteering {
+		issues = append(issues, checkBullets(corpus, requests.SectionVolunteering, v.Organization, v.BulletPoints)...)
+	}
+	return issues
+}
+
+func checkExperiences(source []requests.ExperiencePayload, resume *domain.Resume) []domain.FactIssue {
+	var issues []domain.FactIssue
+	claimed := make([]bool, len(source))
+	kept := resume.Experiences[:0]
+
+	for _, e := range resume.Experiences {
+		key := experienceKey(e.Company, e.Position)
+		idx := claimExperience(source, claimed, e)
+		if idx < 0 {
+			issues = append(issues, domain.FactIssue{
+				Section:   requests.SectionExperiences,
+				Key:       key,
+				Field:     "company",
+				Generated: e.Company,
+				Action:    domain.FactRemoved,
+			})
+			continue
+		}
+		src := source[idx]
+
+		if normalize(e.Position) != normalize(src.Position) {
+			issues = append(issues, domain.FactIssue{
+				Section:   requests.SectionExperiences,
+				Key:       key,
+				Field:     "position",
+				Generated: e.Position,
+				Expected:  src.Position,
+				Action:    domain.FactCorrected,
+			})
+			e.Position = src.Position
+		}
+
+		start, end := dates.Split(src.Years)
+		if src.Years != "" && !sameYears(e.Start+" "+e.End, src.Years) {
+			issues = append(issues, domain.FactIssue{
+				Section:   requests.SectionExperiences,
+				Key:       key,
+				Field:     "dates",
+				Generated: strings.Trim(e.Start+" - "+e.End, " -"),
+				Expected:  src.Years,
+				Action:    domain.FactCorrected,
+			})
+			e.Start, e.End = start, end
+		}
+
+		kept = append(kept, e)
+	}
+	resume.Experiences = kept
+	return issues
+}
+
+// claimExperience finds the source entry a generated experience came from:
+// the same company and title if possible, otherwise the same company.
+func claimExperience(source []requests.ExperiencePayload, claimed []bool, e domain.Experience) int {
+	company, position := normalize(e.Company), normalize(e.Position)
+	fallback := -1
+	for i, src := range source {
+		if claimed[i] || normalize(src.Company) != company {
+			continue
+		}
+		if normalize(src.Position) == position {
+			claimed[i] = true
+			return i
+		}
+		if fallback < 0 {
+			fallback = i
+		}
+	}
+	if fallback >= 0 {
+		claimed[fallback] = true
+	}
+	return fallback
+}
+
+func checkProjects(source []requests.ProjectPayload, resume *domain.Resume) []domain.FactIssue {
+	var issues []domain.FactIssue
+	names := make(map[string]bool, len(source))
+	for _, p := range source {
+		names[normalize(p.Name)] = true
+	}
+
+	kept := resume.Projects[:0]
+	for _, p := range resume.Projects {
+		if !names[normalize(p.Name)] {
+			issues = append(issues, domain.FactIssue{
+				Section:   requests.SectionProjects,
+				Key:       p.Name,
+				Field:     "name",
+				Generated: p.Name,
+				Action:    domain.FactRemoved,
+			})
+			continue
+		}
+		kept = append(kept, p)
+	}
+	resume.Projects = kept
+	return issues
+}
+
+func checkSkills(c corpus, resume *domain.Resume) []domain.FactIssue {
+	var issues []domain.FactIssue
+	kept := resume.Skills[:0]
+	for _, category := range resume.Skills {
+		items := category.SkillItem[:0]
+		for _, skill := range category.SkillItem {
+			if c.knowsPhrase(skill) {
+				items = append(items, skill)
+				continue
+			}
+			issues = append(issues, domain.FactIssue{
+				Section:   requests.SectionSkills,
+				Key:       category.Category,
+				Field:     "technology",
+				Generated: skill,
+				Action:    domain.FactRemoved,
+			})
+		}
+		if len(items) == 0 {
+			continue
+		}
+		category.SkillItem = items
+		kept = append(kept, category)
+	}
+	resume.Skills = kept
+	return issues
+}
+
+func checkBullets(c corpus, section, key string, points []domain.BulletPoint) []domain.FactIssue {
+	var issues []domain.FactIssue
+	for _, p := range points {
+		for _, term := range techTerms(p.Text) {
+			if c.knows(term) {
+				continue
+			}
+			issues = append(issues, domain.FactIssue{
+				Section:   section,
+				Key:       key,
+				Field:     "technology",
+				Generated: term,
+				Action:    domain.FactFlagged,
+			})
+		}
+	}
+	return issues
+}
+
+// sameYears reports whether two date ranges mention the same years. Month
+// names and formatting are allowed to differ.
+func sameYears(generated, source string) bool {
+	a, b := yearPattern.FindAllString(generated, -1), yearPattern.FindAllString(source, -1)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// techTerms picks out the words in a bullet that look like technology
+// names: acronyms and mixed-case words such as AWS or PostgreSQL, and words
+// with symbols or digits such as Node.js, C++ or EC2. Ordinary capitalised
+// words are left alone since they are usually just the start of a sentence.
+func techTerms(text string) []string {
+	var terms []string
+	for _, word := range strings.Fields(text) {
+		word = strings.TrimRight(strings.TrimLeft(word, "(\"'"), ".,;:!?)\"'")
+		if looksTechnical(word) {
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+func looksTechnical(word string) bool {
+	if len(word) < 2 {
+		return false
+	}
+	upper, letters, digits, symbols := 0, 0, 0, 0
+	for i, r := range word {
+		switch {
+		case unicode.IsUpper(r):
+			letters++
+			if i > 0 {
+				upper++
+			}
+		case unicode.IsLetter(r):
+			letters++
+		case unicode.IsDigit(r):
+			digits++
+		case r == '.' || r == '+' || r == '#':
+			symbols++
+		}
+	}
+	if letters == 0 {
+		return false
+	}
+	return upper > 0 || (symbols > 0 && letters > 0) || (digits > 0 && letters > 0)
+}
+
+// corpus is everything the user supplied, reduced to canonical words. Skill
+// aliases are resolved through the taxonomy, so "k8s" in the source vouches
+// for "Kubernetes" in the output and the other way round.
+type corpus struct {
+	text string
+}
+
+func newCorpus(payload *requests.DocumentPayload) corpus {
+	var parts []string
+	parts = append(parts, payload.UserInfo.Summary)
+	for _, s := range payload.Resume.Skills {
+		parts = append(parts, s.Skill)
+	}
+	for _, e := range payload.Resume.Experiences {
+		parts = append(parts, e.Company, e.Position)
+		parts = append(parts, e.BulletPoints...)
+	}
+	for _, p := range payload.Resume.Projects {
+		parts = append(parts, p.Name, p.Description)
+		parts = append(parts, p.BulletPoints...)
+	}
+	for _, v := range payload.Resume.Volunteering {
+		parts = append(parts, v.Organization, v.Role)
+		parts = append(parts, v.BulletPoints...)
+	}
+	for _, c := range payload.Resume.Certifications {
+		parts = append(parts, c.Name, c.Issuer)
+	}
+	for _, p := range payload.Resume.Publications {
+		parts = append(parts, p.Title, p.Summary)
+	}
+	for _, a := range payload.Resume.Awards {
+		parts = append(parts, a.Title, a.Summary)
+	}
+	for _, ed := range payload.Educations() {
+		parts = append(parts, ed.School, ed.Degree)
+		if ed.CourseWork != nil {
+			parts = append(parts, *ed.CourseWork)
+		}
+	}
+	parts = append(parts, additionalInfoText(payload.AdditionalInfo))
+
+	text := taxonomy.Default().CanonicalText(strings.Join(parts, " "))
+	return corpus{text: " " + canonical(text) + " "}
+}
+
+func additionalInfoText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var sections map[string]string
+	if err := json.Unmarshal(raw, &sections); err != nil {
+		return string(raw)
+	}
+	var sb strings.Builder
+	for title, content := range sections {
+		sb.WriteString(title + " " + content + " ")
+	}
+	return sb.String()
+}
+
+// knows reports whether a single term appears in the source, allowing for
+// common spelling variants such as React/React.js or API/APIs.
+func (c corpus) knows(term string) bool {
+	t := canonical(taxonomy.Default().Canonical(term))
+	if t == "" {
+		return true
+	}
+	for _, v := range []string{t, strings.TrimSuffix(t, "js"), strings.TrimSuffix(t, "s"), t + "s", t + "js"} {
+		if v != "" && strings.Contains(c.text, " "+v+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// knowsPhrase accepts a skill phrase if it appears as a whole, or if any of
+// its non-generic words does.
+func (c corpus) knowsPhrase(phrase string) bool {
+	if c.knows(phrase) {
+		return true
+	}
+	significant := false
+	for _, word := range strings.Fields(canonical(phrase)) {
+		if genericSkillWords[word] {
+			continue
+		}
+		significant = true
+		if c.knows(word) {
+			return true
+		}
+	}
+	return !significant
+}
+
+// canonical lowercases s and strips punctuation other than + and #, so
+// "Node.js" and "nodejs" compare equal.
+func canonical(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#':
+			sb.WriteRune(r)
+		case r == '.' || r == '-' || r == '\'':
+		default:
+			sb.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
@@ -0,0 +1,46 @@
+package verify
+
+import (
+	"testing"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/models/requests"
+)
+
+func TestCheckFactsResolvesSkillAliases(t *testing.T) {
+	payload := &requests.DocumentPayload{Resume: requests.ResumePayload{
+		Skills: []requests.SkillsPayload{{Skill: "k8s"}, {Skill: "Golang"}},
+		Experiences: []requests.ExperiencePayload{{
+			Company: "Acme", Position: "Engineer",
+			BulletPoints: []string{"Ran services on Kubernetes."},
+		}},
+	}}
+	resume := &domain.Resume{
+		Skills: []domain.Skills{{Category: "Tools", SkillItem: []string{"Kubernetes", "Go"}}},
+		Experiences: []domain.Experience{{
+			Company: "Acme", Position: "Engineer",
+			BulletPoints: []domain.BulletPoint{{Text: "Ran Go services on K8s."}},
+		}},
+	}
+
+	if issues := CheckFacts(payload, resume); len(issues) > 0 {
+		t.Errorf("aliases of source skills were flagged: %+v", issues)
+	}
+	if got := resume.Skills[0].SkillItem; len(got) != 2 {
+		t.Errorf("skills = %q, want both kept", got)
+	}
+}
+
+func TestCheckFactsFlagsUnknownSkills(t *testing.T) {
+	payload := &requests.DocumentPayload{Resume: requests.ResumePayload{
+		Skills: []requests.SkillsPayload{{Skill: "Go"}},
+	}}
+	resume := &domain.Resume{
+		Skills: []domain.Skills{{Category: "Tools", SkillItem: []string{"Go", "Kubernetes"}}},
+	}
+
+	issues := CheckFacts(payload, resume)
+	if len(issues) != 1 || issues[0].Generated != "Kubernetes" {
+		t.Errorf("issues = %+v, want Kubernetes removed", issues)
+	}
+}
@@ -0,0 +1,344 @@
+package verify
+
+import (
+	"strings"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/models/requests"
+	"github.com/ordo_meritum/shared/libs/dates"
+)
+
+// minRewriteSimilarity is how much of a locked bullet's wording a generated
+// bullet must share to count as a rewrite of it rather than a new bullet.
+const minRewriteSimilarity = 0.5
+
+// entry is the part of an experience or project the lock checks care
+// about, so both can share one code path.
+type entry struct {
+	key           string
+	bullets       []string
+	locked        bool
+	lockedBullets []int
+}
+
+// EnforceLocks puts back any locked text in the generated resume that the
+// model changed or dropped, and reports each repair.
+func EnforceLocks(payload *requests.DocumentPayload, resume *domain.Resume) []domain.LockViolation {
+	var violations []domain.LockViolation
+	locks := &payload.Resume
+
+	if locks.IsLocked(requests.SectionSummary) {
+		violations = append(violations, enforceSummary(payload.UserInfo.Summary, resume)...)
+	}
+	if locks.IsLocked(requests.SectionSkills) {
+		violations = append(violations, enforceSkills(locks.Skills, resume)...)
+	}
+
+	sectionLocked := locks.IsLocked(requests.SectionExperiences)
+	for _, e := range locks.Experiences {
+		src := entry{
+			key:           experienceKey(e.Company, e.Position),
+			bullets:       e.BulletPoints,
+			locked:        e.Locked || sectionLocked,
+			lockedBullets: e.LockedBullets,
+		}
+		if !src.isLocked() {
+			continue
+		}
+		idx := matchExperience(resume.Experiences, e)
+		if idx < 0 {
+			start, end := dates.Split(e.Years)
+			resume.Experiences = append(resume.Experiences, domain.Experience{
+				ID:       e.ID,
+				Company:  e.Company,
+				Position: e.Position,
+				Start:    start,
+				End:      end,
+			})
+			idx = len(resume.Experiences) - 1
+		}
+		var found []domain.LockViolation
+		resume.Experiences[idx].BulletPoints, found = enforceBullets(src, resume.Experiences[idx].BulletPoints, requests.SectionExperiences)
+		violations = append(violations, found...)
+	}
+
+	sectionLocked = locks.IsLocked(requests.SectionProjects)
+	for _, p := range locks.Projects {
+		src := entry{
+			key:           p.Name,
+			bullets:       p.BulletPoints,
+			locked:        p.Locked || sectionLocked,
+			lockedBullets: p.LockedBullets,
+		}
+		if !src.isLocked() {
+			continue
+		}
+		idx := matchProject(resume.Projects, p)
+		if idx < 0 {
+			resume.Projects = append(resume.Projects, domain.Project{
+				ID:   p.ID,
+				Name: p.Name,
+				Role: p.Description,
+			})
+			idx = len(resume.Projects) - 1
+		}
+		var found []domain.LockViolation
+		resume.Projects[idx].BulletPoints, found = enforceBullets(src, resume.Projects[idx].BulletPoints, requests.SectionProjects)
+		violations = append(violations, found...)
+	}
+
+	return violations
+}
+
+// LockedBullets returns a check for whether a bullet's text is locked in the
+// payload, so later passes such as auto-fit can leave it alone.
+func LockedBullets(payload *requests.DocumentPayload) func(text string) bool {
+	locked := make(map[string]bool)
+	add := func(bullets []string, all bool, indexes []int) {
+		if all {
+			for _, b := range bullets {
+				locked[normalize(b)] = true
+			}
+			return
+		}
+		for _, i := range indexes {
+			if i >= 0 && i < len(bullets) {
+				locked[normalize(bullets[i])] = true
+			}
+		}
+	}
+
+	locks := &payload.Resume
+	sectionLocked := locks.IsLocked(requests.SectionExperiences)
+	for _, e := range locks.Experiences {
+		add(e.BulletPoints, e.Locked || sectionLocked, e.LockedBullets)
+	}
+	sectionLocked = locks.IsLocked(requests.SectionProjects)
+	for _, p := range locks.Projects {
+		add(p.BulletPoints, p.Locked || sectionLocked, p.LockedBullets)
+	}
+
+	return func(text string) bool {
+		return locked[normalize(text)]
+	}
+}
+
+func (e entry) isLocked() bool {
+	return e.locked || len(e.lockedBullets) > 0
+}
+
+// enforceSummary restores a locked summary as a single sentence block.
+func enforceSummary(original string, resume *domain.Resume) []domain.LockViolation {
+	original = strings.TrimSpace(original)
+	sentences := make([]string, 0, len(resume.Summary))
+	for _, s := range resume.Summary {
+		sentences = append(sentences, strings.TrimSpace(s.Sentence))
+	}
+	generated := strings.Join(sentences, " ")
+	if normalize(generated) == normalize(original) {
+		clearSummarySuggestions(resume)
+		return nil
+	}
+
+	resume.Summary = nil
+	if original != "" {
+		resume.Summary = []domain.SummaryBody{{Sentence: original}}
+	}
+	return []domain.LockViolation{{Section: requests.SectionSummary, Original: original, Generated: generated}}
+}
+
+func clearSummarySuggestions(resume *domain.Resume) {
+	for i := range resume.Summary {
+		resume.Summary[i].NewSuggestion = false
+		resume.Summary[i].JustificationForChange = ""
+	}
+}
+
+// enforceSkills restores a locked skills section when the model added or
+// dropped skills. Regrouping the same skills into categories is allowed.
+func enforceSkills(original []requests.SkillsPayload, resume *domain.Resume) []domain.LockViolation {
+	want := make([]string, 0, len(original))
+	wantSet := make(map[string]bool, len(original))
+	for _, s := range original {
+		if name := strings.TrimSpace(s.Skill); name != "" {
+			want = append(want, name)
+			wantSet[normalize(name)] = true
+		}
+	}
+
+	var got []string
+	gotSet := make(map[string]bool)
+	for _, category := range resume.Skills {
+		for _, s := range category.SkillItem {
+			if name := strings.TrimSpace(s); name != "" {
+				got = append(got, name)
+				gotSet[normalize(name)] = true
+			}
+		}
+	}
+
+	same := len(wantSet) == len(gotSet)
+	for name := range wantSet {
+		if !gotSet[name] {
+			same = false
+			break
+		}
+	}
+	if same {
+		for i := range resume.Skills {
+			resume.Skills[i].JustificationForChanges = ""
+		}
+		return nil
+	}
+
+	resume.Skills = nil
+	if len(want) > 0 {
+		resume.Skills = []domain.Skills{{Category: "Skills", SkillItem: want}}
+	}
+	return []domain.LockViolation{{
+		Section:   requests.SectionSkills,
+		Original:  strings.Join(want, ", "),
+		Generated: strings.Join(got, ", "),
+	}}
+}
+
+// enforceBullets returns the generated bullets with every locked bullet
+// present word for word. A locked bullet the model rewrote replaces the
+// rewrite; one it dropped is put back at its original position.
+func enforceBullets(src entry, generated []domain.BulletPoint, section string) ([]domain.BulletPoint, []domain.LockViolation) {
+	var violations []domain.LockViolation
+
+	if src.locked {
+		restored := make([]domain.BulletPoint, 0, len(src.bullets))
+		claimed := make([]bool, len(generated))
+		for _, text := range src.bullets {
+			if idx := findExact(generated, claimed, text); idx >= 0 {
+				claimed[idx] = true
+			} else {
+				v := domain.LockViolation{Section: section, Key: src.key, Original: text}
+				if idx := findRewrite(generated, claimed, text); idx >= 0 {
+					claimed[idx] = true
+					v.Generated = generated[idx].Text
+				}
+				violations = append(violations, v)
+			}
+			restored = append(restored, domain.BulletPoint{Text: text})
+		}
+		for i, p := range generated {
+			if !claimed[i] {
+				violations = append(violations, domain.LockViolation{Section: section, Key: src.key, Generated: p.Text})
+			}
+		}
+		return restored, violations
+	}
+
+	claimed := make([]bool, len(generated))
+	type insertion struct {
+		at   int
+		text string
+	}
+	var missing []insertion
+	for _, i := range src.lockedBullets {
+		if i < 0 || i >= len(src.bullets) {
+			continue
+		}
+		text := src.bullets[i]
+		if idx := findExact(generated, claimed, text); idx >= 0 {
+			claimed[idx] = true
+			generated[idx] = domain.BulletPoint{Text: text}
+			continue
+		}
+		if idx := findRewrite(generated, claimed, text); idx >= 0 {
+			claimed[idx] = true
+			violations = append(violations, domain.LockViolation{Section: section, Key: src.key, Original: text, Generated: generated[idx].Text})
+			generated[idx] = domain.BulletPoint{Text: text}
+			continue
+		}
+		violations = append(violations, domain.LockViolation{Section: section, Key: src.key, Original: text})
+		missing = append(missing, insertion{at: i, text: text})
+	}
+
+	for _, m := range missing {
+		at := min(m.at, len(generated))
+		generated = append(generated[:at], append([]domain.BulletPoint{{Text: m.text}}, generated[at:]...)...)
+	}
+	return generated, violations
+}
+
+func findExact(points []domain.BulletPoint, claimed []bool, text string) int {
+	want := normalize(text)
+	for i, p := range points {
+		if !claimed[i] && normalize(p.Text) == want {
+			return i
+		}
+	}
+	return -1
+}
+
+// findRewrite returns the unclaimed bullet sharing the most words with text,
+// or -1 if none shares enough to be a rewrite of it.
+func findRewrite(points []domain.BulletPoint, claimed []bool, text string) int {
+	best, bestScore := -1, minRewriteSimilarity
+	for i, p := range points {
+		if claimed[i] {
+			continue
+		}
+		if score := similarity(text, p.Text); score >= bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
+
+// similarity is the Jaccard index of the two texts' word sets.
+func similarity(a, b string) float64 {
+	wordsA, wordsB := wordSet(a), wordSet(b)
+	if len(wordsA) == 0 && len(wordsB) == 0 {
+		return 1
+	}
+	shared := 0
+	for w := range wordsA {
+		if wordsB[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wordsA)+len(wordsB)-shared)
+}
+
+func wordSet(s string) map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range strings.Fields(strings.ToLower(s)) {
+		if w = strings.Trim(w, ".,;:!?()\"'"); w != "" {
+			words[w] = true
+		}
+	}
+	return words
+}
+
+func matchExperience(experiences []domain.Experience, e requests.ExperiencePayload) int {
+	want := normalize(experienceKey(e.Company, e.Position))
+	for j, got := range experiences {
+		if normalize(experienceKey(got.Company, got.Position)) == want {
+			return j
+		}
+	}
+	return -1
+}
+
+func matchProject(projects []domain.Project, p requests.ProjectPayload) int {
+	want := normalize(p.Name)
+	for j, got := range projects {
+		if normalize(got.Name) == want {
+			return j
+		}
+	}
+	return -1
+}
+
+func experienceKey(company, position string) string {
+	return strings.Trim(company+" - "+position, " -")
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
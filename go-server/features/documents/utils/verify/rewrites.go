@@ -0,0 +1,73 @@
+package verify
+
+import (
+	"strings"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/models/requests"
+)
+
+// MarkRewrites records the source wording on every generated bullet the
+// model rewrote rather than added, so rejecting the rewrite during review
+// can put the original back. A rewrite is matched to the most similar
+// source bullet, or failing that to the one at the same position.
+func MarkRewrites(payload *requests.DocumentPayload, resume *domain.Resume) {
+	for _, e := range payload.Resume.Experiences {
+		if idx := matchExperience(resume.Experiences, e); idx >= 0 {
+			markRewrites(e.BulletPoints, resume.Experiences[idx].BulletPoints)
+		}
+	}
+	for _, p := range payload.Resume.Projects {
+		if idx := matchProject(resume.Projects, p); idx >= 0 {
+			markRewrites(p.BulletPoints, resume.Projects[idx].BulletPoints)
+		}
+	}
+	for _, v := range payload.Resume.Volunteering {
+		want := normalize(experienceKey(v.Organization, v.Role))
+		for i := range resume.Volunteering {
+			got := &resume.Volunteering[i]
+			if normalize(experienceKey(got.Organization, got.Role)) == want {
+				markRewrites(v.BulletPoints, got.BulletPoints)
+				break
+			}
+		}
+	}
+}
+
+func markRewrites(source []string, generated []domain.BulletPoint) {
+	claimed := make([]bool, len(source))
+	var unmatched []int
+	for i := range generated {
+		p := &generated[i]
+		if p.IsNewSuggestion || p.JustificationForChange == "" {
+			continue
+		}
+		if j := findSource(source, claimed, p.Text); j >= 0 {
+			claimed[j] = true
+			p.Original = strings.TrimSpace(source[j])
+			continue
+		}
+		unmatched = append(unmatched, i)
+	}
+	for _, i := range unmatched {
+		if i < len(source) && !claimed[i] {
+			claimed[i] = true
+			generated[i].Original = strings.TrimSpace(source[i])
+		}
+	}
+}
+
+// findSource returns the unclaimed source bullet sharing the most words with
+// text, or -1 if none shares enough for text to be a rewrite of it.
+func findSource(source []string, claimed []bool, text string) int {
+	best, bestScore := -1, minRewriteSimilarity
+	for j, s := range source {
+		if claimed[j] {
+			continue
+		}
+		if score := similarity(s, text); score >= bestScore {
+			best, bestScore = j, score
+		}
+	}
+	return best
+}
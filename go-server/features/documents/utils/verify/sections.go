@@ -0,0 +1,139 @@
+package verify
+
+import (
+	"strings"
+
+	"github.com/ordo_meritum/features/documents/models/domain"
+	"github.com/ordo_meritum/features/documents/models/requests"
+	"github.com/ordo_meritum/shared/libs/dates"
+)
+
+// checkSections ties the additional sections to the source resume. The
+// model may only choose and reorder their entries, so each generated entry
+// is matched to a source entry by name and replaced with it; entries with
+// no source are removed. Volunteering keeps its rewritten bullets.
+func checkSections(source *requests.ResumePayload, resume *domain.Resume) []domain.FactIssue {
+	var issues []domain.FactIssue
+
+	var found []domain.FactIssue
+	resume.Certifications, found = pickSources(requests.SectionCertifications, resume.Certifications, source.Certifications,
+		func(c domain.Certification) string { return c.Name },
+		func(c requests.CertificationPayload) string { return c.Name },
+		func(c requests.CertificationPayload) domain.Certification {
+			return domain.Certification{ID: c.ID, Name: c.Name, Issuer: c.Issuer, Date: c.Date, URL: c.URL}
+		})
+	issues = append(issues, found...)
+
+	resume.Publications, found = pickSources(requests.SectionPublications, resume.Publications, source.Publications,
+		func(p domain.Publication) string { return p.Title },
+		func(p requests.PublicationPayload) string { return p.Title },
+		func(p requests.PublicationPayload) domain.Publication {
+			return domain.Publication{ID: p.ID, Title: p.Title, Publisher: p.Publisher, Date: p.Date, URL: p.URL, Summary: p.Summary}
+		})
+	issues = append(issues, found...)
+
+	resume.Awards, found = pickSources(requests.SectionAwards, resume.Awards, source.Awards,
+		func(a domain.Award) string { return a.Title },
+		func(a requests.AwardPayload) string { return a.Title },
+		func(a requests.AwardPayload) domain.Award {
+			return domain.Award{ID: a.ID, Title: a.Title, Awarder: a.Awarder, Date: a.Date, Summary: a.Summary}
+		})
+	issues = append(issues, found...)
+
+	resume.Languages, found = pickSources(requests.SectionLanguages, resume.Languages, source.Languages,
+		func(l domain.Language) string { return l.Language },
+		func(l requests.LanguagePayload) string { return l.Language },
+		func(l requests.LanguagePayload) domain.Language {
+			return domain.Language{ID: l.ID, Language: l.Language, Fluency: l.Fluency}
+		})
+	issues = append(issues, found...)
+
+	issues = append(issues, checkVolunteering(source.Volunteering, resume)...)
+	return issues
+}
+
+// pickSources replaces each generated entry with the source entry of the same
+// name. Each source entry is used at most once.
+func pickSources[G, S any](section string, generated []G, source []S, genName func(G) string, srcName func(S) string, toDomain func(S) G) ([]G, []domain.FactIssue) {
+	var issues []domain.FactIssue
+	claimed := make([]bool, len(source))
+	var kept []G
+
+	for _, g := range generated {
+		name := genName(g)
+		idx := -1
+		for i, s := range source {
+			if !claimed[i] && normalize(srcName(s)) == normalize(name) {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			issues = append(issues, domain.FactIssue{
+				Section:   section,
+				Key:       name,
+				Field:     "name",
+				Generated: name,
+				Action:    domain.FactRemoved,
+			})
+			continue
+		}
+		claimed[idx] = true
+		kept = append(kept, toDomain(source[idx]))
+	}
+	return kept, issues
+}
+
+func checkVolunteering(source []requests.VolunteeringPayload, resume *domain.Resume) []domain.FactIssue {
+	var issues []domain.FactIssue
+	claimed := make([]bool, len(source))
+	kept := resume.Volunteering[:0]
+
+	for _, v := range resume.Volunteering {
+		idx := -1
+		for i, src := range source {
+			if !claimed[i] && normalize(src.Organization) == normalize(v.Organization) {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			issues = append(issues, domain.FactIssue{
+				Section:   requests.SectionVolunteering,
+				Key:       v.Organization,
+				Field:     "organization",
+				Generated: v.Organization,
+				Action:    domain.FactRemoved,
+			})
+			continue
+		}
+		claimed[idx] = true
+		src := source[idx]
+
+		if normalize(v.Role) != normalize(src.Role) {
+			issues = append(issues, domain.FactIssue{
+				Section:   requests.SectionVolunteering,
+				Key:       src.Organization,
+				Field:     "role",
+				Generated: v.Role,
+				Expected:  src.Role,
+				Action:    domain.FactCorrected,
+			})
+		}
+		if src.Years != "" && !sameYears(v.Start+" "+v.End, src.Years) {
+			issues = append(issues, domain.FactIssue{
+				Section:   requests.SectionVolunteering,
+				Key:       src.Organization,
+				Field:     "dates",
+				Generated: strings.Trim(v.Start+" - "+v.End, " -"),
+				Expected:  src.Years,
+				Action:    domain.FactCorrected,
+			})
+		}
+		v.ID, v.Organization, v.Role = src.ID, src.Organization, src.Role
+		v.Start, v.End = dates.Split(src.Years)
+		kept = append(kept, v)
+	}
+	resume.Volunteering = kept
+	return issues
+}
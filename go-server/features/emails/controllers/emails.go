@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/ordo_meritum/database/emaildrafts"
+	"github.com/ordo_meritum/database/jobs"
+	"github.com/ordo_meritum/features/emails/models/requests"
+	"github.com/ordo_meritum/features/emails/services"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/middleware"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	"github.com/ordo_meritum/shared/webrender"
+	"github.com/rs/zerolog/log"
+)
+
+type Controller struct {
+	service *services.EmailService
+}
+
+func NewController(service *services.EmailService) *Controller {
+	return &Controller{service: service}
+}
+
+func (c *Controller) RegisterRoutes(secureRouter *mux.Router, authRouter *mux.Router) {
+	secureRouter.HandleFunc("/emails", c.HandleDraftEmail).Methods("POST")
+	authRouter.HandleFunc("/emails/suggestions", c.HandleListSuggestions).Methods("GET")
+	authRouter.HandleFunc("/emails/roles/{id:[0-9]+}", c.HandleListDrafts).Methods("GET")
+	authRouter.HandleFunc("/emails/{id:[0-9]+}", c.HandleGetDraft).Methods("GET")
+	authRouter.HandleFunc("/emails/{id:[0-9]+}", c.HandleUpdateDraft).Methods("PUT")
+	authRouter.HandleFunc("/emails/{id:[0-9]+}", c.HandleDeleteDraft).Methods("DELETE")
+	authRouter.HandleFunc("/emails/{id:[0-9]+}/eml", c.HandleExportDraft).Methods("GET")
+}
+
+func (c *Controller) HandleDraftEmail(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	if _, ok := contexts.FromContext(r.Context()); !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var req requests.EmailRequest
+	if webrender.DecodeJSONBody(w, r, &req) != nil {
+		return
+	}
+	if details := req.Payload.Validate(); len(details) > 0 {
+		writeValidationError(w, details)
+		return
+	}
+
+	draft, err := c.service.Draft(r.Context(), &req)
+	if err != nil {
+		writeEmailError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusCreated, draft)
+}
+
+// HandleListSuggestions returns the roles that are due an email. quietDays
+// overrides how long a role must go without a response before a follow-up
+// is suggested.
+func (c *Controller) HandleListSuggestions(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	if _, ok := contexts.FromContext(r.Context()); !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	quietDays := services.DefaultQuietDays
+	if raw := r.URL.Query().Get("quietDays"); raw != "" {
+		days, err := strconv.Atoi(raw)
+		if err != nil || days < 1 {
+			writeValidationError(w, []error_response.ValidationDetail{{Field: "quietDays", Issue: "must be a positive number of days"}})
+			return
+		}
+		quietDays = days
+	}
+
+	suggestions, err := c.service.Suggestions(r.Context(), quietDays)
+	if err != nil {
+		writeEmailError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, suggestions)
+}
+
+func (c *Controller) HandleListDrafts(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	roleID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if _, ok := contexts.FromContext(r.Context()); !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	drafts, err := c.service.List(r.Context(), roleID)
+	if err != nil {
+		writeEmailError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, drafts)
+}
+
+func (c *Controller) HandleGetDraft(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if _, ok := contexts.FromContext(r.Context()); !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	draft, err := c.service.Get(r.Context(), id)
+	if err != nil {
+		writeEmailError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, draft)
+}
+
+func (c *Controller) HandleUpdateDraft(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if _, ok := contexts.FromContext(r.Context()); !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var req requests.DraftUpdateRequest
+	if webrender.DecodeJSONBody(w, r, &req) != nil {
+		return
+	}
+	if details := req.Validate(); len(details) > 0 {
+		writeValidationError(w, details)
+		return
+	}
+
+	draft, err := c.service.Update(r.Context(), id, &req)
+	if err != nil {
+		writeEmailError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, draft)
+}
+
+func (c *Controller) HandleDeleteDraft(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if _, ok := contexts.FromContext(r.Context()); !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	if err := c.service.Delete(r.Context(), id); err != nil {
+		writeEmailError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleExportDraft downloads the draft as an .eml file that mail clients
+// open as an unsent message.
+func (c *Controller) HandleExportDraft(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if _, ok := contexts.FromContext(r.Context()); !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	data, name, err := c.service.Export(r.Context(), id)
+	if err != nil {
+		writeEmailError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "message/rfc822")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		log.Error().Err(err).Int("draftID", id).Msg("Failed to send email draft")
+	}
+}
+
+func writeValidationError(w http.ResponseWriter, details []error_response.ValidationDetail) {
+	middleware.JSON(w, http.StatusBadRequest, error_response.ErrorResponse[[]error_response.ValidationDetail]{
+		ErrorCode: error_response.BAD_REQUEST,
+		Message:   "The request failed validation.",
+		Details:   details,
+	})
+}
+
+func writeEmailError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, emaildrafts.ErrDraftNotFound), errors.Is(err, jobs.ErrJobNotFound):
+		middleware.JSON(w, http.StatusNotFound, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.RESOURCE_UNAVAILABLE,
+			Message:   err.Error(),
+		})
+	default:
+		log.Error().Err(err).Str("service", "emails-controller").Msg("Email request failed")
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// Kind is the occasion an email is written for. Each kind has its own
+// guidance in the email prompt.
+type Kind string
+
+const (
+	KindFollowUp    Kind = "follow_up"
+	KindThankYou    Kind = "thank_you"
+	KindNegotiation Kind = "negotiation"
+	KindWithdrawal  Kind = "withdrawal"
+)
+
+var Kinds = []Kind{KindFollowUp, KindThankYou, KindNegotiation, KindWithdrawal}
+
+func (k Kind) Valid() bool {
+	for _, kind := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Draft is an email written for one of the user's tracked roles.
+type Draft struct {
+	ID             int       `json:"id"`
+	RoleID         int       `json:"roleId"`
+	Kind           Kind      `json:"kind"`
+	Subject        string    `json:"subject"`
+	Body           string    `json:"body"`
+	RecipientName  string    `json:"recipientName,omitempty"`
+	RecipientEmail string    `json:"recipientEmail,omitempty"`
+	InterviewNotes string    `json:"interviewNotes,omitempty"`
+	Provider       string    `json:"provider,omitempty"`
+	Model          string    `json:"model,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// Suggestion points at a role that is due an email: a thank-you once it is
+// interviewing, a follow-up once it has been quiet since the application or
+// the last follow-up, or a negotiation once there is an offer.
+type Suggestion struct {
+	RoleID      int        `json:"roleId"`
+	JobTitle    string     `json:"jobTitle"`
+	CompanyName string     `json:"companyName"`
+	Kind        Kind       `json:"kind"`
+	Reason      string     `json:"reason"`
+	QuietSince  *time.Time `json:"quietSince,omitempty"`
+}
@@ -0,0 +1,107 @@
+package requests
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/ordo_meritum/features/emails/models/domain"
+	"github.com/ordo_meritum/shared/models/requests"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+const (
+	MaxInterviewNotesLength = 10000
+	MaxDetailsLength        = 5000
+	MaxSubjectLength        = 300
+	MaxBodyLength           = 20000
+)
+
+type EmailRequest = requests.RequestBody[EmailPayload, EmailOptions]
+
+// EmailPayload asks for an email of the given kind for a tracked role.
+// InterviewNotes are what the user remembers of their interviews; when left
+// out, the notes from the role's last draft are used. Details carries
+// anything else the email depends on, such as the offer being negotiated or
+// the reason for withdrawing.
+type EmailPayload struct {
+	RoleID         int         `json:"roleId"`
+	Kind           domain.Kind `json:"kind"`
+	RecipientName  string      `json:"recipientName,omitempty"`
+	RecipientEmail string      `json:"recipientEmail,omitempty"`
+	InterviewNotes string      `json:"interviewNotes,omitempty"`
+	Details        string      `json:"details,omitempty"`
+}
+
+type EmailOptions struct {
+	LlmProvider string `json:"llm"`
+	LlmModel    string `json:"llmModel"`
+}
+
+// DraftUpdateRequest saves the user's edits to a draft.
+type DraftUpdateRequest struct {
+	Subject        string `json:"subject"`
+	Body           string `json:"body"`
+	RecipientName  string `json:"recipientName,omitempty"`
+	RecipientEmail string `json:"recipientEmail,omitempty"`
+}
+
+func (p *EmailPayload) Validate() []error_response.ValidationDetail {
+	var details []error_response.ValidationDetail
+	if p.RoleID <= 0 {
+		details = append(details, error_response.ValidationDetail{Field: "roleId", Issue: "must be a tracked role ID"})
+	}
+	if !p.Kind.Valid() {
+		details = append(details, error_response.ValidationDetail{
+			Field: "kind",
+			Issue: fmt.Sprintf("must be one of %v", domain.Kinds),
+		})
+	}
+	details = append(details, validateRecipient(p.RecipientEmail)...)
+	if len(p.InterviewNotes) > MaxInterviewNotesLength {
+		details = append(details, error_response.ValidationDetail{
+			Field: "interviewNotes",
+			Issue: fmt.Sprintf("cannot exceed %d characters", MaxInterviewNotesLength),
+		})
+	}
+	if len(p.Details) > MaxDetailsLength {
+		details = append(details, error_response.ValidationDetail{
+			Field: "details",
+			Issue: fmt.Sprintf("cannot exceed %d characters", MaxDetailsLength),
+		})
+	}
+	return details
+}
+
+func (r *DraftUpdateRequest) Validate() []error_response.ValidationDetail {
+	var details []error_response.ValidationDetail
+	switch subject := strings.TrimSpace(r.Subject); {
+	case subject == "":
+		details = append(details, error_response.ValidationDetail{Field: "subject", Issue: "cannot be empty"})
+	case len(subject) > MaxSubjectLength || strings.ContainsAny(subject, "\r\n"):
+		details = append(details, error_response.ValidationDetail{
+			Field: "subject",
+			Issue: fmt.Sprintf("must be a single line of at most %d characters", MaxSubjectLength),
+		})
+	}
+	switch body := strings.TrimSpace(r.Body); {
+	case body == "":
+		details = append(details, error_response.ValidationDetail{Field: "body", Issue: "cannot be empty"})
+	case len(body) > MaxBodyLength:
+		details = append(details, error_response.ValidationDetail{
+			Field: "body",
+			Issue: fmt.Sprintf("cannot exceed %d characters", MaxBodyLength),
+		})
+	}
+	return append(details, validateRecipient(r.RecipientEmail)...)
+}
+
+func validateRecipient(email string) []error_response.ValidationDetail {
+	if email == "" {
+		return nil
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return []error_response.ValidationDetail{{Field: "recipientEmail", Issue: "must be a valid email address"}}
+	}
+	return nil
+}
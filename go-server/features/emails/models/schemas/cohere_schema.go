@@ -0,0 +1,19 @@
+package schemas
+
+import (
+	cohere "github.com/cohere-ai/cohere-go/v2"
+)
+
+var EmailSchema = map[string]any{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"type":    "object",
+	"properties": map[string]any{
+		"subject": map[string]any{"type": "string"},
+		"body":    map[string]any{"type": "string"},
+	},
+	"required": []string{"subject", "body"},
+}
+
+var CohereEmailSchemaFormat = cohere.JsonResponseFormatV2{
+	JsonSchema: EmailSchema,
+}
@@ -0,0 +1,14 @@
+package schemas
+
+import (
+	"google.golang.org/genai"
+)
+
+var GeminiEmailSchema = &genai.Schema{
+	Type: genai.TypeObject,
+	Properties: map[string]*genai.Schema{
+		"subject": {Type: genai.TypeString},
+		"body":    {Type: genai.TypeString},
+	},
+	Required: []string{"subject", "body"},
+}
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/ordo_meritum/database/emaildrafts"
+	"github.com/ordo_meritum/database/jobs"
+	"github.com/ordo_meritum/database/profiles"
+	"github.com/ordo_meritum/database/resumes"
+	job_mappers "github.com/ordo_meritum/features/application_tracking/utils/mappers"
+	"github.com/ordo_meritum/features/documents/utils/formatters"
+	"github.com/ordo_meritum/features/emails/models/domain"
+	"github.com/ordo_meritum/features/emails/models/requests"
+	"github.com/ordo_meritum/features/emails/utils/eml"
+	profile_domain "github.com/ordo_meritum/features/profiles/models/domain"
+	profile_mappers "github.com/ordo_meritum/features/profiles/utils/mappers"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/libs/llm"
+	schemaregistry "github.com/ordo_meritum/shared/libs/llm/schema_registry"
+	"github.com/ordo_meritum/shared/templates/instructions"
+	"github.com/ordo_meritum/shared/templates/prompts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	shared_formatters "github.com/ordo_meritum/shared/utils/formatters"
+	"github.com/rs/zerolog/log"
+)
+
+var logger = log.With().
+	Str("service", "emails").
+	Logger()
+
+type EmailService struct {
+	draftRepo   emaildrafts.Repository
+	jobRepo     jobs.Repository
+	resumeRepo  resumes.Repository
+	profileRepo profiles.Repository
+}
+
+func NewEmailService(
+	draftRepo emaildrafts.Repository,
+	jobRepo jobs.Repository,
+	resumeRepo resumes.Repository,
+	profileRepo profiles.Repository,
+) *EmailService {
+	return &EmailService{
+		draftRepo:   draftRepo,
+		jobRepo:     jobRepo,
+		resumeRepo:  resumeRepo,
+		profileRepo: profileRepo,
+	}
+}
+
+type llmEmail struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Draft writes an email of the requested kind for the role and stores it.
+func (s *EmailService) Draft(ctx context.Context, r *requests.EmailRequest) (*domain.Draft, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+	payload := r.Payload
+
+	j, err := s.jobRepo.GetFullJobPosting(ctx, payload.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := strings.TrimSpace(payload.InterviewNotes)
+	if notes == "" {
+		notes, err = s.draftRepo.LatestInterviewNotes(ctx, payload.RoleID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	promptData, err := s.buildPromptData(ctx, j, payload, notes)
+	if err != nil {
+		return nil, err
+	}
+	email, err := generateEmail(ctx, r.Options.LlmProvider, promptData)
+	if err != nil {
+		return nil, err
+	}
+
+	draft, err := s.draftRepo.Create(ctx, &domain.Draft{
+		RoleID:         payload.RoleID,
+		Kind:           payload.Kind,
+		Subject:        strings.Join(strings.Fields(email.Subject), " "),
+		Body:           strings.TrimSpace(email.Body),
+		RecipientName:  payload.RecipientName,
+		RecipientEmail: payload.RecipientEmail,
+		InterviewNotes: notes,
+		Provider:       r.Options.LlmProvider,
+		Model:          llm.ModelName(r.Options.LlmProvider),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info().
+		Str("uid", userCtx.UID).
+		Int("roleID", payload.RoleID).
+		Str("kind", string(payload.Kind)).
+		Int("draftID", draft.ID).
+		Msg("Drafted email")
+	return draft, nil
+}
+
+func (s *EmailService) List(ctx context.Context, roleID int) ([]domain.Draft, error) {
+	return s.draftRepo.List(ctx, roleID)
+}
+
+func (s *EmailService) Get(ctx context.Context, id int) (*domain.Draft, error) {
+	return s.draftRepo.Get(ctx, id)
+}
+
+func (s *EmailService) Update(ctx context.Context, id int, r *requests.DraftUpdateRequest) (*domain.Draft, error) {
+	return s.draftRepo.Update(ctx, &domain.Draft{
+		ID:             id,
+		Subject:        strings.TrimSpace(r.Subject),
+		Body:           strings.TrimSpace(r.Body),
+		RecipientName:  strings.TrimSpace(r.RecipientName),
+		RecipientEmail: strings.TrimSpace(r.RecipientEmail),
+	})
+}
+
+func (s *EmailService) Delete(ctx context.Context, id int) error {
+	return s.draftRepo.Delete(ctx, id)
+}
+
+// Export renders the draft as an .eml file, sent from the email on the
+// user's profile when they have one, and returns it with its file name.
+func (s *EmailService) Export(ctx context.Context, id int) ([]byte, string, error) {
+	draft, err := s.draftRepo.Get(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	msg := eml.Message{
+		Subject: draft.Subject,
+		Body:    draft.Body,
+		Date:    time.Now(),
+	}
+	if draft.RecipientEmail != "" {
+		msg.To = &mail.Address{Name: draft.RecipientName, Address: draft.RecipientEmail}
+	}
+	if profile := s.loadProfile(ctx); profile != nil && profile.Contact.Email != "" {
+		msg.From = &mail.Address{Name: senderName(profile), Address: profile.Contact.Email}
+	}
+
+	data, err := eml.Write(msg)
+	if err != nil {
+		return nil, "", err
+	}
+	name := fmt.Sprintf("%s-%d.eml", strings.ReplaceAll(string(draft.Kind), "_", "-"), draft.ID)
+	return data, name, nil
+}
+
+// buildPromptData grounds the email in the job posting, where the
+// application stands, the user's resume for the role and their interview
+// notes.
+func (s *EmailService) buildPromptData(
+	ctx context.Context,
+	j *jobs.FullJobPosting,
+	payload requests.EmailPayload,
+	notes string,
+) (map[string]any, error) {
+	profile := s.loadProfile(ctx)
+	resume, err := shared_formatters.ResumeForPrompt(ctx, s.resumeRepo, payload.RoleID, func() (string, error) {
+		if profile == nil {
+			return "", nil
+		}
+		profilePayload := profile_mappers.ToDocumentPayload(profile)
+		return formatters.FormatResumeRequestForLLMWithXML(&profilePayload), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]any{
+		"Kind":           string(payload.Kind),
+		"JobPost":        job_mappers.NewJobDescriptionFromPost(j).FormatForLLM(),
+		"Status":         "",
+		"AppliedOn":      "",
+		"SalaryRange":    "",
+		"Resume":         resume,
+		"InterviewNotes": notes,
+		"Details":        strings.TrimSpace(payload.Details),
+		"SenderName":     senderName(profile),
+		"RecipientName":  strings.TrimSpace(payload.RecipientName),
+	}
+	if j.ApplicationStatus != nil {
+		data["Status"] = string(*j.ApplicationStatus)
+	}
+	if j.AppliedOn != nil {
+		data["AppliedOn"] = j.AppliedOn.Format("January 2, 2006")
+		data["DaysSinceApplied"] = int(time.Since(*j.AppliedOn).Hours() / 24)
+	}
+	if j.SalaryRange != nil {
+		data["SalaryRange"] = *j.SalaryRange
+	}
+	return data, nil
+}
+
+// loadProfile returns the user's profile, or nil if they don't have one or
+// it fails to load. The profile only adds the sender's name and address, so
+// emails are still written without it.
+func (s *EmailService) loadProfile(ctx context.Context) *profile_domain.Profile {
+	profile, err := s.profileRepo.GetProfile(ctx)
+	switch {
+	case errors.Is(err, profiles.ErrProfileNotFound):
+		return nil
+	case err != nil:
+		logger.Warn().Err(err).Msg("Failed to load profile")
+		return nil
+	}
+	return profile
+}
+
+func senderName(profile *profile_domain.Profile) string {
+	if profile == nil {
+		return ""
+	}
+	return strings.TrimSpace(profile.Contact.FirstName + " " + profile.Contact.LastName)
+}
+
+func generateEmail(ctx context.Context, providerName string, promptData map[string]any) (*llmEmail, error) {
+	llmProvider, err := llm.GetProvider(providerName)
+	if err != nil {
+		return nil, fmt.Errorf("GetProvider Failed %w", err)
+	}
+
+	schema, err := schemaregistry.GetSchema(providerName, schemaregistry.Email)
+	if err != nil {
+		return nil, fmt.Errorf("GetSchema Failed %w", err)
+	}
+
+	prompt, err := shared_formatters.FormatTemplate(prompts.Prompts, "email.txt", promptData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format prompt template: %w", err)
+	}
+
+	instructionBytes, err := instructions.Instructions.ReadFile("email.txt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read instructions file: %w", err)
+	}
+
+	rawResponse, err := llmProvider.Generate(ctx, string(instructionBytes), prompt, schema)
+	if err != nil {
+		return nil, fmt.Errorf("LLM generation failed: %w", err)
+	}
+
+	var email llmEmail
+	if err := json.Unmarshal([]byte(llm.FormatLLMResponse(rawResponse)), &email); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal LLM response: %w. Raw response: %s", err, rawResponse)
+	}
+	if strings.TrimSpace(email.Subject) == "" || strings.TrimSpace(email.Body) == "" {
+		return nil, fmt.Errorf("LLM response is missing a subject or body. Raw response: %s", rawResponse)
+	}
+	return &email, nil
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ordo_meritum/database/models"
+	"github.com/ordo_meritum/features/emails/models/domain"
+)
+
+// DefaultQuietDays is how long an application can go without a response, or
+// a follow-up without a reply, before another follow-up is suggested.
+const DefaultQuietDays = 10
+
+// Suggestions lists the user's roles that are due an email. A role is
+// suggested for a thank-you once it is interviewing, a negotiation once it
+// has an offer, and a follow-up once it has been quiet for quietDays since
+// the application or the last follow-up drafted for it.
+func (s *EmailService) Suggestions(ctx context.Context, quietDays int) ([]domain.Suggestion, error) {
+	postings, err := s.jobRepo.GetAllUserJobPostings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lastDrafted, err := s.draftRepo.LastDrafted(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	suggestions := []domain.Suggestion{}
+	for _, p := range postings {
+		drafted := lastDrafted[p.RoleID]
+		suggestion := domain.Suggestion{
+			RoleID:      p.RoleID,
+			JobTitle:    p.JobTitle,
+			CompanyName: p.CompanyProperName,
+		}
+
+		var status models.AppStatus
+		if p.ApplicationStatus != nil {
+			status = *p.ApplicationStatus
+		}
+		switch status {
+		case models.StatusInterviewing:
+			if _, ok := drafted[domain.KindThankYou]; ok {
+				continue
+			}
+			suggestion.Kind = domain.KindThankYou
+			suggestion.Reason = "Interviewing, and no thank-you has been drafted yet."
+		case models.StatusOffered:
+			if _, ok := drafted[domain.KindNegotiation]; ok {
+				continue
+			}
+			suggestion.Kind = domain.KindNegotiation
+			suggestion.Reason = "An offer has been made."
+		case models.StatusOpen, "":
+			if p.InitialApplicationDate == nil {
+				continue
+			}
+			since := *p.InitialApplicationDate
+			if followUp, ok := drafted[domain.KindFollowUp]; ok && followUp.After(since) {
+				since = followUp
+			}
+			days := int(now.Sub(since).Hours() / 24)
+			if days < quietDays {
+				continue
+			}
+			suggestion.Kind = domain.KindFollowUp
+			suggestion.Reason = fmt.Sprintf("No response in %d days.", days)
+			suggestion.QuietSince = &since
+		default:
+			continue
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, nil
+}
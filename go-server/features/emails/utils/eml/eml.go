@@ -0,0 +1,56 @@
+// Package eml writes email drafts as RFC 5322 messages that mail clients
+// open as unsent drafts.
+package eml
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// Message is an email ready to be written out. From and To are optional;
+// a draft without a recipient opens with the To field empty.
+type Message struct {
+	From    *mail.Address
+	To      *mail.Address
+	Subject string
+	Body    string
+	Date    time.Time
+}
+
+// Write renders the message with CRLF line endings and a quoted-printable
+// UTF-8 body. X-Unsent marks it as a draft for Outlook and Apple Mail.
+func Write(m Message) ([]byte, error) {
+	var buf bytes.Buffer
+	header := func(name, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", name, value)
+	}
+
+	if m.From != nil {
+		header("From", m.From.String())
+	}
+	if m.To != nil {
+		header("To", m.To.String())
+	}
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", m.Date.Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", `text/plain; charset="utf-8"`)
+	header("Content-Transfer-Encoding", "quoted-printable")
+	header("X-Unsent", "1")
+	buf.WriteString("\r\n")
+
+	body := strings.ReplaceAll(strings.ReplaceAll(m.Body, "\r\n", "\n"), "\n", "\r\n")
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(body)); err != nil {
+		return nil, fmt.Errorf("failed to encode email body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode email body: %w", err)
+	}
+	return buf.Bytes(), nil
+}
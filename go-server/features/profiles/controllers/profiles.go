@@ -0,0 +1,334 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/ordo_meritum/database/profiles"
+	"github.com/ordo_meritum/features/profiles/models/domain"
+	"github.com/ordo_meritum/features/profiles/services"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/middleware"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	"github.com/ordo_meritum/shared/webrender"
+	"github.com/rs/zerolog/log"
+)
+
+// maxJSONResumeBytes caps uploaded resume.json files.
+const maxJSONResumeBytes = 1 << 20
+
+type Controller struct {
+	service *services.ProfileService
+}
+
+func NewController(service *services.ProfileService) *Controller {
+	return &Controller{service: service}
+}
+
+func (c *Controller) RegisterRoutes(authRouter *mux.Router) {
+	authRouter.HandleFunc("/profile", c.HandleGetProfile).Methods("GET")
+	authRouter.HandleFunc("/profile", c.HandleReplaceProfile).Methods("PUT")
+	authRouter.HandleFunc("/profile", c.HandleDeleteProfile).Methods("DELETE")
+	authRouter.HandleFunc("/profile/contact", c.HandleUpdateContact).Methods("PUT")
+	authRouter.HandleFunc("/profile/json-resume", c.HandleImportJSONResume).Methods("POST")
+	authRouter.HandleFunc("/profile/json-resume", c.HandleExportJSONResume).Methods("GET")
+
+	authRouter.HandleFunc("/profile/education", createHandler(c.service.CreateEducation)).Methods("POST")
+	authRouter.HandleFunc("/profile/education/{id:[0-9]+}", updateHandler(c.service.UpdateEducation, func(e *domain.Education, id int) { e.ID = id })).Methods("PUT")
+	authRouter.HandleFunc("/profile/education/{id:[0-9]+}", deleteHandler(c.service.DeleteEducation)).Methods("DELETE")
+
+	authRouter.HandleFunc("/profile/experiences", createHandler(c.service.CreateExperience)).Methods("POST")
+	authRouter.HandleFunc("/profile/experiences/{id:[0-9]+}", updateHandler(c.service.UpdateExperience, func(e *domain.Experience, id int) { e.ID = id })).Methods("PUT")
+	authRouter.HandleFunc("/profile/experiences/{id:[0-9]+}", deleteHandler(c.service.DeleteExperience)).Methods("DELETE")
+
+	authRouter.HandleFunc("/profile/projects", createHandler(c.service.CreateProject)).Methods("POST")
+	authRouter.HandleFunc("/profile/projects/{id:[0-9]+}", updateHandler(c.service.UpdateProject, func(p *domain.Project, id int) { p.ID = id })).Methods("PUT")
+	authRouter.HandleFunc("/profile/projects/{id:[0-9]+}", deleteHandler(c.service.DeleteProject)).Methods("DELETE")
+
+	authRouter.HandleFunc("/profile/skills", createHandler(c.service.CreateSkill)).Methods("POST")
+	authRouter.HandleFunc("/profile/skills/{id:[0-9]+}", updateHandler(c.service.UpdateSkill, func(s *domain.Skill, id int) { s.ID = id })).Methods("PUT")
+	authRouter.HandleFunc("/profile/skills/{id:[0-9]+}", deleteHandler(c.service.DeleteSkill)).Methods("DELETE")
+
+	authRouter.HandleFunc("/profile/sections", createHandler(c.service.CreateSection)).Methods("POST")
+	authRouter.HandleFunc("/profile/sections/{id:[0-9]+}", updateHandler(c.service.UpdateSection, func(s *domain.Section, id int) { s.ID = id })).Methods("PUT")
+	authRouter.HandleFunc("/profile/sections/{id:[0-9]+}", deleteHandler(c.service.DeleteSection)).Methods("DELETE")
+
+	authRouter.HandleFunc("/profile/certifications", createHandler(c.service.CreateCertification)).Methods("POST")
+	authRouter.HandleFunc("/profile/certifications/{id:[0-9]+}", updateHandler(c.service.UpdateCertification, func(cert *domain.Certification, id int) { cert.ID = id })).Methods("PUT")
+	authRouter.HandleFunc("/profile/certifications/{id:[0-9]+}", deleteHandler(c.service.DeleteCertification)).Methods("DELETE")
+
+	authRouter.HandleFunc("/profile/publications", createHandler(c.service.CreatePublication)).Methods("POST")
+	authRouter.HandleFunc("/profile/publications/{id:[0-9]+}", updateHandler(c.service.UpdatePublication, func(p *domain.Publication, id int) { p.ID = id })).Methods("PUT")
+	authRouter.HandleFunc("/profile/publications/{id:[0-9]+}", deleteHandler(c.service.DeletePublication)).Methods("DELETE")
+
+	authRouter.HandleFunc("/profile/volunteering", createHandler(c.service.CreateVolunteering)).Methods("POST")
+	authRouter.HandleFunc("/profile/volunteering/{id:[0-9]+}", updateHandler(c.service.UpdateVolunteering, func(v *domain.Volunteering, id int) { v.ID = id })).Methods("PUT")
+	authRouter.HandleFunc("/profile/volunteering/{id:[0-9]+}", deleteHandler(c.service.DeleteVolunteering)).Methods("DELETE")
+
+	authRouter.HandleFunc("/profile/awards", createHandler(c.service.CreateAward)).Methods("POST")
+	authRouter.HandleFunc("/profile/awards/{id:[0-9]+}", updateHandler(c.service.UpdateAward, func(a *domain.Award, id int) { a.ID = id })).Methods("PUT")
+	authRouter.HandleFunc("/profile/awards/{id:[0-9]+}", deleteHandler(c.service.DeleteAward)).Methods("DELETE")
+
+	authRouter.HandleFunc("/profile/languages", createHandler(c.service.CreateLanguage)).Methods("POST")
+	authRouter.HandleFunc("/profile/languages/{id:[0-9]+}", updateHandler(c.service.UpdateLanguage, func(l *domain.Language, id int) { l.ID = id })).Methods("PUT")
+	authRouter.HandleFunc("/profile/languages/{id:[0-9]+}", deleteHandler(c.service.DeleteLanguage)).Methods("DELETE")
+
+	authRouter.HandleFunc("/profile/variants", c.HandleListVariants).Methods("GET")
+	authRouter.HandleFunc("/profile/variants", c.HandleCreateVariant).Methods("POST")
+	authRouter.HandleFunc("/profile/variants/{id:[0-9]+}", c.HandleGetVariant).Methods("GET")
+	authRouter.HandleFunc("/profile/variants/{id:[0-9]+}", c.HandleUpdateVariant).Methods("PUT")
+	authRouter.HandleFunc("/profile/variants/{id:[0-9]+}", deleteHandler(c.service.DeleteVariant)).Methods("DELETE")
+	authRouter.HandleFunc("/profile/variants/{id:[0-9]+}/preview", c.HandlePreviewVariant).Methods("GET")
+}
+
+func parseIDFromVars(r *http.Request) (int, error) {
+	idStr := mux.Vars(r)["id"]
+	return strconv.Atoi(idStr)
+}
+
+func (c *Controller) HandleGetProfile(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	_, ok := contexts.FromContext(r.Context())
+	if !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	profile, err := c.service.GetProfile(r.Context())
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, profile)
+}
+
+func (c *Controller) HandleReplaceProfile(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	_, ok := contexts.FromContext(r.Context())
+	if !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var profile domain.Profile
+	if webrender.DecodeJSONBody(w, r, &profile) != nil {
+		return
+	}
+	if details := profile.Validate(); len(details) > 0 {
+		writeValidationError(w, details)
+		return
+	}
+
+	if err := c.service.ReplaceProfile(r.Context(), &profile); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusNoContent, nil)
+}
+
+func (c *Controller) HandleDeleteProfile(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	_, ok := contexts.FromContext(r.Context())
+	if !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	if err := c.service.DeleteProfile(r.Context()); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusNoContent, nil)
+}
+
+func (c *Controller) HandleUpdateContact(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	_, ok := contexts.FromContext(r.Context())
+	if !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var contact domain.Contact
+	if webrender.DecodeJSONBody(w, r, &contact) != nil {
+		return
+	}
+	if details := contact.Validate(); len(details) > 0 {
+		writeValidationError(w, details)
+		return
+	}
+
+	if err := c.service.UpdateContact(r.Context(), &contact); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, contact)
+}
+
+func (c *Controller) HandleImportJSONResume(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	_, ok := contexts.FromContext(r.Context())
+	if !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(r.Body, maxJSONResumeBytes))
+	if err != nil {
+		middleware.JSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+
+	profile, details, err := c.service.ImportJSONResume(r.Context(), raw)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if len(details) > 0 {
+		writeValidationError(w, details)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, profile)
+}
+
+func (c *Controller) HandleExportJSONResume(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	_, ok := contexts.FromContext(r.Context())
+	if !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	resume, err := c.service.ExportJSONResume(r.Context())
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="resume.json"`)
+	middleware.JSON(w, http.StatusOK, resume)
+}
+
+type validatable interface {
+	Validate() []error_response.ValidationDetail
+}
+
+func createHandler[T any, PT interface {
+	*T
+	validatable
+}](create func(ctx context.Context, item PT) (int, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+
+		_, ok := contexts.FromContext(r.Context())
+		if !ok {
+			middleware.JSON(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		item := PT(new(T))
+		if webrender.DecodeJSONBody(w, r, item) != nil {
+			return
+		}
+		if details := item.Validate(); len(details) > 0 {
+			writeValidationError(w, details)
+			return
+		}
+
+		id, err := create(r.Context(), item)
+		if err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		middleware.JSON(w, http.StatusCreated, map[string]int{"id": id})
+	}
+}
+
+func updateHandler[T any, PT interface {
+	*T
+	validatable
+}](update func(ctx context.Context, item PT) error, setID func(item PT, id int)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+
+		id, err := parseIDFromVars(r)
+		_, ok := contexts.FromContext(r.Context())
+		if !ok || err != nil {
+			middleware.JSON(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		item := PT(new(T))
+		if webrender.DecodeJSONBody(w, r, item) != nil {
+			return
+		}
+		if details := item.Validate(); len(details) > 0 {
+			writeValidationError(w, details)
+			return
+		}
+		setID(item, id)
+
+		if err := update(r.Context(), item); err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		middleware.JSON(w, http.StatusOK, item)
+	}
+}
+
+func deleteHandler(remove func(ctx context.Context, id int) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+
+		id, err := parseIDFromVars(r)
+		_, ok := contexts.FromContext(r.Context())
+		if !ok || err != nil {
+			middleware.JSON(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		if err := remove(r.Context(), id); err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		middleware.JSON(w, http.StatusNoContent, nil)
+	}
+}
+
+func writeValidationError(w http.ResponseWriter, details []error_response.ValidationDetail) {
+	middleware.JSON(w, http.StatusBadRequest, error_response.ErrorResponse[[]error_response.ValidationDetail]{
+		ErrorCode: error_response.BAD_REQUEST,
+		Message:   "The request failed validation.",
+		Details:   details,
+	})
+}
+
+func writeRepoError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, profiles.ErrProfileNotFound),
+		errors.Is(err, profiles.ErrItemNotFound),
+		errors.Is(err, profiles.ErrVariantNotFound):
+		middleware.JSON(w, http.StatusNotFound, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.RESOURCE_UNAVAILABLE,
+			Message:   err.Error(),
+		})
+	case errors.Is(err, profiles.ErrDuplicateName):
+		middleware.JSON(w, http.StatusConflict, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.BAD_REQUEST,
+			Message:   err.Error(),
+		})
+	default:
+		log.Error().Err(err).Str("service", "profiles-controller").Msg("Profile request failed")
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+	}
+}
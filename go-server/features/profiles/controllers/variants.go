@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/ordo_meritum/features/profiles/models/domain"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/middleware"
+	"github.com/ordo_meritum/shared/webrender"
+)
+
+func (c *Controller) HandleListVariants(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	_, ok := contexts.FromContext(r.Context())
+	if !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	variants, err := c.service.ListVariants(r.Context())
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, variants)
+}
+
+func (c *Controller) HandleGetVariant(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	variant, err := c.service.GetVariant(r.Context(), id)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, variant)
+}
+
+func (c *Controller) HandlePreviewVariant(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	profile, err := c.service.PreviewVariant(r.Context(), id)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, profile)
+}
+
+func (c *Controller) HandleCreateVariant(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	_, ok := contexts.FromContext(r.Context())
+	if !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var variant domain.Variant
+	if webrender.DecodeJSONBody(w, r, &variant) != nil {
+		return
+	}
+
+	id, details, err := c.service.CreateVariant(r.Context(), &variant)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if len(details) > 0 {
+		writeValidationError(w, details)
+		return
+	}
+	middleware.JSON(w, http.StatusCreated, map[string]int{"id": id})
+}
+
+func (c *Controller) HandleUpdateVariant(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := parseIDFromVars(r)
+	_, ok := contexts.FromContext(r.Context())
+	if !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var variant domain.Variant
+	if webrender.DecodeJSONBody(w, r, &variant) != nil {
+		return
+	}
+	variant.ID = id
+
+	details, err := c.service.UpdateVariant(r.Context(), &variant)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if len(details) > 0 {
+		writeValidationError(w, details)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, variant)
+}
@@ -0,0 +1,125 @@
+package domain
+
+// Profile is a user's master resume: everything they have done, stored once
+// on the server and tailored per application.
+type Profile struct {
+	Contact     Contact      `json:"contact"`
+	Education   []Education  `json:"education"`
+	Experiences []Experience `json:"experiences"`
+	Projects    []Project    `json:"projects"`
+	Skills      []Skill      `json:"skills"`
+	Sections    []Section    `json:"sections"`
+
+	Certifications []Certification `json:"certifications"`
+	Publications   []Publication   `json:"publications"`
+	Volunteering   []Volunteering  `json:"volunteering"`
+	Awards         []Award         `json:"awards"`
+	Languages      []Language      `json:"languages"`
+}
+
+type Contact struct {
+	FirstName       string `json:"firstName"`
+	LastName        string `json:"lastName"`
+	CurrentLocation string `json:"currentLocation"`
+	Email           string `json:"email"`
+	Github          string `json:"github,omitempty"`
+	Linkedin        string `json:"linkedin,omitempty"`
+	Mobile          string `json:"mobile,omitempty"`
+	Summary         string `json:"summary,omitempty"`
+}
+
+type Education struct {
+	ID         int      `json:"id"`
+	School     string   `json:"school"`
+	Degree     string   `json:"degree"`
+	Location   string   `json:"location"`
+	StartEnd   string   `json:"startEnd"`
+	CourseWork *string  `json:"coursework,omitempty"`
+	GPA        *float64 `json:"gpa,omitempty"`
+	Honors     *string  `json:"honors,omitempty"`
+	SortOrder  int      `json:"sortOrder"`
+}
+
+// Locked keeps an experience's or project's wording out of LLM rewrites;
+// LockedBullets does the same for individual bullets by index.
+type Experience struct {
+	ID            int      `json:"id"`
+	Company       string   `json:"company"`
+	Position      string   `json:"position"`
+	Years         string   `json:"years"`
+	BulletPoints  []string `json:"bulletPoints"`
+	Locked        bool     `json:"locked,omitempty"`
+	LockedBullets []int    `json:"lockedBullets,omitempty"`
+	SortOrder     int      `json:"sortOrder"`
+}
+
+type Project struct {
+	ID            int      `json:"id"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	Years         string   `json:"years"`
+	BulletPoints  []string `json:"bulletPoints"`
+	Locked        bool     `json:"locked,omitempty"`
+	LockedBullets []int    `json:"lockedBullets,omitempty"`
+	SortOrder     int      `json:"sortOrder"`
+}
+
+type Skill struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Category  string `json:"category,omitempty"`
+	SortOrder int    `json:"sortOrder"`
+}
+
+// Section is a free-form block of additional info, such as interests, that
+// the LLM may draw on.
+type Section struct {
+	ID        int    `json:"id"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	SortOrder int    `json:"sortOrder"`
+}
+
+type Certification struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Issuer    string `json:"issuer,omitempty"`
+	Date      string `json:"date,omitempty"`
+	URL       string `json:"url,omitempty"`
+	SortOrder int    `json:"sortOrder"`
+}
+
+type Publication struct {
+	ID        int    `json:"id"`
+	Title     string `json:"title"`
+	Publisher string `json:"publisher,omitempty"`
+	Date      string `json:"date,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Summary   string `json:"summary,omitempty"`
+	SortOrder int    `json:"sortOrder"`
+}
+
+type Volunteering struct {
+	ID           int      `json:"id"`
+	Organization string   `json:"organization"`
+	Role         string   `json:"role"`
+	Years        string   `json:"years"`
+	BulletPoints []string `json:"bulletPoints"`
+	SortOrder    int      `json:"sortOrder"`
+}
+
+type Award struct {
+	ID        int    `json:"id"`
+	Title     string `json:"title"`
+	Awarder   string `json:"awarder,omitempty"`
+	Date      string `json:"date,omitempty"`
+	Summary   string `json:"summary,omitempty"`
+	SortOrder int    `json:"sortOrder"`
+}
+
+type Language struct {
+	ID        int    `json:"id"`
+	Language  string `json:"language"`
+	Fluency   string `json:"fluency,omitempty"`
+	SortOrder int    `json:"sortOrder"`
+}
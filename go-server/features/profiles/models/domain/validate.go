@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+type validator struct {
+	prefix  string
+	details []error_response.ValidationDetail
+}
+
+func (v *validator) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.details = append(v.details, error_response.ValidationDetail{
+			Field: v.prefix + field,
+			Issue: "is required",
+		})
+	}
+}
+
+func (c *Contact) Validate() []error_response.ValidationDetail {
+	v := validator{prefix: "contact."}
+	v.required("firstName", c.FirstName)
+	if c.Email != "" {
+		if _, err := mail.ParseAddress(c.Email); err != nil {
+			v.details = append(v.details, error_response.ValidationDetail{
+				Field: "contact.email",
+				Issue: "is not a valid email address",
+			})
+		}
+	}
+	return v.details
+}
+
+func (e *Education) Validate() []error_response.ValidationDetail {
+	v := validator{}
+	v.required("school", e.School)
+	return v.details
+}
+
+func (e *Experience) Validate() []error_response.ValidationDetail {
+	v := validator{}
+	v.required("company", e.Company)
+	v.required("position", e.Position)
+	return v.details
+}
+
+func (p *Project) Validate() []error_response.ValidationDetail {
+	v := validator{}
+	v.required("name", p.Name)
+	return v.details
+}
+
+func (s *Skill) Validate() []error_response.ValidationDetail {
+	v := validator{}
+	v.required("name", s.Name)
+	return v.details
+}
+
+func (s *Section) Validate() []error_response.ValidationDetail {
+	v := validator{}
+	v.required("title", s.Title)
+	return v.details
+}
+
+func (c *Certification) Validate() []error_response.ValidationDetail {
+	v := validator{}
+	v.required("name", c.Name)
+	return v.details
+}
+
+func (p *Publication) Validate() []error_response.ValidationDetail {
+	v := validator{}
+	v.required("title", p.Title)
+	return v.details
+}
+
+func (vol *Volunteering) Validate() []error_response.ValidationDetail {
+	v := validator{}
+	v.required("organization", vol.Organization)
+	return v.details
+}
+
+func (a *Award) Validate() []error_response.ValidationDetail {
+	v := validator{}
+	v.required("title", a.Title)
+	return v.details
+}
+
+func (l *Language) Validate() []error_response.ValidationDetail {
+	v := validator{}
+	v.required("language", l.Language)
+	return v.details
+}
+
+// Validate checks the whole profile, prefixing each issue with the path of
+// the offending entry.
+func (p *Profile) Validate() []error_response.ValidationDetail {
+	details := p.Contact.Validate()
+	collect := func(name string, i int, d []error_response.ValidationDetail) {
+		for _, detail := range d {
+			detail.Field = fmt.Sprintf("%s[%d].%s", name, i, detail.Field)
+			details = append(details, detail)
+		}
+	}
+	for i := range p.Education {
+		collect("education", i, p.Education[i].Validate())
+	}
+	for i := range p.Experiences {
+		collect("experiences", i, p.Experiences[i].Validate())
+	}
+	for i := range p.Projects {
+		collect("projects", i, p.Projects[i].Validate())
+	}
+	for i := range p.Skills {
+		collect("skills", i, p.Skills[i].Validate())
+	}
+	for i := range p.Sections {
+		collect("sections", i, p.Sections[i].Validate())
+	}
+	for i := range p.Certifications {
+		collect("certifications", i, p.Certifications[i].Validate())
+	}
+	for i := range p.Publications {
+		collect("publications", i, p.Publications[i].Validate())
+	}
+	for i := range p.Volunteering {
+		collect("volunteering", i, p.Volunteering[i].Validate())
+	}
+	for i := range p.Awards {
+		collect("awards", i, p.Awards[i].Validate())
+	}
+	for i := range p.Languages {
+		collect("languages", i, p.Languages[i].Validate())
+	}
+	return details
+}
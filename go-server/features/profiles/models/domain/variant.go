@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+// Variant is a named base resume, such as "Backend" or "Data", built from the
+// master profile. A nil ID list includes every entry of that kind in profile
+// order; a non-nil list includes exactly those entries in the listed order.
+type Variant struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Summary       string `json:"summary,omitempty"`
+	ExperienceIDs []int  `json:"experienceIds"`
+	ProjectIDs    []int  `json:"projectIds"`
+	SkillIDs      []int  `json:"skillIds"`
+}
+
+func (v *Variant) Validate() []error_response.ValidationDetail {
+	val := validator{}
+	val.required("name", v.Name)
+	return val.details
+}
+
+// ValidateAgainst reports IDs that don't belong to the given profile.
+func (v *Variant) ValidateAgainst(p *Profile) []error_response.ValidationDetail {
+	var details []error_response.ValidationDetail
+	check := func(field string, ids []int, known map[int]bool) {
+		for i, id := range ids {
+			if !known[id] {
+				details = append(details, error_response.ValidationDetail{
+					Field: fmt.Sprintf("%s[%d]", field, i),
+					Issue: fmt.Sprintf("no profile entry with id %d", id),
+				})
+			}
+		}
+	}
+
+	experiences := make(map[int]bool, len(p.Experiences))
+	for _, e := range p.Experiences {
+		experiences[e.ID] = true
+	}
+	projects := make(map[int]bool, len(p.Projects))
+	for _, proj := range p.Projects {
+		projects[proj.ID] = true
+	}
+	skills := make(map[int]bool, len(p.Skills))
+	for _, s := range p.Skills {
+		skills[s.ID] = true
+	}
+
+	check("experienceIds", v.ExperienceIDs, experiences)
+	check("projectIds", v.ProjectIDs, projects)
+	check("skillIds", v.SkillIDs, skills)
+	return details
+}
+
+// Apply returns a copy of the profile narrowed and reordered by the variant.
+// IDs that no longer exist in the profile are skipped.
+func (v *Variant) Apply(p *Profile) *Profile {
+	out := *p
+	if strings.TrimSpace(v.Summary) != "" {
+		out.Contact.Summary = v.Summary
+	}
+	out.Experiences = pick(p.Experiences, v.ExperienceIDs, func(e Experience) int { return e.ID })
+	out.Projects = pick(p.Projects, v.ProjectIDs, func(proj Project) int { return proj.ID })
+	out.Skills = pick(p.Skills, v.SkillIDs, func(s Skill) int { return s.ID })
+	return &out
+}
+
+func pick[T any](items []T, ids []int, idOf func(T) int) []T {
+	if ids == nil {
+		return items
+	}
+	byID := make(map[int]T, len(items))
+	for _, item := range items {
+		byID[idOf(item)] = item
+	}
+	picked := make([]T, 0, len(ids))
+	for _, id := range ids {
+		if item, ok := byID[id]; ok {
+			picked = append(picked, item)
+		}
+	}
+	return picked
+}
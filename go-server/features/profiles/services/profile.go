@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ordo_meritum/database/profiles"
+	"github.com/ordo_meritum/features/documents/utils/jsonresume"
+	"github.com/ordo_meritum/features/profiles/models/domain"
+	"github.com/ordo_meritum/features/profiles/utils/mappers"
+	"github.com/ordo_meritum/shared/contexts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type ProfileService struct {
+	profileRepo profiles.Repository
+}
+
+func NewProfileService(profileRepo profiles.Repository) *ProfileService {
+	return &ProfileService{profileRepo: profileRepo}
+}
+
+func (s *ProfileService) GetProfile(ctx context.Context) (*domain.Profile, error) {
+	return s.profileRepo.GetProfile(ctx)
+}
+
+func (s *ProfileService) UpdateContact(ctx context.Context, contact *domain.Contact) error {
+	if err := s.profileRepo.UpsertContact(ctx, contact); err != nil {
+		s.logError(ctx, err, "Failed to update profile contact")
+		return err
+	}
+	return nil
+}
+
+func (s *ProfileService) ReplaceProfile(ctx context.Context, profile *domain.Profile) error {
+	if err := s.profileRepo.ReplaceProfile(ctx, profile); err != nil {
+		s.logError(ctx, err, "Failed to replace profile")
+		return err
+	}
+	return nil
+}
+
+func (s *ProfileService) DeleteProfile(ctx context.Context) error {
+	return s.profileRepo.DeleteProfile(ctx)
+}
+
+// ImportJSONResume replaces the user's profile with the contents of a
+// resume.json upload. Nothing is written if validation fails.
+func (s *ProfileService) ImportJSONResume(
+	ctx context.Context,
+	raw []byte,
+) (*domain.Profile, []error_response.ValidationDetail, error) {
+	var resume jsonresume.Resume
+	if err := json.Unmarshal(raw, &resume); err != nil {
+		return nil, []error_response.ValidationDetail{{Field: "body", Issue: err.Error()}}, nil
+	}
+	if details := resume.Validate(); len(details) > 0 {
+		return nil, details, nil
+	}
+
+	payload := resume.ToDocumentPayload()
+	profile, err := mappers.FromDocumentPayload(&payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to map resume to profile: %w", err)
+	}
+
+	if err := s.ReplaceProfile(ctx, profile); err != nil {
+		return nil, nil, err
+	}
+
+	stored, err := s.profileRepo.GetProfile(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stored, nil, nil
+}
+
+func (s *ProfileService) ExportJSONResume(ctx context.Context) (*jsonresume.Resume, error) {
+	profile, err := s.profileRepo.GetProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := mappers.ToDocumentPayload(profile)
+	return jsonresume.FromDocumentPayload(&payload), nil
+}
+
+func (s *ProfileService) CreateEducation(ctx context.Context, education *domain.Education) (int, error) {
+	return s.profileRepo.CreateEducation(ctx, education)
+}
+
+func (s *ProfileService) UpdateEducation(ctx context.Context, education *domain.Education) error {
+	return s.profileRepo.UpdateEducation(ctx, education)
+}
+
+func (s *ProfileService) DeleteEducation(ctx context.Context, id int) error {
+	return s.profileRepo.DeleteEducation(ctx, id)
+}
+
+func (s *ProfileService) CreateExperience(ctx context.Context, experience *domain.Experience) (int, error) {
+	return s.profileRepo.CreateExperience(ctx, experience)
+}
+
+func (s *ProfileService) UpdateExperience(ctx context.Context, experience *domain.Experience) error {
+	return s.profileRepo.UpdateExperience(ctx, experience)
+}
+
+func (s *ProfileService) DeleteExperience(ctx context.Context, id int) error {
+	return s.profileRepo.DeleteExperience(ctx, id)
+}
+
+func (s *ProfileService) CreateProject(ctx context.Context, project *domain.Project) (int, error) {
+	return s.profileRepo.CreateProject(ctx, project)
+}
+
+func (s *ProfileService) UpdateProject(ctx context.Context, project *domain.Project) error {
+	return s.profileRepo.UpdateProject(ctx, project)
+}
+
+func (s *ProfileService) DeleteProject(ctx context.Context, id int) error {
+	return s.profileRepo.DeleteProject(ctx, id)
+}
+
+func (s *ProfileService) CreateSkill(ctx context.Context, skill *domain.Skill) (int, error) {
+	return s.profileRepo.CreateSkill(ctx, skill)
+}
+
+func (s *ProfileService) UpdateSkill(ctx context.Context, skill *domain.Skill) error {
+	return s.profileRepo.UpdateSkill(ctx, skill)
+}
+
+func (s *ProfileService) DeleteSkill(ctx context.Context, id int) error {
+	return s.profileRepo.DeleteSkill(ctx, id)
+}
+
+func (s *ProfileService) CreateSection(ctx context.Context, section *domain.Section) (int, error) {
+	return s.profileRepo.CreateSection(ctx, section)
+}
+
+func (s *ProfileService) UpdateSection(ctx context.Context, section *domain.Section) error {
+	return s.profileRepo.UpdateSection(ctx, section)
+}
+
+func (s *ProfileService) DeleteSection(ctx context.Context, id int) error {
+	return s.profileRepo.DeleteSection(ctx, id)
+}
+
+func (s *ProfileService) CreateCertification(ctx context.Context, certification *domain.Certification) (int, error) {
+	return s.profileRepo.CreateCertification(ctx, certification)
+}
+
+func (s *ProfileService) UpdateCertification(ctx context.Context, certification *domain.Certification) error {
+	return s.profileRepo.UpdateCertification(ctx, certification)
+}
+
+func (s *ProfileService) DeleteCertification(ctx context.Context, id int) error {
+	return s.profileRepo.DeleteCertification(ctx, id)
+}
+
+func (s *ProfileService) CreatePublication(ctx context.Context, publication *domain.Publication) (int, error) {
+	return s.profileRepo.CreatePublication(ctx, publication)
+}
+
+func (s *ProfileService) UpdatePublication(ctx context.Context, publication *domain.Publication) error {
+	return s.profileRepo.UpdatePublication(ctx, publication)
+}
+
+func (s *ProfileService) DeletePublication(ctx context.Context, id int) error {
+	return s.profileRepo.DeletePublication(ctx, id)
+}
+
+func (s *ProfileService) CreateVolunteering(ctx context.Context, volunteering *domain.Volunteering) (int, error) {
+	return s.profileRepo.CreateVolunteering(ctx, volunteering)
+}
+
+func (s *ProfileService) UpdateVolunteering(ctx context.Context, volunteering *domain.Volunteering) error {
+	return s.profileRepo.UpdateVolunteering(ctx, volunteering)
+}
+
+func (s *ProfileService) DeleteVolunteering(ctx context.Context, id int) error {
+	return s.profileRepo.DeleteVolunteering(ctx, id)
+}
+
+func (s *ProfileService) CreateAward(ctx context.Context, award *domain.Award) (int, error) {
+	return s.profileRepo.CreateAward(ctx, award)
+}
+
+func (s *ProfileService) UpdateAward(ctx context.Context, award *domain.Award) error {
+	return s.profileRepo.UpdateAward(ctx, award)
+}
+
+func (s *ProfileService) DeleteAward(ctx context.Context, id int) error {
+	return s.profileRepo.DeleteAward(ctx, id)
+}
+
+func (s *ProfileService) CreateLanguage(ctx context.Context, language *domain.Language) (int, error) {
+	return s.profileRepo.CreateLanguage(ctx, language)
+}
+
+func (s *ProfileService) UpdateLanguage(ctx context.Context, language *domain.Language) error {
+	return s.profileRepo.UpdateLanguage(ctx, language)
+}
+
+func (s *ProfileService) DeleteLanguage(ctx context.Context, id int) error {
+	return s.profileRepo.DeleteLanguage(ctx, id)
+}
+
+func (s *ProfileService) logError(ctx context.Context, err error, msg string) {
+	l := s.serviceLogger(ctx)
+	l.Error().Err(err).Msg(msg)
+}
+
+func (s *ProfileService) serviceLogger(ctx context.Context) zerolog.Logger {
+	uid := ""
+	if userCtx, ok := contexts.FromContext(ctx); ok {
+		uid = userCtx.UID
+	}
+	return log.With().
+		Str("service", "profiles-service").
+		Str("uid", uid).
+		Logger()
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ordo_meritum/features/profiles/models/domain"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+func (s *ProfileService) ListVariants(ctx context.Context) ([]domain.Variant, error) {
+	return s.profileRepo.ListVariants(ctx)
+}
+
+func (s *ProfileService) GetVariant(ctx context.Context, id int) (*domain.Variant, error) {
+	return s.profileRepo.GetVariant(ctx, id)
+}
+
+// PreviewVariant returns the profile as the variant would present it.
+func (s *ProfileService) PreviewVariant(ctx context.Context, id int) (*domain.Profile, error) {
+	variant, err := s.profileRepo.GetVariant(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := s.profileRepo.GetProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return variant.Apply(profile), nil
+}
+
+func (s *ProfileService) CreateVariant(
+	ctx context.Context,
+	variant *domain.Variant,
+) (int, []error_response.ValidationDetail, error) {
+	if details, err := s.validateVariant(ctx, variant); err != nil || len(details) > 0 {
+		return 0, details, err
+	}
+
+	id, err := s.profileRepo.CreateVariant(ctx, variant)
+	if err != nil {
+		s.logError(ctx, err, "Failed to create variant")
+		return 0, nil, err
+	}
+	return id, nil, nil
+}
+
+func (s *ProfileService) UpdateVariant(
+	ctx context.Context,
+	variant *domain.Variant,
+) ([]error_response.ValidationDetail, error) {
+	if details, err := s.validateVariant(ctx, variant); err != nil || len(details) > 0 {
+		return details, err
+	}
+
+	if err := s.profileRepo.UpdateVariant(ctx, variant); err != nil {
+		s.logError(ctx, err, "Failed to update variant")
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s *ProfileService) DeleteVariant(ctx context.Context, id int) error {
+	return s.profileRepo.DeleteVariant(ctx, id)
+}
+
+func (s *ProfileService) validateVariant(
+	ctx context.Context,
+	variant *domain.Variant,
+) ([]error_response.ValidationDetail, error) {
+	if details := variant.Validate(); len(details) > 0 {
+		return details, nil
+	}
+	if variant.ExperienceIDs == nil && variant.ProjectIDs == nil && variant.SkillIDs == nil {
+		return nil, nil
+	}
+
+	profile, err := s.profileRepo.GetProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return variant.ValidateAgainst(profile), nil
+}
@@ -0,0 +1,272 @@
+package mappers
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/ordo_meritum/features/documents/models/requests"
+	"github.com/ordo_meritum/features/profiles/models/domain"
+)
+
+// ToDocumentPayload builds the generation payload from a stored profile.
+// Every school goes in Education; the first is also set as EducationInfo.
+func ToDocumentPayload(p *domain.Profile) requests.DocumentPayload {
+	payload := requests.DocumentPayload{
+		UserInfo: ToUserInfoPayload(&p.Contact),
+		Resume:   requests.ResumePayload{},
+	}
+
+	for i := range p.Education {
+		payload.Education = append(payload.Education, ToEducationInfoPayload(&p.Education[i]))
+	}
+	if len(payload.Education) > 0 {
+		payload.EducationInfo = payload.Education[0]
+	}
+
+	for _, e := range p.Experiences {
+		payload.Resume.Experiences = append(payload.Resume.Experiences, requests.ExperiencePayload{
+			BulletPoints:  e.BulletPoints,
+			Company:       e.Company,
+			ID:            strconv.Itoa(e.ID),
+			Position:      e.Position,
+			Years:         e.Years,
+			Locked:        e.Locked,
+			LockedBullets: e.LockedBullets,
+		})
+	}
+
+	for _, proj := range p.Projects {
+		payload.Resume.Projects = append(payload.Resume.Projects, requests.ProjectPayload{
+			BulletPoints:  proj.BulletPoints,
+			Description:   proj.Description,
+			ID:            strconv.Itoa(proj.ID),
+			Name:          proj.Name,
+			Years:         proj.Years,
+			Locked:        proj.Locked,
+			LockedBullets: proj.LockedBullets,
+		})
+	}
+
+	for _, s := range p.Skills {
+		payload.Resume.Skills = append(payload.Resume.Skills, requests.SkillsPayload{Skill: s.Name})
+	}
+
+	for _, c := range p.Certifications {
+		payload.Resume.Certifications = append(payload.Resume.Certifications, requests.CertificationPayload{
+			ID:     strconv.Itoa(c.ID),
+			Name:   c.Name,
+			Issuer: c.Issuer,
+			Date:   c.Date,
+			URL:    c.URL,
+		})
+	}
+
+	for _, pub := range p.Publications {
+		payload.Resume.Publications = append(payload.Resume.Publications, requests.PublicationPayload{
+			ID:        strconv.Itoa(pub.ID),
+			Title:     pub.Title,
+			Publisher: pub.Publisher,
+			Date:      pub.Date,
+			URL:       pub.URL,
+			Summary:   pub.Summary,
+		})
+	}
+
+	for _, v := range p.Volunteering {
+		payload.Resume.Volunteering = append(payload.Resume.Volunteering, requests.VolunteeringPayload{
+			BulletPoints: v.BulletPoints,
+			ID:           strconv.Itoa(v.ID),
+			Organization: v.Organization,
+			Role:         v.Role,
+			Years:        v.Years,
+		})
+	}
+
+	for _, a := range p.Awards {
+		payload.Resume.Awards = append(payload.Resume.Awards, requests.AwardPayload{
+			ID:      strconv.Itoa(a.ID),
+			Title:   a.Title,
+			Awarder: a.Awarder,
+			Date:    a.Date,
+			Summary: a.Summary,
+		})
+	}
+
+	for _, l := range p.Languages {
+		payload.Resume.Languages = append(payload.Resume.Languages, requests.LanguagePayload{
+			ID:       strconv.Itoa(l.ID),
+			Language: l.Language,
+			Fluency:  l.Fluency,
+		})
+	}
+
+	if len(p.Sections) > 0 {
+		sections := make(map[string]string, len(p.Sections))
+		for _, s := range p.Sections {
+			sections[s.Title] = s.Content
+		}
+		payload.AdditionalInfo, _ = json.Marshal(sections)
+	}
+
+	return payload
+}
+
+func ToUserInfoPayload(c *domain.Contact) requests.UserInfoPayload {
+	return requests.UserInfoPayload{
+		FirstName:       c.FirstName,
+		LastName:        c.LastName,
+		CurrentLocation: c.CurrentLocation,
+		Email:           c.Email,
+		Github:          c.Github,
+		Linkedin:        c.Linkedin,
+		Mobile:          c.Mobile,
+		Summary:         c.Summary,
+	}
+}
+
+func ToEducationInfoPayload(e *domain.Education) requests.EducationInfoPayload {
+	return requests.EducationInfoPayload{
+		CourseWork: e.CourseWork,
+		Degree:     e.Degree,
+		Location:   e.Location,
+		School:     e.School,
+		StartEnd:   e.StartEnd,
+		GPA:        e.GPA,
+		Honors:     e.Honors,
+	}
+}
+
+// FromDocumentPayload turns an inline payload, e.g. one the client stored
+// locally before profiles existed, into a profile. Additional info sections
+// are ordered by title since the payload keeps them in a map.
+func FromDocumentPayload(p *requests.DocumentPayload) (*domain.Profile, error) {
+	profile := &domain.Profile{
+		Contact: domain.Contact{
+			FirstName:       p.UserInfo.FirstName,
+			LastName:        p.UserInfo.LastName,
+			CurrentLocation: p.UserInfo.CurrentLocation,
+			Email:           p.UserInfo.Email,
+			Github:          p.UserInfo.Github,
+			Linkedin:        p.UserInfo.Linkedin,
+			Mobile:          p.UserInfo.Mobile,
+			Summary:         p.UserInfo.Summary,
+		},
+	}
+
+	for i, e := range p.Educations() {
+		entry := FromEducationInfoPayload(&e)
+		entry.SortOrder = i
+		profile.Education = append(profile.Education, entry)
+	}
+
+	for i, e := range p.Resume.Experiences {
+		profile.Experiences = append(profile.Experiences, domain.Experience{
+			Company:       e.Company,
+			Position:      e.Position,
+			Years:         e.Years,
+			BulletPoints:  e.BulletPoints,
+			Locked:        e.Locked,
+			LockedBullets: e.LockedBullets,
+			SortOrder:     i,
+		})
+	}
+
+	for i, proj := range p.Resume.Projects {
+		profile.Projects = append(profile.Projects, domain.Project{
+			Name:          proj.Name,
+			Description:   proj.Description,
+			Years:         proj.Years,
+			BulletPoints:  proj.BulletPoints,
+			Locked:        proj.Locked,
+			LockedBullets: proj.LockedBullets,
+			SortOrder:     i,
+		})
+	}
+
+	for i, s := range p.Resume.Skills {
+		profile.Skills = append(profile.Skills, domain.Skill{Name: s.Skill, SortOrder: i})
+	}
+
+	for i, c := range p.Resume.Certifications {
+		profile.Certifications = append(profile.Certifications, domain.Certification{
+			Name:      c.Name,
+			Issuer:    c.Issuer,
+			Date:      c.Date,
+			URL:       c.URL,
+			SortOrder: i,
+		})
+	}
+
+	for i, pub := range p.Resume.Publications {
+		profile.Publications = append(profile.Publications, domain.Publication{
+			Title:     pub.Title,
+			Publisher: pub.Publisher,
+			Date:      pub.Date,
+			URL:       pub.URL,
+			Summary:   pub.Summary,
+			SortOrder: i,
+		})
+	}
+
+	for i, v := range p.Resume.Volunteering {
+		profile.Volunteering = append(profile.Volunteering, domain.Volunteering{
+			Organization: v.Organization,
+			Role:         v.Role,
+			Years:        v.Years,
+			BulletPoints: v.BulletPoints,
+			SortOrder:    i,
+		})
+	}
+
+	for i, a := range p.Resume.Awards {
+		profile.Awards = append(profile.Awards, domain.Award{
+			Title:     a.Title,
+			Awarder:   a.Awarder,
+			Date:      a.Date,
+			Summary:   a.Summary,
+			SortOrder: i,
+		})
+	}
+
+	for i, l := range p.Resume.Languages {
+		profile.Languages = append(profile.Languages, domain.Language{
+			Language:  l.Language,
+			Fluency:   l.Fluency,
+			SortOrder: i,
+		})
+	}
+
+	if len(p.AdditionalInfo) > 0 && string(p.AdditionalInfo) != "null" {
+		var sections map[string]string
+		if err := json.Unmarshal(p.AdditionalInfo, &sections); err != nil {
+			return nil, err
+		}
+		titles := make([]string, 0, len(sections))
+		for title := range sections {
+			titles = append(titles, title)
+		}
+		sort.Strings(titles)
+		for i, title := range titles {
+			profile.Sections = append(profile.Sections, domain.Section{
+				Title:     title,
+				Content:   sections[title],
+				SortOrder: i,
+			})
+		}
+	}
+
+	return profile, nil
+}
+
+func FromEducationInfoPayload(e *requests.EducationInfoPayload) domain.Education {
+	return domain.Education{
+		School:     e.School,
+		Degree:     e.Degree,
+		Location:   e.Location,
+		StartEnd:   e.StartEnd,
+		CourseWork: e.CourseWork,
+		GPA:        e.GPA,
+		Honors:     e.Honors,
+	}
+}
@@ -0,0 +1,94 @@
+package matching
+
+import (
+	"strings"
+
+	"github.com/ordo_meritum/features/profiles/models/domain"
+	"github.com/ordo_meritum/shared/libs/taxonomy"
+)
+
+// VariantScore is how well one variant covers a set of job keywords.
+type VariantScore struct {
+	Variant domain.Variant `json:"variant"`
+	Score   float64        `json:"score"`
+	Matched []string       `json:"matched"`
+}
+
+// SelectVariant scores every variant against the keywords and returns the
+// best one. Ties go to the variant listed first. ok is false when there are
+// no variants to choose from.
+func SelectVariant(
+	profile *domain.Profile,
+	variants []domain.Variant,
+	keywords []string,
+) (best VariantScore, scores []VariantScore, ok bool) {
+	for _, v := range variants {
+		score, matched := KeywordOverlap(keywords, ProfileText(v.Apply(profile)))
+		s := VariantScore{Variant: v, Score: score, Matched: matched}
+		scores = append(scores, s)
+		if !ok || s.Score > best.Score {
+			best, ok = s, true
+		}
+	}
+	return best, scores, ok
+}
+
+// KeywordOverlap returns the fraction of distinct keywords that appear in
+// text as whole words or phrases, along with the keywords that matched.
+func KeywordOverlap(keywords []string, text string) (float64, []string) {
+	haystack := " " + normalize(text) + " "
+
+	seen := make(map[string]bool, len(keywords))
+	var matched []string
+	total := 0
+	for _, k := range keywords {
+		needle := normalize(k)
+		if needle == "" || seen[needle] {
+			continue
+		}
+		seen[needle] = true
+		total++
+		if strings.Contains(haystack, " "+needle+" ") {
+			matched = append(matched, k)
+		}
+	}
+
+	if total == 0 {
+		return 0, nil
+	}
+	return float64(len(matched)) / float64(total), matched
+}
+
+// ProfileText flattens the parts of a profile that end up on a resume.
+func ProfileText(p *domain.Profile) string {
+	var sb strings.Builder
+	write := func(s string) {
+		sb.WriteString(s)
+		sb.WriteString("\n")
+	}
+
+	write(p.Contact.Summary)
+	for _, e := range p.Experiences {
+		write(e.Position)
+		for _, b := range e.BulletPoints {
+			write(b)
+		}
+	}
+	for _, proj := range p.Projects {
+		write(proj.Name)
+		write(proj.Description)
+		for _, b := range proj.BulletPoints {
+			write(b)
+		}
+	}
+	for _, s := range p.Skills {
+		write(s.Name)
+	}
+	return sb.String()
+}
+
+// normalize reduces s to space-separated lowercase words with every known
+// skill alias replaced by its canonical name, so "k8s" matches "Kubernetes".
+func normalize(s string) string {
+	return taxonomy.Default().CanonicalText(s)
+}
@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/ordo_meritum/database/jobs"
+	"github.com/ordo_meritum/database/profiles"
+	"github.com/ordo_meritum/database/publicprofiles"
+	"github.com/ordo_meritum/features/public_profiles/models/requests"
+	"github.com/ordo_meritum/features/public_profiles/services"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/middleware"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	"github.com/ordo_meritum/shared/webrender"
+	"github.com/rs/zerolog/log"
+)
+
+type Controller struct {
+	service *services.PublicProfileService
+}
+
+func NewController(service *services.PublicProfileService) *Controller {
+	return &Controller{service: service}
+}
+
+func (c *Controller) RegisterRoutes(secureRouter *mux.Router, authRouter *mux.Router) {
+	secureRouter.HandleFunc("/public-profile", c.HandleGeneratePublicProfile).Methods("POST")
+	authRouter.HandleFunc("/public-profile/demand", c.HandleGetDemand).Methods("GET")
+	authRouter.HandleFunc("/public-profile/revisions", c.HandleListRevisions).Methods("GET")
+	authRouter.HandleFunc("/public-profile/revisions/{id:[0-9]+}", c.HandleGetRevision).Methods("GET")
+}
+
+func (c *Controller) HandleGeneratePublicProfile(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	if _, ok := contexts.FromContext(r.Context()); !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var req requests.PublicProfileRequest
+	if webrender.DecodeJSONBody(w, r, &req) != nil {
+		return
+	}
+	details := append(req.Payload.Validate(), req.Options.Validate()...)
+	if len(details) > 0 {
+		writeValidationError(w, details)
+		return
+	}
+
+	revision, err := c.service.Generate(r.Context(), &req)
+	if err != nil {
+		writePublicProfileError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusCreated, revision)
+}
+
+// HandleGetDemand returns the titles, skills and keywords the tracked roles
+// have in common. roleIds, a comma-separated list, limits it to those roles.
+func (c *Controller) HandleGetDemand(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	if _, ok := contexts.FromContext(r.Context()); !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	var payload requests.PublicProfilePayload
+	if raw := r.URL.Query().Get("roleIds"); raw != "" {
+		for _, part := range strings.Split(raw, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				writeValidationError(w, []error_response.ValidationDetail{{Field: "roleIds", Issue: "must be a comma-separated list of role IDs"}})
+				return
+			}
+			payload.RoleIDs = append(payload.RoleIDs, id)
+		}
+	}
+	if details := payload.Validate(); len(details) > 0 {
+		writeValidationError(w, details)
+		return
+	}
+
+	demand, err := c.service.Demand(r.Context(), payload.RoleIDs)
+	if err != nil {
+		writePublicProfileError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, demand)
+}
+
+func (c *Controller) HandleListRevisions(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	if _, ok := contexts.FromContext(r.Context()); !ok {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	revisions, err := c.service.ListRevisions(r.Context())
+	if err != nil {
+		writePublicProfileError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, revisions)
+}
+
+func (c *Controller) HandleGetRevision(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if _, ok := contexts.FromContext(r.Context()); !ok || err != nil {
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+		return
+	}
+
+	revision, err := c.service.GetRevision(r.Context(), id)
+	if err != nil {
+		writePublicProfileError(w, err)
+		return
+	}
+	middleware.JSON(w, http.StatusOK, revision)
+}
+
+func writeValidationError(w http.ResponseWriter, details []error_response.ValidationDetail) {
+	middleware.JSON(w, http.StatusBadRequest, error_response.ErrorResponse[[]error_response.ValidationDetail]{
+		ErrorCode: error_response.BAD_REQUEST,
+		Message:   "The request failed validation.",
+		Details:   details,
+	})
+}
+
+func writePublicProfileError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, publicprofiles.ErrRevisionNotFound),
+		errors.Is(err, profiles.ErrProfileNotFound),
+		errors.Is(err, services.ErrNoTrackedRoles),
+		errors.Is(err, jobs.ErrJobNotFound):
+		middleware.JSON(w, http.StatusNotFound, error_response.ErrorResponse[struct{}]{
+			ErrorCode: error_response.RESOURCE_UNAVAILABLE,
+			Message:   err.Error(),
+		})
+	default:
+		log.Error().Err(err).Str("service", "public-profiles-controller").Msg("Public profile request failed")
+		middleware.JSON(w, http.StatusInternalServerError, nil)
+	}
+}
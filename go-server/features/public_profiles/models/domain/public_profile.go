@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+// Character limits LinkedIn puts on a headline and an About section.
+const (
+	HeadlineLimit = 220
+	AboutLimit    = 2600
+)
+
+// TermCount is a job title or keyword and how many tracked roles use it.
+type TermCount struct {
+	Term string `json:"term"`
+	Jobs int    `json:"jobs"`
+}
+
+// SkillDemand is a skill the tracked roles ask for. OnProfile is set when
+// the master profile already mentions it.
+type SkillDemand struct {
+	Skill     string `json:"skill"`
+	Category  string `json:"category,omitempty"`
+	Jobs      int    `json:"jobs"`
+	OnProfile bool   `json:"onProfile"`
+}
+
+// Demand is what the user's tracked roles have in common: their titles,
+// skills and keywords, each most common first.
+type Demand struct {
+	Roles    int           `json:"roles"`
+	Titles   []TermCount   `json:"titles"`
+	Skills   []SkillDemand `json:"skills"`
+	Keywords []TermCount   `json:"keywords"`
+}
+
+// Variant is one candidate headline or About section, with why it suits the
+// tracked roles.
+type Variant struct {
+	Text          string `json:"text"`
+	Justification string `json:"justification"`
+}
+
+type FeaturedSkill struct {
+	Skill         string `json:"skill"`
+	Justification string `json:"justification"`
+}
+
+// Content is what one generation produced, along with the demand it was
+// tuned for.
+type Content struct {
+	Headlines      []Variant       `json:"headlines"`
+	About          []Variant       `json:"about"`
+	FeaturedSkills []FeaturedSkill `json:"featuredSkills"`
+	Demand         Demand          `json:"demand"`
+}
+
+// Revision is an immutable snapshot of a generated public profile. Each
+// generation appends a new one, numbered from 1 per user.
+type Revision struct {
+	ID        int       `json:"id"`
+	Number    int       `json:"number"`
+	Provider  string    `json:"provider,omitempty"`
+	Model     string    `json:"model,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Content is nil when revisions are listed.
+	Content *Content `json:"content,omitempty"`
+}
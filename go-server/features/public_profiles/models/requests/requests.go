@@ -0,0 +1,100 @@
+package requests
+
+import (
+	"fmt"
+
+	"github.com/ordo_meritum/shared/models/requests"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+)
+
+const (
+	MaxRoles       = 50
+	MaxFocusLength = 500
+
+	DefaultHeadlines      = 3
+	MaxHeadlines          = 5
+	DefaultAboutVariants  = 2
+	MaxAboutVariants      = 3
+	DefaultFeaturedSkills = 5
+	MaxFeaturedSkills     = 10
+)
+
+type PublicProfileRequest = requests.RequestBody[PublicProfilePayload, PublicProfileOptions]
+
+// PublicProfilePayload narrows generation to some of the tracked roles; an
+// empty RoleIDs uses all of them. Focus is an optional note on the direction
+// the user wants the profile to point, such as "platform engineering".
+type PublicProfilePayload struct {
+	RoleIDs []int  `json:"roleIds,omitempty"`
+	Focus   string `json:"focus,omitempty"`
+}
+
+// PublicProfileOptions sets how many variants of each part to write. Zero
+// uses the default.
+type PublicProfileOptions struct {
+	LlmProvider    string `json:"llm"`
+	LlmModel       string `json:"llmModel"`
+	Headlines      int    `json:"headlines,omitempty"`
+	AboutVariants  int    `json:"aboutVariants,omitempty"`
+	FeaturedSkills int    `json:"featuredSkills,omitempty"`
+}
+
+func (p *PublicProfilePayload) Validate() []error_response.ValidationDetail {
+	var details []error_response.ValidationDetail
+	if len(p.RoleIDs) > MaxRoles {
+		details = append(details, error_response.ValidationDetail{
+			Field: "roleIds",
+			Issue: fmt.Sprintf("cannot contain more than %d roles", MaxRoles),
+		})
+	}
+	for i, id := range p.RoleIDs {
+		if id <= 0 {
+			details = append(details, error_response.ValidationDetail{
+				Field: fmt.Sprintf("roleIds[%d]", i),
+				Issue: "must be a tracked role ID",
+			})
+		}
+	}
+	if len(p.Focus) > MaxFocusLength {
+		details = append(details, error_response.ValidationDetail{
+			Field: "focus",
+			Issue: fmt.Sprintf("cannot exceed %d characters", MaxFocusLength),
+		})
+	}
+	return details
+}
+
+func (o *PublicProfileOptions) Validate() []error_response.ValidationDetail {
+	var details []error_response.ValidationDetail
+	for _, c := range []struct {
+		field string
+		value int
+		max   int
+	}{
+		{"headlines", o.Headlines, MaxHeadlines},
+		{"aboutVariants", o.AboutVariants, MaxAboutVariants},
+		{"featuredSkills", o.FeaturedSkills, MaxFeaturedSkills},
+	} {
+		if c.value < 0 || c.value > c.max {
+			details = append(details, error_response.ValidationDetail{
+				Field: c.field,
+				Issue: fmt.Sprintf("must be between 1 and %d", c.max),
+			})
+		}
+	}
+	return details
+}
+
+// WithDefaults returns the options with unset counts filled in.
+func (o PublicProfileOptions) WithDefaults() PublicProfileOptions {
+	if o.Headlines == 0 {
+		o.Headlines = DefaultHeadlines
+	}
+	if o.AboutVariants == 0 {
+		o.AboutVariants = DefaultAboutVariants
+	}
+	if o.FeaturedSkills == 0 {
+		o.FeaturedSkills = DefaultFeaturedSkills
+	}
+	return o
+}
@@ -0,0 +1,39 @@
+package schemas
+
+import (
+	cohere "github.com/cohere-ai/cohere-go/v2"
+)
+
+var variantSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"text":          map[string]any{"type": "string"},
+		"justification": map[string]any{"type": "string"},
+	},
+	"required": []string{"text", "justification"},
+}
+
+var PublicProfileSchema = map[string]any{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"type":    "object",
+	"properties": map[string]any{
+		"headlines": map[string]any{"type": "array", "items": variantSchema},
+		"about":     map[string]any{"type": "array", "items": variantSchema},
+		"featuredSkills": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"skill":         map[string]any{"type": "string"},
+					"justification": map[string]any{"type": "string"},
+				},
+				"required": []string{"skill", "justification"},
+			},
+		},
+	},
+	"required": []string{"headlines", "about", "featuredSkills"},
+}
+
+var CoherePublicProfileSchemaFormat = cohere.JsonResponseFormatV2{
+	JsonSchema: PublicProfileSchema,
+}
@@ -0,0 +1,34 @@
+package schemas
+
+import (
+	"google.golang.org/genai"
+)
+
+var geminiVariant = &genai.Schema{
+	Type: genai.TypeObject,
+	Properties: map[string]*genai.Schema{
+		"text":          {Type: genai.TypeString},
+		"justification": {Type: genai.TypeString},
+	},
+	Required: []string{"text", "justification"},
+}
+
+var GeminiPublicProfileSchema = &genai.Schema{
+	Type: genai.TypeObject,
+	Properties: map[string]*genai.Schema{
+		"headlines": {Type: genai.TypeArray, Items: geminiVariant},
+		"about":     {Type: genai.TypeArray, Items: geminiVariant},
+		"featuredSkills": {
+			Type: genai.TypeArray,
+			Items: &genai.Schema{
+				Type: genai.TypeObject,
+				Properties: map[string]*genai.Schema{
+					"skill":         {Type: genai.TypeString},
+					"justification": {Type: genai.TypeString},
+				},
+				Required: []string{"skill", "justification"},
+			},
+		},
+	},
+	Required: []string{"headlines", "about", "featuredSkills"},
+}
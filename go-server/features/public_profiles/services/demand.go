@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ordo_meritum/database/jobs"
+	profile_domain "github.com/ordo_meritum/features/profiles/models/domain"
+	"github.com/ordo_meritum/features/profiles/utils/matching"
+	"github.com/ordo_meritum/features/public_profiles/models/domain"
+	"github.com/ordo_meritum/shared/libs/taxonomy"
+)
+
+// ErrNoTrackedRoles is returned when there are no tracked roles to tune the
+// profile for.
+var ErrNoTrackedRoles = errors.New("no tracked roles to build a profile for")
+
+// Caps on how much of the demand is kept. The long tail of one-off skills
+// and keywords says little about what the roles have in common.
+const (
+	maxTitles   = 10
+	maxSkills   = 30
+	maxKeywords = 20
+)
+
+// aggregateDemand counts the titles, skills and keywords of the user's
+// tracked roles, or of the given roles when roleIDs isn't empty. Skills and
+// keywords are counted under their canonical names. profile may be nil, in
+// which case no skill is marked as on the profile.
+func (s *PublicProfileService) aggregateDemand(
+	ctx context.Context,
+	roleIDs []int,
+	profile *profile_domain.Profile,
+) (*domain.Demand, error) {
+	postings, err := s.jobRepo.GetAllUserJobPostings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jobSkills, err := s.jobRepo.GetAllUserJobSkills(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := make(map[int]bool, len(postings))
+	for _, p := range postings {
+		tracked[p.RoleID] = true
+	}
+	include := tracked
+	if len(roleIDs) > 0 {
+		include = make(map[int]bool, len(roleIDs))
+		for _, id := range roleIDs {
+			if !tracked[id] {
+				return nil, fmt.Errorf("%w: role ID %d", jobs.ErrJobNotFound, id)
+			}
+			include[id] = true
+		}
+	}
+	if len(include) == 0 {
+		return nil, ErrNoTrackedRoles
+	}
+
+	titles := newCounter()
+	for _, p := range postings {
+		if include[p.RoleID] {
+			titles.add(p.JobTitle)
+		}
+	}
+
+	tax := taxonomy.Default()
+	skills, keywords := newCounter(), newCounter()
+	for _, j := range jobSkills {
+		if !include[j.RoleID] {
+			continue
+		}
+		var listed []string
+		for _, list := range [][]string{
+			j.ProgrammingLanguages,
+			j.FrameworksAndLibraries,
+			j.Databases,
+			j.CloudTechnologies,
+			j.Tools,
+			j.Certifications,
+		} {
+			listed = append(listed, list...)
+		}
+		for _, name := range tax.NormalizeList(listed) {
+			skills.add(name)
+		}
+		for _, name := range tax.NormalizeList(append(append([]string{}, j.IndustryKeywords...), j.SoftSkills...)) {
+			keywords.add(name)
+		}
+	}
+
+	demand := &domain.Demand{
+		Roles:    len(include),
+		Titles:   titles.top(maxTitles),
+		Keywords: keywords.top(maxKeywords),
+	}
+	topSkills := skills.top(maxSkills)
+	onProfile := map[string]bool{}
+	if profile != nil {
+		names := make([]string, 0, len(topSkills))
+		for _, sk := range topSkills {
+			names = append(names, sk.Term)
+		}
+		_, matched := matching.KeywordOverlap(names, matching.ProfileText(profile))
+		for _, name := range matched {
+			onProfile[taxonomy.Key(name)] = true
+		}
+	}
+	for _, sk := range topSkills {
+		demand.Skills = append(demand.Skills, domain.SkillDemand{
+			Skill:     sk.Term,
+			Category:  tax.Category(sk.Term),
+			Jobs:      sk.Jobs,
+			OnProfile: onProfile[taxonomy.Key(sk.Term)],
+		})
+	}
+	return demand, nil
+}
+
+// counter counts terms case-insensitively, remembering the first spelling
+// it saw for display.
+type counter struct {
+	counts map[string]*domain.TermCount
+}
+
+func newCounter() *counter {
+	return &counter{counts: map[string]*domain.TermCount{}}
+}
+
+func (c *counter) add(term string) {
+	key := taxonomy.Key(term)
+	if key == "" {
+		return
+	}
+	tc, ok := c.counts[key]
+	if !ok {
+		tc = &domain.TermCount{Term: strings.TrimSpace(term)}
+		c.counts[key] = tc
+	}
+	tc.Jobs++
+}
+
+// top returns up to n terms, most common first, ties alphabetically.
+func (c *counter) top(n int) []domain.TermCount {
+	out := make([]domain.TermCount, 0, len(c.counts))
+	for _, tc := range c.counts {
+		out = append(out, *tc)
+	}
+	sort.Slice(out, func(i, k int) bool {
+		if out[i].Jobs != out[k].Jobs {
+			return out[i].Jobs > out[k].Jobs
+		}
+		return out[i].Term < out[k].Term
+	})
+	if len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ordo_meritum/database/jobs"
+	"github.com/ordo_meritum/database/profiles"
+	"github.com/ordo_meritum/database/publicprofiles"
+	"github.com/ordo_meritum/features/documents/utils/formatters"
+	profile_domain "github.com/ordo_meritum/features/profiles/models/domain"
+	profile_mappers "github.com/ordo_meritum/features/profiles/utils/mappers"
+	"github.com/ordo_meritum/features/profiles/utils/matching"
+	"github.com/ordo_meritum/features/public_profiles/models/domain"
+	"github.com/ordo_meritum/features/public_profiles/models/requests"
+	"github.com/ordo_meritum/shared/contexts"
+	"github.com/ordo_meritum/shared/libs/llm"
+	schemaregistry "github.com/ordo_meritum/shared/libs/llm/schema_registry"
+	"github.com/ordo_meritum/shared/libs/taxonomy"
+	"github.com/ordo_meritum/shared/templates/instructions"
+	"github.com/ordo_meritum/shared/templates/prompts"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	shared_formatters "github.com/ordo_meritum/shared/utils/formatters"
+	"github.com/rs/zerolog/log"
+)
+
+var logger = log.With().
+	Str("service", "public-profiles").
+	Logger()
+
+type PublicProfileService struct {
+	revisionRepo publicprofiles.Repository
+	jobRepo      jobs.Repository
+	profileRepo  profiles.Repository
+}
+
+func NewPublicProfileService(
+	revisionRepo publicprofiles.Repository,
+	jobRepo jobs.Repository,
+	profileRepo profiles.Repository,
+) *PublicProfileService {
+	return &PublicProfileService{
+		revisionRepo: revisionRepo,
+		jobRepo:      jobRepo,
+		profileRepo:  profileRepo,
+	}
+}
+
+type llmPublicProfile struct {
+	Headlines      []domain.Variant       `json:"headlines"`
+	About          []domain.Variant       `json:"about"`
+	FeaturedSkills []domain.FeaturedSkill `json:"featuredSkills"`
+}
+
+// Demand returns what the tracked roles have in common, without generating
+// anything. The profile is optional here; without one no skill is marked as
+// on the profile.
+func (s *PublicProfileService) Demand(ctx context.Context, roleIDs []int) (*domain.Demand, error) {
+	profile, err := s.profileRepo.GetProfile(ctx)
+	if err != nil && !errors.Is(err, profiles.ErrProfileNotFound) {
+		return nil, err
+	}
+	return s.aggregateDemand(ctx, roleIDs, profile)
+}
+
+// Generate writes headline and About variants and picks featured skills
+// from the master profile, tuned to the tracked roles, and stores them as a
+// new revision.
+func (s *PublicProfileService) Generate(ctx context.Context, r *requests.PublicProfileRequest) (*domain.Revision, error) {
+	userCtx, ok := contexts.FromContext(ctx)
+	if !ok {
+		return nil, error_response.ErrNoUserContext
+	}
+	opts := r.Options.WithDefaults()
+
+	profile, err := s.profileRepo.GetProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	demand, err := s.aggregateDemand(ctx, r.Payload.RoleIDs, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	generated, err := generatePublicProfile(ctx, opts.LlmProvider, buildPromptData(profile, demand, r.Payload, opts))
+	if err != nil {
+		return nil, err
+	}
+
+	content := &domain.Content{
+		Headlines:      fitVariants(generated.Headlines, opts.Headlines, domain.HeadlineLimit),
+		About:          fitVariants(generated.About, opts.AboutVariants, domain.AboutLimit),
+		FeaturedSkills: profileSkills(generated.FeaturedSkills, profile, opts.FeaturedSkills),
+		Demand:         *demand,
+	}
+	if len(content.Headlines) == 0 || len(content.About) == 0 {
+		return nil, errors.New("LLM response is missing headline or About variants")
+	}
+
+	revision, err := s.revisionRepo.CreateRevision(ctx, opts.LlmProvider, llm.ModelName(opts.LlmProvider), content)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info().
+		Str("uid", userCtx.UID).
+		Int("revision", revision.Number).
+		Int("roles", demand.Roles).
+		Msg("Generated public profile")
+	return revision, nil
+}
+
+func (s *PublicProfileService) ListRevisions(ctx context.Context) ([]domain.Revision, error) {
+	return s.revisionRepo.ListRevisions(ctx)
+}
+
+func (s *PublicProfileService) GetRevision(ctx context.Context, id int) (*domain.Revision, error) {
+	return s.revisionRepo.GetRevision(ctx, id)
+}
+
+func buildPromptData(
+	profile *profile_domain.Profile,
+	demand *domain.Demand,
+	payload requests.PublicProfilePayload,
+	opts requests.PublicProfileOptions,
+) map[string]any {
+	documentPayload := profile_mappers.ToDocumentPayload(profile)
+
+	var titles, skills, keywords strings.Builder
+	for _, t := range demand.Titles {
+		fmt.Fprintf(&titles, "- %s (%d)\n", t.Term, t.Jobs)
+	}
+	for _, sk := range demand.Skills {
+		onProfile := ""
+		if sk.OnProfile {
+			onProfile = ", on profile"
+		}
+		fmt.Fprintf(&skills, "- %s (%d%s)\n", sk.Skill, sk.Jobs, onProfile)
+	}
+	for _, k := range demand.Keywords {
+		fmt.Fprintf(&keywords, "- %s (%d)\n", k.Term, k.Jobs)
+	}
+
+	return map[string]any{
+		"Profile":            formatters.FormatResumeRequestForLLMWithXML(&documentPayload),
+		"Roles":              demand.Roles,
+		"Titles":             titles.String(),
+		"Skills":             skills.String(),
+		"Keywords":           keywords.String(),
+		"Focus":              strings.TrimSpace(payload.Focus),
+		"HeadlineCount":      opts.Headlines,
+		"AboutCount":         opts.AboutVariants,
+		"FeaturedSkillCount": opts.FeaturedSkills,
+		"HeadlineLimit":      domain.HeadlineLimit,
+		"AboutLimit":         domain.AboutLimit,
+	}
+}
+
+// fitVariants drops empty variants, keeps at most n and trims any that run
+// over the limit.
+func fitVariants(variants []domain.Variant, n, limit int) []domain.Variant {
+	out := make([]domain.Variant, 0, n)
+	for _, v := range variants {
+		if len(out) == n {
+			break
+		}
+		v.Text = shared_formatters.FitToLimit(v.Text, limit)
+		v.Justification = strings.TrimSpace(v.Justification)
+		if v.Text == "" {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// profileSkills keeps the featured skills the profile actually shows, under
+// their canonical names and without duplicates, up to n. The LLM is told to
+// only pick skills from the profile; this makes sure of it.
+func profileSkills(picked []domain.FeaturedSkill, profile *profile_domain.Profile, n int) []domain.FeaturedSkill {
+	tax := taxonomy.Default()
+	names := make([]string, 0, len(picked))
+	for _, sk := range picked {
+		names = append(names, sk.Skill)
+	}
+	_, matched := matching.KeywordOverlap(tax.NormalizeList(names), matching.ProfileText(profile))
+	onProfile := make(map[string]bool, len(matched))
+	for _, name := range matched {
+		onProfile[taxonomy.Key(name)] = true
+	}
+
+	seen := map[string]bool{}
+	out := make([]domain.FeaturedSkill, 0, n)
+	for _, sk := range picked {
+		name := tax.Canonical(sk.Skill)
+		key := taxonomy.Key(name)
+		switch {
+		case len(out) == n:
+			return out
+		case seen[key]:
+			continue
+		case !onProfile[key]:
+			logger.Debug().Str("skill", sk.Skill).Msg("Dropped featured skill missing from profile")
+			continue
+		}
+		seen[key] = true
+		out = append(out, domain.FeaturedSkill{
+			Skill:         name,
+			Justification: strings.TrimSpace(sk.Justification),
+		})
+	}
+	return out
+}
+
+func generatePublicProfile(ctx context.Context, providerName string, promptData map[string]any) (*llmPublicProfile, error) {
+	llmProvider, err := llm.GetProvider(providerName)
+	if err != nil {
+		return nil, fmt.Errorf("GetProvider Failed %w", err)
+	}
+
+	schema, err := schemaregistry.GetSchema(providerName, schemaregistry.PublicProfile)
+	if err != nil {
+		return nil, fmt.Errorf("GetSchema Failed %w", err)
+	}
+
+	prompt, err := shared_formatters.FormatTemplate(prompts.Prompts, "publicprofile.txt", promptData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format prompt template: %w", err)
+	}
+
+	instructionBytes, err := instructions.Instructions.ReadFile("publicprofile.txt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read instructions file: %w", err)
+	}
+
+	rawResponse, err := llmProvider.Generate(ctx, string(instructionBytes), prompt, schema)
+	if err != nil {
+		return nil, fmt.Errorf("LLM generation failed: %w", err)
+	}
+
+	var generated llmPublicProfile
+	if err := json.Unmarshal([]byte(llm.FormatLLMResponse(rawResponse)), &generated); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal LLM response: %w. Raw response: %s", err, rawResponse)
+	}
+	return &generated, nil
+}
@@ -3,10 +3,17 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"os"
 	"strconv"
 	"time"
 
+	"github.com/ordo_meritum/database/generations"
+	doc_domain "github.com/ordo_meritum/features/documents/models/domain"
+	doc_services "github.com/ordo_meritum/features/documents/services"
+	"github.com/ordo_meritum/shared/contexts"
+	error_messages "github.com/ordo_meritum/shared/utils/errors"
 	"github.com/ordo_meritum/websocket"
 	"github.com/rs/zerolog/log"
 	"github.com/segmentio/kafka-go"
@@ -15,22 +22,33 @@ import (
 
 const serviceName = "kafka-consumer"
 
+// maxCompiledPDFBytes caps how much of a compiled document is read for the
+// parsability check.
+const maxCompiledPDFBytes = 10 << 20
+
 type consumer struct {
-	reader *kafka.Reader
-	hub    *websocket.Hub
+	reader     *kafka.Reader
+	hub        *websocket.Hub
+	docService *doc_services.DocumentService
 }
 
 type DocumentCompletionEvent struct {
 	UserID       string `json:"user_id"`
 	JobID        int    `json:"job_id"`
+	GenerationID int    `json:"generation_id,omitempty"`
 	Success      bool   `json:"success"`
 	DocumentType string `json:"document_type"`
 	DownloadURL  string `json:"download_url,omitempty"`
 	ChangesURL   string `json:"changes_url,omitempty"`
+	SourceURL    string `json:"source_url,omitempty"`
+	Template     string `json:"template,omitempty"`
+	DocumentID   int    `json:"document_id,omitempty"`
 	Error        string `json:"error,omitempty"`
+
+	Parsability *doc_domain.ParsabilityReport `json:"parsability,omitempty"`
 }
 
-func newConsumer(hub *websocket.Hub) *consumer {
+func newConsumer(hub *websocket.Hub, docService *doc_services.DocumentService) *consumer {
 	broker := os.Getenv("KAFKA_BROKER_URL")
 	if broker == "" {
 		broker = "kafka:29092"
@@ -46,7 +64,7 @@ func newConsumer(hub *websocket.Hub) *consumer {
 		ReadLagInterval: -1,
 	})
 
-	return &consumer{reader: reader, hub: hub}
+	return &consumer{reader: reader, hub: hub, docService: docService}
 }
 
 func (c *consumer) start(ctx context.Context) {
@@ -67,11 +85,11 @@ func (c *consumer) start(ctx context.Context) {
 			time.Sleep(2 * time.Second)
 			continue
 		}
-		c.handleMessage(msg)
+		c.handleMessage(ctx, msg)
 	}
 }
 
-func (c *consumer) handleMessage(msg kafka.Message) {
+func (c *consumer) handleMessage(ctx context.Context, msg kafka.Message) {
 	var event DocumentCompletionEvent
 	if err := json.Unmarshal(msg.Value, &event); err != nil {
 		log.Error().Err(err).Msg("Failed to unmarshal completion event")
@@ -83,30 +101,136 @@ func (c *consumer) handleMessage(msg kafka.Message) {
 		Str("job_id", strconv.Itoa(event.JobID)).
 		Msg("Received completion event")
 
-	c.broadcastEvent(&event, msg.Value)
+	// The parsability check reads the compiler's output, so it runs before
+	// the document is moved into storage.
+	event.Parsability = c.checkParsability(ctx, &event)
+	c.completeGeneration(ctx, &event)
+
+	raw, err := json.Marshal(event)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to marshal completion event")
+		return
+	}
+	c.broadcastEvent(&event, raw)
 }
 
-func (c *consumer) broadcastEvent(event *DocumentCompletionEvent, rawMsg []byte) {
-	if userClients, ok := c.hub.UserClients[event.UserID]; ok && len(userClients) > 0 {
-		log.Info().
-			Str("user_id", event.UserID).
-			Int("client_count", len(userClients)).
-			Msg("Broadcasting notification to connected clients")
-		for client := range userClients {
-			select {
-			case client.Send <- rawMsg:
-			default:
-				close(client.Send)
-				delete(userClients, client)
+// completeGeneration moves the compiled files into storage, adds them to the
+// user's library and records the result against the generation that
+// produced them. The event's paths are replaced with signed download links;
+// results without a generation, which have nowhere to be stored, get none.
+func (c *consumer) completeGeneration(ctx context.Context, event *DocumentCompletionEvent) {
+	files := doc_services.CompiledFiles{
+		DocType:  event.DocumentType,
+		Template: event.Template,
+		PDF:      event.DownloadURL,
+		Changes:  event.ChangesURL,
+		Source:   event.SourceURL,
+	}
+	event.DownloadURL, event.ChangesURL, event.SourceURL = "", "", ""
+	if event.GenerationID == 0 {
+		return
+	}
+	l := log.With().
+		Str("service", serviceName).
+		Str("user_id", event.UserID).
+		Int("generation_id", event.GenerationID).
+		Logger()
+	userCtx := userContext(ctx, event)
+
+	if !event.Success {
+		update := doc_domain.GenerationUpdate{
+			Status:    doc_domain.GenerationFailed,
+			ErrorCode: error_messages.ERR_COMPILATION_FAILED,
+			Error:     event.Error,
+		}
+		if err := c.docService.CompleteGeneration(userCtx, event.GenerationID, update); err != nil {
+			l.Error().Err(err).Msg("Failed to record generation result")
+		}
+		return
+	}
+
+	doc, err := c.docService.RecordCompiledDocument(userCtx, event.GenerationID, files)
+	switch {
+	case errors.Is(err, generations.ErrGenerationFinished):
+		l.Info().Msg("Dropping compiled document for a finished generation")
+	case err != nil:
+		code := error_messages.ERR_STORAGE_FAILED
+		if errors.Is(err, doc_services.ErrMissingPDF) {
+			code = error_messages.ERR_COMPILATION_FAILED
+		}
+		l.Error().Err(err).Msg("Failed to store compiled document")
+		event.Success = false
+		event.Error = error_messages.ErrorMessage(code).Error()
+		update := doc_domain.GenerationUpdate{
+			Status:    doc_domain.GenerationFailed,
+			ErrorCode: code,
+			Error:     err.Error(),
+		}
+		if err := c.docService.CompleteGeneration(userCtx, event.GenerationID, update); err != nil {
+			l.Error().Err(err).Msg("Failed to record generation result")
+		}
+	default:
+		event.DocumentID = doc.ID
+		for _, a := range doc.Artifacts {
+			switch a.Kind {
+			case doc_domain.ArtifactPDF:
+				event.DownloadURL = a.URL
+			case doc_domain.ArtifactChanges:
+				event.ChangesURL = a.URL
+			case doc_domain.ArtifactSource:
+				event.SourceURL = a.URL
 			}
 		}
-	} else {
-		log.Warn().Str("user_id", event.UserID).Msg("No clients connected for user, cannot send notification")
 	}
 }
 
-func RegisterCompletionConsumer(lc fx.Lifecycle, hub *websocket.Hub) {
-	consumer := newConsumer(hub)
+func userContext(ctx context.Context, event *DocumentCompletionEvent) context.Context {
+	return context.WithValue(ctx, contexts.UserContextKey, &contexts.UserContext{UID: event.UserID})
+}
+
+// checkParsability runs the ATS parsability check on a compiled resume. It
+// returns nil for other documents and when the check can't run, so a
+// failure never holds up the completion notification.
+func (c *consumer) checkParsability(ctx context.Context, event *DocumentCompletionEvent) *doc_domain.ParsabilityReport {
+	if !event.Success || event.DocumentType != "resume" || event.DownloadURL == "" {
+		return nil
+	}
+	l := log.With().
+		Str("service", serviceName).
+		Str("user_id", event.UserID).
+		Int("job_id", event.JobID).
+		Logger()
+
+	f, err := os.Open(event.DownloadURL)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to open compiled resume")
+		return nil
+	}
+	defer f.Close()
+	pdf, err := io.ReadAll(io.LimitReader(f, maxCompiledPDFBytes))
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to read compiled resume")
+		return nil
+	}
+
+	report, err := c.docService.CheckParsability(userContext(ctx, event), event.JobID, pdf)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to check resume parsability")
+		return nil
+	}
+	if !report.Parsable {
+		l.Warn().Msg("Compiled resume does not extract cleanly")
+	}
+	return report
+}
+
+func (c *consumer) broadcastEvent(event *DocumentCompletionEvent, rawMsg []byte) {
+	log.Info().Str("user_id", event.UserID).Msg("Broadcasting notification to connected clients")
+	c.hub.SendToUser(event.UserID, rawMsg)
+}
+
+func RegisterCompletionConsumer(lc fx.Lifecycle, hub *websocket.Hub, docService *doc_services.DocumentService) {
+	consumer := newConsumer(hub, docService)
 
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
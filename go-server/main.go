@@ -10,13 +10,23 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/ordo_meritum/config"
 	"github.com/ordo_meritum/database"
+	"github.com/ordo_meritum/database/applicationanswers"
 	"github.com/ordo_meritum/database/candidate_forms"
+	"github.com/ordo_meritum/database/emaildrafts"
+	"github.com/ordo_meritum/database/generations"
 	"github.com/ordo_meritum/database/guides"
 	"github.com/ordo_meritum/database/jobs"
+	"github.com/ordo_meritum/database/library"
+	"github.com/ordo_meritum/database/profiles"
+	"github.com/ordo_meritum/database/publicprofiles"
 	"github.com/ordo_meritum/database/questionnaires"
 	"github.com/ordo_meritum/database/resumes"
+	"github.com/ordo_meritum/database/tracking_requests"
 	"github.com/ordo_meritum/database/users"
 	"github.com/ordo_meritum/database/writingsamples"
+	"github.com/ordo_meritum/database/writingstyles"
+	answer_controllers "github.com/ordo_meritum/features/application_answers/controllers"
+	answer_services "github.com/ordo_meritum/features/application_answers/services"
 	apptracking_controllers "github.com/ordo_meritum/features/application_tracking/controllers"
 	apptracking_services "github.com/ordo_meritum/features/application_tracking/services"
 	auth_controllers "github.com/ordo_meritum/features/auth/controllers"
@@ -25,11 +35,19 @@ import (
 	candidate_form_services "github.com/ordo_meritum/features/candidate_forms/services"
 	doc_controllers "github.com/ordo_meritum/features/documents/controllers"
 	doc_services "github.com/ordo_meritum/features/documents/services"
+	email_controllers "github.com/ordo_meritum/features/emails/controllers"
+	email_services "github.com/ordo_meritum/features/emails/services"
 	jobguide_controllers "github.com/ordo_meritum/features/job_guide/controllers"
 	jobguide_services "github.com/ordo_meritum/features/job_guide/services"
+	profile_controllers "github.com/ordo_meritum/features/profiles/controllers"
+	profile_services "github.com/ordo_meritum/features/profiles/services"
+	public_profile_controllers "github.com/ordo_meritum/features/public_profiles/controllers"
+	public_profile_services "github.com/ordo_meritum/features/public_profiles/services"
 	"github.com/ordo_meritum/kafka"
+	"github.com/ordo_meritum/storage"
 	"github.com/ordo_meritum/web"
 	"github.com/ordo_meritum/websocket"
+	"github.com/ordo_meritum/workers"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"go.uber.org/fx"
@@ -80,13 +98,24 @@ func main() {
 
 			candidate_forms.NewPostgresRepository,
 			writingsamples.NewPostgresRepository,
+			writingstyles.NewPostgresRepository,
 			jobs.NewPostgresRepository,
 			guides.NewPostgresRepository,
 			users.NewPostgresRepository,
 			questionnaires.NewPostgresRepository,
 			resumes.NewPostgresRepository,
+			profiles.NewPostgresRepository,
+			generations.NewPostgresRepository,
+			library.NewPostgresRepository,
+			tracking_requests.NewPostgresRepository,
+			applicationanswers.NewPostgresRepository,
+			emaildrafts.NewPostgresRepository,
+			publicprofiles.NewPostgresRepository,
 
 			kafka.NewLatexWriter,
+			workers.NewPool,
+			storage.NewStore,
+			storage.NewURLSignerFromEnv,
 
 			auth_services.NewAuthService,
 			auth_controllers.NewController,
@@ -98,12 +127,22 @@ func main() {
 			doc_controllers.NewDocumentController,
 			jobguide_services.NewJobGuideService,
 			jobguide_controllers.NewController,
+			profile_services.NewProfileService,
+			profile_controllers.NewController,
+			answer_services.NewApplicationAnswersService,
+			answer_controllers.NewController,
+			email_services.NewEmailService,
+			email_controllers.NewController,
+			public_profile_services.NewPublicProfileService,
+			public_profile_controllers.NewController,
 
 			web.NewRouteDependencies,
 		),
 
 		fx.Invoke(web.InitializeFirebase),
 		fx.Invoke(kafka.RegisterCompletionConsumer),
+		fx.Invoke(doc_services.RegisterGenerationRecovery),
+		fx.Invoke(apptracking_services.RegisterTrackingRecovery),
 		fx.Invoke(web.RegisterRoutes),
 		fx.Invoke(func(lc fx.Lifecycle, hub *websocket.Hub) {
 			lc.Append(fx.Hook{
@@ -0,0 +1,303 @@
+// Package dates parses the free-form date ranges people write on resumes
+// ("Jan 2021 - Present", "03/2022", "Summer 2020", "2019–2021", "enero de
+// 2021 - actualidad") and formats them back in one canonical style.
+package dates
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnrecognized is returned for text that isn't a date or date range.
+var ErrUnrecognized = errors.New("unrecognized date")
+
+// Precision is how much of a date was given.
+type Precision string
+
+const (
+	PrecisionYear   Precision = "year"
+	PrecisionSeason Precision = "season"
+	PrecisionMonth  Precision = "month"
+	PrecisionDay    Precision = "day"
+)
+
+// Date is a point on a resume timeline at the precision it was written
+// with. A season is kept as the month it starts in.
+type Date struct {
+	Year      int        `json:"year"`
+	Month     time.Month `json:"month,omitempty"`
+	Day       int        `json:"day,omitempty"`
+	Precision Precision  `json:"precision"`
+}
+
+// Range is a start date and either an end date or Ongoing. A lone date
+// parses as a range with only a start.
+type Range struct {
+	Start   Date `json:"start"`
+	End     Date `json:"end"`
+	Ongoing bool `json:"ongoing,omitempty"`
+}
+
+var (
+	isoPattern       = regexp.MustCompile(`^(\d{4})-(\d{1,2})(?:-(\d{1,2}))?$`)
+	monthYearPattern = regexp.MustCompile(`^(\d{1,2})[/.\-](\d{4})$`)
+	yearMonthPattern = regexp.MustCompile(`^(\d{4})[/.](\d{1,2})$`)
+	yearPattern      = regexp.MustCompile(`^\d{4}$`)
+	dashReplacer     = strings.NewReplacer("–", "-", "—", "-", "‒", "-", "−", "-", "--", "-")
+)
+
+// rangeSeparators split a range into its two ends, tried in order.
+var rangeSeparators = []string{" - ", " to ", " until ", " hasta ", " à ", " au ", " bis ", " até ", " a ", " al ", " tot ", "-"}
+
+// Parse reads a date or date range. Month names, seasons and ongoing
+// markers are recognised in English, Spanish, French, German, Portuguese,
+// Italian and Dutch. A start month without a year borrows the end's year,
+// as in "Jan - Mar 2021".
+func Parse(s string) (Range, error) {
+	text := clean(s)
+	if text == "" {
+		return Range{}, ErrUnrecognized
+	}
+	if ongoingMarkers[text] {
+		return Range{Ongoing: true}, nil
+	}
+	for _, prefix := range sincePrefixes {
+		if rest, ok := strings.CutPrefix(text, prefix+" "); ok {
+			if start, err := parseDate(rest); err == nil {
+				return Range{Start: start, Ongoing: true}, nil
+			}
+		}
+	}
+	if d, err := parseDate(text); err == nil {
+		return Range{Start: d}, nil
+	}
+
+	if r, _, _, ok := cutRange(text); ok {
+		return r, nil
+	}
+	return Range{}, fmt.Errorf("%w: %q", ErrUnrecognized, s)
+}
+
+// Split cuts a date range into its start and end as written, so "03-2021 -
+// 05-2022" gives "03-2021" and "05-2022". A single date, or text that isn't
+// a range, comes back whole as the start.
+func Split(s string) (string, string) {
+	text := strings.Join(strings.Fields(dashReplacer.Replace(s)), " ")
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// Lowercasing changed some byte lengths, so offsets into lower
+		// wouldn't line up with text.
+		lower = text
+	}
+	if _, err := parseDate(clean(lower)); err == nil {
+		return text, ""
+	}
+	if _, i, j, ok := cutRange(lower); ok {
+		return strings.TrimSpace(text[:i]), strings.TrimSpace(text[j:])
+	}
+	if start, end, ok := strings.Cut(text, " - "); ok {
+		return strings.TrimSpace(start), strings.TrimSpace(end)
+	}
+	return text, ""
+}
+
+// cutRange finds a separator in lowercased text with a date on either side,
+// trying each separator at every place it occurs. The start ends at text[:i]
+// and the end begins at text[j:].
+func cutRange(text string) (r Range, i, j int, ok bool) {
+	for _, sep := range rangeSeparators {
+		for i := strings.Index(text, sep); i >= 0; {
+			j := i + len(sep)
+			if r, ok := parseEnds(clean(text[:i]), clean(text[j:])); ok {
+				return r, i, j, true
+			}
+			next := strings.Index(text[j:], sep)
+			if next < 0 {
+				break
+			}
+			i = j + next
+		}
+	}
+	return Range{}, 0, 0, false
+}
+
+// ParseDate reads a single date.
+func ParseDate(s string) (Date, error) {
+	d, err := parseDate(clean(s))
+	if err != nil {
+		return Date{}, fmt.Errorf("%w: %q", ErrUnrecognized, s)
+	}
+	return d, nil
+}
+
+// IsOngoing reports whether s is a marker such as "Present" or "heute".
+func IsOngoing(s string) bool {
+	return ongoingMarkers[clean(s)]
+}
+
+func parseEnds(left, right string) (Range, bool) {
+	if left == "" {
+		return Range{}, false
+	}
+	var r Range
+	switch {
+	case right == "" || ongoingMarkers[right]:
+		r.Ongoing = true
+	default:
+		end, err := parseDate(right)
+		if err != nil {
+			return Range{}, false
+		}
+		r.End = end
+	}
+
+	start, err := parseDate(left)
+	if err != nil && !r.End.IsZero() {
+		// "Jan - Mar 2021": the start month borrows the end's year.
+		start, err = parseDate(left + " " + strconv.Itoa(r.End.Year))
+	}
+	if err != nil {
+		return Range{}, false
+	}
+	r.Start = start
+	return r, true
+}
+
+func parseDate(s string) (Date, error) {
+	if m := isoPattern.FindStringSubmatch(s); m != nil {
+		d := Date{Year: atoi(m[1]), Month: time.Month(atoi(m[2])), Precision: PrecisionMonth}
+		if m[3] != "" {
+			d.Day, d.Precision = atoi(m[3]), PrecisionDay
+		}
+		return d, d.validate()
+	}
+	if m := monthYearPattern.FindStringSubmatch(s); m != nil {
+		d := Date{Year: atoi(m[2]), Month: time.Month(atoi(m[1])), Precision: PrecisionMonth}
+		return d, d.validate()
+	}
+	if m := yearMonthPattern.FindStringSubmatch(s); m != nil {
+		d := Date{Year: atoi(m[1]), Month: time.Month(atoi(m[2])), Precision: PrecisionMonth}
+		return d, d.validate()
+	}
+	if yearPattern.MatchString(s) {
+		return Date{Year: atoi(s), Precision: PrecisionYear}, nil
+	}
+	return parseWords(s)
+}
+
+// parseWords reads dates written with a month or season name, in either
+// order around the year, with an optional day: "Jan. 2021", "15 March
+// 2021", "March 15, 2021", "Summer 2020", "enero de 2021".
+func parseWords(s string) (Date, error) {
+	var d Date
+	for _, tok := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' || r == '.' }) {
+		switch {
+		case fillerWords[tok]:
+		case yearPattern.MatchString(tok) && d.Year == 0:
+			d.Year = atoi(tok)
+		case len(tok) <= 2 && isDigits(tok) && d.Day == 0:
+			d.Day = atoi(tok)
+		case months[tok] != 0 && d.Month == 0:
+			d.Month, d.Precision = months[tok], PrecisionMonth
+		case seasons[tok] != 0 && d.Month == 0:
+			d.Month, d.Precision = seasons[tok], PrecisionSeason
+		default:
+			return Date{}, ErrUnrecognized
+		}
+	}
+	if d.Year == 0 || d.Month == 0 {
+		return Date{}, ErrUnrecognized
+	}
+	if d.Day != 0 {
+		if d.Precision == PrecisionSeason {
+			return Date{}, ErrUnrecognized
+		}
+		d.Precision = PrecisionDay
+	}
+	return d, d.validate()
+}
+
+func (d Date) validate() error {
+	if d.Month < time.January || d.Month > time.December {
+		return ErrUnrecognized
+	}
+	if d.Day != 0 && d.Time().Day() != d.Day {
+		return ErrUnrecognized
+	}
+	return nil
+}
+
+// IsZero reports whether the date is unset.
+func (d Date) IsZero() bool {
+	return d.Year == 0
+}
+
+// Time is the first instant the date covers, in UTC. The zero Date gives the
+// zero time.
+func (d Date) Time() time.Time {
+	if d.IsZero() {
+		return time.Time{}
+	}
+	month, day := d.Month, d.Day
+	if month == 0 {
+		month = time.January
+	}
+	if day == 0 {
+		day = 1
+	}
+	return time.Date(d.Year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// FromTime is the date t falls on, at the given precision. An unknown
+// precision is taken as a month; the zero time gives the zero Date.
+func FromTime(t time.Time, precision Precision) Date {
+	if t.IsZero() {
+		return Date{}
+	}
+	d := Date{Year: t.Year(), Month: t.Month(), Day: t.Day(), Precision: precision}
+	switch precision {
+	case PrecisionYear:
+		d.Month, d.Day = 0, 0
+	case PrecisionSeason, PrecisionMonth:
+		d.Day = 0
+	case PrecisionDay:
+	default:
+		d.Day, d.Precision = 0, PrecisionMonth
+	}
+	return d
+}
+
+// ISO is the date in ISO 8601 at its precision. Seasons come out as the
+// month they start in.
+func (d Date) ISO() string {
+	switch d.Precision {
+	case PrecisionYear:
+		return fmt.Sprintf("%04d", d.Year)
+	case PrecisionDay:
+		return d.Time().Format("2006-01-02")
+	}
+	return d.Time().Format("2006-01")
+}
+
+func clean(s string) string {
+	s = strings.ToLower(dashReplacer.Replace(s))
+	return strings.Trim(strings.Join(strings.Fields(s), " "), " ,;")
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
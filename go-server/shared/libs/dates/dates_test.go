@@ -0,0 +1,102 @@
+package dates
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func month(year int, m time.Month) Date {
+	return Date{Year: year, Month: m, Precision: PrecisionMonth}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Range
+	}{
+		{"Present", Range{Ongoing: true}},
+		{"Jan 2021 - Present", Range{Start: month(2021, time.January), Ongoing: true}},
+		{"Jan. 2021 – present", Range{Start: month(2021, time.January), Ongoing: true}},
+		{"Since 2021", Range{Start: Date{Year: 2021, Precision: PrecisionYear}, Ongoing: true}},
+		{"enero de 2021 - actualidad", Range{Start: month(2021, time.January), Ongoing: true}},
+		{"Summer 2020", Range{Start: Date{Year: 2020, Month: time.June, Precision: PrecisionSeason}}},
+		{"Fall 2019 to Spring 2021", Range{
+			Start: Date{Year: 2019, Month: time.September, Precision: PrecisionSeason},
+			End:   Date{Year: 2021, Month: time.March, Precision: PrecisionSeason},
+		}},
+		{"Winter 2021", Range{Start: Date{Year: 2021, Month: time.January, Precision: PrecisionSeason}}},
+		{"03/2022", Range{Start: month(2022, time.March)}},
+		{"03/2021 - 11/2022", Range{Start: month(2021, time.March), End: month(2022, time.November)}},
+		{"03-2021 - 05-2022", Range{Start: month(2021, time.March), End: month(2022, time.May)}},
+		{"2019–2021", Range{
+			Start: Date{Year: 2019, Precision: PrecisionYear},
+			End:   Date{Year: 2021, Precision: PrecisionYear},
+		}},
+		{"2019 - 2021", Range{
+			Start: Date{Year: 2019, Precision: PrecisionYear},
+			End:   Date{Year: 2021, Precision: PrecisionYear},
+		}},
+		{"Sept 2020", Range{Start: month(2020, time.September)}},
+		{"Sep. 2020 - Dec 2021", Range{Start: month(2020, time.September), End: month(2021, time.December)}},
+		{"Jan - Mar 2021", Range{Start: month(2021, time.January), End: month(2021, time.March)}},
+		{"15 March 2021", Range{Start: Date{Year: 2021, Month: time.March, Day: 15, Precision: PrecisionDay}}},
+		{"2021-03", Range{Start: month(2021, time.March)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUnrecognized(t *testing.T) {
+	for _, in := range []string{"", "soon", "13/2021", "Smarch 2021", "Feb 30 2021"} {
+		if _, err := Parse(in); !errors.Is(err, ErrUnrecognized) {
+			t.Errorf("Parse(%q) error = %v, want ErrUnrecognized", in, err)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		in, start, end string
+	}{
+		{"03-2021 - 05-2022", "03-2021", "05-2022"},
+		{"2019–2021", "2019", "2021"},
+		{"Jan 2021 - Present", "Jan 2021", "Present"},
+		{"Summer 2020 to Fall 2021", "Summer 2020", "Fall 2021"},
+		{"03/2022", "03/2022", ""},
+		{"05-2022", "05-2022", ""},
+		{"a while - later", "a while", "later"},
+	}
+	for _, tt := range tests {
+		start, end := Split(tt.in)
+		if start != tt.start || end != tt.end {
+			t.Errorf("Split(%q) = %q, %q, want %q, %q", tt.in, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"january 2021 - now", "Jan. 2021 - Present"},
+		{"05/2020 – 09/2021", "May 2020 - Sep. 2021"},
+		{"summer 2020", "Summer 2020"},
+		{"2019–2021", "2019 - 2021"},
+		{"not a date", "not a date"},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.in, DefaultStyle); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
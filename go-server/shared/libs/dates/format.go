@@ -0,0 +1,79 @@
+package dates
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Style is how a resume template prints dates.
+type Style struct {
+	// MonthLayout is the time layout for dates with a month, e.g. "Jan. 2006".
+	// Dates given to the day are printed at month precision.
+	MonthLayout string
+	Separator   string
+	Ongoing     string
+}
+
+// DefaultStyle matches the dates the generator has always stored and
+// printed, e.g. "Jan. 2021 - Present".
+var DefaultStyle = Style{MonthLayout: "Jan. 2006", Separator: " - ", Ongoing: "Present"}
+
+var seasonNames = map[time.Month]string{
+	time.January:   "Winter",
+	time.March:     "Spring",
+	time.June:      "Summer",
+	time.September: "Fall",
+}
+
+// Format prints the date at its precision.
+func (d Date) Format(style Style) string {
+	switch {
+	case d.IsZero():
+		return ""
+	case d.Precision == PrecisionYear:
+		return strconv.Itoa(d.Year)
+	case d.Precision == PrecisionSeason && seasonNames[d.Month] != "":
+		return seasonNames[d.Month] + " " + strconv.Itoa(d.Year)
+	}
+	out := d.Time().Format(style.MonthLayout)
+	// May is already short; "May." reads as a typo.
+	return strings.Replace(out, "May.", "May", 1)
+}
+
+// Format prints the range, or just its start when it has no end.
+func (r Range) Format(style Style) string {
+	start := r.Start.Format(style)
+	switch {
+	case r.Ongoing && start == "":
+		return style.Ongoing
+	case r.Ongoing:
+		return start + style.Separator + style.Ongoing
+	case r.End.IsZero():
+		return start
+	}
+	return start + style.Separator + r.End.Format(style)
+}
+
+// Normalize reformats a date range in style. Text that doesn't parse is
+// returned unchanged so nothing the user wrote is lost.
+func Normalize(s string, style Style) string {
+	r, err := Parse(s)
+	if err != nil {
+		return s
+	}
+	return r.Format(style)
+}
+
+// NormalizeDate reformats a single date, or an ongoing marker such as
+// "now", in style. Text that doesn't parse is returned unchanged.
+func NormalizeDate(s string, style Style) string {
+	if IsOngoing(s) {
+		return style.Ongoing
+	}
+	d, err := ParseDate(s)
+	if err != nil {
+		return s
+	}
+	return d.Format(style)
+}
@@ -0,0 +1,69 @@
+package dates
+
+import "time"
+
+// months maps month names and their common abbreviations, in the languages
+// resumes usually arrive in, to the month.
+var months = map[string]time.Month{}
+
+// seasons maps season names to the month the season is taken to start in.
+// Winter starts the year, as in academic terms ("Winter 2021" is the term in
+// early 2021).
+var seasons = map[string]time.Month{}
+
+// ongoingMarkers are the words that stand in for the end of a current role.
+var ongoingMarkers = map[string]bool{}
+
+// sincePrefixes open a range with no end, as in "Since 2021".
+var sincePrefixes = []string{"since", "desde", "depuis", "seit", "dal", "sinds"}
+
+// fillerWords may sit between a month and its year ("enero de 2021").
+var fillerWords = map[string]bool{"of": true, "de": true, "del": true, "du": true, "di": true}
+
+func init() {
+	names := [][]string{
+		// en, es, fr, de, pt, it, nl
+		{"january", "jan", "enero", "ene", "janvier", "janv", "januar", "janeiro", "gennaio", "gen", "januari"},
+		{"february", "feb", "febrero", "février", "fevrier", "févr", "fevr", "februar", "fevereiro", "fev", "febbraio", "februari"},
+		{"march", "mar", "marzo", "mars", "märz", "marz", "mär", "março", "marco", "maart", "mrt"},
+		{"april", "apr", "abril", "abr", "avril", "avr", "aprile", "apr"},
+		{"may", "mayo", "mai", "maio", "maggio", "mag", "mei"},
+		{"june", "jun", "junio", "juin", "juni", "junho", "giugno", "giu"},
+		{"july", "jul", "julio", "juillet", "juil", "juli", "julho", "luglio", "lug"},
+		{"august", "aug", "agosto", "ago", "août", "aout", "augustus"},
+		{"september", "sep", "sept", "septiembre", "septembre", "setembro", "set", "settembre"},
+		{"october", "oct", "octubre", "octobre", "oktober", "okt", "outubro", "out", "ottobre", "ott"},
+		{"november", "nov", "noviembre", "novembre", "novembro"},
+		{"december", "dec", "diciembre", "dic", "décembre", "decembre", "déc", "dezember", "dez", "dezembro", "dicembre"},
+	}
+	for i, list := range names {
+		for _, name := range list {
+			months[name] = time.Month(i + 1)
+		}
+	}
+
+	for _, name := range []string{"winter", "invierno", "hiver", "inverno"} {
+		seasons[name] = time.January
+	}
+	for _, name := range []string{"spring", "primavera", "printemps", "frühling", "fruhling", "lente", "voorjaar"} {
+		seasons[name] = time.March
+	}
+	for _, name := range []string{"summer", "verano", "été", "ete", "sommer", "verão", "verao", "estate", "zomer"} {
+		seasons[name] = time.June
+	}
+	for _, name := range []string{"fall", "autumn", "otoño", "otono", "automne", "herbst", "outono", "autunno", "herfst", "najaar"} {
+		seasons[name] = time.September
+	}
+
+	for _, word := range []string{
+		"present", "current", "now", "ongoing", "today", "to date", "date",
+		"actualidad", "presente", "actual", "actualmente", "hoy",
+		"présent", "aujourd'hui", "aujourd’hui", "actuel", "en cours",
+		"heute", "jetzt", "aktuell", "laufend",
+		"atual", "atualmente", "hoje",
+		"oggi", "attuale", "in corso",
+		"heden", "nu", "huidig",
+	} {
+		ongoingMarkers[word] = true
+	}
+}
@@ -39,6 +39,25 @@ func GetProvider(llm string) (LLMProvider, error) {
 	}
 }
 
+// ModelNamer is implemented by providers that can report which model they
+// call.
+type ModelNamer interface {
+	ModelName() string
+}
+
+// ModelName returns the model behind the named provider, or "" if the
+// provider doesn't say.
+func ModelName(llm string) string {
+	provider, err := GetProvider(llm)
+	if err != nil {
+		return ""
+	}
+	if m, ok := provider.(ModelNamer); ok {
+		return m.ModelName()
+	}
+	return ""
+}
+
 func FormatLLMResponse(raw string) string {
 	clean := strings.TrimSpace(raw)
 
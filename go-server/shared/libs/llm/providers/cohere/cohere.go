@@ -21,6 +21,10 @@ func NewClient() *CohereClient {
 	}
 }
 
+func (c *CohereClient) ModelName() string {
+	return c.model
+}
+
 func (c *CohereClient) Generate(
 	ctx context.Context,
 	instructions string,
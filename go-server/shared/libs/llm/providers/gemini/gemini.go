@@ -24,6 +24,10 @@ func NewClient() *GeminiClient {
 	}
 }
 
+func (c *GeminiClient) ModelName() string {
+	return c.model
+}
+
 // Generate generates content based on the given prompt and instructions.
 //
 // It will make a single request to the Gemini API with the given
@@ -3,26 +3,42 @@ package schemaregistry
 import (
 	"fmt"
 
+	answer_schemas "github.com/ordo_meritum/features/application_answers/models/schemas"
 	app_schemas "github.com/ordo_meritum/features/application_tracking/models/schemas"
 	doc_schemas "github.com/ordo_meritum/features/documents/models/schemas"
+	email_schemas "github.com/ordo_meritum/features/emails/models/schemas"
+	public_profile_schemas "github.com/ordo_meritum/features/public_profiles/models/schemas"
 )
 
 var (
 	Resume              = "resume"
 	Coverletter         = "coverletter"
+	CV                  = "cv"
 	MatchSummary        = "match_summary"
 	ApplicationTracking = "application_tracking"
+	ApplicationAnswers  = "application_answers"
+	Email               = "email"
+	PublicProfile       = "public_profile"
 )
 
 var ProviderSchemaRegistry = map[string]map[string]any{
 	"gemini": {
 		"resume":      doc_schemas.GeminiResumeSchema,
 		"coverletter": doc_schemas.GeminiCoverLetterSchema,
+		"cv":          doc_schemas.GeminiCVSchema,
+
+		"application_answers": answer_schemas.GeminiApplicationAnswersSchema,
+		"email":               email_schemas.GeminiEmailSchema,
+		"public_profile":      public_profile_schemas.GeminiPublicProfileSchema,
 	},
 	"cohere": {
 		"resume":               doc_schemas.CohereResumeSchema,
 		"coverletter":          doc_schemas.CohereCoverLetterSchemaFormat,
+		"cv":                   doc_schemas.CohereCVSchemaFormat,
 		"application_tracking": app_schemas.CohereJobDescriptionSchemaFormat,
+		"application_answers":  answer_schemas.CohereApplicationAnswersSchemaFormat,
+		"email":                email_schemas.CohereEmailSchemaFormat,
+		"public_profile":       public_profile_schemas.CoherePublicProfileSchemaFormat,
 	},
 }
 
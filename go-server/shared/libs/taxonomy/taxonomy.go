@@ -0,0 +1,261 @@
+// Package taxonomy maps the many spellings of a skill ("JS", "Javascript",
+// "ECMAScript") to one canonical name, and knows each skill's category and
+// parent, e.g. React under JavaScript.
+package taxonomy
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:embed skills.json
+var embeddedSkills []byte
+
+// maxPhraseWords is the longest alias, in words, that CanonicalText looks
+// for.
+const maxPhraseWords = 4
+
+type Skill struct {
+	Name     string   `json:"name"`
+	Category string   `json:"category"`
+	Aliases  []string `json:"aliases,omitempty"`
+	Parent   string   `json:"parent,omitempty"`
+}
+
+type Taxonomy struct {
+	skills map[string]*Skill // by Key(name)
+	index  map[string]string // Key(name or alias) -> canonical name
+}
+
+var (
+	defaultOnce     sync.Once
+	defaultTaxonomy *Taxonomy
+)
+
+// Default returns the embedded taxonomy, extended with the skills in the
+// JSON file named by SKILL_TAXONOMY_PATH if that is set. A bad extension file
+// is logged and skipped.
+func Default() *Taxonomy {
+	defaultOnce.Do(func() {
+		t, err := Load(embeddedSkills)
+		if err != nil {
+			panic(fmt.Sprintf("taxonomy: embedded skills.json is invalid: %v", err))
+		}
+		if path := os.Getenv("SKILL_TAXONOMY_PATH"); path != "" {
+			if err := t.extendFromFile(path); err != nil {
+				log.Error().Err(err).Str("path", path).Msg("Failed to load skill taxonomy extension")
+			}
+		}
+		defaultTaxonomy = t
+	})
+	return defaultTaxonomy
+}
+
+// Load builds a taxonomy from a JSON array of skills.
+func Load(data []byte) (*Taxonomy, error) {
+	t := &Taxonomy{skills: map[string]*Skill{}, index: map[string]string{}}
+	var skills []Skill
+	if err := json.Unmarshal(data, &skills); err != nil {
+		return nil, fmt.Errorf("failed to parse skills: %w", err)
+	}
+	if err := t.Extend(skills...); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Extend adds skills to the taxonomy. A skill whose name is already known
+// replaces the existing entry; an alias already claimed by a different skill
+// is an error. On error the taxonomy is left as it was.
+func (t *Taxonomy) Extend(skills ...Skill) error {
+	next := &Taxonomy{skills: maps.Clone(t.skills), index: maps.Clone(t.index)}
+	if err := next.extend(skills); err != nil {
+		return err
+	}
+	t.skills, t.index = next.skills, next.index
+	return nil
+}
+
+// extend is Extend without the rollback; t is left half-updated on error.
+func (t *Taxonomy) extend(skills []Skill) error {
+	for i := range skills {
+		s := skills[i]
+		key := Key(s.Name)
+		if key == "" {
+			return fmt.Errorf("skill %d has no name", i)
+		}
+		if old, ok := t.skills[key]; ok {
+			for _, a := range old.Aliases {
+				delete(t.index, Key(a))
+			}
+		}
+		if owner, ok := t.index[key]; ok && Key(owner) != key {
+			return fmt.Errorf("skill %q is already an alias of %q", s.Name, owner)
+		}
+
+		t.skills[key] = &s
+		t.index[key] = s.Name
+		for _, a := range s.Aliases {
+			ak := Key(a)
+			if owner, ok := t.index[ak]; ok && Key(owner) != key {
+				return fmt.Errorf("alias %q of %q is already used by %q", a, s.Name, owner)
+			}
+			t.index[ak] = s.Name
+		}
+	}
+
+	for _, s := range t.skills {
+		if s.Parent != "" && t.skills[Key(s.Parent)] == nil {
+			return fmt.Errorf("skill %q has unknown parent %q", s.Name, s.Parent)
+		}
+	}
+	return nil
+}
+
+func (t *Taxonomy) extendFromFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var skills []Skill
+	if err := json.Unmarshal(data, &skills); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return t.Extend(skills...)
+}
+
+// Lookup finds the skill a term names, by canonical name or alias.
+func (t *Taxonomy) Lookup(term string) (Skill, bool) {
+	name, ok := t.index[Key(term)]
+	if !ok {
+		return Skill{}, false
+	}
+	return *t.skills[Key(name)], true
+}
+
+// Canonical returns the canonical name for term, or term itself, trimmed,
+// if the taxonomy doesn't know it.
+func (t *Taxonomy) Canonical(term string) string {
+	if name, ok := t.index[Key(term)]; ok {
+		return name
+	}
+	return strings.TrimSpace(term)
+}
+
+// Category returns the category of the skill term names, or "".
+func (t *Taxonomy) Category(term string) string {
+	s, ok := t.Lookup(term)
+	if !ok {
+		return ""
+	}
+	return s.Category
+}
+
+// Ancestors returns the canonical names of the skill's parent, grandparent
+// and so on, nearest first.
+func (t *Taxonomy) Ancestors(term string) []string {
+	var out []string
+	seen := map[string]bool{}
+	s, ok := t.Lookup(term)
+	for ok && s.Parent != "" && !seen[Key(s.Parent)] {
+		seen[Key(s.Parent)] = true
+		out = append(out, t.Canonical(s.Parent))
+		s, ok = t.Lookup(s.Parent)
+	}
+	return out
+}
+
+// Descendants returns the canonical names of every skill under term, in
+// alphabetical order.
+func (t *Taxonomy) Descendants(term string) []string {
+	root := Key(t.Canonical(term))
+	var out []string
+	for _, s := range t.skills {
+		for _, a := range t.Ancestors(s.Name) {
+			if Key(a) == root {
+				out = append(out, s.Name)
+				break
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// NormalizeList canonicalizes each term and drops blanks and duplicates,
+// keeping the first occurrence's position.
+func (t *Taxonomy) NormalizeList(terms []string) []string {
+	if terms == nil {
+		return nil
+	}
+	out := make([]string, 0, len(terms))
+	seen := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		name := t.Canonical(term)
+		key := Key(name)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, name)
+	}
+	return out
+}
+
+// CanonicalText rewrites free text word by word, replacing every known
+// alias, including multi-word ones, with its canonical name. The result is
+// lowercased and stripped of punctuation that isn't part of a skill name, so
+// it is meant for matching rather than display.
+func (t *Taxonomy) CanonicalText(text string) string {
+	ws := Words(text)
+	out := make([]string, 0, len(ws))
+	for i := 0; i < len(ws); {
+		n := min(maxPhraseWords, len(ws)-i)
+		for ; n > 0; n-- {
+			if name, ok := t.index[strings.Join(ws[i:i+n], " ")]; ok {
+				out = append(out, Key(name))
+				break
+			}
+		}
+		if n == 0 {
+			out = append(out, ws[i])
+			n = 1
+		}
+		i += n
+	}
+	return strings.Join(out, " ")
+}
+
+// Key is the form names and aliases are compared in: lowercase, single
+// spaced, without surrounding punctuation.
+func Key(term string) string {
+	return strings.Join(Words(term), " ")
+}
+
+// Words lowercases s and splits it into words, keeping the symbols that are
+// part of skill names (C++, C#, Node.js, CI/CD, .NET).
+func Words(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("+#./-", r)
+	})
+	out := fields[:0]
+	for _, f := range fields {
+		f = strings.TrimRight(f, "./-")
+		if f != ".net" {
+			f = strings.TrimLeft(f, "./-")
+		}
+		if f != "" {
+			out = append(out, f)
+		}
+	}
+	return out
+}
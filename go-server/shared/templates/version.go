@@ -0,0 +1,22 @@
+package templates
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/ordo_meritum/shared/templates/instructions"
+	"github.com/ordo_meritum/shared/templates/prompts"
+)
+
+// Version fingerprints the prompt and instruction templates stored under
+// file, so generated content can be traced back to the exact wording that
+// produced it. Missing templates hash as empty.
+func Version(file string) string {
+	h := sha256.New()
+	prompt, _ := prompts.Prompts.ReadFile(file)
+	instruction, _ := instructions.Instructions.ReadFile(file)
+	h.Write(prompt)
+	h.Write([]byte{0})
+	h.Write(instruction)
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
@@ -41,6 +41,14 @@ var (
 
 	ERR_INVALID_REQUEST_FORMAT = "ERR_INVALID_REQUEST_FORMAT"
 	ERR_INVALID_SCHEMA         = "ERR_INVALID_SCHEMA"
+
+	ERR_KAFKA_FAILED_TO_PUBLISH = "ERR_KAFKA_FAILED_TO_PUBLISH"
+	ERR_COMPILATION_FAILED      = "ERR_COMPILATION_FAILED"
+	ERR_COMPILATION_TIMEOUT     = "ERR_COMPILATION_TIMEOUT"
+
+	ERR_QUEUE_FULL         = "ERR_QUEUE_FULL"
+	ERR_WORKER_INTERRUPTED = "ERR_WORKER_INTERRUPTED"
+	ERR_STORAGE_FAILED     = "ERR_STORAGE_FAILED"
 )
 
 var (
@@ -116,6 +124,20 @@ func ErrorMessage(msg string) error {
 	case ERR_INVALID_SCHEMA:
 		return fmt.Errorf("invalid schema")
 
+	case ERR_KAFKA_FAILED_TO_PUBLISH:
+		return fmt.Errorf("failed to queue document for compilation")
+	case ERR_COMPILATION_FAILED:
+		return fmt.Errorf("document failed to compile")
+	case ERR_COMPILATION_TIMEOUT:
+		return fmt.Errorf("the compiler did not return the document in time")
+
+	case ERR_QUEUE_FULL:
+		return fmt.Errorf("too many requests are waiting, try again later")
+	case ERR_WORKER_INTERRUPTED:
+		return fmt.Errorf("the server stopped before the request finished")
+	case ERR_STORAGE_FAILED:
+		return fmt.Errorf("failed to store the compiled document")
+
 	default:
 		return fmt.Errorf("unknown error")
 	}
@@ -0,0 +1,26 @@
+package formatters
+
+import (
+	"context"
+
+	"github.com/ordo_meritum/database/resumes"
+)
+
+// ResumeForPrompt returns what a prompt should see of the user's background
+// for a role: the resume tailored for it, or, when none has been written
+// yet, whatever fallback returns, usually the user's profile.
+func ResumeForPrompt(
+	ctx context.Context,
+	repo resumes.Repository,
+	roleID int,
+	fallback func() (string, error),
+) (string, error) {
+	resume, err := repo.GetFullResume(ctx, roleID)
+	if err != nil {
+		return "", err
+	}
+	if len(resume.Experiences) > 0 || len(resume.Projects) > 0 || len(resume.Skills) > 0 {
+		return resume.FormatForLLM(), nil
+	}
+	return fallback()
+}
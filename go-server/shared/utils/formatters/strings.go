@@ -3,6 +3,7 @@ package formatters
 import (
 	"regexp"
 	"strings"
+	"unicode"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -49,3 +50,29 @@ func ToSnakeCase(s string) string {
 
 	return snakeCase
 }
+
+// FitToLimit trims text that runs over its character limit back to the
+// last complete sentence that fits, or the last whole word if no sentence
+// does.
+func FitToLimit(text string, limit int) string {
+	text = strings.TrimSpace(text)
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+
+	cut := runes[:limit]
+	for i := len(cut) - 1; i > 0; i-- {
+		if strings.ContainsRune(".!?", cut[i]) && unicode.IsSpace(runes[i+1]) {
+			return string(cut[:i+1])
+		}
+	}
+	for i := len(cut) - 1; i > 0; i-- {
+		if unicode.IsSpace(cut[i]) {
+			return strings.TrimRightFunc(string(cut[:i]), func(r rune) bool {
+				return unicode.IsSpace(r) || unicode.IsPunct(r) || r == '|'
+			})
+		}
+	}
+	return string(cut)
+}
@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// maxObjectBytes caps what Put will buffer to sign an upload.
+const maxObjectBytes = 50 << 20
+
+type S3Config struct {
+	// Endpoint is the service's base URL, e.g. http://minio:9000.
+	Endpoint        string
+	Bucket          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// VirtualHosted addresses the bucket as a subdomain of the endpoint.
+	// Off by default, since MinIO and most stand-ins expect the bucket in
+	// the path.
+	VirtualHosted bool
+}
+
+// S3Store keeps documents in an S3-compatible bucket. It speaks the REST API
+// directly, signing each request with SigV4.
+type S3Store struct {
+	cfg      S3Config
+	endpoint *url.URL
+	creds    aws.Credentials
+	signer   *v4.Signer
+	client   *http.Client
+}
+
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("S3_ENDPOINT and S3_BUCKET are required for s3 storage")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3_ENDPOINT %q", cfg.Endpoint)
+	}
+
+	return &S3Store{
+		cfg:      cfg,
+		endpoint: endpoint,
+		creds: aws.Credentials{
+			AccessKeyID:     cfg.AccessKeyID,
+			SecretAccessKey: cfg.SecretAccessKey,
+		},
+		signer: v4.NewSigner(),
+		client: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	data, err := io.ReadAll(io.LimitReader(body, maxObjectBytes+1))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	if len(data) > maxObjectBytes {
+		return fmt.Errorf("%s is larger than %d bytes", key, maxObjectBytes)
+	}
+
+	req, err := s.request(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	res, err := s.do(req, data)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return s.responseError(res, key)
+	}
+	return nil
+}
+
+func (s *S3Store) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.request(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.do(req, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch res.StatusCode {
+	case http.StatusOK:
+		return res.Body, nil
+	case http.StatusNotFound:
+		res.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer res.Body.Close()
+		return nil, s.responseError(res, key)
+	}
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := s.request(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	res, err := s.do(req, nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotFound {
+		return s.responseError(res, key)
+	}
+	return nil
+}
+
+func (s *S3Store) request(ctx context.Context, method, key string, body []byte) (*http.Request, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	u := *s.endpoint
+	if s.cfg.VirtualHosted {
+		u.Host = s.cfg.Bucket + "." + u.Host
+		u.Path = u.Path + "/" + key
+	} else {
+		u.Path = u.Path + "/" + s.cfg.Bucket + "/" + key
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for %s: %w", key, err)
+	}
+	return req, nil
+}
+
+// do signs and sends req. S3 wants the payload's hash in a header as well
+// as in the signature.
+func (s *S3Store) do(req *http.Request, body []byte) (*http.Response, error) {
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	err := s.signer.SignHTTP(req.Context(), s.creds, req, payloadHash, "s3", s.cfg.Region, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign storage request: %w", err)
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("storage request failed: %w", err)
+	}
+	return res, nil
+}
+
+func (s *S3Store) responseError(res *http.Response, key string) error {
+	detail, _ := io.ReadAll(io.LimitReader(res.Body, 1<<10))
+	return fmt.Errorf("storage returned %s for %s: %s", res.Status, key, strings.TrimSpace(string(detail)))
+}
+
+var _ Store = (*S3Store)(nil)
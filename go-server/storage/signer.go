@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// downloadPath is where signed URLs are served from.
+const downloadPath = "/api/auth/downloads"
+
+var (
+	ErrURLExpired       = errors.New("download link has expired")
+	ErrInvalidSignature = errors.New("download link is not valid")
+)
+
+// URLSigner issues and checks expiring download links. A link is bound to
+// both the document key and the user it was issued to, so it can't be used
+// from another account or for another document.
+type URLSigner struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewURLSigner(secret []byte, ttl time.Duration) *URLSigner {
+	return &URLSigner{secret: secret, ttl: ttl}
+}
+
+// NewURLSignerFromEnv reads DOWNLOAD_URL_SECRET and DOWNLOAD_URL_TTL. Without
+// a secret a random one is used, so links stop working on restart and can't
+// be shared between instances.
+func NewURLSignerFromEnv() (*URLSigner, error) {
+	secret := []byte(os.Getenv("DOWNLOAD_URL_SECRET"))
+	if len(secret) == 0 {
+		log.Warn().Str("service", serviceName).Msg("DOWNLOAD_URL_SECRET is not set, using a random secret")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate download secret: %w", err)
+		}
+	}
+
+	ttl := 15 * time.Minute
+	if v := os.Getenv("DOWNLOAD_URL_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid DOWNLOAD_URL_TTL %q", v)
+		}
+		ttl = d
+	}
+	return NewURLSigner(secret, ttl), nil
+}
+
+// URL returns a link to key for uid, valid until the signer's TTL passes.
+func (s *URLSigner) URL(uid, key string) string {
+	expires := time.Now().Add(s.ttl).Unix()
+	q := url.Values{}
+	q.Set("key", key)
+	q.Set("expires", strconv.FormatInt(expires, 10))
+	q.Set("sig", s.sign(uid, key, expires))
+	return downloadPath + "?" + q.Encode()
+}
+
+// Verify checks a link's signature and expiry for uid, and that uid owns
+// the document.
+func (s *URLSigner) Verify(uid, key, expires, sig string) error {
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	want, err := hex.DecodeString(s.sign(uid, key, exp))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, want) {
+		return ErrInvalidSignature
+	}
+	if owner, ok := KeyOwner(key); !ok || owner != uid {
+		return ErrInvalidSignature
+	}
+	if time.Now().Unix() > exp {
+		return ErrURLExpired
+	}
+	return nil
+}
+
+func (s *URLSigner) sign(uid, key string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s\n%s\n%d", uid, key, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const serviceName = "storage"
+
+var (
+	ErrNotFound   = errors.New("stored object not found")
+	ErrInvalidKey = errors.New("invalid storage key")
+)
+
+// Store keeps generated documents. Keys are slash separated and relative,
+// as built by DocumentKey.
+type Store interface {
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	// Open returns ErrNotFound when nothing is stored under key.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// documentsPrefix is the top level every document key lives under.
+const documentsPrefix = "documents"
+
+// DocumentKey is where a generation's file is stored:
+// documents/<uid>/<generation id>/<name>.
+func DocumentKey(uid string, generationID int, name string) string {
+	return path.Join(documentsPrefix, uid, fmt.Sprint(generationID), name)
+}
+
+// KeyOwner returns the UID a document key belongs to. It reports false for
+// anything that isn't a well-formed document key, which includes file paths
+// recorded before documents were stored.
+func KeyOwner(key string) (string, bool) {
+	if validateKey(key) != nil {
+		return "", false
+	}
+	parts := strings.Split(key, "/")
+	if len(parts) != 4 || parts[0] != documentsPrefix {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// validateKey rejects keys that could escape the store's root.
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) || path.Clean(key) != key {
+		return ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == ".." || part == "." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// NewStore picks the backend from STORAGE_BACKEND: "local" (the default)
+// keeps files under STORAGE_DIR, "s3" uses an S3-compatible bucket such as
+// MinIO.
+func NewStore() (Store, error) {
+	switch backend := os.Getenv("STORAGE_BACKEND"); backend {
+	case "", "local":
+		dir := os.Getenv("STORAGE_DIR")
+		if dir == "" {
+			dir = "./documents"
+		}
+		log.Info().Str("service", serviceName).Str("dir", dir).Msg("Using local document storage")
+		return NewLocalStore(dir)
+	case "s3":
+		cfg := S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			Region:          os.Getenv("S3_REGION"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			VirtualHosted:   os.Getenv("S3_VIRTUAL_HOSTED") == "true",
+		}
+		log.Info().Str("service", serviceName).Str("endpoint", cfg.Endpoint).Str("bucket", cfg.Bucket).Msg("Using S3 document storage")
+		return NewS3Store(cfg)
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_BACKEND %q", backend)
+	}
+}
@@ -2,18 +2,18 @@ package web
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"io"
-	"mime/multipart"
+	"mime"
 	"net/http"
-	"net/textproto"
-	"os"
-	"path/filepath"
-	"strings"
+	"path"
 
 	"github.com/gorilla/websocket"
 	"github.com/ordo_meritum/config"
+	"github.com/ordo_meritum/shared/contexts"
 	"github.com/ordo_meritum/shared/middleware"
+	error_response "github.com/ordo_meritum/shared/types/errors"
+	"github.com/ordo_meritum/storage"
 	ordows "github.com/ordo_meritum/websocket"
 	"github.com/rs/zerolog/log"
 )
@@ -62,55 +62,63 @@ func ServeWs(
 	go client.ReadPump()
 }
 
-type DownloadRequest struct {
-	DownloadURL string `json:"download_url"`
-	ChangesURL  string `json:"changes_url"`
-}
-
-func HandleDownload(
-	w http.ResponseWriter,
-	r *http.Request,
-) {
-	var req DownloadRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
-		return
+// HandleDownload serves a stored document through a signed link issued by
+// storage.URLSigner. The link must have been issued to the caller and must
+// not have expired.
+func HandleDownload(store storage.Store, signer *storage.URLSigner) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+
+		userCtx, ok := contexts.FromContext(r.Context())
+		if !ok {
+			middleware.JSON(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		q := r.URL.Query()
+		key := q.Get("key")
+		err := signer.Verify(userCtx.UID, key, q.Get("expires"), q.Get("sig"))
+		switch {
+		case errors.Is(err, storage.ErrURLExpired):
+			middleware.JSON(w, http.StatusGone, error_response.ErrorResponse[struct{}]{
+				ErrorCode: error_response.RESOURCE_UNAVAILABLE,
+				Message:   err.Error(),
+			})
+			return
+		case err != nil:
+			middleware.JSON(w, http.StatusForbidden, error_response.ErrorResponse[struct{}]{
+				ErrorCode: error_response.UNAUTHORIZED_USER,
+				Message:   err.Error(),
+			})
+			return
+		}
+
+		file, err := store.Open(r.Context(), key)
+		if errors.Is(err, storage.ErrNotFound) {
+			middleware.JSON(w, http.StatusNotFound, error_response.ErrorResponse[struct{}]{
+				ErrorCode: error_response.RESOURCE_UNAVAILABLE,
+				Message:   err.Error(),
+			})
+			return
+		}
+		if err != nil {
+			log.Error().Err(err).Str("key", key).Msg("Failed to open stored document")
+			middleware.JSON(w, http.StatusInternalServerError, nil)
+			return
+		}
+		defer file.Close()
+
+		name := path.Base(key)
+		contentType := mime.TypeByExtension(path.Ext(name))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", `attachment; filename="`+name+`"`)
+		w.Header().Set("Cache-Control", "private, no-store")
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.Copy(w, file); err != nil {
+			log.Error().Err(err).Str("key", key).Msg("Failed to send stored document")
+		}
 	}
-
-	pdfPath := strings.TrimPrefix(req.DownloadURL, "http://localhost:8080/")
-	jsonPath := strings.TrimPrefix(req.ChangesURL, "http://localhost:8080/")
-
-	pdfPath = filepath.Join("./shared_pdfs", filepath.Base(pdfPath))
-	jsonPath = filepath.Join("./shared_pdfs", filepath.Base(jsonPath))
-
-	pdfFile, err := os.Open(req.DownloadURL)
-	if err != nil {
-		http.Error(w, "Failed to open PDF file", http.StatusNotFound)
-		return
-	}
-	defer pdfFile.Close()
-
-	jsonFile, err := os.Open(req.ChangesURL)
-	if err != nil {
-		http.Error(w, "Failed to open JSON file", http.StatusNotFound)
-	}
-	defer jsonFile.Close()
-
-	mw := multipart.NewWriter(w)
-	w.Header().Set("Content-Disposition", "attachment; filename="+mw.Boundary())
-	w.WriteHeader(http.StatusOK)
-
-	pdfHeader := textproto.MIMEHeader{}
-	pdfHeader.Set("Content-Disposition", `attachment; filename="`+filepath.Base(pdfPath)+`"`)
-	pdfHeader.Set("Content-Type", "application/pdf")
-	pdfPart, _ := mw.CreatePart(pdfHeader)
-	io.Copy(pdfPart, pdfFile)
-
-	jsonHeader := textproto.MIMEHeader{}
-	jsonHeader.Set("Content-Disposition", `attachment; filename="`+filepath.Base(jsonPath)+`"`)
-	jsonHeader.Set("Content-Type", "application/json")
-	jsonPart, _ := mw.CreatePart(jsonHeader)
-	io.Copy(jsonPart, jsonFile)
-
-	mw.Close()
 }
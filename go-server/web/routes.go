@@ -4,23 +4,32 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	answer_controllers "github.com/ordo_meritum/features/application_answers/controllers"
 	apptracking_controllers "github.com/ordo_meritum/features/application_tracking/controllers"
 	auth_controllers "github.com/ordo_meritum/features/auth/controllers"
 	user_controllers "github.com/ordo_meritum/features/candidate_forms/controllers"
 	doc_controllers "github.com/ordo_meritum/features/documents/controllers"
+	email_controllers "github.com/ordo_meritum/features/emails/controllers"
 	jobguide_controllers "github.com/ordo_meritum/features/job_guide/controllers"
+	profile_controllers "github.com/ordo_meritum/features/profiles/controllers"
+	public_profile_controllers "github.com/ordo_meritum/features/public_profiles/controllers"
 	"github.com/ordo_meritum/security"
+	"github.com/ordo_meritum/storage"
 	"github.com/ordo_meritum/websocket"
 	"github.com/rs/zerolog/log"
 )
 
 type RouteDependencies struct {
-	AuthController       *auth_controllers.Controller
-	UserController       *user_controllers.Controller
-	AppTrackerController *apptracking_controllers.Controller
-	DocController        *doc_controllers.Controller
-	JobGuideController   *jobguide_controllers.Controller
-	WebSocketHub         *websocket.Hub
+	AuthController          *auth_controllers.Controller
+	UserController          *user_controllers.Controller
+	AppTrackerController    *apptracking_controllers.Controller
+	DocController           *doc_controllers.Controller
+	JobGuideController      *jobguide_controllers.Controller
+	ProfileController       *profile_controllers.Controller
+	AnswerController        *answer_controllers.Controller
+	EmailController         *email_controllers.Controller
+	PublicProfileController *public_profile_controllers.Controller
+	WebSocketHub            *websocket.Hub
 }
 
 func NewRouteDependencies(
@@ -29,15 +38,23 @@ func NewRouteDependencies(
 	appTrackerController *apptracking_controllers.Controller,
 	docController *doc_controllers.Controller,
 	jobGuideController *jobguide_controllers.Controller,
+	profileController *profile_controllers.Controller,
+	answerController *answer_controllers.Controller,
+	emailController *email_controllers.Controller,
+	publicProfileController *public_profile_controllers.Controller,
 	hub *websocket.Hub,
 ) *RouteDependencies {
 	return &RouteDependencies{
-		AuthController:       authController,
-		UserController:       userController,
-		AppTrackerController: appTrackerController,
-		DocController:        docController,
-		JobGuideController:   jobGuideController,
-		WebSocketHub:         hub,
+		AuthController:          authController,
+		UserController:          userController,
+		AppTrackerController:    appTrackerController,
+		DocController:           docController,
+		JobGuideController:      jobGuideController,
+		ProfileController:       profileController,
+		AnswerController:        answerController,
+		EmailController:         emailController,
+		PublicProfileController: publicProfileController,
+		WebSocketHub:            hub,
 	}
 }
 
@@ -47,6 +64,8 @@ func RegisterRoutes(
 	secureRouter *SecureRouter,
 	deps *RouteDependencies,
 	hub *websocket.Hub,
+	store storage.Store,
+	signer *storage.URLSigner,
 ) {
 	log.Info().Str("service", "startup").Msg("Registering feature routes")
 
@@ -55,13 +74,20 @@ func RegisterRoutes(
 	})
 	mainRouter.Handle("/ws", wsHandler)
 
-	authenticatedRouter.HandleFunc("/downloads", HandleDownload).Methods("POST")
+	authenticatedRouter.HandleFunc("/downloads", HandleDownload(store, signer)).Methods("GET")
 	mainRouter.HandleFunc("/public-key", security.GetPublicKeyHandler).Methods("GET")
 	mainRouter.HandleFunc("/public-key-stream", security.PublicKeyStreamHandler)
 
 	deps.AuthController.RegisterRoutes(authenticatedRouter.PathPrefix("/").Subrouter())
-	deps.UserController.RegisterRoutes(secureRouter.PathPrefix("/user").Subrouter())
+	deps.UserController.RegisterRoutes(
+		secureRouter.PathPrefix("/user").Subrouter(),
+		authenticatedRouter.PathPrefix("/user").Subrouter(),
+	)
 	deps.AppTrackerController.RegisterRoutes(secureRouter.Router, authenticatedRouter.Router)
-	deps.DocController.RegisterRoutes(secureRouter.Router)
+	deps.DocController.RegisterRoutes(secureRouter.Router, authenticatedRouter.Router)
 	deps.JobGuideController.RegisterRoutes(secureRouter.Router, authenticatedRouter.Router)
+	deps.ProfileController.RegisterRoutes(authenticatedRouter.Router)
+	deps.AnswerController.RegisterRoutes(secureRouter.Router, authenticatedRouter.Router)
+	deps.EmailController.RegisterRoutes(secureRouter.Router, authenticatedRouter.Router)
+	deps.PublicProfileController.RegisterRoutes(secureRouter.Router, authenticatedRouter.Router)
 }
@@ -21,11 +21,17 @@ type Client struct {
 	Send   chan []byte
 }
 
+type userMessage struct {
+	userID  string
+	message []byte
+}
+
 type Hub struct {
 	clients     map[*Client]bool
 	UserClients map[string]map[*Client]bool
 	register    chan *Client
 	unregister  chan *Client
+	send        chan userMessage
 }
 
 func NewHub() *Hub {
@@ -34,9 +40,17 @@ func NewHub() *Hub {
 		UserClients: make(map[string]map[*Client]bool),
 		register:    make(chan *Client),
 		unregister:  make(chan *Client),
+		send:        make(chan userMessage, 256),
 	}
 }
 
+// SendToUser queues message for every connection the user has open. It is
+// safe to call from any goroutine; the hub drops the message when the user
+// isn't connected.
+func (h *Hub) SendToUser(userID string, message []byte) {
+	h.send <- userMessage{userID: userID, message: message}
+}
+
 func (h *Hub) Register(client *Client) {
 	h.register <- client
 }
@@ -67,6 +81,24 @@ func (h *Hub) Run() {
 				close(client.Send)
 				log.Printf("Client unregistered for user %s", client.UserID)
 			}
+		case msg := <-h.send:
+			userClients := h.UserClients[msg.userID]
+			if len(userClients) == 0 {
+				log.Printf("No clients connected for user %s, dropping message", msg.userID)
+				continue
+			}
+			for client := range userClients {
+				select {
+				case client.Send <- msg.message:
+				default:
+					delete(h.clients, client)
+					delete(userClients, client)
+					close(client.Send)
+				}
+			}
+			if len(userClients) == 0 {
+				delete(h.UserClients, msg.userID)
+			}
 		}
 	}
 }
@@ -0,0 +1,248 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"go.uber.org/fx"
+)
+
+const serviceName = "worker-pool"
+
+var (
+	// ErrQueueFull is returned when the pool has no room for another task.
+	ErrQueueFull = errors.New("worker queue is full")
+	// ErrUserQueueFull is returned when one user already has as many tasks
+	// waiting as they're allowed.
+	ErrUserQueueFull = errors.New("too many queued tasks for user")
+	ErrPoolStopped   = errors.New("worker pool is stopped")
+)
+
+// Task is one unit of background work. Its context is cancelled when the
+// pool shuts down; a task dropped from the queue at shutdown is still called,
+// with an already cancelled context, so it can record that it never ran.
+type Task func(ctx context.Context)
+
+type Config struct {
+	// Workers is how many tasks run at once across all users.
+	Workers int
+	// PerUser is how many of one user's tasks run at once.
+	PerUser int
+	// MaxQueued caps the tasks waiting across all users.
+	MaxQueued int
+	// MaxQueuedPerUser caps the tasks one user can have waiting.
+	MaxQueuedPerUser int
+}
+
+type queuedTask struct {
+	ctx  context.Context
+	task Task
+}
+
+// Pool runs LLM-bound work in the background with a bounded number of
+// workers. Users are served round robin, so one user queueing many tasks
+// doesn't hold up everyone else.
+type Pool struct {
+	cfg Config
+
+	mu       sync.Mutex
+	cond     *sync.Cond
+	queues   map[string][]queuedTask
+	running  map[string]int
+	order    []string
+	queued   int
+	stopping bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(cfg Config) *Pool {
+	cfg.Workers = max(cfg.Workers, 1)
+	cfg.PerUser = max(cfg.PerUser, 1)
+	cfg.MaxQueued = max(cfg.MaxQueued, 1)
+	cfg.MaxQueuedPerUser = max(cfg.MaxQueuedPerUser, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool{
+		cfg:     cfg,
+		queues:  make(map[string][]queuedTask),
+		running: make(map[string]int),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	p.cond = sync.NewCond(&p.mu)
+	return p
+}
+
+// NewPool builds the shared pool from the environment and ties it to the
+// app lifecycle.
+func NewPool(lc fx.Lifecycle) *Pool {
+	p := New(Config{
+		Workers:          envInt("WORKER_POOL_SIZE", 4),
+		PerUser:          envInt("WORKER_POOL_PER_USER", 1),
+		MaxQueued:        envInt("WORKER_QUEUE_SIZE", 100),
+		MaxQueuedPerUser: envInt("WORKER_QUEUE_PER_USER", 10),
+	})
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			p.Start()
+			log.Info().
+				Str("service", serviceName).
+				Int("workers", p.cfg.Workers).
+				Int("perUser", p.cfg.PerUser).
+				Msg("Worker pool started")
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			log.Info().Str("service", serviceName).Msg("Stopping worker pool...")
+			return p.Stop(ctx)
+		},
+	})
+	return p
+}
+
+func (p *Pool) Start() {
+	for range p.cfg.Workers {
+		p.wg.Add(1)
+		go p.work()
+	}
+}
+
+// Submit queues task for userID. The task keeps ctx's values but not its
+// cancellation, so a request context can be passed straight through.
+func (p *Pool) Submit(ctx context.Context, userID string, task Task) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case p.stopping:
+		return ErrPoolStopped
+	case p.queued >= p.cfg.MaxQueued:
+		return ErrQueueFull
+	case len(p.queues[userID]) >= p.cfg.MaxQueuedPerUser:
+		return ErrUserQueueFull
+	}
+
+	if len(p.queues[userID]) == 0 {
+		p.order = append(p.order, userID)
+	}
+	p.queues[userID] = append(p.queues[userID], queuedTask{ctx: context.WithoutCancel(ctx), task: task})
+	p.queued++
+	p.cond.Signal()
+	return nil
+}
+
+// Stop stops taking tasks, calls every queued task with a cancelled context
+// and waits for running ones. Running tasks are cancelled if ctx expires
+// first.
+func (p *Pool) Stop(ctx context.Context) error {
+	p.mu.Lock()
+	p.stopping = true
+	dropped := p.queues
+	p.queues = make(map[string][]queuedTask)
+	p.order = nil
+	p.queued = 0
+	p.cond.Broadcast()
+	p.mu.Unlock()
+
+	for _, tasks := range dropped {
+		for _, t := range tasks {
+			taskCtx, cancel := context.WithCancel(t.ctx)
+			cancel()
+			p.run(taskCtx, t.task)
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		return ctx.Err()
+	}
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+	for {
+		userID, t, ok := p.next()
+		if !ok {
+			return
+		}
+
+		taskCtx, cancel := context.WithCancel(t.ctx)
+		stop := context.AfterFunc(p.ctx, cancel)
+		p.run(taskCtx, t.task)
+		stop()
+		cancel()
+
+		p.mu.Lock()
+		p.running[userID]--
+		if p.running[userID] == 0 {
+			delete(p.running, userID)
+		}
+		p.cond.Broadcast()
+		p.mu.Unlock()
+	}
+}
+
+// next blocks until a task may run. Users take turns: the first waiting user
+// below their concurrency limit goes next and then moves to the back.
+func (p *Pool) next() (string, queuedTask, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for {
+		if p.stopping {
+			return "", queuedTask{}, false
+		}
+		for i, userID := range p.order {
+			if p.running[userID] >= p.cfg.PerUser {
+				continue
+			}
+			queue := p.queues[userID]
+			t := queue[0]
+			p.order = append(p.order[:i:i], p.order[i+1:]...)
+			if len(queue) > 1 {
+				p.queues[userID] = queue[1:]
+				p.order = append(p.order, userID)
+			} else {
+				delete(p.queues, userID)
+			}
+			p.queued--
+			p.running[userID]++
+			return userID, t, true
+		}
+		p.cond.Wait()
+	}
+}
+
+func (p *Pool) run(ctx context.Context, task Task) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().Str("service", serviceName).Interface("panic", r).Msg("Worker task panicked")
+		}
+	}()
+	task(ctx)
+}
+
+func envInt(key string, fallback int) int {
+	n, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || n < 1 {
+		return fallback
+	}
+	return n
+}
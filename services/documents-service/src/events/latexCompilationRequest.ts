@@ -65,11 +65,51 @@ export const ProjectPayloadSchema = z.object({
   bulletPoints: z.array(BulletPointSchema),
 });
 
+export const CertificationPayloadSchema = z.object({
+  name: z.string(),
+  issuer: z.string().optional(),
+  date: z.string().optional(),
+  url: z.string().optional(),
+});
+
+export const PublicationPayloadSchema = z.object({
+  title: z.string(),
+  publisher: z.string().optional(),
+  date: z.string().optional(),
+  url: z.string().optional(),
+  summary: z.string().optional(),
+});
+
+export const VolunteeringPayloadSchema = z.object({
+  organization: z.string(),
+  role: z.string(),
+  start: z.string(),
+  end: z.string(),
+  bulletPoints: z.array(BulletPointSchema),
+});
+
+export const AwardPayloadSchema = z.object({
+  title: z.string(),
+  awarder: z.string().optional(),
+  date: z.string().optional(),
+  summary: z.string().optional(),
+});
+
+export const LanguagePayloadSchema = z.object({
+  language: z.string(),
+  fluency: z.string().optional(),
+});
+
 export const ResumePayloadSchema = z.object({
   summary: z.array(SummaryPayloadSchema),
   skills: z.array(SkillPayloadSchema),
   experiences: z.array(ExperiencePayloadSchema),
   projects: z.array(ProjectPayloadSchema).optional().nullable(),
+  certifications: z.array(CertificationPayloadSchema).optional().nullable(),
+  publications: z.array(PublicationPayloadSchema).optional().nullable(),
+  volunteering: z.array(VolunteeringPayloadSchema).optional().nullable(),
+  awards: z.array(AwardPayloadSchema).optional().nullable(),
+  languages: z.array(LanguagePayloadSchema).optional().nullable(),
 });
 
 export const CoverLetterBody = z.object({
@@ -83,21 +123,92 @@ export const CoverLetterPayloadSchema = z.object({
   companyProperName: z.string(),
   jobTitle: z.string(),
   body: CoverLetterBody,
+  structure: z.enum(["sections", "plain"]).optional(),
 })
 
 
 export const CompilationRequestSchema = z.object({
   jobID: z.number().int(),
+  generationID: z.number().int().optional(),
   userID: z.string(),
   companyName: z.string(),
   docType: z.string(),
   userInfo: UserInfoSchema,
   educationInfo: EducationPayloadSchema,
+  education: z.array(EducationPayloadSchema).optional().nullable(),
   resume: ResumePayloadSchema.optional().nullable(),
   coverLetter: CoverLetterPayloadSchema.optional().nullable(),
 });
 
+export const AppointmentPayloadSchema = z.object({
+  position: z.string(),
+  institution: z.string(),
+  dates: z.string(),
+  bulletPoints: z.array(z.string()),
+});
+
+export const PresentationPayloadSchema = z.object({
+  title: z.string(),
+  event: z.string(),
+  location: z.string().optional(),
+  date: z.string(),
+  invited: z.boolean().optional(),
+});
+
+export const TeachingPayloadSchema = z.object({
+  course: z.string(),
+  institution: z.string(),
+  role: z.string(),
+  dates: z.string(),
+  description: z.string().optional(),
+});
+
+export const CommitteePayloadSchema = z.object({
+  committee: z.string(),
+  role: z.string(),
+  dates: z.string(),
+});
+
+export const GrantPayloadSchema = z.object({
+  title: z.string(),
+  funder: z.string(),
+  amount: z.string().optional(),
+  role: z.string().optional(),
+  dates: z.string(),
+  description: z.string().optional(),
+});
+
+export const CVPayloadSchema = z.object({
+  researchInterests: z.string().optional(),
+  appointments: z.array(AppointmentPayloadSchema).nullable(),
+  publications: z.array(PublicationPayloadSchema).nullable(),
+  presentations: z.array(PresentationPayloadSchema).nullable(),
+  teaching: z.array(TeachingPayloadSchema).nullable(),
+  committees: z.array(CommitteePayloadSchema).nullable(),
+  grants: z.array(GrantPayloadSchema).nullable(),
+  awards: z.array(AwardPayloadSchema).nullable(),
+});
+
+// A CV isn't written for a company, so it has its own request shape.
+export const CVCompilationRequestSchema = z.object({
+  jobID: z.number().int(),
+  generationID: z.number().int().optional(),
+  userID: z.string(),
+  docType: z.literal("cv"),
+  userInfo: UserInfoSchema,
+  education: z.array(EducationPayloadSchema).nullable(),
+  cv: CVPayloadSchema,
+});
+
+export const DocumentCompilationRequestSchema = z.union([
+  CVCompilationRequestSchema,
+  CompilationRequestSchema,
+]);
+
 export type CompilationRequest = z.infer<typeof CompilationRequestSchema>;
+export type CVCompilationRequest = z.infer<typeof CVCompilationRequestSchema>;
+export type DocumentCompilationRequest = z.infer<typeof DocumentCompilationRequestSchema>;
+export type CVPayload = z.infer<typeof CVPayloadSchema>;
 export type UserInfo = z.infer<typeof UserInfoSchema>;
 export type EducationInfo = z.infer<typeof EducationPayloadSchema>;
 export type ResumePayload = z.infer<typeof ResumePayloadSchema>;
@@ -3,10 +3,13 @@ import { z } from "zod";
 export const CompilationResultSchema = z.object({
   user_id: z.string(),
   job_id: z.number().int(),
+  generation_id: z.number().int().optional(),
   success: z.boolean(),
   document_type: z.string().optional(),
   download_url: z.string().optional(),
   changes_url: z.string().optional(),
+  source_url: z.string().optional(),
+  template: z.string().optional(),
   error: z.string().optional(),
 });
 
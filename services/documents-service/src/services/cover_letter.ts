@@ -4,7 +4,7 @@ import * as latex from "@utils/latex/index.js";
 import * as schemas from "@events/index.js";
 
 import dotenv from "dotenv";
-import { exportLatex } from "./export.js";
+import { LATEX_TEMPLATE, exportLatex, exportLatexSource } from "./export.js";
 import { logger } from "@shared/utils/logger.js";
 import paths from "@shared/constants/paths.js";
 
@@ -38,7 +38,7 @@ export const compileCoverLetter = async (
     await docs.createHeader(
       docRequest.userID,
       docRequest.userInfo,
-      docRequest.educationInfo,
+      [docRequest.educationInfo],
       tempFolder,
       docRequest.docType,
       docRequest.coverLetter?.companyProperName,
@@ -47,7 +47,7 @@ export const compileCoverLetter = async (
 
     await latex.generateLatexSectionFile(
       "coverletter",
-      docRequest.coverLetter?.body,
+      { ...data.data.body, structure: data.data.structure },
       tempFolder
     );
 
@@ -59,14 +59,16 @@ export const compileCoverLetter = async (
       docRequest.docType
     );
 
-    const pdfPath = await exportLatex({
+    const exportOptions = {
       jobNameSuffix: "cover_letter",
       outputPath: tempPdf,
       compiledPdfPath: tempFolderCompiled,
       companyName: companyName,
       jobId: docRequest.jobID,
       docType: "coverletter",
-    });
+    };
+    const pdfPath = await exportLatex(exportOptions);
+    const sourcePath = await exportLatexSource(exportOptions);
 
     return {
       user_id: docRequest.userID,
@@ -75,6 +77,8 @@ export const compileCoverLetter = async (
       document_type: docRequest.docType,
       download_url: pdfPath,
       changes_url: jsonFile,
+      source_url: sourcePath,
+      template: LATEX_TEMPLATE,
     };
   } catch (error) {
     logger.error("Failed to compile cover letter: " + (error as Error).message);
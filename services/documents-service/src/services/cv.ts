@@ -0,0 +1,101 @@
+import * as docs from "@utils/documents/index.js";
+import * as fs from "fs";
+import * as latex from "@utils/latex/index.js";
+import * as schemas from "@events/index.js";
+
+import dotenv from "dotenv";
+import { LATEX_TEMPLATE, exportLatex, exportLatexSource } from "./export.js";
+import { logger } from "@shared/utils/logger.js";
+import paths from "@shared/constants/paths.js";
+
+dotenv.config();
+
+export const compileCV = async (
+  docRequest: schemas.CVCompilationRequest
+): Promise<schemas.CompilationResult> => {
+  const data = schemas.CVPayloadSchema.safeParse(docRequest.cv);
+  if (!data.success) {
+    logger.error("Malformed cv request", data.error);
+    return {
+      user_id: docRequest.userID,
+      job_id: docRequest.jobID,
+      success: false,
+      error: data.error.message,
+    };
+  }
+
+  try {
+    const { tempFolder, tempPdf, tempFolderCompiled, tempJson } =
+      await docs.initializeDocumentWorkspace(
+        docRequest.userID,
+        docRequest.docType
+      );
+    const fileName = docs.companyNameToFile(
+      `${docRequest.userInfo.first_name} ${docRequest.userInfo.last_name}`
+    );
+
+    fs.cpSync(paths.latex.originalTemplate, tempFolder, { recursive: true });
+
+    await docs.createHeader(
+      docRequest.userID,
+      docRequest.userInfo,
+      docRequest.education ?? [],
+      tempFolder,
+      "cv",
+    );
+
+    const sectionData = {
+      research: docRequest.cv.researchInterests,
+      appointments: docRequest.cv.appointments,
+      publications: docRequest.cv.publications,
+      presentations: docRequest.cv.presentations,
+      teaching: docRequest.cv.teaching,
+      grants: docRequest.cv.grants,
+      committees: docRequest.cv.committees,
+      awards: docRequest.cv.awards,
+    };
+    await Promise.all(
+      Object.entries(sectionData).map(([sectionName, sectionContent]) =>
+        latex.generateLatexSectionFile(sectionName, sectionContent, tempFolder)
+      )
+    );
+
+    const jsonFile = await docs.saveJson(
+      docRequest.cv,
+      fileName,
+      docRequest.jobID,
+      tempJson,
+      docRequest.docType
+    );
+
+    const exportOptions = {
+      jobNameSuffix: "cv",
+      outputPath: tempPdf,
+      compiledPdfPath: tempFolderCompiled,
+      companyName: fileName,
+      jobId: docRequest.jobID,
+      docType: "cv",
+    };
+    const pdfPath = await exportLatex(exportOptions);
+    const sourcePath = await exportLatexSource(exportOptions);
+
+    return {
+      user_id: docRequest.userID,
+      job_id: docRequest.jobID,
+      success: true,
+      document_type: docRequest.docType,
+      download_url: pdfPath,
+      changes_url: jsonFile,
+      source_url: sourcePath,
+      template: LATEX_TEMPLATE,
+    };
+  } catch (error) {
+    logger.error("Failed to compile cv: " + (error as Error).message);
+    return {
+      user_id: docRequest.userID,
+      job_id: docRequest.jobID,
+      success: false,
+      error: (error as Error).message,
+    };
+  }
+};
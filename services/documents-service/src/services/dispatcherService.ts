@@ -3,7 +3,7 @@ import * as schemas from "@events/index.js";
 import { docConfig } from "@utils/documents/index.js";
 
 export const generateIfNeeded = async (
-  docRequest: schemas.CompilationRequest
+  docRequest: schemas.DocumentCompilationRequest
 ): Promise<schemas.CompilationResult> => {
   type DocType = keyof typeof docConfig;
   const { generate } = docConfig[docRequest.docType as DocType];
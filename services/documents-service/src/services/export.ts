@@ -1,9 +1,16 @@
+import * as fs from "fs";
 import * as path from "path";
 
 import { forceSinglePagePDF } from "@shared/utils/documents/pdf/pdf.helpers.js";
 import { logger } from "@shared/utils/logger.js";
 import { spawn } from "child_process";
 import { validatePath } from "@shared/utils/documents/file.helpers.js";
+import paths from "@shared/constants/paths.js";
+
+/** The LaTeX template every document is compiled from. */
+export const LATEX_TEMPLATE = path.basename(paths.latex.class, ".cls");
+
+const INPUT_PATTERN = /\\input\{([^}]+)\}/g;
 
 export const exportLatex = async ({
   jobNameSuffix,
@@ -42,6 +49,50 @@ export const exportLatex = async ({
   return pdfPath;
 };
 
+/**
+ * Writes a standalone copy of a compiled document's LaTeX source next to its
+ * PDF, with the section files it \input from the workspace inlined, so the
+ * source still builds once the workspace is reused. Returns undefined when
+ * the source can't be written; the PDF is still usable without it.
+ */
+export const exportLatexSource = async ({
+  jobNameSuffix,
+  outputPath,
+  compiledPdfPath,
+  companyName,
+  jobId,
+  docType,
+}: {
+  jobNameSuffix: string;
+  outputPath: string;
+  compiledPdfPath: string;
+  companyName: string;
+  jobId: number;
+  docType: string;
+}): Promise<string | undefined> => {
+  const latexFilePath = `${compiledPdfPath}/${docType}.tex`;
+  const sourcePath = path.join(
+    outputPath,
+    `${companyName}_${jobNameSuffix}_${jobId}.tex`
+  );
+
+  try {
+    const main = await fs.promises.readFile(latexFilePath, "utf8");
+    const inlined = main.replace(INPUT_PATTERN, (input, file: string) => {
+      const inputPath = file.endsWith(".tex") ? file : `${file}.tex`;
+      if (!path.isAbsolute(inputPath) || !fs.existsSync(inputPath)) {
+        return input;
+      }
+      return fs.readFileSync(inputPath, "utf8");
+    });
+    await fs.promises.writeFile(sourcePath, inlined);
+    return sourcePath;
+  } catch (error) {
+    logger.error("Failed to export LaTeX source: " + (error as Error).message);
+    return undefined;
+  }
+};
+
 const executeLatex = (
   companyName: string,
   jobNameSuffix: string,
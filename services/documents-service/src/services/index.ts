@@ -1,3 +1,4 @@
 export * from "./resume.js";
 export * from "./cover_letter.js";
+export * from "./cv.js";
 export * from "./dispatcherService.js";
@@ -4,7 +4,7 @@ import * as latex from "@utils/latex/index.js";
 import * as schemas from "@events/index.js";
 
 import dotenv from "dotenv";
-import { exportLatex } from "./export.js";
+import { LATEX_TEMPLATE, exportLatex, exportLatexSource } from "./export.js";
 import { logger } from "@shared/utils/logger.js";
 import paths from "@shared/constants/paths.js";
 
@@ -37,7 +37,9 @@ export const compileResume = async (
     await docs.createHeader(
       docRequest.userID,
       docRequest.userInfo,
-      docRequest.educationInfo,
+      docRequest.education?.length
+        ? docRequest.education
+        : [docRequest.educationInfo],
       tempFolder,
       "resume",
     );
@@ -47,6 +49,11 @@ export const compileResume = async (
       "skills",
       "projects",
       "summary",
+      "volunteering",
+      "certifications",
+      "publications",
+      "awards",
+      "languages",
     ] as const;
     await Promise.all(
       sectionNames.map((sectionName) =>
@@ -66,14 +73,16 @@ export const compileResume = async (
       docRequest.docType
     );
 
-    const pdfPath = await exportLatex({
+    const exportOptions = {
       jobNameSuffix: "resume",
       outputPath: tempPdf,
       compiledPdfPath: tempFolderCompiled,
       companyName: companyName,
       jobId: docRequest.jobID,
       docType: "resume",
-    });
+    };
+    const pdfPath = await exportLatex(exportOptions);
+    const sourcePath = await exportLatexSource(exportOptions);
 
     return {
       user_id: docRequest.userID,
@@ -82,6 +91,8 @@ export const compileResume = async (
       document_type: docRequest.docType,
       download_url: pdfPath,
       changes_url: jsonFile,
+      source_url: sourcePath,
+      template: LATEX_TEMPLATE,
     };
   } catch (error) {
     logger.error("Failed to compile resume: " + (error as Error).message);
@@ -3,7 +3,7 @@ import * as schemas from '@events/index.js';
 
 import paths from '@shared/constants/paths.js';
 
-export type DocType = 'resume' | 'cover-letter';
+export type DocType = 'resume' | 'cover-letter' | 'cv';
 
 export type ContentType = 'application/pdf' | 'text/plain';
 
@@ -11,7 +11,7 @@ interface DocConfig {
   pathFn: (uid: string, jobId: number) => string;
   jsonPathFn: (uid: string, docType: string) => string;
   generate: (
-    docRequest: schemas.CompilationRequest
+    docRequest: schemas.DocumentCompilationRequest
   ) => Promise<schemas.CompilationResult>;
   filename: (company: string, jobId: number) => string;
   contentType: ContentType;
@@ -21,15 +21,25 @@ export const docConfig: Record<DocType, DocConfig> = {
   resume: {
     pathFn: paths.paths.tempPdf,
     jsonPathFn: paths.paths.tempJson,
-    generate: async (docRequest) => compileDoc.compileResume(docRequest),
+    generate: async (docRequest) =>
+      compileDoc.compileResume(docRequest as schemas.CompilationRequest),
     filename: (company, jobId) => `${company}_resume_${jobId}.pdf`,
     contentType: 'application/pdf' as const,
   },
   'cover-letter': {
     pathFn: paths.paths.tempPdf,
     jsonPathFn: paths.paths.tempJson,
-    generate: async (docRequest) => compileDoc.compileCoverLetter(docRequest),
+    generate: async (docRequest) =>
+      compileDoc.compileCoverLetter(docRequest as schemas.CompilationRequest),
     filename: (company, uid) => `${company}_cover_letter_${uid}.pdf`,
     contentType: 'application/pdf',
+  },
+  cv: {
+    pathFn: paths.paths.tempPdf,
+    jsonPathFn: paths.paths.tempJson,
+    generate: async (docRequest) =>
+      compileDoc.compileCV(docRequest as schemas.CVCompilationRequest),
+    filename: (name, jobId) => `${name}_cv_${jobId}.pdf`,
+    contentType: 'application/pdf',
   }
 };
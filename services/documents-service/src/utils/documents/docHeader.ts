@@ -3,12 +3,13 @@ import * as path from "path";
 import * as schemas from "@events/index.js";
 
 import { formatTextForLatex } from "../latex/latex_formatters.js";
+import { replaceSectionContent } from "../latex/latex_sections.js";
 
 /**
  * Creates the header files for a document.
  * @param uid The user ID.
  * @param userInfo The user information.
- * @param education The education information.
+ * @param education The education entries, one per school.
  * @param tempFolder The temporary folder to write files to.
  * @param docType The document type to generate.
  * @param companyName The company name to generate the cover letter for.
@@ -18,7 +19,7 @@ import { formatTextForLatex } from "../latex/latex_formatters.js";
 export const createHeader = async (
   uid: string,
   userInfo: schemas.UserInfo,
-  education: schemas.EducationInfo,
+  education: schemas.EducationInfo[],
   tempFolder: string,
   docType?: string,
   companyName?: string,
@@ -31,7 +32,7 @@ export const createHeader = async (
     newClassFile
   );
 
-  if (docType !== "resume") {
+  if (docType !== "resume" && docType !== "cv") {
     const coverLetterInfoTemplate = path.join(
       tempFolder,
       "templates",
@@ -61,7 +62,7 @@ export const createHeader = async (
   const userInfoTemplate = path.join(
     tempFolder,
     "templates",
-    "resume-template.tex"
+    `${docType}-template.tex`
   );
   const educationTemplate = path.join(
     tempFolder,
@@ -74,10 +75,15 @@ export const createHeader = async (
   const resumeInfoWithVariables = replaceVariables(resumeWithPaths, userInfo);
 
   const educationInfo = fs.readFileSync(educationTemplate, "utf-8");
-  const educationInfoWithVariables = replaceVariables(educationInfo, education);
+  const educationEntry = environmentBody(educationInfo, "cventries");
+  const educationInfoWithVariables = replaceSectionContent(
+    educationInfo,
+    education.map((school) => replaceVariables(educationEntry, school)),
+    "cventries"
+  );
 
   await fs.promises.writeFile(
-    path.join(tempFolder, "compiled", "resume.tex"),
+    path.join(tempFolder, "compiled", `${docType}.tex`),
     resumeInfoWithVariables
   );
   await fs.promises.writeFile(
@@ -102,3 +108,14 @@ const replaceVariables = (
   }
   return result;
 };
+
+/**
+ * Returns the text between \begin{env} and \end{env}, which the education
+ * template uses as the entry repeated for each school.
+ */
+const environmentBody = (template: string, env: string): string => {
+  const start = template.indexOf(`\\begin{${env}}`);
+  const end = template.indexOf(`\\end{${env}}`);
+  if (start === -1 || end === -1) return "";
+  return template.slice(start + `\\begin{${env}}`.length, end).trim();
+};
@@ -19,19 +19,26 @@ export const generateLatexSectionFile = async (
   data: any,
   tempFolder: string
 ) => {
-  if (!data) return;
-
-  const latexTemplatePath = path.join(
-    tempFolder,
-    "templates",
-    `${sectionName}-template.tex`
-  );
   const compiledLatexPath = path.join(
     tempFolder,
     "compiled",
     `${sectionName}.tex`
   );
 
+  // Optional sections are still \input by the resume template, so an empty
+  // file stands in for them (and clears one left over from a previous run).
+  if (!data || (Array.isArray(data) && data.length === 0)) {
+    if (sectionName !== "coverletter") {
+      await fs.promises.writeFile(compiledLatexPath, "");
+    }
+    return;
+  }
+
+  const latexTemplatePath = path.join(
+    tempFolder,
+    "templates",
+    `${sectionName}-template.tex`
+  );
   const originalLatexContent = await fs.promises.readFile(
     sectionName === "coverletter" ? compiledLatexPath : latexTemplatePath,
     "utf8"
@@ -4,6 +4,17 @@ export const formatLatexSection = (sectionType: string) => (sectionData: any) =>
     case "experiences": return formatExperiences(sectionData);
     case "skills": return formatSkills(sectionData);
     case "projects": return formatProjects(sectionData);
+    case "volunteering": return formatVolunteering(sectionData);
+    case "certifications": return formatCertifications(sectionData);
+    case "publications": return formatPublications(sectionData);
+    case "awards": return formatAwards(sectionData);
+    case "languages": return formatLanguages(sectionData);
+    case "research": return formatTextForLatex(sectionData);
+    case "appointments": return formatAppointments(sectionData);
+    case "presentations": return formatPresentations(sectionData);
+    case "teaching": return formatTeaching(sectionData);
+    case "committees": return formatCommittees(sectionData);
+    case "grants": return formatGrants(sectionData);
     case "coverletter": return formatCoverLetter(sectionData);
     default: throw new Error(`Invalid section type: ${sectionType}`);
   }
@@ -116,17 +127,124 @@ ${items}
   }`;
 };
 
-const formatCoverLetter = (data: any) => `
-\\lettersection{About}
-${formatTextForLatex(data.about)}
+const formatVolunteering = (data: any) => {
+  const items = data.bulletPoints.map(({ text }: { text: string }) => `    \\item {${formatTextForLatex(text)}}`).join("\n");
+  return `
+\\cventry
+  {${formatTextForLatex(data.role)}} % Role
+  {${formatTextForLatex(data.organization)}} % Organization
+  {} % Location
+  {${formatTextForLatex(data.start)} - ${formatTextForLatex(data.end)}} % Date(s)
+  {
+    \\begin{cvitems}
+${items}
+    \\end{cvitems}
+  }`;
+};
 
-\\lettersection{Experience}
-${formatTextForLatex(data.experience)}
+const formatCertifications = (data: any) => `
+\\cvhonor
+  {${formatTextForLatex(data.name)}} % Certification
+  {${formatTextForLatex(data.issuer)}} % Issuer
+  {} % Location
+  {${formatTextForLatex(data.date)}} % Date`;
+
+const formatPublications = (data: any) => `
+\\cvhonor
+  {${formatTextForLatex(data.title)}} % Title
+  {${formatTextForLatex(data.publisher)}} % Publisher
+  {} % Location
+  {${formatTextForLatex(data.date)}} % Date`;
 
-\\lettersection{What I Bring}
-${formatTextForLatex(data.whatIBring)}
-`
-;
+const formatAwards = (data: any) => `
+\\cvhonor
+  {${formatTextForLatex(data.title)}} % Award
+  {${formatTextForLatex(data.awarder)}} % Awarder
+  {} % Location
+  {${formatTextForLatex(data.date)}} % Date`;
+
+const formatLanguages = (data: any) => `
+\\cvskill
+  {${formatTextForLatex(data.language)}} % Language
+  {${formatTextForLatex(data.fluency)}} % Fluency`;
+
+const formatAppointments = (data: any) => {
+  const items = data.bulletPoints.map((text: string) => `    \\item {${formatTextForLatex(text)}}`).join("\n");
+  return `
+\\cventry
+  {${formatTextForLatex(data.position)}} % Position
+  {${formatTextForLatex(data.institution)}} % Institution
+  {} % Location
+  {${formatTextForLatex(data.dates)}} % Date(s)
+  {${items ? `
+    \\begin{cvitems}
+${items}
+    \\end{cvitems}
+  ` : ""}}`;
+};
+
+const formatPresentations = (data: any) => `
+\\cvhonor
+  {${formatTextForLatex(data.title)}${data.invited ? " (Invited)" : ""}} % Title
+  {${formatTextForLatex(data.event)}} % Event
+  {${formatTextForLatex(data.location)}} % Location
+  {${formatTextForLatex(data.date)}} % Date`;
+
+const formatTeaching = (data: any) => `
+\\cventry
+  {${formatTextForLatex(data.role)}} % Role
+  {${formatTextForLatex(data.course)}} % Course
+  {${formatTextForLatex(data.institution)}} % Institution
+  {${formatTextForLatex(data.dates)}} % Date(s)
+  {${data.description ? `
+    \\begin{cvitems}
+    \\item {${formatTextForLatex(data.description)}}
+    \\end{cvitems}
+  ` : ""}}`;
+
+const formatCommittees = (data: any) => `
+\\cvhonor
+  {${formatTextForLatex(data.role)}} % Position
+  {${formatTextForLatex(data.committee)}} % Committee
+  {} % Location
+  {${formatTextForLatex(data.dates)}} % Date(s)`;
+
+const formatGrants = (data: any) => {
+  const role = [data.role, data.amount].filter(Boolean).join(", ");
+  return `
+\\cventry
+  {${formatTextForLatex(data.title)}} % Grant
+  {${formatTextForLatex(data.funder)}} % Funder
+  {${formatTextForLatex(role)}} % Role and amount
+  {${formatTextForLatex(data.dates)}} % Date(s)
+  {${data.description ? `
+    \\begin{cvitems}
+    \\item {${formatTextForLatex(data.description)}}
+    \\end{cvitems}
+  ` : ""}}`;
+};
+
+// Keeps the blank lines between paragraphs that formatTextForLatex would
+// collapse, so multi-paragraph sections stay multi-paragraph.
+const formatParagraphsForLatex = (text: string) =>
+  (text || '')
+    .split(/\n\s*\n/)
+    .map(p => formatTextForLatex(p))
+    .filter(p => p !== '')
+    .join('\n\n');
+
+// A "plain" cover letter drops the section headings and reads as one letter.
+const formatCoverLetter = (data: any) => {
+  const section = (title: string, text: string) =>
+    data.structure === "plain"
+      ? `${formatParagraphsForLatex(text)}\n`
+      : `\\lettersection{${title}}\n${formatParagraphsForLatex(text)}\n`;
+
+  return `
+${section("About", data.about)}
+${section("Experience", data.experience)}
+${section("What I Bring", data.whatIBring)}`;
+};
 
 
 const formatTitle = (str: string): string => {
@@ -2,23 +2,35 @@ import { ResumeSectionNotFoundError } from "@shared/errors/resume_builder.errors
 
 export const sectionToLatexEnvMap: Record<
   string,
-  "cvskills" | "cventries" | "cvletter" | "cvparagraph"
+  "cvskills" | "cventries" | "cvhonors" | "cvletter" | "cvparagraph"
 > = {
   summary: "cvparagraph",
   projects: "cventries",
   experiences: "cventries",
   skills: "cvskills",
+  volunteering: "cventries",
+  certifications: "cvhonors",
+  publications: "cvhonors",
+  awards: "cvhonors",
+  languages: "cvskills",
+  research: "cvparagraph",
+  appointments: "cventries",
+  presentations: "cvhonors",
+  teaching: "cventries",
+  committees: "cvhonors",
+  grants: "cventries",
   coverletter: "cvletter",
 };
 
 export const replaceSectionContent = (
   texContent: string,
   newContent: string[],
-  sectionType: "cvskills" | "cventries" | "cvletter" | "cvparagraph"
+  sectionType: "cvskills" | "cventries" | "cvhonors" | "cvletter" | "cvparagraph"
 ) => {
   const environments = [
     { name: "cvskills", start: "\\begin{cvskills}", end: "\\end{cvskills}" },
     { name: "cventries", start: "\\begin{cventries}", end: "\\end{cventries}" },
+    { name: "cvhonors", start: "\\begin{cvhonors}", end: "\\end{cvhonors}" },
     { name: "cvletter", start: "\\begin{cvletter}", end: "\\end{cvletter}" },
     { name: "cvparagraph", start: "\\begin{cvparagraph}", end: "\\end{cvparagraph}" }
   ];
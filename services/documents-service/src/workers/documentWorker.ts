@@ -1,7 +1,7 @@
 import * as kafka from "@kafka/index.js";
 import * as services from "@services/index.js";
 
-import { CompilationRequestSchema } from "@events/index.js";
+import { DocumentCompilationRequestSchema } from "@events/index.js";
 import { logger } from "@shared/utils/logger.js";
 
 /**
@@ -9,7 +9,7 @@ import { logger } from "@shared/utils/logger.js";
  * generates a document based on the message payload, and produces a message to the latex-compilation-results topic.
  * The document worker will connect to the Kafka topic, subscribe to the latex-compilation-requests topic,
  * and run indefinitely until the process is exited.
- * Each message received from the topic will be parsed into a CompilationRequestSchema, and if the message is invalid,
+ * Each message received from the topic will be parsed into a DocumentCompilationRequestSchema (a CV or a resume/cover letter request), and if the message is invalid,
  * an error will be logged and the message will be skipped.
  * If the message is valid, the document worker will call the generateIfNeeded function to generate a document based on the request.
  * The result of the generateIfNeeded function will be logged and a message will be sent to the latex-compilation-results topic.
@@ -28,7 +28,7 @@ export async function startDocumentWorker() {
       console.log("Received Kafka message:", message.value.toString());
       let request;
       try {
-        request = CompilationRequestSchema.parse(
+        request = DocumentCompilationRequestSchema.parse(
           JSON.parse(message.value.toString())
         );
       } catch (err) {
@@ -45,7 +45,8 @@ export async function startDocumentWorker() {
         return
       }
 
-      const resultPayload = await services.generateIfNeeded(request);
+      const result = await services.generateIfNeeded(request);
+      const resultPayload = { ...result, generation_id: request.generationID };
       logger.info("Generated document:", resultPayload);
 
       await kafka.producer.send({